@@ -0,0 +1,293 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package authorization
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/common/errs2"
+)
+
+// ErrAdmin is the default error class for the authorization admin endpoint.
+var ErrAdmin = errs.Class("authorization admin endpoint error")
+
+// AdminConfig configures the authorization admin endpoint.
+type AdminConfig struct {
+	Address            string `help:"address for the authorization admin http api to listen on; disabled when empty" default:""`
+	AuthorizationToken string `internal:"true" help:"token required in the Authorization header of admin requests; admin requests are refused when empty" default:""`
+}
+
+// AuthorizationInfo is the admin representation of an authorization.
+type AuthorizationInfo struct {
+	Token   string       `json:"token"`
+	Claimed bool         `json:"claimed"`
+	Claim   *ClaimRecord `json:"claim,omitempty"`
+}
+
+// AdminEndpoint provides an authenticated http api for managing authorizations.
+//
+// architecture: Endpoint
+type AdminEndpoint struct {
+	log      *zap.Logger
+	service  *Service
+	server   http.Server
+	listener net.Listener
+	token    string
+}
+
+// NewAdminEndpoint creates an authorization admin endpoint.
+func NewAdminEndpoint(log *zap.Logger, service *Service, listener net.Listener, config AdminConfig) *AdminEndpoint {
+	router := mux.NewRouter()
+	endpoint := &AdminEndpoint{
+		log:      log,
+		service:  service,
+		listener: listener,
+		token:    config.AuthorizationToken,
+		server: http.Server{
+			Addr:    listener.Addr().String(),
+			Handler: router,
+		},
+	}
+
+	router.Use(endpoint.withAuth)
+	router.HandleFunc("/api/authorizations", endpoint.handleUsers).Methods(http.MethodGet)
+	router.HandleFunc("/api/authorizations/export", endpoint.handleExport).Methods(http.MethodGet)
+	router.HandleFunc("/api/authorizations/{userID}", endpoint.handleGet).Methods(http.MethodGet)
+	router.HandleFunc("/api/authorizations/{userID}", endpoint.handleCreate).Methods(http.MethodPost)
+	router.HandleFunc("/api/tokens/{token}", endpoint.handleRevoke).Methods(http.MethodDelete)
+	router.HandleFunc("/api/claims", endpoint.handleClaims).Methods(http.MethodGet)
+
+	return endpoint
+}
+
+// Run starts the admin endpoint HTTP server and waits for the context to be
+// cancelled or for `Close` to be called.
+func (endpoint *AdminEndpoint) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	var group errgroup.Group
+	group.Go(func() error {
+		<-ctx.Done()
+		return endpoint.server.Shutdown(context.Background())
+	})
+	group.Go(func() error {
+		defer cancel()
+		err := endpoint.server.Serve(endpoint.listener)
+		if errs2.IsCanceled(err) || errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		return err
+	})
+
+	return ErrAdmin.Wrap(group.Wait())
+}
+
+// Close closes the admin endpoint HTTP server.
+func (endpoint *AdminEndpoint) Close() error {
+	return ErrAdmin.Wrap(endpoint.server.Close())
+}
+
+// withAuth only lets through requests with the configured authorization token.
+func (endpoint *AdminEndpoint) withAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if endpoint.token == "" {
+			endpoint.serveJSONError(w, http.StatusForbidden, "Authorization not enabled.")
+			return
+		}
+
+		equality := subtle.ConstantTimeCompare(
+			[]byte(r.Header.Get("Authorization")),
+			[]byte(endpoint.token),
+		)
+		if equality != 1 {
+			endpoint.serveJSONError(w, http.StatusForbidden, "Forbidden")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleUsers serves the number of claimed and open authorizations of every user.
+func (endpoint *AdminEndpoint) handleUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	users, err := endpoint.service.Users(ctx)
+	if err != nil {
+		endpoint.serveError(w, err)
+		return
+	}
+	endpoint.serveJSON(w, http.StatusOK, users)
+}
+
+// handleGet serves the authorizations of a user.
+func (endpoint *AdminEndpoint) handleGet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	group, err := endpoint.service.Get(ctx, mux.Vars(r)["userID"])
+	if err != nil {
+		endpoint.serveError(w, err)
+		return
+	}
+	endpoint.serveJSON(w, http.StatusOK, authorizationInfos(group))
+}
+
+// handleCreate creates new authorizations for a user. The number of
+// authorizations is set with the optional count query parameter.
+func (endpoint *AdminEndpoint) handleCreate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	count := 1
+	if value := r.URL.Query().Get("count"); value != "" {
+		count, err = strconv.Atoi(value)
+		if err != nil {
+			endpoint.serveJSONError(w, http.StatusBadRequest, "invalid count: "+err.Error())
+			return
+		}
+	}
+
+	group, err := endpoint.service.Create(ctx, mux.Vars(r)["userID"], count)
+	if err != nil {
+		endpoint.serveError(w, err)
+		return
+	}
+	endpoint.serveJSON(w, http.StatusCreated, authorizationInfos(group))
+}
+
+// handleRevoke revokes an unclaimed authorization.
+func (endpoint *AdminEndpoint) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	if err = endpoint.service.Revoke(ctx, mux.Vars(r)["token"]); err != nil {
+		endpoint.serveError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleExport serves the authorizations of all users, or only of the user
+// set with the userId query parameter, as CSV.
+func (endpoint *AdminEndpoint) handleExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	var group Group
+	if userID := r.URL.Query().Get("userId"); userID != "" {
+		group, err = endpoint.service.Get(ctx, userID)
+	} else {
+		group, err = endpoint.service.List(ctx)
+	}
+	if err != nil {
+		endpoint.serveError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="authorizations.csv"`)
+
+	writer := csv.NewWriter(w)
+	for _, auth := range group {
+		err = writer.Write([]string{
+			auth.Token.UserID,
+			auth.Token.String(),
+			strconv.FormatBool(auth.Claim != nil),
+		})
+		if err != nil {
+			break
+		}
+	}
+	writer.Flush()
+	if err = errs.Combine(err, writer.Error()); err != nil {
+		endpoint.log.Error("error writing response to client", zap.Error(err))
+	}
+}
+
+// handleClaims serves the claim history of all users, or only of the user
+// set with the userId query parameter.
+func (endpoint *AdminEndpoint) handleClaims(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	claims, err := endpoint.service.Claims(ctx, r.URL.Query().Get("userId"))
+	if err != nil {
+		endpoint.serveError(w, err)
+		return
+	}
+	endpoint.serveJSON(w, http.StatusOK, claims)
+}
+
+// serveError serves err with the status code matching its class.
+func (endpoint *AdminEndpoint) serveError(w http.ResponseWriter, err error) {
+	switch {
+	case ErrNotFound.Has(err):
+		endpoint.serveJSONError(w, http.StatusNotFound, err.Error())
+	case ErrAlreadyClaimed.Has(err):
+		endpoint.serveJSONError(w, http.StatusConflict, err.Error())
+	case ErrInvalidToken.Has(err), errs.Is(err, ErrEmptyUserID), errs.Is(err, ErrCount):
+		endpoint.serveJSONError(w, http.StatusBadRequest, err.Error())
+	default:
+		endpoint.log.Error("internal error", zap.Error(err))
+		endpoint.serveJSONError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// serveJSONError serves the error message as JSON.
+func (endpoint *AdminEndpoint) serveJSONError(w http.ResponseWriter, status int, message string) {
+	var response struct {
+		Error string `json:"error"`
+	}
+	response.Error = message
+
+	endpoint.serveJSON(w, status, response)
+}
+
+// serveJSON serves value as JSON.
+func (endpoint *AdminEndpoint) serveJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		endpoint.log.Error("error writing response to client", zap.Error(err))
+	}
+}
+
+// authorizationInfos converts a group of authorizations to their admin representation.
+func authorizationInfos(group Group) []AuthorizationInfo {
+	infos := make([]AuthorizationInfo, 0, len(group))
+	for _, auth := range group {
+		info := AuthorizationInfo{
+			Token:   auth.Token.String(),
+			Claimed: auth.Claim != nil,
+		}
+		if auth.Claim != nil {
+			record := newClaimRecord(auth)
+			info.Claim = &record
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package authorization
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/errs2"
+	"storj.io/common/testcontext"
+)
+
+func TestAdminEndpoint(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	log := zaptest.NewLogger(t)
+	authDB := newTestAuthDB(t, ctx)
+	defer ctx.Check(authDB.Close)
+
+	service := NewService(log, authDB)
+	endpoint := NewAdminEndpoint(log, service, listener, AdminConfig{
+		AuthorizationToken: "admin-token",
+	})
+
+	ctx.Go(func() error {
+		return errs2.IgnoreCanceled(endpoint.Run(ctx))
+	})
+	defer ctx.Check(endpoint.Close)
+
+	baseURL := "http://" + listener.Addr().String()
+
+	do := func(method, path, token string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, method, baseURL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", token)
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return res
+	}
+	doJSON := func(method, path string, expectedStatus int, value interface{}) {
+		res := do(method, path, "admin-token")
+		defer func() { require.NoError(t, res.Body.Close()) }()
+
+		require.Equal(t, expectedStatus, res.StatusCode)
+		if value != nil {
+			require.NoError(t, json.NewDecoder(res.Body).Decode(value))
+		}
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		for _, token := range []string{"", "wrong-token"} {
+			res := do(http.MethodGet, "/api/authorizations", token)
+			require.NoError(t, res.Body.Close())
+			require.Equal(t, http.StatusForbidden, res.StatusCode)
+		}
+	})
+
+	var created []AuthorizationInfo
+	doJSON(http.MethodPost, "/api/authorizations/alice@mail.test?count=3", http.StatusCreated, &created)
+	require.Len(t, created, 3)
+	doJSON(http.MethodPost, "/api/authorizations/bob@mail.test", http.StatusCreated, nil)
+	doJSON(http.MethodPost, "/api/authorizations/bob@mail.test?count=0", http.StatusBadRequest, nil)
+	doJSON(http.MethodPost, "/api/authorizations/bob@mail.test?count=x", http.StatusBadRequest, nil)
+
+	// claim one of alice's authorizations
+	claimedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	auths, err := authDB.Get(ctx, "alice@mail.test")
+	require.NoError(t, err)
+	auths[1].Claim = &Claim{Addr: "1.2.3.4:5", Timestamp: claimedAt.Unix()}
+	require.NoError(t, authDB.put(ctx, "alice@mail.test", auths))
+
+	var users []UserAuthorizations
+	doJSON(http.MethodGet, "/api/authorizations", http.StatusOK, &users)
+	assert.Equal(t, []UserAuthorizations{
+		{UserID: "alice@mail.test", Claimed: 1, Open: 2},
+		{UserID: "bob@mail.test", Claimed: 0, Open: 1},
+	}, users)
+
+	var infos []AuthorizationInfo
+	doJSON(http.MethodGet, "/api/authorizations/alice@mail.test", http.StatusOK, &infos)
+	require.Len(t, infos, 3)
+	assert.False(t, infos[0].Claimed)
+	require.True(t, infos[1].Claimed)
+	require.NotNil(t, infos[1].Claim)
+	assert.Equal(t, "1.2.3.4", infos[1].Claim.IP)
+
+	doJSON(http.MethodGet, "/api/authorizations/nobody@mail.test", http.StatusNotFound, nil)
+
+	var claims []ClaimRecord
+	doJSON(http.MethodGet, "/api/claims", http.StatusOK, &claims)
+	require.Len(t, claims, 1)
+	assert.Equal(t, "alice@mail.test", claims[0].UserID)
+	assert.Equal(t, created[1].Token, claims[0].Token)
+	assert.Equal(t, "1.2.3.4:5", claims[0].Addr)
+	assert.Equal(t, "1.2.3.4", claims[0].IP)
+	assert.True(t, claimedAt.Equal(claims[0].ClaimedAt))
+
+	doJSON(http.MethodGet, "/api/claims?userId=bob@mail.test", http.StatusOK, &claims)
+	assert.Empty(t, claims)
+
+	t.Run("revoke", func(t *testing.T) {
+		doJSON(http.MethodDelete, "/api/tokens/"+url.PathEscape(created[0].Token), http.StatusNoContent, nil)
+		doJSON(http.MethodDelete, "/api/tokens/"+url.PathEscape(created[0].Token), http.StatusNotFound, nil)
+		doJSON(http.MethodDelete, "/api/tokens/"+url.PathEscape(created[1].Token), http.StatusConflict, nil)
+		doJSON(http.MethodDelete, "/api/tokens/invalid", http.StatusBadRequest, nil)
+
+		doJSON(http.MethodGet, "/api/authorizations/alice@mail.test", http.StatusOK, &infos)
+		require.Len(t, infos, 2)
+		assert.Equal(t, created[1].Token, infos[0].Token)
+		assert.Equal(t, created[2].Token, infos[1].Token)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		res := do(http.MethodGet, "/api/authorizations/export?userId=alice@mail.test", "admin-token")
+		defer func() { require.NoError(t, res.Body.Close()) }()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "text/csv", res.Header.Get("Content-Type"))
+
+		records, err := csv.NewReader(res.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"alice@mail.test", created[1].Token, "true"},
+			{"alice@mail.test", created[2].Token, "false"},
+		}, records)
+	})
+}
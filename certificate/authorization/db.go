@@ -271,6 +271,42 @@ func (authDB *DB) Unclaim(ctx context.Context, authToken string) (err error) {
 	return errs.New("token not found in authorizations DB")
 }
 
+// Revoke removes an unclaimed authorization, so that it can't be claimed anymore.
+func (authDB *DB) Revoke(ctx context.Context, authToken string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	token, err := ParseToken(authToken)
+	if err != nil {
+		return err
+	}
+
+	auths, err := authDB.Get(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+
+	for i, auth := range auths {
+		if !auth.Token.Equal(token) {
+			continue
+		}
+		if auth.Claim != nil {
+			return ErrAlreadyClaimed.New("%s", auth.String())
+		}
+
+		auths = append(auths[:i], auths[i+1:]...)
+		if err := authDB.put(ctx, token.UserID, auths); err != nil {
+			return err
+		}
+
+		mon.Meter("authorization_revoke").Mark(1)
+		return nil
+	}
+
+	tokenFmt := Authorization{
+		Token: *token,
+	}
+	return ErrNotFound.New("%s", tokenFmt.String())
+}
+
 func (authDB *DB) add(ctx context.Context, userID string, newAuths Group) (err error) {
 	defer mon.Task()(&ctx, userID)(&err)
 
@@ -414,3 +414,37 @@ func newTestAuthDB(t *testing.T, ctx *testcontext.Context) *DB {
 	require.NoError(t, err)
 	return db
 }
+
+func TestAuthorizationDB_Revoke(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	authDB := newTestAuthDB(t, ctx)
+	defer ctx.Check(authDB.Close)
+
+	userID := "user@mail.test"
+
+	auths, err := authDB.Create(ctx, userID, 3)
+	require.NoError(t, err)
+
+	auths[1].Claim = &Claim{Addr: "1.2.3.4:5", Timestamp: time.Now().Unix()}
+	require.NoError(t, authDB.put(ctx, userID, auths))
+
+	err = authDB.Revoke(ctx, auths[0].Token.String())
+	require.NoError(t, err)
+
+	err = authDB.Revoke(ctx, auths[0].Token.String())
+	require.True(t, ErrNotFound.Has(err), err)
+
+	err = authDB.Revoke(ctx, auths[1].Token.String())
+	require.True(t, ErrAlreadyClaimed.Has(err), err)
+
+	err = authDB.Revoke(ctx, "not a token")
+	require.True(t, ErrInvalidToken.Has(err), err)
+
+	remaining, err := authDB.Get(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, remaining, 2)
+	assert.Equal(t, auths[1].Token, remaining[0].Token)
+	assert.Equal(t, auths[2].Token, remaining[1].Token)
+}
@@ -16,6 +16,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/errs2"
+	"storj.io/storj/private/web"
 )
 
 // ErrEndpoint is the default error class for the authorization endpoint.
@@ -23,26 +24,33 @@ var ErrEndpoint = errs.Class("authorization endpoint error")
 
 // Endpoint provides a http endpoint for interacting with an authorization service.
 type Endpoint struct {
-	log      *zap.Logger
-	service  *Service
-	server   http.Server
-	listener net.Listener
+	log         *zap.Logger
+	service     *Service
+	server      http.Server
+	listener    net.Listener
+	rateLimiter *web.IPRateLimiter
 }
 
-// NewEndpoint creates a authorization endpoint.
-func NewEndpoint(log *zap.Logger, service *Service, listener net.Listener) *Endpoint {
+// NewEndpoint creates a authorization endpoint. Requests are rate limited
+// per client IP, unless rateLimiter is nil.
+func NewEndpoint(log *zap.Logger, service *Service, rateLimiter *web.IPRateLimiter, listener net.Listener) *Endpoint {
 	mux := http.NewServeMux()
 	endpoint := &Endpoint{
-		log:      log,
-		listener: listener,
-		service:  service,
+		log:         log,
+		listener:    listener,
+		service:     service,
+		rateLimiter: rateLimiter,
 		server: http.Server{
 			Addr:    listener.Addr().String(),
 			Handler: mux,
 		},
 	}
 
-	mux.HandleFunc("/v1/authorizations/", endpoint.handleAuthorization)
+	var handler http.Handler = http.HandlerFunc(endpoint.handleAuthorization)
+	if rateLimiter != nil {
+		handler = rateLimiter.Limit(handler)
+	}
+	mux.Handle("/v1/authorizations/", handler)
 
 	return endpoint
 }
@@ -58,6 +66,12 @@ func (endpoint *Endpoint) Run(ctx context.Context) (err error) {
 		<-ctx.Done()
 		return endpoint.server.Shutdown(context.Background())
 	})
+	if endpoint.rateLimiter != nil {
+		group.Go(func() error {
+			endpoint.rateLimiter.Run(ctx)
+			return nil
+		})
+	}
 	group.Go(func() error {
 		defer cancel()
 		err := endpoint.server.Serve(endpoint.listener)
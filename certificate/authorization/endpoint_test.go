@@ -8,12 +8,14 @@ import (
 	"net"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
 	"storj.io/common/errs2"
 	"storj.io/common/testcontext"
+	"storj.io/storj/private/web"
 )
 
 func TestEndpoint_Run_httpSuccess(t *testing.T) {
@@ -29,7 +31,7 @@ func TestEndpoint_Run_httpSuccess(t *testing.T) {
 	defer ctx.Check(authDB.Close)
 
 	service := NewService(log, authDB)
-	endpoint := NewEndpoint(log, service, listener)
+	endpoint := NewEndpoint(log, service, nil, listener)
 	require.NotNil(t, endpoint)
 
 	ctx.Go(func() error {
@@ -75,7 +77,7 @@ func TestEndpoint_Run_httpErrors(t *testing.T) {
 	defer ctx.Check(authDB.Close)
 
 	service := NewService(log, authDB)
-	endpoint := NewEndpoint(log, service, listener)
+	endpoint := NewEndpoint(log, service, nil, listener)
 	require.NotNil(t, endpoint)
 
 	ctx.Go(func() error {
@@ -137,3 +139,41 @@ func TestEndpoint_Run_httpErrors(t *testing.T) {
 		require.Equal(t, testCase.statusCode, res.StatusCode)
 	}
 }
+
+func TestEndpoint_Run_rateLimit(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	log := zaptest.NewLogger(t)
+	authDB := newTestAuthDB(t, ctx)
+	defer ctx.Check(authDB.Close)
+
+	rateLimiter := web.NewIPRateLimiter(web.IPRateLimiterConfig{
+		Duration:  time.Hour,
+		Burst:     2,
+		NumLimits: 10,
+	})
+
+	service := NewService(log, authDB)
+	endpoint := NewEndpoint(log, service, rateLimiter, listener)
+
+	ctx.Go(func() error {
+		return errs2.IgnoreCanceled(endpoint.Run(ctx))
+	})
+	defer ctx.Check(endpoint.Close)
+
+	url := "http://" + listener.Addr().String() + "/v1/authorizations/user@mail.test"
+	for _, expected := range []int{http.StatusCreated, http.StatusCreated, http.StatusTooManyRequests} {
+		req, err := http.NewRequest(http.MethodPut, url, nil)
+		require.NoError(t, err)
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+
+		require.Equal(t, expected, res.StatusCode)
+	}
+}
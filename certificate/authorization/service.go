@@ -6,9 +6,14 @@ package authorization
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+
+	"storj.io/common/storj"
 )
 
 // ErrService is the default error class for the authorization service.
@@ -74,3 +79,144 @@ func (service *Service) GetOrCreate(ctx context.Context, userID string) (_ *Toke
 	authorization := createdGroup[0]
 	return &authorization.Token, nil
 }
+
+// UserAuthorizations summarizes the authorizations of a user.
+type UserAuthorizations struct {
+	UserID  string `json:"userId"`
+	Claimed int    `json:"claimed"`
+	Open    int    `json:"open"`
+}
+
+// ClaimRecord describes when, from where and by which node an authorization
+// was claimed.
+type ClaimRecord struct {
+	UserID    string       `json:"userId"`
+	Token     string       `json:"token"`
+	Addr      string       `json:"addr"`
+	IP        string       `json:"ip"`
+	ClaimedAt time.Time    `json:"claimedAt"`
+	NodeID    storj.NodeID `json:"nodeId"`
+}
+
+// Create creates count new authorizations for the given user ID.
+func (service *Service) Create(ctx context.Context, userID string, count int) (_ Group, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	group, err := service.db.Create(ctx, userID, count)
+	if err != nil {
+		return nil, ErrService.Wrap(err)
+	}
+
+	service.log.Info("authorizations created", zap.String("user", userID), zap.Int("count", count))
+	return group, nil
+}
+
+// Get returns the authorizations of the given user ID.
+func (service *Service) Get(ctx context.Context, userID string) (_ Group, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	group, err := service.db.Get(ctx, userID)
+	return group, ErrService.Wrap(err)
+}
+
+// List returns the authorizations of all users.
+func (service *Service) List(ctx context.Context) (_ Group, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	group, err := service.db.List(ctx)
+	return group, ErrService.Wrap(err)
+}
+
+// Users returns the number of claimed and open authorizations of every user, sorted by user ID.
+func (service *Service) Users(ctx context.Context) (_ []UserAuthorizations, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	auths, err := service.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[string]*UserAuthorizations)
+	for _, auth := range auths {
+		user, ok := byUser[auth.Token.UserID]
+		if !ok {
+			user = &UserAuthorizations{UserID: auth.Token.UserID}
+			byUser[auth.Token.UserID] = user
+		}
+
+		if auth.Claim != nil {
+			user.Claimed++
+		} else {
+			user.Open++
+		}
+	}
+
+	users := make([]UserAuthorizations, 0, len(byUser))
+	for _, user := range byUser {
+		users = append(users, *user)
+	}
+	sort.Slice(users, func(i, k int) bool {
+		return users[i].UserID < users[k].UserID
+	})
+
+	return users, nil
+}
+
+// Revoke removes an unclaimed authorization, so that it can't be claimed anymore.
+func (service *Service) Revoke(ctx context.Context, authToken string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := service.db.Revoke(ctx, authToken); err != nil {
+		return ErrService.Wrap(err)
+	}
+
+	service.log.Info("authorization revoked", zap.String("token", authToken))
+	return nil
+}
+
+// Claims returns the claim history, newest first. When userID is not empty,
+// only the claims of that user are returned.
+func (service *Service) Claims(ctx context.Context, userID string) (_ []ClaimRecord, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var auths Group
+	if userID != "" {
+		auths, err = service.db.Get(ctx, userID)
+	} else {
+		auths, err = service.db.List(ctx)
+	}
+	if err != nil {
+		return nil, ErrService.Wrap(err)
+	}
+
+	claims := []ClaimRecord{}
+	for _, auth := range auths {
+		if auth.Claim == nil {
+			continue
+		}
+		claims = append(claims, newClaimRecord(auth))
+	}
+	sort.SliceStable(claims, func(i, k int) bool {
+		return claims[i].ClaimedAt.After(claims[k].ClaimedAt)
+	})
+
+	return claims, nil
+}
+
+// newClaimRecord creates the claim record of a claimed authorization.
+func newClaimRecord(auth *Authorization) ClaimRecord {
+	record := ClaimRecord{
+		UserID:    auth.Token.UserID,
+		Token:     auth.Token.String(),
+		Addr:      auth.Claim.Addr,
+		IP:        auth.Claim.Addr,
+		ClaimedAt: time.Unix(auth.Claim.Timestamp, 0).UTC(),
+	}
+	if host, _, err := net.SplitHostPort(auth.Claim.Addr); err == nil {
+		record.IP = host
+	}
+	if auth.Claim.Identity != nil {
+		record.NodeID = auth.Claim.Identity.ID
+	}
+	return record
+}
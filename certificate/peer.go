@@ -19,6 +19,7 @@ import (
 	"storj.io/storj/certificate/authorization"
 	"storj.io/storj/pkg/revocation"
 	"storj.io/storj/pkg/server"
+	"storj.io/storj/private/web"
 )
 
 var (
@@ -37,9 +38,20 @@ type Config struct {
 	AuthorizationDB   authorization.DBConfig
 	AuthorizationAddr string `default:"127.0.0.1:9000" help:"address for authorization http proxy to listen on"`
 
+	AuthorizationRateLimit AuthorizationRateLimitConfig
+	AuthorizationAdmin     authorization.AdminConfig
+
 	MinDifficulty uint `default:"36" help:"minimum difficulty of the requester's identity required to claim an authorization"`
 }
 
+// AuthorizationRateLimitConfig configures the per client IP rate limit of the
+// authorization endpoint.
+type AuthorizationRateLimitConfig struct {
+	Enabled        bool   `default:"false" help:"rate limit the authorization endpoint per client IP"`
+	TrustedProxies string `default:"" help:"comma separated list of networks (CIDR) of reverse proxies, whose X-Real-IP and X-Forwarded-For headers are used as the client IP"`
+	web.IPRateLimiterConfig
+}
+
 // Peer is the certificates server.
 type Peer struct {
 	// core dependencies
@@ -59,6 +71,11 @@ type Peer struct {
 		Service  *authorization.Service
 		Endpoint *authorization.Endpoint
 	}
+
+	AuthorizationAdmin struct {
+		Listener net.Listener
+		Endpoint *authorization.AdminEndpoint
+	}
 }
 
 // New creates a new certificates peer.
@@ -76,7 +93,7 @@ func New(log *zap.Logger, ident *identity.FullIdentity, ca *identity.FullCertifi
 			return nil, Error.Wrap(errs.Combine(err, peer.Close()))
 		}
 
-		peer.Server, err = server.New(log.Named("server"), tlsOptions, sc.Address, sc.PrivateAddress)
+		peer.Server, err = server.New(log.Named("server"), tlsOptions, sc)
 		if err != nil {
 			return nil, Error.Wrap(err)
 		}
@@ -95,8 +112,26 @@ func New(log *zap.Logger, ident *identity.FullIdentity, ca *identity.FullCertifi
 		return nil, errs.Combine(err, peer.Close())
 	}
 
-	authorizationService := authorization.NewService(log, authorizationDB)
-	peer.Authorization.Endpoint = authorization.NewEndpoint(log.Named("authorization"), authorizationService, peer.Authorization.Listener)
+	peer.Authorization.Service = authorization.NewService(log, authorizationDB)
+
+	var rateLimiter *web.IPRateLimiter
+	if config.AuthorizationRateLimit.Enabled {
+		trustedProxies, err := server.ParseTrustedProxies(config.AuthorizationRateLimit.TrustedProxies)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		rateLimiter = web.NewProxiedIPRateLimiter(config.AuthorizationRateLimit.IPRateLimiterConfig, trustedProxies)
+	}
+	peer.Authorization.Endpoint = authorization.NewEndpoint(log.Named("authorization"), peer.Authorization.Service, rateLimiter, peer.Authorization.Listener)
+
+	if config.AuthorizationAdmin.Address != "" {
+		peer.AuthorizationAdmin.Listener, err = net.Listen("tcp", config.AuthorizationAdmin.Address)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.AuthorizationAdmin.Endpoint = authorization.NewAdminEndpoint(log.Named("authorization:admin"), peer.Authorization.Service, peer.AuthorizationAdmin.Listener, config.AuthorizationAdmin)
+	}
 
 	return peer, nil
 }
@@ -115,6 +150,12 @@ func (peer *Peer) Run(ctx context.Context) (err error) {
 		return errs2.IgnoreCanceled(peer.Authorization.Endpoint.Run(ctx))
 	})
 
+	if peer.AuthorizationAdmin.Endpoint != nil {
+		group.Go(func() error {
+			return errs2.IgnoreCanceled(peer.AuthorizationAdmin.Endpoint.Run(ctx))
+		})
+	}
+
 	return group.Wait()
 }
 
@@ -122,6 +163,10 @@ func (peer *Peer) Run(ctx context.Context) (err error) {
 func (peer *Peer) Close() error {
 	var errlist errs.Group
 
+	if peer.AuthorizationAdmin.Endpoint != nil {
+		errlist.Add(peer.AuthorizationAdmin.Endpoint.Close())
+	}
+
 	if peer.Authorization.Endpoint != nil {
 		errlist.Add(peer.Authorization.Endpoint.Close())
 	}
@@ -13,6 +13,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
@@ -64,6 +65,11 @@ var (
 		Use:   "health",
 		Short: "commands for querying health of a stored data",
 	}
+	auditsCmd = &cobra.Command{
+		Use:   "audits [node-id...]",
+		Short: "list when nodes were audited and their audit rates",
+		RunE:  getNodeAudits,
+	}
 	irreparableCmd = &cobra.Command{
 		Use:   "irreparable",
 		Short: "list segments in irreparable database",
@@ -90,6 +96,7 @@ type Inspector struct {
 	overlayclient internalpb.DRPCOverlayInspectorClient
 	irrdbclient   internalpb.DRPCIrreparableInspectorClient
 	healthclient  internalpb.DRPCHealthInspectorClient
+	auditclient   internalpb.DRPCAuditInspectorClient
 }
 
 // NewInspector creates a new inspector client for access to overlay.
@@ -113,6 +120,7 @@ func NewInspector(ctx context.Context, address, path string) (*Inspector, error)
 		overlayclient: internalpb.NewDRPCOverlayInspectorClient(conn),
 		irrdbclient:   internalpb.NewDRPCIrreparableInspectorClient(conn),
 		healthclient:  internalpb.NewDRPCHealthInspectorClient(conn),
+		auditclient:   internalpb.NewDRPCAuditInspectorClient(conn),
 	}, nil
 }
 
@@ -429,9 +437,71 @@ func sortSegments(segments []*internalpb.IrreparableSegment) map[string][]*inter
 	return objects
 }
 
+// nodeAudits is the audit information of a node printed by the audits command.
+type nodeAudits struct {
+	NodeID       storj.NodeID `json:"nodeId"`
+	FirstAudited *time.Time   `json:"firstAudited"`
+	LastAudited  *time.Time   `json:"lastAudited"`
+	AuditCount   int64        `json:"auditCount"`
+	AuditsPerDay float64      `json:"auditsPerDay"`
+	Vetted       bool         `json:"vetted"`
+	Suspended    bool         `json:"suspended"`
+	Overdue      bool         `json:"overdue"`
+}
+
+// getNodeAudits prints when the nodes were audited and their audit rates.
+func getNodeAudits(cmd *cobra.Command, args []string) (err error) {
+	nodeIDs := make([]storj.NodeID, len(args))
+	for i, arg := range args {
+		nodeIDs[i], err = storj.NodeIDFromString(arg)
+		if err != nil {
+			return ErrArgs.Wrap(err)
+		}
+	}
+
+	ctx, _ := process.Ctx(cmd)
+	i, err := NewInspector(ctx, *Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, i.Close()) }()
+
+	res, err := i.auditclient.NodeAudits(ctx, &internalpb.NodeAuditsRequest{NodeIds: nodeIDs})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	unixTime := func(seconds int64) *time.Time {
+		if seconds == 0 {
+			return nil
+		}
+		t := time.Unix(seconds, 0).UTC()
+		return &t
+	}
+
+	nodes := make([]nodeAudits, 0, len(res.Nodes))
+	for _, node := range res.Nodes {
+		nodes = append(nodes, nodeAudits{
+			NodeID:       node.NodeId,
+			FirstAudited: unixTime(node.FirstAudited),
+			LastAudited:  unixTime(node.LastAudited),
+			AuditCount:   node.AuditCount,
+			AuditsPerDay: node.AuditsPerDay,
+			Vetted:       node.Vetted,
+			Suspended:    node.Suspended,
+			Overdue:      node.Overdue,
+		})
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(nodes)
+}
+
 func init() {
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(irreparableCmd)
+	rootCmd.AddCommand(auditsCmd)
 	rootCmd.AddCommand(healthCmd)
 
 	healthCmd.AddCommand(objectHealthCmd)
@@ -51,6 +51,17 @@ func cmdAPIRun(cmd *cobra.Command, args []string) (err error) {
 		err = errs.Combine(err, pointerDB.Close())
 	}()
 
+	var metabaseDB metainfo.MetabaseDB
+	if runCfg.Config.Metainfo.MetabaseDatabaseURL != "" {
+		metabaseDB, err = metainfo.OpenMetabase(ctx, log.Named("metabase"), runCfg.Config.Metainfo.MetabaseDatabaseURL, "satellite-api")
+		if err != nil {
+			return errs.New("Error creating metabase connection on satellite api: %+v", err)
+		}
+		defer func() {
+			err = errs.Combine(err, metabaseDB.Close())
+		}()
+	}
+
 	revocationDB, err := revocation.OpenDBFromCfg(ctx, runCfg.Config.Server.Config)
 	if err != nil {
 		return errs.New("Error creating revocation database on satellite api: %+v", err)
@@ -72,7 +83,7 @@ func cmdAPIRun(cmd *cobra.Command, args []string) (err error) {
 		err = errs.Combine(err, rollupsWriteCache.CloseAndFlush(context2.WithoutCancellation(ctx)))
 	}()
 
-	peer, err := satellite.NewAPI(log, identity, db, pointerDB, revocationDB, accountingCache, rollupsWriteCache, &runCfg.Config, version.Build, process.AtomicLevel(cmd))
+	peer, err := satellite.NewAPI(log, identity, db, pointerDB, metabaseDB, revocationDB, accountingCache, rollupsWriteCache, &runCfg.Config, version.Build, process.AtomicLevel(cmd))
 	if err != nil {
 		return err
 	}
@@ -16,6 +16,7 @@ import (
 	"storj.io/common/uuid"
 	"storj.io/storj/private/dbutil"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/satellitedb"
 	"storj.io/storj/satellite/satellitedb/dbx"
@@ -80,6 +81,7 @@ func setupPayments(log *zap.Logger, db satellite.DB) (*stripecoinpayments.Servic
 		db.StripeCoinPayments(),
 		db.Console().Projects(),
 		db.ProjectAccounting(),
+		metainfo.NewBucketPriceMultipliers(db.Buckets(), runCfg.Metainfo.RedundancyProfiles),
 		pc.StorageTBPrice,
 		pc.EgressTBPrice,
 		pc.ObjectPrice,
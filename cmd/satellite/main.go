@@ -87,6 +87,16 @@ var (
 		Short: "Run the satellite database migration",
 		RunE:  cmdMigrationRun,
 	}
+	migrateMetabaseCmd = &cobra.Command{
+		Use:   "migrate-metabase",
+		Short: "Copy objects and segments from the pointer database into the metabase",
+		RunE:  cmdMigrateMetabase,
+	}
+	verifyMetabaseCmd = &cobra.Command{
+		Use:   "verify-metabase",
+		Short: "Verify that all segments of the pointer database are in the metabase",
+		RunE:  cmdVerifyMetabase,
+	}
 	runAPICmd = &cobra.Command{
 		Use:   "api",
 		Short: "Run the satellite API",
@@ -271,6 +281,8 @@ func init() {
 	runCmd.AddCommand(runRepairerCmd)
 	runCmd.AddCommand(runGCCmd)
 	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(migrateMetabaseCmd)
+	rootCmd.AddCommand(verifyMetabaseCmd)
 	rootCmd.AddCommand(qdiagCmd)
 	rootCmd.AddCommand(reportsCmd)
 	rootCmd.AddCommand(compensationCmd)
@@ -294,6 +306,8 @@ func init() {
 	process.Bind(runAdminCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(runRepairerCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(runGCCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(migrateMetabaseCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(verifyMetabaseCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
 	process.Bind(qdiagCmd, &qdiagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(nodeUsageCmd, &nodeUsageCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
@@ -346,6 +360,17 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		err = errs.Combine(err, pointerDB.Close())
 	}()
 
+	var metabaseDB metainfo.MetabaseDB
+	if runCfg.Metainfo.MetabaseDatabaseURL != "" {
+		metabaseDB, err = metainfo.OpenMetabase(ctx, log.Named("metabase"), runCfg.Metainfo.MetabaseDatabaseURL, "satellite-core")
+		if err != nil {
+			return errs.New("Error creating metabase connection: %+v", err)
+		}
+		defer func() {
+			err = errs.Combine(err, metabaseDB.Close())
+		}()
+	}
+
 	revocationDB, err := revocation.OpenDBFromCfg(ctx, runCfg.Server.Config)
 	if err != nil {
 		return errs.New("Error creating revocation database: %+v", err)
@@ -367,7 +392,7 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		err = errs.Combine(err, rollupsWriteCache.CloseAndFlush(context2.WithoutCancellation(ctx)))
 	}()
 
-	peer, err := satellite.New(log, identity, db, pointerDB, revocationDB, liveAccounting, rollupsWriteCache, version.Build, &runCfg.Config, process.AtomicLevel(cmd))
+	peer, err := satellite.New(log, identity, db, pointerDB, metabaseDB, revocationDB, liveAccounting, rollupsWriteCache, version.Build, &runCfg.Config, process.AtomicLevel(cmd))
 	if err != nil {
 		return err
 	}
@@ -427,6 +452,20 @@ func cmdMigrationRun(cmd *cobra.Command, args []string) (err error) {
 		return errs.New("Error creating tables for pointer database on satellite: %+v", err)
 	}
 
+	if runCfg.Metainfo.MetabaseDatabaseURL != "" {
+		mdb, err := metainfo.OpenMetabase(ctx, log.Named("migration"), runCfg.Metainfo.MetabaseDatabaseURL, "satellite-migration")
+		if err != nil {
+			return errs.New("Error creating metabase connection on satellite: %+v", err)
+		}
+		defer func() {
+			err = errs.Combine(err, mdb.Close())
+		}()
+		err = mdb.MigrateToLatest(ctx)
+		if err != nil {
+			return errs.New("Error creating tables for metabase on satellite: %+v", err)
+		}
+	}
+
 	return nil
 }
 
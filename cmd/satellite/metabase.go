@@ -0,0 +1,79 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/private/process"
+	"storj.io/storj/satellite/metainfo"
+)
+
+func cmdMigrateMetabase(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L().Named("metabase-migration")
+
+	if runCfg.Metainfo.MetabaseDatabaseURL == "" {
+		return errs.New("metabase database url is not configured")
+	}
+
+	pointerDB, err := metainfo.OpenStore(ctx, log.Named("pointerdb"), runCfg.Metainfo.DatabaseURL, "satellite-metabase-migration")
+	if err != nil {
+		return errs.New("Error creating pointer database connection: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, pointerDB.Close())
+	}()
+
+	metabaseDB, err := metainfo.OpenMetabase(ctx, log.Named("metabase"), runCfg.Metainfo.MetabaseDatabaseURL, "satellite-metabase-migration")
+	if err != nil {
+		return errs.New("Error creating metabase connection: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, metabaseDB.Close())
+	}()
+
+	if err := metabaseDB.MigrateToLatest(ctx); err != nil {
+		return errs.New("Error creating tables for metabase: %+v", err)
+	}
+
+	_, err = metainfo.MigrateToMetabase(ctx, log, pointerDB, metabaseDB, runCfg.Metainfo.Loop.ListLimit)
+	return err
+}
+
+func cmdVerifyMetabase(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L().Named("metabase-verification")
+
+	if runCfg.Metainfo.MetabaseDatabaseURL == "" {
+		return errs.New("metabase database url is not configured")
+	}
+
+	pointerDB, err := metainfo.OpenStore(ctx, log.Named("pointerdb"), runCfg.Metainfo.DatabaseURL, "satellite-metabase-verification")
+	if err != nil {
+		return errs.New("Error creating pointer database connection: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, pointerDB.Close())
+	}()
+
+	metabaseDB, err := metainfo.OpenMetabase(ctx, log.Named("metabase"), runCfg.Metainfo.MetabaseDatabaseURL, "satellite-metabase-verification")
+	if err != nil {
+		return errs.New("Error creating metabase connection: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, metabaseDB.Close())
+	}()
+
+	inconsistent, err := metainfo.VerifyMetabase(ctx, log, pointerDB, metabaseDB, runCfg.Metainfo.Loop.ListLimit)
+	if err != nil {
+		return err
+	}
+	if inconsistent > 0 {
+		return errs.New("%d segments of the pointer database are missing in the metabase, run migrate-metabase", inconsistent)
+	}
+	return nil
+}
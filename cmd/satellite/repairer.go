@@ -47,6 +47,17 @@ func cmdRepairerRun(cmd *cobra.Command, args []string) (err error) {
 		err = errs.Combine(err, pointerDB.Close())
 	}()
 
+	var metabaseDB metainfo.MetabaseDB
+	if runCfg.Metainfo.MetabaseDatabaseURL != "" {
+		metabaseDB, err = metainfo.OpenMetabase(ctx, log.Named("metabase"), runCfg.Metainfo.MetabaseDatabaseURL, "satellite-repairer")
+		if err != nil {
+			return errs.New("Error creating metabase connection: %+v", err)
+		}
+		defer func() {
+			err = errs.Combine(err, metabaseDB.Close())
+		}()
+	}
+
 	revocationDB, err := revocation.OpenDBFromCfg(ctx, runCfg.Server.Config)
 	if err != nil {
 		return errs.New("Error creating revocation database: %+v", err)
@@ -64,8 +75,10 @@ func cmdRepairerRun(cmd *cobra.Command, args []string) (err error) {
 		log,
 		identity,
 		pointerDB,
+		metabaseDB,
 		revocationDB,
 		db.RepairQueue(),
+		db.ReencodeQueue(),
 		db.Buckets(),
 		db.OverlayCache(),
 		rollupsWriteCache,
@@ -8,6 +8,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -20,6 +21,7 @@ import (
 
 	"storj.io/common/sync2"
 	"storj.io/private/version"
+	"storj.io/storj/private/version/manifest"
 )
 
 func binaryVersion(location string) (version.SemVer, error) {
@@ -41,7 +43,19 @@ func binaryVersion(location string) (version.SemVer, error) {
 	return version.SemVer{}, errs.New("unable to determine binary version")
 }
 
-func downloadBinary(ctx context.Context, url, target string) error {
+// downloadBinary downloads the archive from url and unpacks it to target. When
+// expected isn't nil, the size and SHA-256 hash of the archive are verified.
+func downloadBinary(ctx context.Context, url string, expected *manifest.Binary, target string) (err error) {
+	var expectedHash []byte
+	size := int64(-1)
+	if expected != nil {
+		expectedHash, err = expected.Hash()
+		if err != nil {
+			return errs.Wrap(err)
+		}
+		size = expected.Size
+	}
+
 	f, err := ioutil.TempFile("", createPattern(url))
 	if err != nil {
 		return errs.New("cannot create temporary archive: %v", err)
@@ -55,9 +69,13 @@ func downloadBinary(ctx context.Context, url, target string) error {
 
 	zap.L().Info("Download started.", zap.String("From", url), zap.String("To", f.Name()))
 
-	if err = downloadArchive(ctx, f, url); err != nil {
+	hash := sha256.New()
+	if err = downloadArchive(ctx, io.MultiWriter(f, hash), url, size); err != nil {
 		return errs.Wrap(err)
 	}
+	if actualHash := hash.Sum(nil); expected != nil && !bytes.Equal(actualHash, expectedHash) {
+		return errs.New("invalid archive downloaded: wants sha256 %x got %x", expectedHash, actualHash)
+	}
 	if err = unpackBinary(ctx, f.Name(), target); err != nil {
 		return errs.Wrap(err)
 	}
@@ -66,7 +84,9 @@ func downloadBinary(ctx context.Context, url, target string) error {
 	return nil
 }
 
-func downloadArchive(ctx context.Context, file io.Writer, url string) (err error) {
+// downloadArchive downloads the archive to file, failing when its size isn't the
+// expected size. A negative size isn't checked.
+func downloadArchive(ctx context.Context, file io.Writer, url string, size int64) (err error) {
 	resp, err := http.Get(url)
 	if err != nil {
 		return err
@@ -78,8 +98,20 @@ func downloadArchive(ctx context.Context, file io.Writer, url string) (err error
 		return errs.New("bad status: %s", resp.Status)
 	}
 
-	_, err = sync2.Copy(ctx, file, resp.Body)
-	return err
+	if size < 0 {
+		_, err = sync2.Copy(ctx, file, resp.Body)
+		return err
+	}
+
+	// read one more byte than expected to detect larger archives
+	n, err := sync2.Copy(ctx, file, io.LimitReader(resp.Body, size+1))
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return errs.New("invalid archive downloaded: wants %d bytes got %d bytes", size, n)
+	}
+	return nil
 }
 
 // unpackBinary unpack zip compressed binary.
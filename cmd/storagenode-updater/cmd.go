@@ -57,6 +57,15 @@ var (
 
 		BinaryLocation string `help:"the storage node executable binary location" default:"storagenode"`
 		ServiceName    string `help:"storage node OS service name" default:"storagenode"`
+
+		Rollback RollbackConfig
+
+		// ManifestPublicKey is the public half of the release signing key, which is held by
+		// the release managers and used by versioncontrol (--manifest.signing-key) to sign the
+		// release manifest. It's published with the release notes and has no default until
+		// such a key exists.
+		ManifestPublicKey string        `help:"hex-encoded ed25519 public key of the release signing key; when set, only binaries described by a release manifest signed with it are installed" default:""`
+		ManifestMaxAge    time.Duration `help:"how long after signing a release manifest is accepted; older manifests are refused, so that outdated releases can't be replayed" default:"24h0m0s"`
 		// deprecated
 		Log string `help:"deprecated, use --log.output" default:""`
 	}
@@ -6,6 +6,10 @@ package main_test
 import (
 	"archive/zip"
 	"compress/flate"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -14,6 +18,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"testing"
 	"time"
@@ -28,6 +33,7 @@ import (
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
 	"storj.io/private/version"
+	"storj.io/storj/private/version/manifest"
 	"storj.io/storj/versioncontrol"
 )
 
@@ -78,7 +84,7 @@ func TestAutoUpdater(t *testing.T) {
 	}
 
 	// run versioncontrol and update zips http servers
-	versionControlPeer, cleanupVersionControl := testVersionControlWithUpdates(ctx, t, updateBins)
+	versionControlPeer, publicKey, cleanupVersionControl := testVersionControlWithUpdates(ctx, t, updateBins, false)
 	defer cleanupVersionControl()
 
 	logPath := ctx.File("storagenode-updater.log")
@@ -94,6 +100,7 @@ func TestAutoUpdater(t *testing.T) {
 		"--version.check-interval", "0s",
 		"--identity.cert-path", identConfig.CertPath,
 		"--identity.key-path", identConfig.KeyPath,
+		"--manifest-public-key", publicKey,
 		"--log", logPath,
 	}
 
@@ -130,6 +137,138 @@ func TestAutoUpdater(t *testing.T) {
 	require.NotZero(t, backupUpdaterInfo.Size())
 }
 
+func TestAutoUpdater_TamperedArchive(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	oldSemVer, err := version.NewSemVer(oldVersion)
+	require.NoError(t, err)
+
+	oldInfo := version.Info{
+		Timestamp: time.Now(),
+		Version:   oldSemVer,
+	}
+
+	oldBin := CompileWithVersion(ctx, "storj.io/storj/cmd/storagenode-updater", oldInfo)
+	storagenodePath := ctx.File("fake", "storagenode.exe")
+	copyBin(ctx, t, oldBin, storagenodePath)
+
+	updaterPath := ctx.File("fake", "storagenode-updater.exe")
+	move(t, oldBin, updaterPath)
+
+	// the archive content doesn't matter, it must be refused before being unpacked
+	updateBins := map[string]string{
+		"storagenode":         storagenodePath,
+		"storagenode-updater": storagenodePath,
+	}
+
+	versionControlPeer, publicKey, cleanupVersionControl := testVersionControlWithUpdates(ctx, t, updateBins, true)
+	defer cleanupVersionControl()
+
+	logPath := ctx.File("storagenode-updater.log")
+	identConfig := testIdentityFiles(ctx, t)
+
+	args := []string{"run",
+		"--config-dir", ctx.Dir(),
+		"--version.server-address", "http://" + versionControlPeer.Addr(),
+		"--binary-location", storagenodePath,
+		"--version.check-interval", "0s",
+		"--identity.cert-path", identConfig.CertPath,
+		"--identity.key-path", identConfig.KeyPath,
+		"--manifest-public-key", publicKey,
+		"--log", logPath,
+	}
+
+	out, err := exec.Command(updaterPath, args...).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	logData, err := ioutil.ReadFile(logPath)
+	require.NoError(t, err)
+	logStr := string(logData)
+	require.Contains(t, logStr, "invalid archive downloaded", logStr)
+	require.NotContains(t, logStr, "Service restarted successfully.", logStr)
+
+	_, err = os.Stat(ctx.File("fake", "storagenode"+".old."+oldVersion+".exe"))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(ctx.File("fake", "storagenode"+"."+newVersion+".exe"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestAutoUpdater_Rollback(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	oldSemVer, err := version.NewSemVer(oldVersion)
+	require.NoError(t, err)
+	newSemVer, err := version.NewSemVer(newVersion)
+	require.NoError(t, err)
+
+	oldBin := CompileWithVersion(ctx, "storj.io/storj/cmd/storagenode-updater", version.Info{Timestamp: time.Now(), Version: oldSemVer})
+	storagenodePath := ctx.File("fake", "storagenode.exe")
+	copyBin(ctx, t, oldBin, storagenodePath)
+
+	updaterPath := ctx.File("fake", "storagenode-updater.exe")
+	move(t, oldBin, updaterPath)
+
+	newBin := CompileWithVersion(ctx, "storj.io/storj/cmd/storagenode-updater", version.Info{Timestamp: time.Now(), Version: newSemVer})
+	updateBins := map[string]string{
+		"storagenode": newBin,
+	}
+
+	versionControlPeer, publicKey, cleanupVersionControl := testVersionControlWithUpdates(ctx, t, updateBins, false)
+	defer cleanupVersionControl()
+
+	// the updated storage node never becomes healthy
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	identConfig := testIdentityFiles(ctx, t)
+	failedPath := ctx.File("failed.json")
+
+	runUpdater := func(logPath string) string {
+		args := []string{"run",
+			"--config-dir", ctx.Dir(),
+			"--version.server-address", "http://" + versionControlPeer.Addr(),
+			"--binary-location", storagenodePath,
+			"--version.check-interval", "0s",
+			"--identity.cert-path", identConfig.CertPath,
+			"--identity.key-path", identConfig.KeyPath,
+			"--manifest-public-key", publicKey,
+			"--rollback.grace-period", "1s",
+			"--rollback.check-interval", "500ms",
+			"--rollback.health-url", unhealthy.URL,
+			"--rollback.failed-path", failedPath,
+			"--log", logPath,
+		}
+
+		out, err := exec.Command(updaterPath, args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+
+		logData, err := ioutil.ReadFile(logPath)
+		require.NoError(t, err)
+		return string(logData)
+	}
+
+	logStr := runUpdater(ctx.File("first.log"))
+	require.Contains(t, logStr, `Service rolled back successfully.`, logStr)
+
+	// the previous binary is restored
+	out, err := exec.Command(storagenodePath, "version").CombinedOutput()
+	require.NoError(t, err)
+	require.Contains(t, string(out), "Version: "+oldVersion)
+
+	failedData, err := ioutil.ReadFile(failedPath)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"storagenode": "`+newVersion+`"}`, string(failedData))
+
+	// the failed version isn't retried
+	logStr = runUpdater(ctx.File("second.log"))
+	require.Contains(t, logStr, "Suggested version was rolled back before, not retrying it.", logStr)
+	require.NotContains(t, logStr, `Service restarted successfully.	{"Service": "storagenode"}`, logStr)
+}
+
 // CompileWithVersion compiles the specified package with the version variables set
 // to the passed version info values and returns the executable name.
 func CompileWithVersion(ctx *testcontext.Context, pkg string, info version.Info) string {
@@ -184,9 +323,13 @@ func testIdentityFiles(ctx *testcontext.Context, t *testing.T) identity.Config {
 	return identConfig
 }
 
-func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updateBins map[string]string) (peer *versioncontrol.Peer, cleanup func()) {
+// testVersionControlWithUpdates runs versioncontrol with a signed release manifest
+// of the update zips. When tamper is set, the served zips don't match the manifest.
+func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updateBins map[string]string, tamper bool) (peer *versioncontrol.Peer, publicKey string, cleanup func()) {
 	t.Helper()
 
+	releaseBinaries := map[string]manifest.Binary{}
+
 	var mux http.ServeMux
 	for name, src := range updateBins {
 		dst := ctx.File("updates", name+".zip")
@@ -194,6 +337,18 @@ func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updat
 		zipData, err := ioutil.ReadFile(dst)
 		require.NoError(t, err)
 
+		hash := sha256.Sum256(zipData)
+		releaseBinaries[name] = manifest.Binary{
+			OS:     runtime.GOOS,
+			Arch:   runtime.GOARCH,
+			URL:    "/" + name,
+			SHA256: hex.EncodeToString(hash[:]),
+			Size:   int64(len(zipData)),
+		}
+		if tamper {
+			zipData[len(zipData)/2] ^= 0xFF
+		}
+
 		mux.HandleFunc("/"+name, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			_, err := w.Write(zipData)
 			require.NoError(t, err)
@@ -202,6 +357,24 @@ func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updat
 
 	ts := httptest.NewServer(&mux)
 
+	releases := map[string]manifest.Process{}
+	for name, binary := range releaseBinaries {
+		binary.URL = ts.URL + binary.URL
+		releases[name] = manifest.Process{
+			Version:  newVersion,
+			Binaries: []manifest.Binary{binary},
+		}
+	}
+	releasesData, err := json.Marshal(releases)
+	require.NoError(t, err)
+	releasesPath := ctx.File("manifest", "releases.json")
+	require.NoError(t, ioutil.WriteFile(releasesPath, releasesData, 0644))
+
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	signingKeyPath := ctx.File("manifest", "signing.key")
+	require.NoError(t, manifest.SavePrivateKey(signingKeyPath, private))
+
 	var randSeed version.RolloutBytes
 	testrand.Read(randSeed[:])
 	storagenodeSeed := fmt.Sprintf("%x", randSeed)
@@ -249,13 +422,17 @@ func testVersionControlWithUpdates(ctx *testcontext.Context, t *testing.T, updat
 				},
 			},
 		},
+		Manifest: versioncontrol.ManifestConfig{
+			SigningKey: signingKeyPath,
+			Releases:   releasesPath,
+		},
 	}
-	peer, err := versioncontrol.New(zaptest.NewLogger(t), config)
+	peer, err = versioncontrol.New(zaptest.NewLogger(t), config)
 	require.NoError(t, err)
 	ctx.Go(func() error {
 		return peer.Run(ctx)
 	})
-	return peer, func() {
+	return peer, hex.EncodeToString(public), func() {
 		ts.Close()
 		ctx.Check(peer.Close)
 	}
@@ -17,18 +17,28 @@ import (
 func loopFunc(ctx context.Context) error {
 	zap.L().Info("Downloading versions.", zap.String("Server Address", runCfg.Version.ServerAddress))
 
-	all, err := checker.New(runCfg.Version.ClientConfig).All(ctx)
+	client := checker.New(runCfg.Version.ClientConfig)
+
+	all, err := client.All(ctx)
 	if err != nil {
 		zap.L().Error("Error retrieving version info.", zap.Error(err))
 		return nil
 	}
 
-	if err := update(ctx, runCfg.ServiceName, runCfg.BinaryLocation, all.Processes.Storagenode); err != nil {
+	// when a public key is configured, binaries are only installed when they are
+	// described by a release manifest signed with it.
+	releases, err := releaseManifest(ctx, client)
+	if err != nil {
+		zap.L().Error("Error retrieving release manifest.", zap.Error(err))
+		return nil
+	}
+
+	if err := update(ctx, runCfg.ServiceName, "storagenode", runCfg.BinaryLocation, all.Processes.Storagenode, releases); err != nil {
 		// don't finish loop in case of error just wait for another execution
 		zap.L().Error("Error updating service.", zap.String("Service", runCfg.ServiceName), zap.Error(err))
 	}
 
-	if err := update(ctx, updaterServiceName, updaterBinaryPath, all.Processes.StoragenodeUpdater); err != nil {
+	if err := update(ctx, updaterServiceName, updaterServiceName, updaterBinaryPath, all.Processes.StoragenodeUpdater, releases); err != nil {
 		// don't finish loop in case of error just wait for another execution
 		zap.L().Error("Error updating service.", zap.String("Service", updaterServiceName), zap.Error(err))
 	}
@@ -15,24 +15,35 @@ import (
 
 	"storj.io/private/version"
 	"storj.io/storj/private/version/checker"
+	"storj.io/storj/private/version/manifest"
 )
 
 // loopFunc is func that is run by the update cycle.
 func loopFunc(ctx context.Context) error {
 	zap.L().Info("Downloading versions.", zap.String("Server Address", runCfg.Version.ServerAddress))
 
-	all, err := checker.New(runCfg.Version.ClientConfig).All(ctx)
+	client := checker.New(runCfg.Version.ClientConfig)
+
+	all, err := client.All(ctx)
 	if err != nil {
 		zap.L().Error("Error retrieving version info.", zap.Error(err))
 		return nil
 	}
 
-	if err := update(ctx, runCfg.ServiceName, runCfg.BinaryLocation, all.Processes.Storagenode); err != nil {
+	// when a public key is configured, binaries are only installed when they are
+	// described by a release manifest signed with it.
+	releases, err := releaseManifest(ctx, client)
+	if err != nil {
+		zap.L().Error("Error retrieving release manifest.", zap.Error(err))
+		return nil
+	}
+
+	if err := update(ctx, runCfg.ServiceName, "storagenode", runCfg.BinaryLocation, all.Processes.Storagenode, releases); err != nil {
 		// don't finish loop in case of error just wait for another execution
 		zap.L().Error("Error updating service.", zap.String("Service", runCfg.ServiceName), zap.Error(err))
 	}
 
-	if err := updateSelf(ctx, updaterBinaryPath, all.Processes.StoragenodeUpdater); err != nil {
+	if err := updateSelf(ctx, updaterBinaryPath, all.Processes.StoragenodeUpdater, releases); err != nil {
 		// don't finish loop in case of error just wait for another execution
 		zap.L().Error("Error updating service.", zap.String("Service", updaterServiceName), zap.Error(err))
 	}
@@ -40,7 +51,7 @@ func loopFunc(ctx context.Context) error {
 	return nil
 }
 
-func updateSelf(ctx context.Context, binaryLocation string, ver version.Process) error {
+func updateSelf(ctx context.Context, binaryLocation string, ver version.Process, releases *manifest.Manifest) error {
 	suggestedVersion, err := ver.Suggested.SemVer()
 	if err != nil {
 		return errs.Wrap(err)
@@ -66,9 +77,14 @@ func updateSelf(ctx context.Context, binaryLocation string, ver version.Process)
 		return nil
 	}
 
+	url, expected, err := releaseBinary(releases, updaterServiceName, ver)
+	if err != nil {
+		return err
+	}
+
 	newVersionPath := prependExtension(binaryLocation, ver.Suggested.Version)
 
-	if err = downloadBinary(ctx, parseDownloadURL(ver.Suggested.URL), newVersionPath); err != nil {
+	if err = downloadBinary(ctx, url, expected, newVersionPath); err != nil {
 		return errs.Wrap(err)
 	}
 
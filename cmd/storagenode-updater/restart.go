@@ -28,3 +28,7 @@ func restartService(ctx context.Context, service, binaryLocation, newVersionPath
 
 	return nil
 }
+
+func serviceStatus(service string) (running bool, pid int, err error) {
+	return false, 0, errStatusUnsupported
+}
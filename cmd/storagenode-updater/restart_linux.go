@@ -41,6 +41,36 @@ func restartService(ctx context.Context, service, binaryLocation, newVersionPath
 	return nil
 }
 
+// serviceStatus returns whether the service is active and its main process id.
+func serviceStatus(service string) (running bool, pid int, err error) {
+	args := []string{
+		"show",
+		"--property=ActiveState",
+		"--property=MainPID",
+		service,
+	}
+
+	out, err := exec.Command("systemctl", args...).CombinedOutput()
+	if err != nil {
+		return false, 0, errs.New("Error retrieving service status: systemctl: %s %v", string(out), err)
+	}
+
+	var state string
+	for _, line := range strings.Split(string(out), "\n") {
+		switch {
+		case strings.HasPrefix(line, "ActiveState="):
+			state = strings.TrimPrefix(line, "ActiveState=")
+		case strings.HasPrefix(line, "MainPID="):
+			pid, err = strconv.Atoi(strings.TrimPrefix(line, "MainPID="))
+			if err != nil {
+				return false, 0, err
+			}
+		}
+	}
+
+	return state == "active", pid, nil
+}
+
 func stopProcess(service string) (err error) {
 	pid, err := getServicePID(service)
 	if err != nil {
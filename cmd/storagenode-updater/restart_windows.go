@@ -113,6 +113,24 @@ func restartService(ctx context.Context, service, binaryLocation, newVersionPath
 	return nil
 }
 
+// serviceStatus returns whether the service is running and its process id.
+func serviceStatus(service string) (running bool, pid int, err error) {
+	srvc, err := openService(service)
+	if err != nil {
+		return false, 0, err
+	}
+	defer func() {
+		err = errs.Combine(err, errs.Wrap(srvc.Close()))
+	}()
+
+	status, err := srvc.Query()
+	if err != nil {
+		return false, 0, errs.Wrap(err)
+	}
+
+	return status.State == svc.Running, int(status.ProcessId), nil
+}
+
 func openService(name string) (_ *mgr.Service, err error) {
 	manager, err := mgr.Connect()
 	if err != nil {
@@ -0,0 +1,180 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+)
+
+// errStatusUnsupported is returned by serviceStatus when the service state
+// can't be watched on this platform or build.
+var errStatusUnsupported = errs.New("service status is not supported")
+
+// RollbackConfig configures watching the service after an update and rolling
+// it back when it isn't healthy.
+type RollbackConfig struct {
+	GracePeriod   time.Duration `help:"how long the service is watched after an update before it's considered healthy; automatic rollback is disabled when zero" default:"2m0s"`
+	CheckInterval time.Duration `help:"how often the service is checked during the grace period" default:"10s"`
+	HealthURL     string        `help:"optional storage node dashboard url which must respond successfully at the end of the grace period, e.g. http://127.0.0.1:14002/api/sno/" default:""`
+	FailedPath    string        `help:"path of the file recording the versions which were rolled back" default:"$CONFDIR/storagenode-updater-failed.json"`
+}
+
+// waitHealthy watches the service for the grace period after it has been
+// restarted and returns an error when the service isn't healthy. status returns
+// the state of the service and oldPID is the process which was replaced, it may
+// still be stopping when waitHealthy is called.
+func waitHealthy(ctx context.Context, service string, status func() (running bool, pid int, err error), oldPID int, config RollbackConfig) error {
+	if config.GracePeriod <= 0 {
+		return nil
+	}
+
+	_, _, err := status()
+	watchService := !errors.Is(err, errStatusUnsupported)
+	if !watchService && config.HealthURL == "" {
+		// nothing we are able to watch
+		return nil
+	}
+
+	interval := config.CheckInterval
+	if interval <= 0 || interval > config.GracePeriod {
+		interval = config.GracePeriod
+	}
+
+	var lastPID int
+	if watchService {
+		// the grace period starts once the updated process runs, otherwise the old
+		// process being replaced would look like a crash.
+		lastPID, err = waitStarted(ctx, status, oldPID, config.GracePeriod, interval)
+		if err != nil {
+			return err
+		}
+	}
+
+	zap.L().Info("Watching service health.",
+		zap.String("Service", service),
+		zap.Stringer("Grace Period", config.GracePeriod),
+	)
+
+	deadline := time.Now().Add(config.GracePeriod)
+	running := true
+	for {
+		if !sync2.Sleep(ctx, interval) {
+			return ctx.Err()
+		}
+
+		if watchService {
+			var pid int
+			running, pid, err = status()
+			if err != nil {
+				return errs.Wrap(err)
+			}
+			// a different process means the service crashed and was restarted.
+			if running && pid != lastPID {
+				return errs.New("service restarted during grace period")
+			}
+		}
+
+		if !time.Now().Before(deadline) {
+			break
+		}
+	}
+
+	if watchService && !running {
+		return errs.New("service is not running after grace period")
+	}
+
+	if config.HealthURL != "" {
+		if err := checkHealthURL(ctx, config.HealthURL, interval); err != nil {
+			return errs.New("health check failed: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// waitStarted waits until the service runs a process other than oldPID and
+// returns its process id.
+func waitStarted(ctx context.Context, status func() (running bool, pid int, err error), oldPID int, timeout, interval time.Duration) (int, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		running, pid, err := status()
+		if err != nil {
+			return 0, errs.Wrap(err)
+		}
+		if running && pid != 0 && pid != oldPID {
+			return pid, nil
+		}
+
+		if !time.Now().Before(deadline) {
+			return 0, errs.New("service did not start within %s", timeout)
+		}
+		if !sync2.Sleep(ctx, interval) {
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// checkHealthURL checks that the url responds successfully.
+func checkHealthURL(ctx context.Context, url string, timeout time.Duration) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, resp.Body.Close()) }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errs.New("bad status: %s", resp.Status)
+	}
+	return nil
+}
+
+// failedVersions records by service name the version which was rolled back,
+// so that it isn't retried until the suggested version changes.
+type failedVersions map[string]string
+
+// loadFailedVersions loads the failed versions from path.
+func loadFailedVersions(path string) (failedVersions, error) {
+	failed := failedVersions{}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return failed, nil
+		}
+		return nil, errs.Wrap(err)
+	}
+
+	if err := json.Unmarshal(data, &failed); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return failed, nil
+}
+
+// save saves the failed versions to path.
+func (failed failedVersions) save(path string) error {
+	data, err := json.Marshal(failed)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	return errs.Wrap(ioutil.WriteFile(path, data, 0644))
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+)
+
+func TestWaitHealthy(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	config := RollbackConfig{
+		GracePeriod:   200 * time.Millisecond,
+		CheckInterval: 10 * time.Millisecond,
+	}
+
+	type state struct {
+		running bool
+		pid     int
+	}
+
+	// fakeStatus returns the states one after another and keeps returning the last one.
+	fakeStatus := func(states ...state) func() (bool, int, error) {
+		var mu sync.Mutex
+		return func() (bool, int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			current := states[0]
+			if len(states) > 1 {
+				states = states[1:]
+			}
+			return current.running, current.pid, nil
+		}
+	}
+
+	t.Run("old process still stopping", func(t *testing.T) {
+		status := fakeStatus(state{true, 1}, state{true, 1}, state{true, 1}, state{false, 0}, state{true, 2})
+		require.NoError(t, waitHealthy(ctx, "test", status, 1, config))
+	})
+
+	t.Run("crash during grace period", func(t *testing.T) {
+		status := fakeStatus(state{true, 1}, state{true, 2}, state{true, 2}, state{false, 0}, state{true, 3})
+		require.Error(t, waitHealthy(ctx, "test", status, 1, config))
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		status := fakeStatus(state{true, 2}, state{true, 2}, state{false, 0})
+		require.Error(t, waitHealthy(ctx, "test", status, 1, config))
+	})
+
+	t.Run("never started", func(t *testing.T) {
+		status := fakeStatus(state{true, 1})
+		require.Error(t, waitHealthy(ctx, "test", status, 1, config))
+	})
+}
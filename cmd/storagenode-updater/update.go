@@ -6,14 +6,71 @@ package main
 import (
 	"context"
 	"os"
+	"runtime"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/private/version"
+	"storj.io/storj/private/version/checker"
+	"storj.io/storj/private/version/manifest"
 )
 
-func update(ctx context.Context, serviceName, binaryLocation string, ver version.Process) error {
+// lastManifest is the timestamp of the newest release manifest accepted so far.
+var lastManifest time.Time
+
+// releaseManifest retrieves the release manifest and verifies its signature
+// with the configured public key. Manifests signed before the last accepted one
+// or longer than the max age ago are refused, so that a replayed manifest can't
+// announce outdated releases. It returns nil when no public key is configured.
+func releaseManifest(ctx context.Context, client *checker.Client) (*manifest.Manifest, error) {
+	if runCfg.ManifestPublicKey == "" {
+		return nil, nil
+	}
+
+	publicKey, err := manifest.ParsePublicKey(runCfg.ManifestPublicKey)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	signed, err := client.Manifest(ctx)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	releases, err := manifest.Verify(publicKey, signed)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+
+	if releases.Timestamp.Before(lastManifest) {
+		return nil, errs.New("release manifest signed at %s is older than the last one signed at %s", releases.Timestamp, lastManifest)
+	}
+	if age := time.Since(releases.Timestamp); runCfg.ManifestMaxAge > 0 && age > runCfg.ManifestMaxAge {
+		return nil, errs.New("release manifest signed at %s is stale", releases.Timestamp)
+	}
+	lastManifest = releases.Timestamp
+
+	return &releases, nil
+}
+
+// releaseBinary returns the download URL of the suggested version of the
+// process and, when there is a release manifest, the binary the download must
+// match. Without a release manifest the suggested URL is downloaded unverified.
+func releaseBinary(releases *manifest.Manifest, process string, ver version.Process) (url string, expected *manifest.Binary, err error) {
+	if releases == nil {
+		return parseDownloadURL(ver.Suggested.URL), nil, nil
+	}
+
+	binary, err := releases.Binary(process, ver.Suggested.Version, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return "", nil, errs.Wrap(err)
+	}
+	return parseDownloadURL(binary.URL), &binary, nil
+}
+
+func update(ctx context.Context, serviceName, processName, binaryLocation string, ver version.Process, releases *manifest.Manifest) error {
 	suggestedVersion, err := ver.Suggested.SemVer()
 	if err != nil {
 		return errs.Wrap(err)
@@ -39,9 +96,26 @@ func update(ctx context.Context, serviceName, binaryLocation string, ver version
 		return nil
 	}
 
+	failed, err := loadFailedVersions(runCfg.Rollback.FailedPath)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if failed[serviceName] == ver.Suggested.Version {
+		zap.L().Info("Suggested version was rolled back before, not retrying it.",
+			zap.String("Service", serviceName),
+			zap.String("Version", ver.Suggested.Version),
+		)
+		return nil
+	}
+
+	url, expected, err := releaseBinary(releases, processName, ver)
+	if err != nil {
+		return err
+	}
+
 	newVersionPath := prependExtension(binaryLocation, ver.Suggested.Version)
 
-	if err = downloadBinary(ctx, parseDownloadURL(ver.Suggested.URL), newVersionPath); err != nil {
+	if err = downloadBinary(ctx, url, expected, newVersionPath); err != nil {
 		return errs.Wrap(err)
 	}
 
@@ -68,10 +142,55 @@ func update(ctx context.Context, serviceName, binaryLocation string, ver version
 
 	zap.L().Info("Restarting service.", zap.String("Service", serviceName))
 
+	status := func() (bool, int, error) { return serviceStatus(serviceName) }
+	// the process being replaced, so that it isn't taken for the updated one.
+	_, oldPID, _ := status()
+
 	if err = restartService(ctx, serviceName, binaryLocation, newVersionPath, backupPath); err != nil {
 		return errs.Wrap(err)
 	}
 
 	zap.L().Info("Service restarted successfully.", zap.String("Service", serviceName))
+
+	if serviceName == updaterServiceName {
+		// NB: the updater can't watch itself being restarted.
+		return nil
+	}
+
+	if err = waitHealthy(ctx, serviceName, status, oldPID, runCfg.Rollback); err != nil {
+		zap.L().Error("Service is unhealthy after update. Rolling back.",
+			zap.String("Service", serviceName),
+			zap.String("Version", ver.Suggested.Version),
+			zap.Error(err),
+		)
+		return errs.Combine(err, rollback(ctx, serviceName, binaryLocation, backupPath, ver.Suggested.Version, failed))
+	}
+
+	if _, ok := failed[serviceName]; ok {
+		delete(failed, serviceName)
+		if err := failed.save(runCfg.Rollback.FailedPath); err != nil {
+			zap.L().Error("Unable to save failed versions.", zap.Error(err))
+		}
+	}
+
 	return nil
 }
+
+// rollback restores the backup binary of the service, restarts it and records
+// the failed version, so that it isn't retried until the suggested version changes.
+func rollback(ctx context.Context, serviceName, binaryLocation, backupPath, failedVersion string, failed failedVersions) error {
+	failed[serviceName] = failedVersion
+	saveErr := failed.save(runCfg.Rollback.FailedPath)
+
+	failedPath := prependExtension(binaryLocation, "failed."+failedVersion)
+	if err := restartService(ctx, serviceName, binaryLocation, backupPath, failedPath); err != nil {
+		return errs.Combine(errs.New("rollback failed: %v", err), saveErr)
+	}
+
+	zap.L().Info("Service rolled back successfully.",
+		zap.String("Service", serviceName),
+		zap.String("Failed Version", failedVersion),
+	)
+
+	return errs.Combine(saveErr, os.Remove(failedPath))
+}
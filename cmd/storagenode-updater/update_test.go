@@ -0,0 +1,90 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/private/version"
+	"storj.io/storj/private/version/checker"
+	"storj.io/storj/private/version/manifest"
+)
+
+func TestReleaseManifest_Stale(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	var timestamp time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signed, err := manifest.Sign(private, manifest.Manifest{Timestamp: timestamp})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(signed)
+	}))
+	defer server.Close()
+
+	savedKey, savedMaxAge := runCfg.ManifestPublicKey, runCfg.ManifestMaxAge
+	defer func() {
+		runCfg.ManifestPublicKey, runCfg.ManifestMaxAge = savedKey, savedMaxAge
+		lastManifest = time.Time{}
+	}()
+	runCfg.ManifestPublicKey = hex.EncodeToString(public)
+	runCfg.ManifestMaxAge = time.Hour
+
+	client := checker.New(checker.ClientConfig{ServerAddress: server.URL})
+	now := time.Now().UTC()
+
+	timestamp = now.Add(-2 * time.Hour)
+	_, err = releaseManifest(ctx, client)
+	require.Error(t, err)
+
+	timestamp = now
+	releases, err := releaseManifest(ctx, client)
+	require.NoError(t, err)
+	require.True(t, now.Equal(releases.Timestamp))
+
+	// a replayed older manifest is refused, even when it isn't stale yet
+	timestamp = now.Add(-time.Minute)
+	_, err = releaseManifest(ctx, client)
+	require.Error(t, err)
+
+	timestamp = now.Add(time.Minute)
+	_, err = releaseManifest(ctx, client)
+	require.NoError(t, err)
+}
+
+func TestReleaseManifest_NotConfigured(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	savedKey := runCfg.ManifestPublicKey
+	defer func() { runCfg.ManifestPublicKey = savedKey }()
+	runCfg.ManifestPublicKey = ""
+
+	// the manifest isn't retrieved, so the server is never contacted
+	client := checker.New(checker.ClientConfig{ServerAddress: "http://127.0.0.1:0"})
+	releases, err := releaseManifest(ctx, client)
+	require.NoError(t, err)
+	require.Nil(t, releases)
+
+	ver := version.Process{Suggested: version.Version{Version: "v1.2.3", URL: "https://example.test/{arch}/storagenode.zip"}}
+	url, expected, err := releaseBinary(releases, "storagenode", ver)
+	require.NoError(t, err)
+	require.Nil(t, expected)
+	require.Equal(t, parseDownloadURL(ver.Suggested.URL), url)
+}
@@ -91,6 +91,20 @@ var (
 		Short: "Issue apikey for mnd",
 		RunE:  cmdIssue,
 	}
+	migrateStorageCmd = &cobra.Command{
+		Use:   "migrate-storage [destination]",
+		Short: "Copy pieces to a new storage location",
+		Long: "Copy all pieces and trash from the current storage path to the destination " +
+			"and verify every copied piece. This can be done while the storage node keeps " +
+			"running and repeated to catch up with new uploads.\n" +
+			"Afterwards stop the storage node and run the command with --final. It copies the " +
+			"remaining pieces and switches storage.path in the config file to the destination " +
+			"once a pass finds nothing new to copy. Pieces which are corrupt in the current " +
+			"storage path can't be copied, switching without them requires --skip-corrupt.",
+		Args:        cobra.ExactArgs(1),
+		RunE:        cmdMigrateStorage,
+		Annotations: map[string]string{"type": "helper"},
+	}
 
 	runCfg       StorageNodeFlags
 	setupCfg     StorageNodeFlags
@@ -126,6 +140,8 @@ func init() {
 	rootCmd.AddCommand(gracefulExitInitCmd)
 	rootCmd.AddCommand(gracefulExitStatusCmd)
 	rootCmd.AddCommand(issueAPITokenCmd)
+	rootCmd.AddCommand(migrateStorageCmd)
+	rootCmd.AddCommand(ordersCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
 	process.Bind(configCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
@@ -134,6 +150,15 @@ func init() {
 	process.Bind(gracefulExitInitCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 	process.Bind(gracefulExitStatusCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 	process.Bind(issueAPITokenCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(migrateStorageCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	migrateStorageCmd.Flags().BoolVar(&migrateStorageFinal, "final", false, "copy the remaining pieces while the storage node is stopped and switch to the destination")
+	_ = migrateStorageCmd.Flags().SetAnnotation("final", "source", []string{cfgstruct.FlagSource})
+	migrateStorageCmd.Flags().BoolVar(&migrateStorageSkipCorrupt, "skip-corrupt", false, "switch to the destination even though some pieces are corrupt in the current storage path and weren't copied")
+	_ = migrateStorageCmd.Flags().SetAnnotation("skip-corrupt", "source", []string{cfgstruct.FlagSource})
+	process.Bind(ordersListCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
+	process.Bind(ordersInspectCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
+	process.Bind(ordersSettleCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
+	process.Bind(ordersRepairCmd, &diagCfg, defaults, cfgstruct.ConfDir(defaultDiagDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
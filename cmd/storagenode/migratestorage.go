@@ -0,0 +1,186 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/private/process"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storagenodedb"
+)
+
+// maxMigrationPasses is the number of copy passes done before giving up on
+// waiting for the source directory to settle.
+const maxMigrationPasses = 5
+
+// migrateStorageFinal is set for the last migration run, done while the storage
+// node is stopped, which switches the storage path to the destination.
+var migrateStorageFinal bool
+
+// migrateStorageSkipCorrupt is set when the operator accepts to switch to the
+// destination without the pieces which are corrupt in the source directory.
+var migrateStorageSkipCorrupt bool
+
+func cmdMigrateStorage(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+	log := zap.L()
+
+	identity, err := runCfg.Identity.Load()
+	if err != nil {
+		return errs.New("Failed to load identity: %+v", err)
+	}
+
+	if backend := runCfg.Config.Storage2.PieceBackend; backend != "" && backend != storagenodedb.FilestoreBackend {
+		return errs.New("migrating storage is not supported for the %q piece backend", backend)
+	}
+
+	source, err := filepath.Abs(runCfg.Storage.Path)
+	if err != nil {
+		return err
+	}
+	destination, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+	if isSubPath(source, destination) || isSubPath(destination, source) {
+		return errs.New("destination %q must not overlap with the current storage path %q", destination, source)
+	}
+
+	from, err := filestore.OpenDir(log.Named("source"), source)
+	if err != nil {
+		return errs.New("Error opening storage directory: %+v", err)
+	}
+	if err := from.Verify(identity.ID); err != nil {
+		return errs.New("Error verifying storage directory: %+v", err)
+	}
+
+	to, err := filestore.NewDir(log.Named("destination"), destination)
+	if err != nil {
+		return errs.New("Error creating destination directory: %+v", err)
+	}
+
+	if migrateStorageFinal && nodeListening(runCfg.Server.Address, runCfg.Server.PrivateAddress) {
+		return errs.New("the storage node is still running; stop it before running the final migration")
+	}
+
+	migrator := pieces.NewDirMigrator(log.Named("migrator"), from, to)
+
+	// A running node keeps accepting uploads into the source directory while we
+	// copy, so keep doing passes until one of them doesn't find anything new.
+	var total pieces.DirMigrationStats
+	settled := false
+	for pass := 1; pass <= maxMigrationPasses && !settled; pass++ {
+		stats, err := migrator.Pass(ctx)
+		total.Add(stats)
+		if err != nil {
+			return errs.New("Error migrating pieces: %+v", err)
+		}
+		log.Info("Migration pass finished.",
+			zap.Int("Pass", pass),
+			zap.Int64("Copied", stats.Copied),
+			zap.Int64("Trash", stats.Trash),
+			zap.Int64("Failed", stats.Failed),
+			zap.Int64("Corrupt", stats.Corrupt),
+			zap.Stringer("Size", memory.Size(stats.Bytes)))
+
+		settled = stats.Copied == 0 && stats.Trash == 0 && stats.Failed == 0
+	}
+
+	removed, err := migrator.RemoveDeleted(ctx)
+	total.Add(removed)
+	if err != nil {
+		return errs.New("Error removing deleted pieces: %+v", err)
+	}
+
+	fmt.Printf("Copied %d pieces and %d trashed pieces (%s), removed %d deleted pieces.\n",
+		total.Copied, total.Trash, memory.Size(total.Bytes).String(), total.Removed)
+
+	corrupt := migrator.Corrupt()
+	if len(corrupt) > 0 {
+		fmt.Printf("Skipped %d pieces which are corrupt in %q:\n", len(corrupt), source)
+		for _, ref := range corrupt {
+			fmt.Printf("  %s\n", filepath.Join(hex.EncodeToString(ref.Namespace), hex.EncodeToString(ref.Key)))
+		}
+	}
+
+	if total.Failed > 0 {
+		return errs.New("%d pieces failed verification, keeping storage path %q; check the log and run the migration again", total.Failed, source)
+	}
+
+	if !migrateStorageFinal {
+		fmt.Printf("The storage node still uses %q. Stop the storage node and run the migration "+
+			"again with --final to copy the remaining pieces and switch to %q.\n", source, destination)
+		return nil
+	}
+
+	// Nothing writes to the source directory while the node is stopped, so the
+	// last pass finds something new only when more passes were needed than allowed.
+	if !settled {
+		return errs.New("pieces were still being copied after %d passes, keeping storage path %q; run the final migration again", maxMigrationPasses, source)
+	}
+
+	// the node fails audits for the skipped pieces, so switching without them
+	// must be a decision of the operator.
+	if len(corrupt) > 0 && !migrateStorageSkipCorrupt {
+		return errs.New("%d pieces are corrupt in %q, keeping storage path %q; run the final migration again with --skip-corrupt to switch without them", len(corrupt), source, source)
+	}
+
+	if err := to.CreateVerificationFile(identity.ID); err != nil {
+		return errs.New("Error creating verification file: %+v", err)
+	}
+
+	// Databases live in the storage directory unless configured otherwise. Leave
+	// them where they are, only the pieces are moved.
+	overrides := map[string]interface{}{
+		"storage.path": destination,
+	}
+	if runCfg.Config.Storage2.DatabaseDir == "" {
+		overrides["storage2.database-dir"] = source
+	}
+
+	configFile := filepath.Join(confDir, "config.yaml")
+	if err := process.SaveConfig(cmd, configFile, process.SaveConfigWithOverrides(overrides)); err != nil {
+		return errs.New("Error updating config file: %+v", err)
+	}
+
+	fmt.Printf("Switched storage path to %q, start the storage node to use it. The pieces in %q "+
+		"are no longer used once the node runs from the new location.\n", destination, source)
+	return nil
+}
+
+// nodeListening returns whether any of the addresses is already in use, which
+// means the storage node is running on this machine.
+func nodeListening(addresses ...string) bool {
+	for _, address := range addresses {
+		if address == "" {
+			continue
+		}
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return true
+		}
+		_ = listener.Close()
+	}
+	return false
+}
+
+// isSubPath returns whether path is equal to or inside of parent.
+func isSubPath(parent, path string) bool {
+	rel, err := filepath.Rel(parent, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
@@ -0,0 +1,245 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/private/process"
+	"storj.io/storj/storagenode/internalpb"
+)
+
+var (
+	ordersCmd = &cobra.Command{
+		Use:         "orders",
+		Short:       "Inspect and manage unsent orders",
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersListCmd = &cobra.Command{
+		Use:         "list",
+		Short:       "List unsent orders windows",
+		RunE:        cmdOrdersList,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersInspectCmd = &cobra.Command{
+		Use:         "inspect <satellite-id> <window>",
+		Short:       "Display the orders and totals of an unsent window",
+		Long:        "Display the orders and totals of an unsent window. The window is the order creation hour in RFC3339 format, as displayed by the list command.",
+		Args:        cobra.ExactArgs(2),
+		RunE:        cmdOrdersInspect,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersSettleCmd = &cobra.Command{
+		Use:         "settle <satellite-id> <window>",
+		Short:       "Send the orders of an unsent window to the satellite now",
+		Args:        cobra.ExactArgs(2),
+		RunE:        cmdOrdersSettle,
+		Annotations: map[string]string{"type": "helper"},
+	}
+	ordersRepairCmd = &cobra.Command{
+		Use:   "repair <satellite-id> <window>",
+		Short: "Salvage the valid orders of a corrupted or truncated unsent window",
+		Long: "Rewrite the orders file of an unsent window keeping only the orders, which can be read. " +
+			"Files without corrupted orders are left unchanged.",
+		Args:        cobra.ExactArgs(2),
+		RunE:        cmdOrdersRepair,
+		Annotations: map[string]string{"type": "helper"},
+	}
+)
+
+func init() {
+	ordersCmd.AddCommand(ordersListCmd)
+	ordersCmd.AddCommand(ordersInspectCmd)
+	ordersCmd.AddCommand(ordersSettleCmd)
+	ordersCmd.AddCommand(ordersRepairCmd)
+}
+
+type ordersClient struct {
+	conn *rpc.Conn
+}
+
+func dialOrdersClient(ctx context.Context, address string) (*ordersClient, error) {
+	conn, err := rpc.NewDefaultDialer(nil).DialAddressUnencrypted(ctx, address)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &ordersClient{conn: conn}, nil
+}
+
+func (client *ordersClient) client() internalpb.DRPCNodeOrdersClient {
+	return internalpb.NewDRPCNodeOrdersClient(client.conn)
+}
+
+func (client *ordersClient) close() {
+	if err := client.conn.Close(); err != nil {
+		zap.L().Debug("Closing orders client failed.", zap.Error(err))
+	}
+}
+
+// parseOrdersWindow parses the satellite ID and the window arguments.
+func parseOrdersWindow(args []string) (storj.NodeID, time.Time, error) {
+	satelliteID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return storj.NodeID{}, time.Time{}, errs.New("invalid satellite ID %q: %v", args[0], err)
+	}
+	createdAt, err := time.Parse(time.RFC3339, args[1])
+	if err != nil {
+		return storj.NodeID{}, time.Time{}, errs.New("invalid window %q: %v", args[1], err)
+	}
+	return satelliteID, createdAt, nil
+}
+
+func cmdOrdersList(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	client, err := dialOrdersClient(ctx, diagCfg.Server.PrivateAddress)
+	if err != nil {
+		return err
+	}
+	defer client.close()
+
+	response, err := client.client().ListUnsentWindows(ctx, &internalpb.ListUnsentWindowsRequest{})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	if len(response.Windows) == 0 {
+		fmt.Println("No unsent orders.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { err = errs.Combine(err, w.Flush()) }()
+
+	fmt.Fprintln(w, "Satellite ID\tWindow\tVersion\tSize\tReady")
+	for _, window := range response.Windows {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n",
+			window.SatelliteId,
+			window.CreatedAtHour.UTC().Format(time.RFC3339),
+			window.Version,
+			memory.Size(window.FileSize),
+			window.Ready)
+	}
+	return nil
+}
+
+func cmdOrdersInspect(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satelliteID, createdAt, err := parseOrdersWindow(args)
+	if err != nil {
+		return err
+	}
+
+	client, err := dialOrdersClient(ctx, diagCfg.Server.PrivateAddress)
+	if err != nil {
+		return err
+	}
+	defer client.close()
+
+	response, err := client.client().InspectUnsentWindow(ctx, &internalpb.InspectUnsentWindowRequest{
+		SatelliteId: satelliteID,
+		CreatedAt:   createdAt,
+	})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer func() { err = errs.Combine(err, w.Flush()) }()
+
+	fmt.Fprintf(w, "Satellite ID:\t%s\n", response.Window.SatelliteId)
+	fmt.Fprintf(w, "Window:\t%s\n", response.Window.CreatedAtHour.UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "Version:\t%s\n", response.Window.Version)
+	fmt.Fprintf(w, "Size:\t%s\n", memory.Size(response.Window.FileSize))
+	fmt.Fprintf(w, "Ready:\t%t\n", response.Window.Ready)
+	fmt.Fprintf(w, "Orders:\t%d\n", len(response.Orders))
+	fmt.Fprintf(w, "Corrupted:\t%d\n", response.Corrupted)
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Action\tOrders\tAmount")
+	for _, total := range response.Totals {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", total.Action, total.Count, memory.Size(total.Amount))
+	}
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Serial Number\tPiece ID\tAction\tAmount\tLimit\tCreated At")
+	for _, order := range response.Orders {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\n",
+			order.SerialNumber,
+			order.PieceId,
+			order.Action,
+			order.Amount,
+			order.Limit,
+			order.OrderCreation.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func cmdOrdersSettle(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satelliteID, createdAt, err := parseOrdersWindow(args)
+	if err != nil {
+		return err
+	}
+
+	client, err := dialOrdersClient(ctx, diagCfg.Server.PrivateAddress)
+	if err != nil {
+		return err
+	}
+	defer client.close()
+
+	response, err := client.client().SettleUnsentWindow(ctx, &internalpb.SettleUnsentWindowRequest{
+		SatelliteId: satelliteID,
+		CreatedAt:   createdAt,
+	})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	fmt.Printf("Sent %d orders, settlement status: %s\n", response.Count, response.Status)
+	return nil
+}
+
+func cmdOrdersRepair(cmd *cobra.Command, args []string) (err error) {
+	ctx, _ := process.Ctx(cmd)
+
+	satelliteID, createdAt, err := parseOrdersWindow(args)
+	if err != nil {
+		return err
+	}
+
+	client, err := dialOrdersClient(ctx, diagCfg.Server.PrivateAddress)
+	if err != nil {
+		return err
+	}
+	defer client.close()
+
+	response, err := client.client().RepairUnsentWindow(ctx, &internalpb.RepairUnsentWindowRequest{
+		SatelliteId: satelliteID,
+		CreatedAt:   createdAt,
+	})
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	if !response.Repaired {
+		fmt.Printf("No corrupted orders found, %d orders are valid.\n", response.Salvaged)
+		return nil
+	}
+	fmt.Printf("Salvaged %d orders, dropped %d corrupted entries.\n", response.Salvaged, response.Corrupted)
+	return nil
+}
@@ -4,6 +4,9 @@
 package main
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,6 +18,7 @@ import (
 	"storj.io/private/cfgstruct"
 	"storj.io/private/process"
 	_ "storj.io/storj/private/version" // This attaches version information during release builds.
+	"storj.io/storj/private/version/manifest"
 	"storj.io/storj/versioncontrol"
 )
 
@@ -28,6 +32,12 @@ var (
 		Short: "Run the versioncontrol server",
 		RunE:  cmdRun,
 	}
+	signingKeyCmd = &cobra.Command{
+		Use:   "signing-key <path>",
+		Short: "Create a key for signing the release manifest",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdSigningKey,
+	}
 	setupCmd = &cobra.Command{
 		Use:         "setup",
 		Short:       "Create config files",
@@ -51,6 +61,7 @@ func init() {
 	defaults := cfgstruct.DefaultsFlag(rootCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(signingKeyCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.SetupMode())
 }
@@ -66,6 +77,21 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 	return err
 }
 
+func cmdSigningKey(cmd *cobra.Command, args []string) (err error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	if err := manifest.SavePrivateKey(args[0], privateKey); err != nil {
+		return err
+	}
+
+	fmt.Printf("Release manifest signing key saved to %s.\n", args[0])
+	fmt.Printf("Configure storage node updaters with --manifest-public-key %s\n", hex.EncodeToString(publicKey))
+	return nil
+}
+
 func cmdSetup(cmd *cobra.Command, args []string) (err error) {
 	setupDir, err := filepath.Abs(confDir)
 	if err != nil {
@@ -163,6 +163,56 @@ func (controller *Nodes) List(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Info handles retrieving the information of the node from the node itself.
+func (controller *Nodes) Info(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	vars := mux.Vars(r)
+
+	nodeID, err := storj.NodeIDFromString(vars["id"])
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrNodes.Wrap(err))
+		return
+	}
+
+	info, err := controller.service.Info(ctx, nodeID)
+	if err != nil {
+		controller.log.Error("get node info not found error", zap.Error(err))
+		controller.serveError(w, http.StatusNotFound, ErrNodes.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(info); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Dashboard handles retrieving the information of all nodes and their totals.
+func (controller *Nodes) Dashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	dashboard, err := controller.service.Dashboard(ctx)
+	if err != nil {
+		controller.log.Error("dashboard internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrNodes.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(dashboard); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
 // Delete handles node removal.
 func (controller *Nodes) Delete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -0,0 +1,196 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/multinode/payouts"
+)
+
+var (
+	// ErrPayouts is an internal error type for payouts web api controller.
+	ErrPayouts = errs.Class("payouts web api controller error")
+)
+
+// Payouts is a web api controller.
+type Payouts struct {
+	log     *zap.Logger
+	service *payouts.Service
+}
+
+// NewPayouts is a constructor for Payouts.
+func NewPayouts(log *zap.Logger, service *payouts.Service) *Payouts {
+	return &Payouts{
+		log:     log,
+		service: service,
+	}
+}
+
+// PayStubs handles retrieving the paystubs of all nodes for the period range
+// and an optional satellite.
+func (controller *Payouts) PayStubs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	query := r.URL.Query()
+
+	satelliteID, err := satelliteIDFromQuery(query.Get("satelliteId"))
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrPayouts.Wrap(err))
+		return
+	}
+
+	payStubs, err := controller.service.PayStubs(ctx, query.Get("start"), query.Get("end"), satelliteID)
+	if err != nil {
+		controller.log.Error("paystubs internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrPayouts.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(payStubs); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// PayStubsCSV handles exporting the paystubs of all nodes for the period range
+// and an optional satellite as CSV.
+func (controller *Payouts) PayStubsCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	query := r.URL.Query()
+
+	satelliteID, err := satelliteIDFromQuery(query.Get("satelliteId"))
+	if err != nil {
+		w.Header().Add("Content-Type", "application/json")
+		controller.serveError(w, http.StatusBadRequest, ErrPayouts.Wrap(err))
+		return
+	}
+
+	payStubs, err := controller.service.PayStubs(ctx, query.Get("start"), query.Get("end"), satelliteID)
+	if err != nil {
+		controller.log.Error("paystubs internal error", zap.Error(err))
+		w.Header().Add("Content-Type", "application/json")
+		controller.serveError(w, http.StatusInternalServerError, ErrPayouts.Wrap(err))
+		return
+	}
+
+	w.Header().Add("Content-Type", "text/csv")
+	w.Header().Add("Content-Disposition", `attachment; filename="paystubs.csv"`)
+
+	if err = payouts.WritePayStubsCSV(w, payStubs.PayStubs); err != nil {
+		controller.log.Error("failed to write csv response", zap.Error(err))
+		return
+	}
+}
+
+// HeldAmounts handles retrieving the held amounts of all nodes.
+func (controller *Payouts) HeldAmounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	heldAmounts, err := controller.service.HeldAmounts(ctx)
+	if err != nil {
+		controller.log.Error("held amounts internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrPayouts.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(heldAmounts); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// Estimations handles retrieving the estimated payouts of all nodes for an optional satellite.
+func (controller *Payouts) Estimations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	satelliteID, err := satelliteIDFromQuery(r.URL.Query().Get("satelliteId"))
+	if err != nil {
+		controller.serveError(w, http.StatusBadRequest, ErrPayouts.Wrap(err))
+		return
+	}
+
+	estimations, err := controller.service.Estimations(ctx, satelliteID)
+	if err != nil {
+		controller.log.Error("estimations internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrPayouts.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(estimations); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// PaymentHistory handles retrieving the payments to all nodes for the period.
+func (controller *Payouts) PaymentHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		controller.serveError(w, http.StatusBadRequest, ErrPayouts.New("period query parameter is missing"))
+		return
+	}
+
+	history, err := controller.service.PaymentHistory(ctx, period)
+	if err != nil {
+		controller.log.Error("payment history internal error", zap.Error(err))
+		controller.serveError(w, http.StatusInternalServerError, ErrPayouts.Wrap(err))
+		return
+	}
+
+	if err = json.NewEncoder(w).Encode(history); err != nil {
+		controller.log.Error("failed to write json response", zap.Error(err))
+		return
+	}
+}
+
+// serveError set http statuses and send json error.
+func (controller *Payouts) serveError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(err))
+	}
+}
+
+// satelliteIDFromQuery parses the optional satellite id query parameter.
+func satelliteIDFromQuery(value string) (storj.NodeID, error) {
+	if value == "" {
+		return storj.NodeID{}, nil
+	}
+	return storj.NodeIDFromString(value)
+}
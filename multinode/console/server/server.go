@@ -15,6 +15,7 @@ import (
 
 	"storj.io/storj/multinode/console/controllers"
 	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/multinode/payouts"
 )
 
 var (
@@ -34,19 +35,21 @@ type Config struct {
 type Server struct {
 	log *zap.Logger
 
-	config Config
-	nodes  *nodes.Service
+	config  Config
+	nodes   *nodes.Service
+	payouts *payouts.Service
 
 	listener net.Listener
 	http     http.Server
 }
 
 // NewServer returns new instance of Multinode Dashboard http server.
-func NewServer(log *zap.Logger, config Config, nodes *nodes.Service, listener net.Listener) (*Server, error) {
+func NewServer(log *zap.Logger, config Config, nodes *nodes.Service, payouts *payouts.Service, listener net.Listener) (*Server, error) {
 	server := Server{
 		log:      log,
 		config:   config,
 		nodes:    nodes,
+		payouts:  payouts,
 		listener: listener,
 	}
 
@@ -58,9 +61,19 @@ func NewServer(log *zap.Logger, config Config, nodes *nodes.Service, listener ne
 	nodesRouter := apiRouter.PathPrefix("/nodes").Subrouter()
 	nodesRouter.HandleFunc("", nodesController.Add).Methods(http.MethodPost)
 	nodesRouter.HandleFunc("", nodesController.List).Methods(http.MethodGet)
+	nodesRouter.HandleFunc("/dashboard", nodesController.Dashboard).Methods(http.MethodGet)
 	nodesRouter.HandleFunc("/{id}", nodesController.Get).Methods(http.MethodGet)
 	nodesRouter.HandleFunc("/{id}", nodesController.UpdateName).Methods(http.MethodPatch)
 	nodesRouter.HandleFunc("/{id}", nodesController.Delete).Methods(http.MethodDelete)
+	nodesRouter.HandleFunc("/{id}/info", nodesController.Info).Methods(http.MethodGet)
+
+	payoutsController := controllers.NewPayouts(server.log, server.payouts)
+	payoutsRouter := apiRouter.PathPrefix("/payouts").Subrouter()
+	payoutsRouter.HandleFunc("/paystubs", payoutsController.PayStubs).Methods(http.MethodGet)
+	payoutsRouter.HandleFunc("/paystubs/csv", payoutsController.PayStubsCSV).Methods(http.MethodGet)
+	payoutsRouter.HandleFunc("/held-amounts", payoutsController.HeldAmounts).Methods(http.MethodGet)
+	payoutsRouter.HandleFunc("/estimations", payoutsController.Estimations).Methods(http.MethodGet)
+	payoutsRouter.HandleFunc("/payment-history", payoutsController.PaymentHistory).Methods(http.MethodGet)
 
 	server.http = http.Server{
 		Handler: router,
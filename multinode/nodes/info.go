@@ -0,0 +1,67 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes
+
+import (
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// Info contains the information retrieved from a storage node.
+type Info struct {
+	ID            storj.NodeID `json:"id"`
+	Name          string       `json:"name"`
+	PublicAddress string       `json:"publicAddress"`
+
+	// Online is set when the node answered with its status.
+	Online     bool         `json:"online"`
+	Version    string       `json:"version"`
+	StartedAt  time.Time    `json:"startedAt"`
+	DiskSpace  DiskSpace    `json:"diskSpace"`
+	Reputation []Reputation `json:"reputation"`
+
+	// Error describes why the information could not be retrieved from the node.
+	Error string `json:"error,omitempty"`
+	// RetrievedAt is the time the information was retrieved from the node.
+	RetrievedAt time.Time `json:"retrievedAt"`
+}
+
+// DiskSpace describes the disk space of a storage node.
+type DiskSpace struct {
+	Used      int64 `json:"used"`
+	Available int64 `json:"available"`
+	Trash     int64 `json:"trash"`
+	Overused  int64 `json:"overused"`
+}
+
+// Add adds other disk space to the disk space.
+func (diskSpace *DiskSpace) Add(other DiskSpace) {
+	diskSpace.Used += other.Used
+	diskSpace.Available += other.Available
+	diskSpace.Trash += other.Trash
+	diskSpace.Overused += other.Overused
+}
+
+// Reputation describes the reputation of a storage node on a satellite.
+type Reputation struct {
+	SatelliteID     storj.NodeID `json:"satelliteId"`
+	AuditScore      float64      `json:"auditScore"`
+	SuspensionScore float64      `json:"suspensionScore"`
+	OnlineScore     float64      `json:"onlineScore"`
+
+	JoinedAt           time.Time  `json:"joinedAt"`
+	Disqualified       *time.Time `json:"disqualified"`
+	Suspended          *time.Time `json:"suspended"`
+	OfflineSuspended   *time.Time `json:"offlineSuspended"`
+	OfflineUnderReview *time.Time `json:"offlineUnderReview"`
+}
+
+// Dashboard contains the information of all added nodes and their totals.
+type Dashboard struct {
+	Online    int       `json:"online"`
+	Offline   int       `json:"offline"`
+	DiskSpace DiskSpace `json:"diskSpace"`
+	Nodes     []Info    `json:"nodes"`
+}
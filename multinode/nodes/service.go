@@ -5,12 +5,16 @@ package nodes
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
+	"storj.io/common/rpc"
 	"storj.io/common/storj"
+	"storj.io/storj/multinodepb"
 )
 
 var (
@@ -20,19 +24,33 @@ var (
 	Error = errs.Class("nodes service error")
 )
 
+// Config contains configuration for the nodes service.
+type Config struct {
+	Timeout         time.Duration `help:"how long to wait for a storage node to respond" default:"10s"`
+	CacheExpiration time.Duration `help:"how long the information retrieved from a storage node is reused" default:"30s"`
+}
+
 // Service exposes all nodes related logic.
 //
 // architecture: Service
 type Service struct {
-	log   *zap.Logger
-	nodes DB
+	log    *zap.Logger
+	dialer rpc.Dialer
+	nodes  DB
+	config Config
+
+	mu    sync.Mutex
+	cache map[storj.NodeID]Info
 }
 
 // NewService creates new instance of Service.
-func NewService(log *zap.Logger, nodes DB) *Service {
+func NewService(log *zap.Logger, dialer rpc.Dialer, nodes DB, config Config) *Service {
 	return &Service{
-		log:   log,
-		nodes: nodes,
+		log:    log,
+		dialer: dialer,
+		nodes:  nodes,
+		config: config,
+		cache:  make(map[storj.NodeID]Info),
 	}
 }
 
@@ -76,5 +94,159 @@ func (service *Service) List(ctx context.Context) (_ []Node, err error) {
 // Remove removes node from the system.
 func (service *Service) Remove(ctx context.Context, id storj.NodeID) (err error) {
 	defer mon.Task()(&ctx)(&err)
+
+	service.mu.Lock()
+	delete(service.cache, id)
+	service.mu.Unlock()
+
 	return Error.Wrap(service.nodes.Remove(ctx, id))
 }
+
+// Info retrieves the information of the node from the node itself.
+func (service *Service) Info(ctx context.Context, id storj.NodeID) (_ Info, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := service.nodes.Get(ctx, id)
+	if err != nil {
+		return Info{}, Error.Wrap(err)
+	}
+
+	return service.info(ctx, node), nil
+}
+
+// Dashboard retrieves the information of all added nodes from the nodes
+// themselves and sums it up. Nodes which can't be reached are reported with
+// an error instead of failing the whole request.
+func (service *Service) Dashboard(ctx context.Context) (_ Dashboard, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodes, err := service.nodes.List(ctx)
+	if err != nil {
+		return Dashboard{}, Error.Wrap(err)
+	}
+
+	dashboard := Dashboard{
+		Nodes: make([]Info, len(nodes)),
+	}
+
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		i, node := i, node
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dashboard.Nodes[i] = service.info(ctx, node)
+		}()
+	}
+	wg.Wait()
+
+	for _, info := range dashboard.Nodes {
+		if !info.Online {
+			dashboard.Offline++
+			continue
+		}
+		dashboard.Online++
+		dashboard.DiskSpace.Add(info.DiskSpace)
+	}
+
+	return dashboard, nil
+}
+
+// info returns the information of the node, retrieving it from the node when
+// the cached information has expired.
+func (service *Service) info(ctx context.Context, node Node) Info {
+	service.mu.Lock()
+	info, ok := service.cache[node.ID]
+	service.mu.Unlock()
+
+	if !ok || time.Since(info.RetrievedAt) > service.config.CacheExpiration {
+		info = service.retrieve(ctx, node)
+
+		service.mu.Lock()
+		service.cache[node.ID] = info
+		service.mu.Unlock()
+	}
+
+	info.Name = node.Name
+	info.PublicAddress = node.PublicAddress
+	return info
+}
+
+// Call dials the node and calls fn with the connection. The context passed to
+// fn carries the API secret of the node and is cancelled after the configured
+// timeout.
+func (service *Service) Call(ctx context.Context, node Node, fn func(ctx context.Context, conn *rpc.Conn) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.config.Timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, service.config.Timeout)
+		defer cancel()
+	}
+
+	conn, err := service.dialer.DialAddressUnencrypted(ctx, node.PublicAddress)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, conn.Close()) }()
+
+	ctx = multinodepb.WithAPIKey(ctx, node.APISecret)
+	return fn(ctx, conn)
+}
+
+// retrieve dials the node and retrieves its information.
+func (service *Service) retrieve(ctx context.Context, node Node) (info Info) {
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	info = Info{
+		ID:          node.ID,
+		RetrievedAt: time.Now(),
+	}
+
+	err = service.Call(ctx, node, func(ctx context.Context, conn *rpc.Conn) error {
+		status, err := multinodepb.NewDRPCStatusClient(conn).Get(ctx, &multinodepb.GetRequest{})
+		if err != nil {
+			return err
+		}
+		info.Online = true
+		info.Version = status.Version
+		info.StartedAt = status.StartedAt
+
+		diskSpace, err := multinodepb.NewDRPCNodeDiskSpaceClient(conn).GetDiskSpace(ctx, &multinodepb.GetDiskSpaceRequest{})
+		if err != nil {
+			return err
+		}
+		info.DiskSpace = DiskSpace{
+			Used:      diskSpace.DiskSpace.GetUsed(),
+			Available: diskSpace.DiskSpace.GetAvailable(),
+			Trash:     diskSpace.DiskSpace.GetTrash(),
+			Overused:  diskSpace.DiskSpace.GetOverused(),
+		}
+
+		reputation, err := multinodepb.NewDRPCReputationClient(conn).All(ctx, &multinodepb.AllRequest{})
+		if err != nil {
+			return err
+		}
+		for _, stats := range reputation.Reputation {
+			info.Reputation = append(info.Reputation, Reputation{
+				SatelliteID:        stats.SatelliteId,
+				AuditScore:         stats.AuditCheck.GetReputationScore(),
+				SuspensionScore:    stats.AuditCheck.GetUnknownReputationScore(),
+				OnlineScore:        stats.OnlineScore,
+				JoinedAt:           stats.JoinedAt,
+				Disqualified:       stats.Disqualified,
+				Suspended:          stats.Suspended,
+				OfflineSuspended:   stats.OfflineSuspended,
+				OfflineUnderReview: stats.OfflineUnderReview,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		service.log.Debug("unable to retrieve node information", zap.Stringer("Node ID", node.ID), zap.Error(err))
+		info.Error = err.Error()
+	}
+
+	return info
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nodes_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/pkg/server"
+)
+
+func TestServiceDashboard(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	ident := testidentity.MustPregeneratedIdentity(0, storj.LatestIDVersion())
+	tlsOptions, err := tlsopts.NewOptions(ident, tlsopts.Config{PeerIDVersions: "*"}, nil)
+	require.NoError(t, err)
+
+	srv, err := server.New(zaptest.NewLogger(t), tlsOptions, server.Config{
+		Address:        "127.0.0.1:0",
+		PrivateAddress: "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+	defer ctx.Check(srv.Close)
+
+	endpoint := &fakeEndpoint{
+		apiSecret:   testrand.BytesInt(32),
+		satelliteID: testrand.NodeID(),
+	}
+	require.NoError(t, multinodepb.DRPCRegisterStatus(srv.PrivateDRPC(), endpoint))
+	require.NoError(t, multinodepb.DRPCRegisterNodeDiskSpace(srv.PrivateDRPC(), endpoint))
+	require.NoError(t, multinodepb.DRPCRegisterReputation(srv.PrivateDRPC(), endpoint))
+
+	ctx.Go(func() error { return srv.Run(ctx) })
+
+	// an address nobody listens on
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	offlineAddress := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	db := newFakeDB()
+	online := nodes.Node{ID: testrand.NodeID(), APISecret: endpoint.apiSecret, PublicAddress: srv.PrivateAddr().String(), Name: "online"}
+	unauthorized := nodes.Node{ID: testrand.NodeID(), APISecret: testrand.BytesInt(32), PublicAddress: srv.PrivateAddr().String()}
+	offline := nodes.Node{ID: testrand.NodeID(), APISecret: endpoint.apiSecret, PublicAddress: offlineAddress}
+	for _, node := range []nodes.Node{online, unauthorized, offline} {
+		require.NoError(t, db.Add(ctx, node.ID, node.APISecret, node.PublicAddress))
+	}
+	require.NoError(t, db.UpdateName(ctx, online.ID, online.Name))
+
+	service := nodes.NewService(zaptest.NewLogger(t), rpc.NewDefaultDialer(nil), db, nodes.Config{
+		Timeout:         10 * time.Second,
+		CacheExpiration: time.Hour,
+	})
+
+	dashboard, err := service.Dashboard(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, dashboard.Online)
+	require.Equal(t, 2, dashboard.Offline)
+	require.Equal(t, nodes.DiskSpace{Used: 10, Available: 100, Trash: 1}, dashboard.DiskSpace)
+	require.Len(t, dashboard.Nodes, 3)
+
+	infos := map[storj.NodeID]nodes.Info{}
+	for _, info := range dashboard.Nodes {
+		infos[info.ID] = info
+	}
+
+	info := infos[online.ID]
+	require.True(t, info.Online)
+	require.Empty(t, info.Error)
+	require.Equal(t, "online", info.Name)
+	require.Equal(t, "v1.2.3", info.Version)
+	require.Equal(t, nodes.DiskSpace{Used: 10, Available: 100, Trash: 1}, info.DiskSpace)
+	require.Len(t, info.Reputation, 1)
+	require.Equal(t, endpoint.satelliteID, info.Reputation[0].SatelliteID)
+	require.Equal(t, 0.95, info.Reputation[0].AuditScore)
+
+	// the node rejected the api secret
+	require.False(t, infos[unauthorized.ID].Online)
+	require.NotEmpty(t, infos[unauthorized.ID].Error)
+
+	require.False(t, infos[offline.ID].Online)
+	require.NotEmpty(t, infos[offline.ID].Error)
+
+	// the information is served from the cache
+	calls := atomic.LoadInt64(&endpoint.calls)
+	info, err = service.Info(ctx, online.ID)
+	require.NoError(t, err)
+	require.Equal(t, "v1.2.3", info.Version)
+	require.Equal(t, calls, atomic.LoadInt64(&endpoint.calls))
+}
+
+type fakeEndpoint struct {
+	apiSecret   []byte
+	satelliteID storj.NodeID
+	calls       int64
+}
+
+func (endpoint *fakeEndpoint) authenticate(ctx context.Context) error {
+	atomic.AddInt64(&endpoint.calls, 1)
+	apiKey, ok := multinodepb.APIKeyFromContext(ctx)
+	if !ok || !bytes.Equal(apiKey, endpoint.apiSecret) {
+		return rpcstatus.Error(rpcstatus.Unauthenticated, "invalid api key")
+	}
+	return nil
+}
+
+func (endpoint *fakeEndpoint) Get(ctx context.Context, req *multinodepb.GetRequest) (*multinodepb.GetResponse, error) {
+	if err := endpoint.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return &multinodepb.GetResponse{StartedAt: time.Now(), Version: "v1.2.3"}, nil
+}
+
+func (endpoint *fakeEndpoint) GetDiskSpace(ctx context.Context, req *multinodepb.GetDiskSpaceRequest) (*multinodepb.GetDiskSpaceResponse, error) {
+	if err := endpoint.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return &multinodepb.GetDiskSpaceResponse{DiskSpace: &multinodepb.DiskSpace{Used: 10, Available: 100, Trash: 1}}, nil
+}
+
+func (endpoint *fakeEndpoint) DailyStorageUsage(ctx context.Context, req *multinodepb.DailyStorageUsageRequest) (*multinodepb.DailyStorageUsageResponse, error) {
+	return nil, rpcstatus.Error(rpcstatus.Unimplemented, "not implemented")
+}
+
+func (endpoint *fakeEndpoint) SatelliteSummary(ctx context.Context, req *multinodepb.SatelliteSummaryRequest) (*multinodepb.SatelliteSummaryResponse, error) {
+	return nil, rpcstatus.Error(rpcstatus.Unimplemented, "not implemented")
+}
+
+func (endpoint *fakeEndpoint) GetBySatelliteID(ctx context.Context, req *multinodepb.GetBySatelliteIDRequest) (*multinodepb.GetBySatelliteIDResponse, error) {
+	return nil, rpcstatus.Error(rpcstatus.Unimplemented, "not implemented")
+}
+
+func (endpoint *fakeEndpoint) All(ctx context.Context, req *multinodepb.AllRequest) (*multinodepb.AllResponse, error) {
+	if err := endpoint.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return &multinodepb.AllResponse{
+		Reputation: []*multinodepb.GetBySatelliteIDResponse{{
+			SatelliteId: endpoint.satelliteID,
+			AuditCheck:  &multinodepb.ReputationStats{ReputationScore: 0.95, UnknownReputationScore: 1},
+			OnlineScore: 1,
+			JoinedAt:    time.Now(),
+		}},
+	}, nil
+}
+
+// fakeDB is an in-memory nodes database.
+type fakeDB struct {
+	mu    sync.Mutex
+	nodes map[storj.NodeID]nodes.Node
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{nodes: map[storj.NodeID]nodes.Node{}}
+}
+
+func (db *fakeDB) Get(ctx context.Context, id storj.NodeID) (nodes.Node, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	node, ok := db.nodes[id]
+	if !ok {
+		return nodes.Node{}, nodes.ErrNoNode.New("%s", id)
+	}
+	return node, nil
+}
+
+func (db *fakeDB) List(ctx context.Context) ([]nodes.Node, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	var list []nodes.Node
+	for _, node := range db.nodes {
+		list = append(list, node)
+	}
+	return list, nil
+}
+
+func (db *fakeDB) Add(ctx context.Context, id storj.NodeID, apiSecret []byte, publicAddress string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.nodes[id] = nodes.Node{ID: id, APISecret: apiSecret, PublicAddress: publicAddress}
+	return nil
+}
+
+func (db *fakeDB) Remove(ctx context.Context, id storj.NodeID) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	delete(db.nodes, id)
+	return nil
+}
+
+func (db *fakeDB) UpdateName(ctx context.Context, id storj.NodeID, name string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	node := db.nodes[id]
+	node.Name = name
+	db.nodes[id] = node
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// payStubsCSVHeader is the header row of the paystubs CSV export.
+var payStubsCSVHeader = []string{
+	"Node ID", "Node Name", "Satellite ID", "Period", "Created",
+	"Usage At Rest (byte-hours)", "Usage Get (bytes)", "Usage Put (bytes)",
+	"Usage Get Repair (bytes)", "Usage Put Repair (bytes)", "Usage Get Audit (bytes)",
+	"Comp At Rest (USD)", "Comp Get (USD)", "Comp Put (USD)",
+	"Comp Get Repair (USD)", "Comp Put Repair (USD)", "Comp Get Audit (USD)",
+	"Surge Percent", "Held (USD)", "Owed (USD)", "Disposed (USD)", "Paid (USD)",
+}
+
+// WritePayStubsCSV writes the paystubs as CSV, one row per node, satellite and period.
+// The amounts are written in dollars.
+func WritePayStubsCSV(w io.Writer, payStubs []PayStub) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(payStubsCSVHeader); err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, payStub := range payStubs {
+		err := writer.Write([]string{
+			payStub.NodeID.String(),
+			payStub.NodeName,
+			payStub.SatelliteID.String(),
+			payStub.Period,
+			payStub.Created.UTC().Format(time.RFC3339),
+			strconv.FormatFloat(payStub.UsageAtRest, 'f', -1, 64),
+			strconv.FormatInt(payStub.UsageGet, 10),
+			strconv.FormatInt(payStub.UsagePut, 10),
+			strconv.FormatInt(payStub.UsageGetRepair, 10),
+			strconv.FormatInt(payStub.UsagePutRepair, 10),
+			strconv.FormatInt(payStub.UsageGetAudit, 10),
+			formatDollars(payStub.CompAtRest),
+			formatDollars(payStub.CompGet),
+			formatDollars(payStub.CompPut),
+			formatDollars(payStub.CompGetRepair),
+			formatDollars(payStub.CompPutRepair),
+			formatDollars(payStub.CompGetAudit),
+			strconv.FormatInt(payStub.SurgePercent, 10),
+			formatDollars(payStub.Held),
+			formatDollars(payStub.Owed),
+			formatDollars(payStub.Disposed),
+			formatDollars(payStub.Paid),
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	writer.Flush()
+	return Error.Wrap(writer.Error())
+}
+
+// formatDollars formats an amount of micro dollars as dollars, without losing precision.
+func formatDollars(micro int64) string {
+	sign := ""
+	abs := uint64(micro)
+	if micro < 0 {
+		sign = "-"
+		abs = uint64(-micro)
+	}
+
+	fraction := strconv.FormatUint(abs%1e6, 10)
+	for len(fraction) < 6 {
+		fraction = "0" + fraction
+	}
+
+	return sign + strconv.FormatUint(abs/1e6, 10) + "." + fraction
+}
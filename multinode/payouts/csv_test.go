@@ -0,0 +1,70 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+)
+
+func TestFormatDollars(t *testing.T) {
+	for _, test := range []struct {
+		micro    int64
+		expected string
+	}{
+		{0, "0.000000"},
+		{1, "0.000001"},
+		{1000000, "1.000000"},
+		{1234567, "1.234567"},
+		{-1234567, "-1.234567"},
+		{-5, "-0.000005"},
+		{9007199254740993, "9007199254.740993"},
+	} {
+		require.Equal(t, test.expected, formatDollars(test.micro))
+	}
+}
+
+func TestWritePayStubsCSV(t *testing.T) {
+	payStub := PayStub{
+		NodeID:      testrand.NodeID(),
+		NodeName:    "node, with comma",
+		SatelliteID: testrand.NodeID(),
+		Period:      "2020-11",
+		Created:     time.Date(2020, 12, 3, 10, 0, 0, 0, time.UTC),
+		UsageAtRest: 1.5,
+		UsageGet:    100,
+		CompGet:     2000000,
+		Held:        250000,
+		Owed:        1750000,
+		Paid:        1750000,
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePayStubsCSV(&buf, []PayStub{payStub}))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, payStubsCSVHeader, records[0])
+
+	row := records[1]
+	require.Len(t, row, len(payStubsCSVHeader))
+	require.Equal(t, payStub.NodeID.String(), row[0])
+	require.Equal(t, "node, with comma", row[1])
+	require.Equal(t, payStub.SatelliteID.String(), row[2])
+	require.Equal(t, "2020-11", row[3])
+	require.Equal(t, "2020-12-03T10:00:00Z", row[4])
+	require.Equal(t, "1.5", row[5])
+	require.Equal(t, "100", row[6])
+	require.Equal(t, "2.000000", row[12])
+	require.Equal(t, "0.250000", row[18])
+	require.Equal(t, "1.750000", row[19])
+	require.Equal(t, "1.750000", row[21])
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// NodeError describes why the payout information could not be retrieved from a node.
+type NodeError struct {
+	NodeID   storj.NodeID `json:"nodeId"`
+	NodeName string       `json:"nodeName"`
+	Error    string       `json:"error"`
+}
+
+// PayStub is the payout data of a node for a satellite by specific period.
+type PayStub struct {
+	NodeID         storj.NodeID `json:"nodeId"`
+	NodeName       string       `json:"nodeName"`
+	SatelliteID    storj.NodeID `json:"satelliteId"`
+	Period         string       `json:"period"`
+	Created        time.Time    `json:"created"`
+	Codes          string       `json:"codes"`
+	UsageAtRest    float64      `json:"usageAtRest"`
+	UsageGet       int64        `json:"usageGet"`
+	UsagePut       int64        `json:"usagePut"`
+	UsageGetRepair int64        `json:"usageGetRepair"`
+	UsagePutRepair int64        `json:"usagePutRepair"`
+	UsageGetAudit  int64        `json:"usageGetAudit"`
+	CompAtRest     int64        `json:"compAtRest"`
+	CompGet        int64        `json:"compGet"`
+	CompPut        int64        `json:"compPut"`
+	CompGetRepair  int64        `json:"compGetRepair"`
+	CompPutRepair  int64        `json:"compPutRepair"`
+	CompGetAudit   int64        `json:"compGetAudit"`
+	SurgePercent   int64        `json:"surgePercent"`
+	Held           int64        `json:"held"`
+	Owed           int64        `json:"owed"`
+	Disposed       int64        `json:"disposed"`
+	Paid           int64        `json:"paid"`
+}
+
+// PayStubSummary sums up the paystubs of all nodes for a satellite by specific period.
+type PayStubSummary struct {
+	SatelliteID storj.NodeID `json:"satelliteId"`
+	Period      string       `json:"period"`
+	Nodes       int          `json:"nodes"`
+	UsageAtRest float64      `json:"usageAtRest"`
+	UsageGet    int64        `json:"usageGet"`
+	UsagePut    int64        `json:"usagePut"`
+	CompAtRest  int64        `json:"compAtRest"`
+	CompGet     int64        `json:"compGet"`
+	CompPut     int64        `json:"compPut"`
+	Held        int64        `json:"held"`
+	Owed        int64        `json:"owed"`
+	Disposed    int64        `json:"disposed"`
+	Paid        int64        `json:"paid"`
+}
+
+// add adds the paystub to the summary.
+func (summary *PayStubSummary) add(payStub PayStub) {
+	summary.Nodes++
+	summary.UsageAtRest += payStub.UsageAtRest
+	summary.UsageGet += payStub.UsageGet
+	summary.UsagePut += payStub.UsagePut
+	summary.CompAtRest += payStub.CompAtRest
+	summary.CompGet += payStub.CompGet
+	summary.CompPut += payStub.CompPut
+	summary.Held += payStub.Held
+	summary.Owed += payStub.Owed
+	summary.Disposed += payStub.Disposed
+	summary.Paid += payStub.Paid
+}
+
+// PayStubs contains the paystubs of all nodes and their summaries by satellite and period.
+type PayStubs struct {
+	PayStubs  []PayStub        `json:"payStubs"`
+	Summaries []PayStubSummary `json:"summaries"`
+	Errors    []NodeError      `json:"errors"`
+}
+
+// HeldAmount is the amount held from a node by a satellite since the node joined it.
+type HeldAmount struct {
+	NodeID              storj.NodeID `json:"nodeId"`
+	NodeName            string       `json:"nodeName"`
+	SatelliteID         storj.NodeID `json:"satelliteId"`
+	SatelliteName       string       `json:"satelliteName"`
+	HoldForFirstPeriod  int64        `json:"holdForFirstPeriod"`
+	HoldForSecondPeriod int64        `json:"holdForSecondPeriod"`
+	HoldForThirdPeriod  int64        `json:"holdForThirdPeriod"`
+	TotalHeld           int64        `json:"totalHeld"`
+	TotalDisposed       int64        `json:"totalDisposed"`
+	JoinedAt            time.Time    `json:"joinedAt"`
+}
+
+// HeldAmounts contains the held amounts of all nodes and their totals.
+type HeldAmounts struct {
+	HeldAmounts   []HeldAmount `json:"heldAmounts"`
+	TotalHeld     int64        `json:"totalHeld"`
+	TotalDisposed int64        `json:"totalDisposed"`
+	Errors        []NodeError  `json:"errors"`
+}
+
+// EstimatedMonth contains usage and estimated payout for a month.
+type EstimatedMonth struct {
+	EgressBandwidth         int64   `json:"egressBandwidth"`
+	EgressBandwidthPayout   float64 `json:"egressBandwidthPayout"`
+	EgressRepairAudit       int64   `json:"egressRepairAudit"`
+	EgressRepairAuditPayout float64 `json:"egressRepairAuditPayout"`
+	DiskSpace               float64 `json:"diskSpace"`
+	DiskSpacePayout         float64 `json:"diskSpacePayout"`
+	Payout                  float64 `json:"payout"`
+	Held                    float64 `json:"held"`
+}
+
+// add adds other estimation to the estimation.
+func (month *EstimatedMonth) add(other EstimatedMonth) {
+	month.EgressBandwidth += other.EgressBandwidth
+	month.EgressBandwidthPayout += other.EgressBandwidthPayout
+	month.EgressRepairAudit += other.EgressRepairAudit
+	month.EgressRepairAuditPayout += other.EgressRepairAuditPayout
+	month.DiskSpace += other.DiskSpace
+	month.DiskSpacePayout += other.DiskSpacePayout
+	month.Payout += other.Payout
+	month.Held += other.Held
+}
+
+// NodeEstimation contains the estimated payout of a node.
+type NodeEstimation struct {
+	NodeID        storj.NodeID   `json:"nodeId"`
+	NodeName      string         `json:"nodeName"`
+	CurrentMonth  EstimatedMonth `json:"currentMonth"`
+	PreviousMonth EstimatedMonth `json:"previousMonth"`
+}
+
+// Estimations contains the estimated payouts of all nodes and their totals.
+type Estimations struct {
+	Nodes         []NodeEstimation `json:"nodes"`
+	CurrentMonth  EstimatedMonth   `json:"currentMonth"`
+	PreviousMonth EstimatedMonth   `json:"previousMonth"`
+	Errors        []NodeError      `json:"errors"`
+}
+
+// Payment contains the payout of a satellite to a node for a period.
+type Payment struct {
+	NodeID         storj.NodeID `json:"nodeId"`
+	NodeName       string       `json:"nodeName"`
+	SatelliteID    storj.NodeID `json:"satelliteId"`
+	SatelliteURL   string       `json:"satelliteUrl"`
+	Period         string       `json:"period"`
+	Age            int64        `json:"age"`
+	Earned         int64        `json:"earned"`
+	Surge          int64        `json:"surge"`
+	SurgePercent   int64        `json:"surgePercent"`
+	Held           int64        `json:"held"`
+	HeldPercent    float64      `json:"heldPercent"`
+	AfterHeld      int64        `json:"afterHeld"`
+	Disposed       int64        `json:"disposed"`
+	Paid           int64        `json:"paid"`
+	Receipt        string       `json:"receipt"`
+	IsExitComplete bool         `json:"isExitComplete"`
+}
+
+// PaymentHistory contains the payments to all nodes for a period and their totals.
+type PaymentHistory struct {
+	Payments []Payment   `json:"payments"`
+	Earned   int64       `json:"earned"`
+	Held     int64       `json:"held"`
+	Disposed int64       `json:"disposed"`
+	Paid     int64       `json:"paid"`
+	Errors   []NodeError `json:"errors"`
+}
@@ -0,0 +1,308 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/multinodepb"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is an error class for payouts service error.
+	Error = errs.Class("payouts service error")
+)
+
+// Service retrieves the payout information of all added nodes from the nodes themselves.
+//
+// architecture: Service
+type Service struct {
+	log   *zap.Logger
+	nodes *nodes.Service
+}
+
+// NewService creates new instance of Service. The nodes are dialed by the
+// nodes service, a node that does not respond within its timeout is reported
+// as an error.
+func NewService(log *zap.Logger, nodes *nodes.Service) *Service {
+	return &Service{
+		log:   log,
+		nodes: nodes,
+	}
+}
+
+// PayStubs retrieves the paystubs of all nodes within the period range, for
+// the specified satellite or for all satellites when satelliteID is zero.
+func (service *Service) PayStubs(ctx context.Context, periodStart, periodEnd string, satelliteID storj.NodeID) (_ PayStubs, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var result PayStubs
+	var mu sync.Mutex
+
+	result.Errors, err = service.forEachNode(ctx, func(ctx context.Context, node nodes.Node, client multinodepb.DRPCPayoutsClient) error {
+		resp, err := client.PayStubs(ctx, &multinodepb.PayStubsRequest{
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+			SatelliteId: satelliteID,
+		})
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, payStub := range resp.PayStubs {
+			result.PayStubs = append(result.PayStubs, PayStub{
+				NodeID:         node.ID,
+				NodeName:       node.Name,
+				SatelliteID:    payStub.SatelliteId,
+				Period:         payStub.Period,
+				Created:        payStub.Created,
+				Codes:          payStub.Codes,
+				UsageAtRest:    payStub.UsageAtRest,
+				UsageGet:       payStub.UsageGet,
+				UsagePut:       payStub.UsagePut,
+				UsageGetRepair: payStub.UsageGetRepair,
+				UsagePutRepair: payStub.UsagePutRepair,
+				UsageGetAudit:  payStub.UsageGetAudit,
+				CompAtRest:     payStub.CompAtRest,
+				CompGet:        payStub.CompGet,
+				CompPut:        payStub.CompPut,
+				CompGetRepair:  payStub.CompGetRepair,
+				CompPutRepair:  payStub.CompPutRepair,
+				CompGetAudit:   payStub.CompGetAudit,
+				SurgePercent:   payStub.SurgePercent,
+				Held:           payStub.Held,
+				Owed:           payStub.Owed,
+				Disposed:       payStub.Disposed,
+				Paid:           payStub.Paid,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return PayStubs{}, Error.Wrap(err)
+	}
+
+	sort.Slice(result.PayStubs, func(i, k int) bool {
+		a, b := result.PayStubs[i], result.PayStubs[k]
+		if a.Period != b.Period {
+			return a.Period < b.Period
+		}
+		if a.SatelliteID != b.SatelliteID {
+			return a.SatelliteID.Less(b.SatelliteID)
+		}
+		return a.NodeID.Less(b.NodeID)
+	})
+
+	for _, payStub := range result.PayStubs {
+		n := len(result.Summaries)
+		if n == 0 || result.Summaries[n-1].Period != payStub.Period || result.Summaries[n-1].SatelliteID != payStub.SatelliteID {
+			result.Summaries = append(result.Summaries, PayStubSummary{
+				SatelliteID: payStub.SatelliteID,
+				Period:      payStub.Period,
+			})
+			n++
+		}
+		result.Summaries[n-1].add(payStub)
+	}
+
+	return result, nil
+}
+
+// HeldAmounts retrieves the amounts held from all nodes since they joined the satellites.
+func (service *Service) HeldAmounts(ctx context.Context) (_ HeldAmounts, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var result HeldAmounts
+	var mu sync.Mutex
+
+	result.Errors, err = service.forEachNode(ctx, func(ctx context.Context, node nodes.Node, client multinodepb.DRPCPayoutsClient) error {
+		resp, err := client.HeldHistory(ctx, &multinodepb.HeldHistoryRequest{})
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, held := range resp.HeldHistory {
+			result.HeldAmounts = append(result.HeldAmounts, HeldAmount{
+				NodeID:              node.ID,
+				NodeName:            node.Name,
+				SatelliteID:         held.SatelliteId,
+				SatelliteName:       held.SatelliteName,
+				HoldForFirstPeriod:  held.HoldForFirstPeriod,
+				HoldForSecondPeriod: held.HoldForSecondPeriod,
+				HoldForThirdPeriod:  held.HoldForThirdPeriod,
+				TotalHeld:           held.TotalHeld,
+				TotalDisposed:       held.TotalDisposed,
+				JoinedAt:            held.JoinedAt,
+			})
+			result.TotalHeld += held.TotalHeld
+			result.TotalDisposed += held.TotalDisposed
+		}
+		return nil
+	})
+	if err != nil {
+		return HeldAmounts{}, Error.Wrap(err)
+	}
+
+	return result, nil
+}
+
+// Estimations retrieves the estimated payouts of all nodes, for the specified
+// satellite or for all satellites when satelliteID is zero.
+func (service *Service) Estimations(ctx context.Context, satelliteID storj.NodeID) (_ Estimations, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var result Estimations
+	var mu sync.Mutex
+
+	result.Errors, err = service.forEachNode(ctx, func(ctx context.Context, node nodes.Node, client multinodepb.DRPCPayoutsClient) error {
+		resp, err := client.EstimatedPayout(ctx, &multinodepb.EstimatedPayoutRequest{SatelliteId: satelliteID})
+		if err != nil {
+			return err
+		}
+
+		estimation := NodeEstimation{
+			NodeID:        node.ID,
+			NodeName:      node.Name,
+			CurrentMonth:  estimatedMonthFromPB(resp.CurrentMonth),
+			PreviousMonth: estimatedMonthFromPB(resp.PreviousMonth),
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		result.Nodes = append(result.Nodes, estimation)
+		result.CurrentMonth.add(estimation.CurrentMonth)
+		result.PreviousMonth.add(estimation.PreviousMonth)
+		return nil
+	})
+	if err != nil {
+		return Estimations{}, Error.Wrap(err)
+	}
+
+	return result, nil
+}
+
+// PaymentHistory retrieves the payments to all nodes for the period.
+func (service *Service) PaymentHistory(ctx context.Context, period string) (_ PaymentHistory, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var result PaymentHistory
+	var mu sync.Mutex
+
+	result.Errors, err = service.forEachNode(ctx, func(ctx context.Context, node nodes.Node, client multinodepb.DRPCPayoutsClient) error {
+		resp, err := client.PaymentHistory(ctx, &multinodepb.PaymentHistoryRequest{Period: period})
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, payment := range resp.Payments {
+			result.Payments = append(result.Payments, Payment{
+				NodeID:         node.ID,
+				NodeName:       node.Name,
+				SatelliteID:    payment.SatelliteId,
+				SatelliteURL:   payment.SatelliteUrl,
+				Period:         period,
+				Age:            payment.Age,
+				Earned:         payment.Earned,
+				Surge:          payment.Surge,
+				SurgePercent:   payment.SurgePercent,
+				Held:           payment.Held,
+				HeldPercent:    payment.HeldPercent,
+				AfterHeld:      payment.AfterHeld,
+				Disposed:       payment.Disposed,
+				Paid:           payment.Paid,
+				Receipt:        payment.Receipt,
+				IsExitComplete: payment.IsExitComplete,
+			})
+			result.Earned += payment.Earned
+			result.Held += payment.Held
+			result.Disposed += payment.Disposed
+			result.Paid += payment.Paid
+		}
+		return nil
+	})
+	if err != nil {
+		return PaymentHistory{}, Error.Wrap(err)
+	}
+
+	return result, nil
+}
+
+// forEachNode concurrently calls fn with a payouts client of every added node.
+// The nodes for which fn fails are returned as errors, instead of failing the
+// whole request.
+func (service *Service) forEachNode(ctx context.Context, fn func(ctx context.Context, node nodes.Node, client multinodepb.DRPCPayoutsClient) error) (_ []NodeError, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	list, err := service.nodes.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var nodeErrors []NodeError
+
+	var wg sync.WaitGroup
+	for _, node := range list {
+		node := node
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := service.nodes.Call(ctx, node, func(ctx context.Context, conn *rpc.Conn) error {
+				return fn(ctx, node, multinodepb.NewDRPCPayoutsClient(conn))
+			})
+			if err == nil {
+				return
+			}
+
+			service.log.Debug("unable to retrieve payouts", zap.Stringer("Node ID", node.ID), zap.Error(err))
+
+			mu.Lock()
+			defer mu.Unlock()
+			nodeErrors = append(nodeErrors, NodeError{
+				NodeID:   node.ID,
+				NodeName: node.Name,
+				Error:    err.Error(),
+			})
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(nodeErrors, func(i, k int) bool {
+		return nodeErrors[i].NodeID.Less(nodeErrors[k].NodeID)
+	})
+
+	return nodeErrors, nil
+}
+
+// estimatedMonthFromPB converts the protobuf representation of an estimated month.
+func estimatedMonthFromPB(month *multinodepb.EstimatedMonthlyPayout) EstimatedMonth {
+	return EstimatedMonth{
+		EgressBandwidth:         month.GetEgressBandwidth(),
+		EgressBandwidthPayout:   month.GetEgressBandwidthPayout(),
+		EgressRepairAudit:       month.GetEgressRepairAudit(),
+		EgressRepairAuditPayout: month.GetEgressRepairAuditPayout(),
+		DiskSpace:               month.GetDiskSpace(),
+		DiskSpacePayout:         month.GetDiskSpacePayout(),
+		Payout:                  month.GetPayout(),
+		Held:                    month.GetHeld(),
+	}
+}
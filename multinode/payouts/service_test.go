@@ -0,0 +1,257 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payouts_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity"
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/multinode/payouts"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/pkg/server"
+)
+
+func TestService(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	endpoint := &fakeEndpoint{
+		apiSecret:  testrand.BytesInt(32),
+		satellites: []storj.NodeID{testrand.NodeID(), testrand.NodeID()},
+	}
+
+	// both nodes are served by the same fake endpoint
+	firstIdent := testidentity.MustPregeneratedIdentity(0, storj.LatestIDVersion())
+	firstSrv := startNode(ctx, t, firstIdent, endpoint)
+	defer ctx.Check(firstSrv.Close)
+	secondIdent := testidentity.MustPregeneratedIdentity(1, storj.LatestIDVersion())
+	secondSrv := startNode(ctx, t, secondIdent, endpoint)
+	defer ctx.Check(secondSrv.Close)
+
+	// a node that accepts connections but never responds
+	hanging, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ctx.Check(hanging.Close)
+
+	db := &fakeDB{}
+	first := nodes.Node{ID: firstIdent.ID, Name: "first", APISecret: endpoint.apiSecret, PublicAddress: firstSrv.PrivateAddr().String()}
+	second := nodes.Node{ID: secondIdent.ID, Name: "second", APISecret: endpoint.apiSecret, PublicAddress: secondSrv.PrivateAddr().String()}
+	unauthorized := nodes.Node{ID: secondIdent.ID, Name: "unauthorized", APISecret: testrand.BytesInt(32), PublicAddress: secondSrv.PrivateAddr().String()}
+	unresponsive := nodes.Node{ID: testrand.NodeID(), Name: "unresponsive", APISecret: endpoint.apiSecret, PublicAddress: hanging.Addr().String()}
+	db.nodes = []nodes.Node{first, second, unauthorized, unresponsive}
+
+	service := payouts.NewService(zaptest.NewLogger(t), nodes.NewService(zaptest.NewLogger(t), rpc.NewDefaultDialer(nil), db, nodes.Config{
+		Timeout: time.Second,
+	}))
+
+	t.Run("paystubs", func(t *testing.T) {
+		payStubs, err := service.PayStubs(ctx, "2020-10", "2020-11", storj.NodeID{})
+		require.NoError(t, err)
+
+		// two nodes, two satellites, two periods
+		require.Len(t, payStubs.PayStubs, 8)
+		require.Len(t, payStubs.Summaries, 4)
+		for i, summary := range payStubs.Summaries {
+			require.Equal(t, 2, summary.Nodes)
+			require.EqualValues(t, 2000000, summary.Paid)
+			require.EqualValues(t, 500000, summary.Held)
+			if i > 0 {
+				require.True(t, payStubs.Summaries[i-1].Period <= summary.Period)
+			}
+		}
+		require.Equal(t, "2020-10", payStubs.Summaries[0].Period)
+		require.Equal(t, "2020-11", payStubs.Summaries[3].Period)
+
+		require.Len(t, payStubs.Errors, 2)
+		errorNodes := []string{payStubs.Errors[0].NodeName, payStubs.Errors[1].NodeName}
+		require.ElementsMatch(t, []string{"unauthorized", "unresponsive"}, errorNodes)
+
+		payStubs, err = service.PayStubs(ctx, "2020-10", "2020-10", endpoint.satellites[1])
+		require.NoError(t, err)
+		require.Len(t, payStubs.PayStubs, 2)
+		require.Len(t, payStubs.Summaries, 1)
+		require.Equal(t, endpoint.satellites[1], payStubs.Summaries[0].SatelliteID)
+	})
+
+	t.Run("held amounts", func(t *testing.T) {
+		heldAmounts, err := service.HeldAmounts(ctx)
+		require.NoError(t, err)
+		require.Len(t, heldAmounts.HeldAmounts, 4)
+		require.EqualValues(t, 4*300, heldAmounts.TotalHeld)
+		require.EqualValues(t, 4*100, heldAmounts.TotalDisposed)
+		require.Len(t, heldAmounts.Errors, 2)
+	})
+
+	t.Run("estimations", func(t *testing.T) {
+		estimations, err := service.Estimations(ctx, storj.NodeID{})
+		require.NoError(t, err)
+		require.Len(t, estimations.Nodes, 2)
+		require.Equal(t, 20.0, estimations.CurrentMonth.Payout)
+		require.Equal(t, 6.0, estimations.PreviousMonth.Payout)
+		require.Len(t, estimations.Errors, 2)
+	})
+
+	t.Run("payment history", func(t *testing.T) {
+		history, err := service.PaymentHistory(ctx, "2020-11")
+		require.NoError(t, err)
+		require.Len(t, history.Payments, 4)
+		for _, payment := range history.Payments {
+			require.Equal(t, "2020-11", payment.Period)
+		}
+		require.EqualValues(t, 4*1500, history.Earned)
+		require.EqualValues(t, 4*1000, history.Paid)
+		require.Len(t, history.Errors, 2)
+	})
+}
+
+func startNode(ctx *testcontext.Context, t *testing.T, ident *identity.FullIdentity, endpoint *fakeEndpoint) *server.Server {
+	tlsOptions, err := tlsopts.NewOptions(ident, tlsopts.Config{PeerIDVersions: "*"}, nil)
+	require.NoError(t, err)
+
+	srv, err := server.New(zaptest.NewLogger(t), tlsOptions, server.Config{
+		Address:        "127.0.0.1:0",
+		PrivateAddress: "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+	require.NoError(t, multinodepb.DRPCRegisterPayouts(srv.PrivateDRPC(), endpoint))
+
+	ctx.Go(func() error { return srv.Run(ctx) })
+	return srv
+}
+
+type fakeEndpoint struct {
+	apiSecret  []byte
+	satellites []storj.NodeID
+}
+
+func (endpoint *fakeEndpoint) authenticate(ctx context.Context) error {
+	apiKey, ok := multinodepb.APIKeyFromContext(ctx)
+	if !ok || !bytes.Equal(apiKey, endpoint.apiSecret) {
+		return rpcstatus.Error(rpcstatus.Unauthenticated, "invalid api key")
+	}
+	return nil
+}
+
+func (endpoint *fakeEndpoint) PayStubs(ctx context.Context, req *multinodepb.PayStubsRequest) (*multinodepb.PayStubsResponse, error) {
+	if err := endpoint.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	var resp multinodepb.PayStubsResponse
+	for _, period := range []string{"2020-10", "2020-11"} {
+		if period < req.PeriodStart || period > req.PeriodEnd {
+			continue
+		}
+		for _, satelliteID := range endpoint.satellites {
+			if !req.SatelliteId.IsZero() && req.SatelliteId != satelliteID {
+				continue
+			}
+			resp.PayStubs = append(resp.PayStubs, &multinodepb.PayStub{
+				SatelliteId: satelliteID,
+				Period:      period,
+				Created:     time.Now(),
+				Held:        250000,
+				Owed:        1000000,
+				Paid:        1000000,
+			})
+		}
+	}
+	return &resp, nil
+}
+
+func (endpoint *fakeEndpoint) HeldHistory(ctx context.Context, req *multinodepb.HeldHistoryRequest) (*multinodepb.HeldHistoryResponse, error) {
+	if err := endpoint.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	var resp multinodepb.HeldHistoryResponse
+	for _, satelliteID := range endpoint.satellites {
+		resp.HeldHistory = append(resp.HeldHistory, &multinodepb.HeldHistory{
+			SatelliteId:   satelliteID,
+			TotalHeld:     300,
+			TotalDisposed: 100,
+			JoinedAt:      time.Now(),
+		})
+	}
+	return &resp, nil
+}
+
+func (endpoint *fakeEndpoint) EstimatedPayout(ctx context.Context, req *multinodepb.EstimatedPayoutRequest) (*multinodepb.EstimatedPayoutResponse, error) {
+	if err := endpoint.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	return &multinodepb.EstimatedPayoutResponse{
+		CurrentMonth:  &multinodepb.EstimatedMonthlyPayout{Payout: 10},
+		PreviousMonth: &multinodepb.EstimatedMonthlyPayout{Payout: 3},
+	}, nil
+}
+
+func (endpoint *fakeEndpoint) PaymentHistory(ctx context.Context, req *multinodepb.PaymentHistoryRequest) (*multinodepb.PaymentHistoryResponse, error) {
+	if err := endpoint.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	var resp multinodepb.PaymentHistoryResponse
+	for _, satelliteID := range endpoint.satellites {
+		resp.Payments = append(resp.Payments, &multinodepb.SatellitePayment{
+			SatelliteId: satelliteID,
+			Earned:      1500,
+			Held:        500,
+			Paid:        1000,
+		})
+	}
+	return &resp, nil
+}
+
+// fakeDB is an in-memory nodes database.
+type fakeDB struct {
+	mu    sync.Mutex
+	nodes []nodes.Node
+}
+
+func (db *fakeDB) Get(ctx context.Context, id storj.NodeID) (nodes.Node, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, node := range db.nodes {
+		if node.ID == id {
+			return node, nil
+		}
+	}
+	return nodes.Node{}, nodes.ErrNoNode.New("%s", id)
+}
+
+func (db *fakeDB) List(ctx context.Context) ([]nodes.Node, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return append([]nodes.Node(nil), db.nodes...), nil
+}
+
+func (db *fakeDB) Add(ctx context.Context, id storj.NodeID, apiSecret []byte, publicAddress string) error {
+	return nil
+}
+
+func (db *fakeDB) Remove(ctx context.Context, id storj.NodeID) error {
+	return nil
+}
+
+func (db *fakeDB) UpdateName(ctx context.Context, id storj.NodeID, name string) error {
+	return nil
+}
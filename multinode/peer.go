@@ -12,10 +12,12 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/identity"
+	"storj.io/common/rpc"
 	"storj.io/private/debug"
 	"storj.io/storj/multinode/console"
 	"storj.io/storj/multinode/console/server"
 	"storj.io/storj/multinode/nodes"
+	"storj.io/storj/multinode/payouts"
 	"storj.io/storj/private/lifecycle"
 )
 
@@ -43,6 +45,7 @@ type Config struct {
 	Identity identity.Config
 	Debug    debug.Config
 
+	Nodes   nodes.Config
 	Console server.Config
 }
 
@@ -54,12 +57,18 @@ type Peer struct {
 	Log      *zap.Logger
 	Identity *identity.FullIdentity
 	DB       DB
+	Dialer   rpc.Dialer
 
 	// contains logic of nodes domain.
 	Nodes struct {
 		Service *nodes.Service
 	}
 
+	// contains logic of payouts domain.
+	Payouts struct {
+		Service *payouts.Service
+	}
+
 	// Web server with web UI.
 	Console struct {
 		Listener net.Listener
@@ -79,9 +88,21 @@ func New(log *zap.Logger, full *identity.FullIdentity, config Config, db DB) (_
 	}
 
 	{ // nodes setup
+		// storage nodes serve the multinode endpoints on their private address, which is unencrypted.
+		peer.Dialer = rpc.NewDefaultDialer(nil)
+
 		peer.Nodes.Service = nodes.NewService(
 			peer.Log.Named("nodes:service"),
+			peer.Dialer,
 			peer.DB.Nodes(),
+			config.Nodes,
+		)
+	}
+
+	{ // payouts setup
+		peer.Payouts.Service = payouts.NewService(
+			peer.Log.Named("payouts:service"),
+			peer.Nodes.Service,
 		)
 	}
 
@@ -95,6 +116,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, config Config, db DB) (_
 			peer.Log.Named("console:endpoint"),
 			config.Console,
 			peer.Nodes.Service,
+			peer.Payouts.Service,
 			peer.Console.Listener,
 		)
 		if err != nil {
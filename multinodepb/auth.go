@@ -0,0 +1,40 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinodepb
+
+import (
+	"context"
+	"encoding/base64"
+
+	"storj.io/drpc/drpcmetadata"
+)
+
+// APIKeyMetadataKey is the drpc metadata key which carries the storage node
+// api key issued by `storagenode issue-apikey`.
+const APIKeyMetadataKey = "multinode-apikey"
+
+// WithAPIKey returns a context which sends apiKey along with the requests made with it.
+func WithAPIKey(ctx context.Context, apiKey []byte) context.Context {
+	return drpcmetadata.Add(ctx, APIKeyMetadataKey, base64.URLEncoding.EncodeToString(apiKey))
+}
+
+// APIKeyFromContext returns the api key sent along with the request.
+func APIKeyFromContext(ctx context.Context) ([]byte, bool) {
+	metadata, ok := drpcmetadata.Get(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	encoded, ok := metadata[APIKeyMetadataKey]
+	if !ok {
+		return nil, false
+	}
+
+	apiKey, err := base64.URLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false
+	}
+
+	return apiKey, true
+}
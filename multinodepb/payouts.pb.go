@@ -0,0 +1,1126 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: payouts.proto
+
+package multinodepb
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+	time "time"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// PayStub is node payout data for satellite by specific period.
+type PayStub struct {
+	SatelliteId          NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	Period               string    `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	Created              time.Time `protobuf:"bytes,3,opt,name=created,proto3,stdtime" json:"created"`
+	Codes                string    `protobuf:"bytes,4,opt,name=codes,proto3" json:"codes,omitempty"`
+	UsageAtRest          float64   `protobuf:"fixed64,5,opt,name=usage_at_rest,json=usageAtRest,proto3" json:"usage_at_rest,omitempty"`
+	UsageGet             int64     `protobuf:"varint,6,opt,name=usage_get,json=usageGet,proto3" json:"usage_get,omitempty"`
+	UsagePut             int64     `protobuf:"varint,7,opt,name=usage_put,json=usagePut,proto3" json:"usage_put,omitempty"`
+	UsageGetRepair       int64     `protobuf:"varint,8,opt,name=usage_get_repair,json=usageGetRepair,proto3" json:"usage_get_repair,omitempty"`
+	UsagePutRepair       int64     `protobuf:"varint,9,opt,name=usage_put_repair,json=usagePutRepair,proto3" json:"usage_put_repair,omitempty"`
+	UsageGetAudit        int64     `protobuf:"varint,10,opt,name=usage_get_audit,json=usageGetAudit,proto3" json:"usage_get_audit,omitempty"`
+	CompAtRest           int64     `protobuf:"varint,11,opt,name=comp_at_rest,json=compAtRest,proto3" json:"comp_at_rest,omitempty"`
+	CompGet              int64     `protobuf:"varint,12,opt,name=comp_get,json=compGet,proto3" json:"comp_get,omitempty"`
+	CompPut              int64     `protobuf:"varint,13,opt,name=comp_put,json=compPut,proto3" json:"comp_put,omitempty"`
+	CompGetRepair        int64     `protobuf:"varint,14,opt,name=comp_get_repair,json=compGetRepair,proto3" json:"comp_get_repair,omitempty"`
+	CompPutRepair        int64     `protobuf:"varint,15,opt,name=comp_put_repair,json=compPutRepair,proto3" json:"comp_put_repair,omitempty"`
+	CompGetAudit         int64     `protobuf:"varint,16,opt,name=comp_get_audit,json=compGetAudit,proto3" json:"comp_get_audit,omitempty"`
+	SurgePercent         int64     `protobuf:"varint,17,opt,name=surge_percent,json=surgePercent,proto3" json:"surge_percent,omitempty"`
+	Held                 int64     `protobuf:"varint,18,opt,name=held,proto3" json:"held,omitempty"`
+	Owed                 int64     `protobuf:"varint,19,opt,name=owed,proto3" json:"owed,omitempty"`
+	Disposed             int64     `protobuf:"varint,20,opt,name=disposed,proto3" json:"disposed,omitempty"`
+	Paid                 int64     `protobuf:"varint,21,opt,name=paid,proto3" json:"paid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PayStub) Reset()         { *m = PayStub{} }
+func (m *PayStub) String() string { return proto.CompactTextString(m) }
+func (*PayStub) ProtoMessage()    {}
+func (*PayStub) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{0}
+}
+func (m *PayStub) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayStub.Unmarshal(m, b)
+}
+func (m *PayStub) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayStub.Marshal(b, m, deterministic)
+}
+func (m *PayStub) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayStub.Merge(m, src)
+}
+func (m *PayStub) XXX_Size() int {
+	return xxx_messageInfo_PayStub.Size(m)
+}
+func (m *PayStub) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayStub.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayStub proto.InternalMessageInfo
+
+func (m *PayStub) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+func (m *PayStub) GetCreated() time.Time {
+	if m != nil {
+		return m.Created
+	}
+	return time.Time{}
+}
+
+func (m *PayStub) GetCodes() string {
+	if m != nil {
+		return m.Codes
+	}
+	return ""
+}
+
+func (m *PayStub) GetUsageAtRest() float64 {
+	if m != nil {
+		return m.UsageAtRest
+	}
+	return 0
+}
+
+func (m *PayStub) GetUsageGet() int64 {
+	if m != nil {
+		return m.UsageGet
+	}
+	return 0
+}
+
+func (m *PayStub) GetUsagePut() int64 {
+	if m != nil {
+		return m.UsagePut
+	}
+	return 0
+}
+
+func (m *PayStub) GetUsageGetRepair() int64 {
+	if m != nil {
+		return m.UsageGetRepair
+	}
+	return 0
+}
+
+func (m *PayStub) GetUsagePutRepair() int64 {
+	if m != nil {
+		return m.UsagePutRepair
+	}
+	return 0
+}
+
+func (m *PayStub) GetUsageGetAudit() int64 {
+	if m != nil {
+		return m.UsageGetAudit
+	}
+	return 0
+}
+
+func (m *PayStub) GetCompAtRest() int64 {
+	if m != nil {
+		return m.CompAtRest
+	}
+	return 0
+}
+
+func (m *PayStub) GetCompGet() int64 {
+	if m != nil {
+		return m.CompGet
+	}
+	return 0
+}
+
+func (m *PayStub) GetCompPut() int64 {
+	if m != nil {
+		return m.CompPut
+	}
+	return 0
+}
+
+func (m *PayStub) GetCompGetRepair() int64 {
+	if m != nil {
+		return m.CompGetRepair
+	}
+	return 0
+}
+
+func (m *PayStub) GetCompPutRepair() int64 {
+	if m != nil {
+		return m.CompPutRepair
+	}
+	return 0
+}
+
+func (m *PayStub) GetCompGetAudit() int64 {
+	if m != nil {
+		return m.CompGetAudit
+	}
+	return 0
+}
+
+func (m *PayStub) GetSurgePercent() int64 {
+	if m != nil {
+		return m.SurgePercent
+	}
+	return 0
+}
+
+func (m *PayStub) GetHeld() int64 {
+	if m != nil {
+		return m.Held
+	}
+	return 0
+}
+
+func (m *PayStub) GetOwed() int64 {
+	if m != nil {
+		return m.Owed
+	}
+	return 0
+}
+
+func (m *PayStub) GetDisposed() int64 {
+	if m != nil {
+		return m.Disposed
+	}
+	return 0
+}
+
+func (m *PayStub) GetPaid() int64 {
+	if m != nil {
+		return m.Paid
+	}
+	return 0
+}
+
+// PayStubsRequest requests paystubs from period_start to period_end (both yyyy-mm),
+// for all satellites when satellite_id is not set.
+type PayStubsRequest struct {
+	PeriodStart          string   `protobuf:"bytes,1,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd            string   `protobuf:"bytes,2,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	SatelliteId          NodeID   `protobuf:"bytes,3,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PayStubsRequest) Reset()         { *m = PayStubsRequest{} }
+func (m *PayStubsRequest) String() string { return proto.CompactTextString(m) }
+func (*PayStubsRequest) ProtoMessage()    {}
+func (*PayStubsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{1}
+}
+func (m *PayStubsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayStubsRequest.Unmarshal(m, b)
+}
+func (m *PayStubsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayStubsRequest.Marshal(b, m, deterministic)
+}
+func (m *PayStubsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayStubsRequest.Merge(m, src)
+}
+func (m *PayStubsRequest) XXX_Size() int {
+	return xxx_messageInfo_PayStubsRequest.Size(m)
+}
+func (m *PayStubsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayStubsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayStubsRequest proto.InternalMessageInfo
+
+func (m *PayStubsRequest) GetPeriodStart() string {
+	if m != nil {
+		return m.PeriodStart
+	}
+	return ""
+}
+
+func (m *PayStubsRequest) GetPeriodEnd() string {
+	if m != nil {
+		return m.PeriodEnd
+	}
+	return ""
+}
+
+type PayStubsResponse struct {
+	PayStubs             []*PayStub `protobuf:"bytes,1,rep,name=pay_stubs,json=payStubs,proto3" json:"pay_stubs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PayStubsResponse) Reset()         { *m = PayStubsResponse{} }
+func (m *PayStubsResponse) String() string { return proto.CompactTextString(m) }
+func (*PayStubsResponse) ProtoMessage()    {}
+func (*PayStubsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{2}
+}
+func (m *PayStubsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PayStubsResponse.Unmarshal(m, b)
+}
+func (m *PayStubsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PayStubsResponse.Marshal(b, m, deterministic)
+}
+func (m *PayStubsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PayStubsResponse.Merge(m, src)
+}
+func (m *PayStubsResponse) XXX_Size() int {
+	return xxx_messageInfo_PayStubsResponse.Size(m)
+}
+func (m *PayStubsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PayStubsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PayStubsResponse proto.InternalMessageInfo
+
+func (m *PayStubsResponse) GetPayStubs() []*PayStub {
+	if m != nil {
+		return m.PayStubs
+	}
+	return nil
+}
+
+type HeldHistoryRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeldHistoryRequest) Reset()         { *m = HeldHistoryRequest{} }
+func (m *HeldHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HeldHistoryRequest) ProtoMessage()    {}
+func (*HeldHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{3}
+}
+func (m *HeldHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldHistoryRequest.Unmarshal(m, b)
+}
+func (m *HeldHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *HeldHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldHistoryRequest.Merge(m, src)
+}
+func (m *HeldHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_HeldHistoryRequest.Size(m)
+}
+func (m *HeldHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldHistoryRequest proto.InternalMessageInfo
+
+// HeldHistory is the amount held by a satellite since the node joined it.
+type HeldHistory struct {
+	SatelliteId          NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	SatelliteName        string    `protobuf:"bytes,2,opt,name=satellite_name,json=satelliteName,proto3" json:"satellite_name,omitempty"`
+	HoldForFirstPeriod   int64     `protobuf:"varint,3,opt,name=hold_for_first_period,json=holdForFirstPeriod,proto3" json:"hold_for_first_period,omitempty"`
+	HoldForSecondPeriod  int64     `protobuf:"varint,4,opt,name=hold_for_second_period,json=holdForSecondPeriod,proto3" json:"hold_for_second_period,omitempty"`
+	HoldForThirdPeriod   int64     `protobuf:"varint,5,opt,name=hold_for_third_period,json=holdForThirdPeriod,proto3" json:"hold_for_third_period,omitempty"`
+	TotalHeld            int64     `protobuf:"varint,6,opt,name=total_held,json=totalHeld,proto3" json:"total_held,omitempty"`
+	TotalDisposed        int64     `protobuf:"varint,7,opt,name=total_disposed,json=totalDisposed,proto3" json:"total_disposed,omitempty"`
+	JoinedAt             time.Time `protobuf:"bytes,8,opt,name=joined_at,json=joinedAt,proto3,stdtime" json:"joined_at"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *HeldHistory) Reset()         { *m = HeldHistory{} }
+func (m *HeldHistory) String() string { return proto.CompactTextString(m) }
+func (*HeldHistory) ProtoMessage()    {}
+func (*HeldHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{4}
+}
+func (m *HeldHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldHistory.Unmarshal(m, b)
+}
+func (m *HeldHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldHistory.Marshal(b, m, deterministic)
+}
+func (m *HeldHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldHistory.Merge(m, src)
+}
+func (m *HeldHistory) XXX_Size() int {
+	return xxx_messageInfo_HeldHistory.Size(m)
+}
+func (m *HeldHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldHistory proto.InternalMessageInfo
+
+func (m *HeldHistory) GetSatelliteName() string {
+	if m != nil {
+		return m.SatelliteName
+	}
+	return ""
+}
+
+func (m *HeldHistory) GetHoldForFirstPeriod() int64 {
+	if m != nil {
+		return m.HoldForFirstPeriod
+	}
+	return 0
+}
+
+func (m *HeldHistory) GetHoldForSecondPeriod() int64 {
+	if m != nil {
+		return m.HoldForSecondPeriod
+	}
+	return 0
+}
+
+func (m *HeldHistory) GetHoldForThirdPeriod() int64 {
+	if m != nil {
+		return m.HoldForThirdPeriod
+	}
+	return 0
+}
+
+func (m *HeldHistory) GetTotalHeld() int64 {
+	if m != nil {
+		return m.TotalHeld
+	}
+	return 0
+}
+
+func (m *HeldHistory) GetTotalDisposed() int64 {
+	if m != nil {
+		return m.TotalDisposed
+	}
+	return 0
+}
+
+func (m *HeldHistory) GetJoinedAt() time.Time {
+	if m != nil {
+		return m.JoinedAt
+	}
+	return time.Time{}
+}
+
+type HeldHistoryResponse struct {
+	HeldHistory          []*HeldHistory `protobuf:"bytes,1,rep,name=held_history,json=heldHistory,proto3" json:"held_history,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *HeldHistoryResponse) Reset()         { *m = HeldHistoryResponse{} }
+func (m *HeldHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*HeldHistoryResponse) ProtoMessage()    {}
+func (*HeldHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{5}
+}
+func (m *HeldHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldHistoryResponse.Unmarshal(m, b)
+}
+func (m *HeldHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *HeldHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldHistoryResponse.Merge(m, src)
+}
+func (m *HeldHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_HeldHistoryResponse.Size(m)
+}
+func (m *HeldHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldHistoryResponse proto.InternalMessageInfo
+
+func (m *HeldHistoryResponse) GetHeldHistory() []*HeldHistory {
+	if m != nil {
+		return m.HeldHistory
+	}
+	return nil
+}
+
+// EstimatedPayoutRequest requests the estimated payout for all satellites when satellite_id is not set.
+type EstimatedPayoutRequest struct {
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimatedPayoutRequest) Reset()         { *m = EstimatedPayoutRequest{} }
+func (m *EstimatedPayoutRequest) String() string { return proto.CompactTextString(m) }
+func (*EstimatedPayoutRequest) ProtoMessage()    {}
+func (*EstimatedPayoutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{6}
+}
+func (m *EstimatedPayoutRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimatedPayoutRequest.Unmarshal(m, b)
+}
+func (m *EstimatedPayoutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimatedPayoutRequest.Marshal(b, m, deterministic)
+}
+func (m *EstimatedPayoutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimatedPayoutRequest.Merge(m, src)
+}
+func (m *EstimatedPayoutRequest) XXX_Size() int {
+	return xxx_messageInfo_EstimatedPayoutRequest.Size(m)
+}
+func (m *EstimatedPayoutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimatedPayoutRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimatedPayoutRequest proto.InternalMessageInfo
+
+// EstimatedMonthlyPayout contains usage and estimated payout for a month.
+type EstimatedMonthlyPayout struct {
+	EgressBandwidth         int64    `protobuf:"varint,1,opt,name=egress_bandwidth,json=egressBandwidth,proto3" json:"egress_bandwidth,omitempty"`
+	EgressBandwidthPayout   float64  `protobuf:"fixed64,2,opt,name=egress_bandwidth_payout,json=egressBandwidthPayout,proto3" json:"egress_bandwidth_payout,omitempty"`
+	EgressRepairAudit       int64    `protobuf:"varint,3,opt,name=egress_repair_audit,json=egressRepairAudit,proto3" json:"egress_repair_audit,omitempty"`
+	EgressRepairAuditPayout float64  `protobuf:"fixed64,4,opt,name=egress_repair_audit_payout,json=egressRepairAuditPayout,proto3" json:"egress_repair_audit_payout,omitempty"`
+	DiskSpace               float64  `protobuf:"fixed64,5,opt,name=disk_space,json=diskSpace,proto3" json:"disk_space,omitempty"`
+	DiskSpacePayout         float64  `protobuf:"fixed64,6,opt,name=disk_space_payout,json=diskSpacePayout,proto3" json:"disk_space_payout,omitempty"`
+	HeldRate                float64  `protobuf:"fixed64,7,opt,name=held_rate,json=heldRate,proto3" json:"held_rate,omitempty"`
+	Payout                  float64  `protobuf:"fixed64,8,opt,name=payout,proto3" json:"payout,omitempty"`
+	Held                    float64  `protobuf:"fixed64,9,opt,name=held,proto3" json:"held,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
+}
+
+func (m *EstimatedMonthlyPayout) Reset()         { *m = EstimatedMonthlyPayout{} }
+func (m *EstimatedMonthlyPayout) String() string { return proto.CompactTextString(m) }
+func (*EstimatedMonthlyPayout) ProtoMessage()    {}
+func (*EstimatedMonthlyPayout) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{7}
+}
+func (m *EstimatedMonthlyPayout) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimatedMonthlyPayout.Unmarshal(m, b)
+}
+func (m *EstimatedMonthlyPayout) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimatedMonthlyPayout.Marshal(b, m, deterministic)
+}
+func (m *EstimatedMonthlyPayout) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimatedMonthlyPayout.Merge(m, src)
+}
+func (m *EstimatedMonthlyPayout) XXX_Size() int {
+	return xxx_messageInfo_EstimatedMonthlyPayout.Size(m)
+}
+func (m *EstimatedMonthlyPayout) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimatedMonthlyPayout.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimatedMonthlyPayout proto.InternalMessageInfo
+
+func (m *EstimatedMonthlyPayout) GetEgressBandwidth() int64 {
+	if m != nil {
+		return m.EgressBandwidth
+	}
+	return 0
+}
+
+func (m *EstimatedMonthlyPayout) GetEgressBandwidthPayout() float64 {
+	if m != nil {
+		return m.EgressBandwidthPayout
+	}
+	return 0
+}
+
+func (m *EstimatedMonthlyPayout) GetEgressRepairAudit() int64 {
+	if m != nil {
+		return m.EgressRepairAudit
+	}
+	return 0
+}
+
+func (m *EstimatedMonthlyPayout) GetEgressRepairAuditPayout() float64 {
+	if m != nil {
+		return m.EgressRepairAuditPayout
+	}
+	return 0
+}
+
+func (m *EstimatedMonthlyPayout) GetDiskSpace() float64 {
+	if m != nil {
+		return m.DiskSpace
+	}
+	return 0
+}
+
+func (m *EstimatedMonthlyPayout) GetDiskSpacePayout() float64 {
+	if m != nil {
+		return m.DiskSpacePayout
+	}
+	return 0
+}
+
+func (m *EstimatedMonthlyPayout) GetHeldRate() float64 {
+	if m != nil {
+		return m.HeldRate
+	}
+	return 0
+}
+
+func (m *EstimatedMonthlyPayout) GetPayout() float64 {
+	if m != nil {
+		return m.Payout
+	}
+	return 0
+}
+
+func (m *EstimatedMonthlyPayout) GetHeld() float64 {
+	if m != nil {
+		return m.Held
+	}
+	return 0
+}
+
+type EstimatedPayoutResponse struct {
+	CurrentMonth         *EstimatedMonthlyPayout `protobuf:"bytes,1,opt,name=current_month,json=currentMonth,proto3" json:"current_month,omitempty"`
+	PreviousMonth        *EstimatedMonthlyPayout `protobuf:"bytes,2,opt,name=previous_month,json=previousMonth,proto3" json:"previous_month,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *EstimatedPayoutResponse) Reset()         { *m = EstimatedPayoutResponse{} }
+func (m *EstimatedPayoutResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimatedPayoutResponse) ProtoMessage()    {}
+func (*EstimatedPayoutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{8}
+}
+func (m *EstimatedPayoutResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimatedPayoutResponse.Unmarshal(m, b)
+}
+func (m *EstimatedPayoutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimatedPayoutResponse.Marshal(b, m, deterministic)
+}
+func (m *EstimatedPayoutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimatedPayoutResponse.Merge(m, src)
+}
+func (m *EstimatedPayoutResponse) XXX_Size() int {
+	return xxx_messageInfo_EstimatedPayoutResponse.Size(m)
+}
+func (m *EstimatedPayoutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimatedPayoutResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimatedPayoutResponse proto.InternalMessageInfo
+
+func (m *EstimatedPayoutResponse) GetCurrentMonth() *EstimatedMonthlyPayout {
+	if m != nil {
+		return m.CurrentMonth
+	}
+	return nil
+}
+
+func (m *EstimatedPayoutResponse) GetPreviousMonth() *EstimatedMonthlyPayout {
+	if m != nil {
+		return m.PreviousMonth
+	}
+	return nil
+}
+
+// PaymentHistoryRequest requests payments of the period (yyyy-mm).
+type PaymentHistoryRequest struct {
+	Period               string   `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PaymentHistoryRequest) Reset()         { *m = PaymentHistoryRequest{} }
+func (m *PaymentHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*PaymentHistoryRequest) ProtoMessage()    {}
+func (*PaymentHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{9}
+}
+func (m *PaymentHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaymentHistoryRequest.Unmarshal(m, b)
+}
+func (m *PaymentHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaymentHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *PaymentHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaymentHistoryRequest.Merge(m, src)
+}
+func (m *PaymentHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_PaymentHistoryRequest.Size(m)
+}
+func (m *PaymentHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaymentHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaymentHistoryRequest proto.InternalMessageInfo
+
+func (m *PaymentHistoryRequest) GetPeriod() string {
+	if m != nil {
+		return m.Period
+	}
+	return ""
+}
+
+// SatellitePayment contains payout information of a satellite for a period.
+type SatellitePayment struct {
+	SatelliteId          NodeID   `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	SatelliteUrl         string   `protobuf:"bytes,2,opt,name=satellite_url,json=satelliteUrl,proto3" json:"satellite_url,omitempty"`
+	Age                  int64    `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
+	Earned               int64    `protobuf:"varint,4,opt,name=earned,proto3" json:"earned,omitempty"`
+	Surge                int64    `protobuf:"varint,5,opt,name=surge,proto3" json:"surge,omitempty"`
+	SurgePercent         int64    `protobuf:"varint,6,opt,name=surge_percent,json=surgePercent,proto3" json:"surge_percent,omitempty"`
+	Held                 int64    `protobuf:"varint,7,opt,name=held,proto3" json:"held,omitempty"`
+	HeldPercent          float64  `protobuf:"fixed64,8,opt,name=held_percent,json=heldPercent,proto3" json:"held_percent,omitempty"`
+	AfterHeld            int64    `protobuf:"varint,9,opt,name=after_held,json=afterHeld,proto3" json:"after_held,omitempty"`
+	Disposed             int64    `protobuf:"varint,10,opt,name=disposed,proto3" json:"disposed,omitempty"`
+	Paid                 int64    `protobuf:"varint,11,opt,name=paid,proto3" json:"paid,omitempty"`
+	Receipt              string   `protobuf:"bytes,12,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	IsExitComplete       bool     `protobuf:"varint,13,opt,name=is_exit_complete,json=isExitComplete,proto3" json:"is_exit_complete,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SatellitePayment) Reset()         { *m = SatellitePayment{} }
+func (m *SatellitePayment) String() string { return proto.CompactTextString(m) }
+func (*SatellitePayment) ProtoMessage()    {}
+func (*SatellitePayment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{10}
+}
+func (m *SatellitePayment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SatellitePayment.Unmarshal(m, b)
+}
+func (m *SatellitePayment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SatellitePayment.Marshal(b, m, deterministic)
+}
+func (m *SatellitePayment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SatellitePayment.Merge(m, src)
+}
+func (m *SatellitePayment) XXX_Size() int {
+	return xxx_messageInfo_SatellitePayment.Size(m)
+}
+func (m *SatellitePayment) XXX_DiscardUnknown() {
+	xxx_messageInfo_SatellitePayment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SatellitePayment proto.InternalMessageInfo
+
+func (m *SatellitePayment) GetSatelliteUrl() string {
+	if m != nil {
+		return m.SatelliteUrl
+	}
+	return ""
+}
+
+func (m *SatellitePayment) GetAge() int64 {
+	if m != nil {
+		return m.Age
+	}
+	return 0
+}
+
+func (m *SatellitePayment) GetEarned() int64 {
+	if m != nil {
+		return m.Earned
+	}
+	return 0
+}
+
+func (m *SatellitePayment) GetSurge() int64 {
+	if m != nil {
+		return m.Surge
+	}
+	return 0
+}
+
+func (m *SatellitePayment) GetSurgePercent() int64 {
+	if m != nil {
+		return m.SurgePercent
+	}
+	return 0
+}
+
+func (m *SatellitePayment) GetHeld() int64 {
+	if m != nil {
+		return m.Held
+	}
+	return 0
+}
+
+func (m *SatellitePayment) GetHeldPercent() float64 {
+	if m != nil {
+		return m.HeldPercent
+	}
+	return 0
+}
+
+func (m *SatellitePayment) GetAfterHeld() int64 {
+	if m != nil {
+		return m.AfterHeld
+	}
+	return 0
+}
+
+func (m *SatellitePayment) GetDisposed() int64 {
+	if m != nil {
+		return m.Disposed
+	}
+	return 0
+}
+
+func (m *SatellitePayment) GetPaid() int64 {
+	if m != nil {
+		return m.Paid
+	}
+	return 0
+}
+
+func (m *SatellitePayment) GetReceipt() string {
+	if m != nil {
+		return m.Receipt
+	}
+	return ""
+}
+
+func (m *SatellitePayment) GetIsExitComplete() bool {
+	if m != nil {
+		return m.IsExitComplete
+	}
+	return false
+}
+
+type PaymentHistoryResponse struct {
+	Payments             []*SatellitePayment `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PaymentHistoryResponse) Reset()         { *m = PaymentHistoryResponse{} }
+func (m *PaymentHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*PaymentHistoryResponse) ProtoMessage()    {}
+func (*PaymentHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_abfb9c4b4f60e63a, []int{11}
+}
+func (m *PaymentHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PaymentHistoryResponse.Unmarshal(m, b)
+}
+func (m *PaymentHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PaymentHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *PaymentHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PaymentHistoryResponse.Merge(m, src)
+}
+func (m *PaymentHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_PaymentHistoryResponse.Size(m)
+}
+func (m *PaymentHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PaymentHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PaymentHistoryResponse proto.InternalMessageInfo
+
+func (m *PaymentHistoryResponse) GetPayments() []*SatellitePayment {
+	if m != nil {
+		return m.Payments
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*PayStub)(nil), "payouts.PayStub")
+	proto.RegisterType((*PayStubsRequest)(nil), "payouts.PayStubsRequest")
+	proto.RegisterType((*PayStubsResponse)(nil), "payouts.PayStubsResponse")
+	proto.RegisterType((*HeldHistoryRequest)(nil), "payouts.HeldHistoryRequest")
+	proto.RegisterType((*HeldHistory)(nil), "payouts.HeldHistory")
+	proto.RegisterType((*HeldHistoryResponse)(nil), "payouts.HeldHistoryResponse")
+	proto.RegisterType((*EstimatedPayoutRequest)(nil), "payouts.EstimatedPayoutRequest")
+	proto.RegisterType((*EstimatedMonthlyPayout)(nil), "payouts.EstimatedMonthlyPayout")
+	proto.RegisterType((*EstimatedPayoutResponse)(nil), "payouts.EstimatedPayoutResponse")
+	proto.RegisterType((*PaymentHistoryRequest)(nil), "payouts.PaymentHistoryRequest")
+	proto.RegisterType((*SatellitePayment)(nil), "payouts.SatellitePayment")
+	proto.RegisterType((*PaymentHistoryResponse)(nil), "payouts.PaymentHistoryResponse")
+}
+
+func init() { proto.RegisterFile("payouts.proto", fileDescriptor_abfb9c4b4f60e63a) }
+
+var fileDescriptor_abfb9c4b4f60e63a = []byte{
+	// 1213 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x4d, 0x73, 0xdc, 0x44,
+	0x13, 0x7e, 0xe5, 0xb5, 0xbd, 0x52, 0xef, 0x67, 0xc6, 0x1f, 0x51, 0x36, 0xf1, 0xeb, 0x8d, 0x80,
+	0xd4, 0x42, 0x15, 0xeb, 0x8a, 0x53, 0xc0, 0x81, 0x2a, 0x28, 0x9b, 0x7c, 0x16, 0x45, 0x58, 0xe4,
+	0x70, 0xe1, 0xa2, 0x1a, 0xaf, 0xc6, 0xbb, 0x0a, 0x5a, 0x8d, 0x98, 0x19, 0x91, 0xf8, 0x0f, 0x70,
+	0xe1, 0xc2, 0x95, 0x5f, 0xc0, 0xaf, 0xe0, 0xce, 0x8d, 0x3b, 0x87, 0xf0, 0x47, 0x38, 0x50, 0xf3,
+	0x25, 0xc9, 0xeb, 0x35, 0x90, 0xdc, 0x34, 0x4f, 0x3f, 0x4f, 0xcf, 0xf4, 0x74, 0x4f, 0xb7, 0xa0,
+	0x93, 0xe3, 0x73, 0x5a, 0x08, 0x3e, 0xce, 0x19, 0x15, 0x14, 0x35, 0xcd, 0x72, 0x00, 0x33, 0x3a,
+	0xa3, 0x1a, 0x1c, 0xec, 0xcf, 0x28, 0x9d, 0xa5, 0xe4, 0x40, 0xad, 0x4e, 0x8b, 0xb3, 0x03, 0x91,
+	0x2c, 0x08, 0x17, 0x78, 0x91, 0x6b, 0x42, 0xf0, 0xfb, 0x06, 0x34, 0x27, 0xf8, 0xfc, 0x44, 0x14,
+	0xa7, 0xe8, 0x2e, 0xb4, 0x39, 0x16, 0x24, 0x4d, 0x13, 0x41, 0xa2, 0x24, 0xf6, 0x9d, 0xa1, 0x33,
+	0x6a, 0x1f, 0x77, 0x7f, 0x7b, 0xb5, 0xff, 0xbf, 0x3f, 0x5e, 0xed, 0x6f, 0x3e, 0xa5, 0x31, 0x79,
+	0x72, 0x3f, 0x6c, 0x95, 0x9c, 0x27, 0x31, 0xda, 0x85, 0xcd, 0x9c, 0xb0, 0x84, 0xc6, 0xfe, 0xda,
+	0xd0, 0x19, 0x79, 0xa1, 0x59, 0xa1, 0x4f, 0xa0, 0x39, 0x65, 0x04, 0x0b, 0x12, 0xfb, 0x8d, 0xa1,
+	0x33, 0x6a, 0x1d, 0x0e, 0xc6, 0xfa, 0x24, 0x63, 0x7b, 0x92, 0xf1, 0x33, 0x7b, 0x92, 0x63, 0x57,
+	0xee, 0xf0, 0xd3, 0x9f, 0xfb, 0x4e, 0x68, 0x45, 0x68, 0x1b, 0x36, 0xa6, 0x34, 0x26, 0xdc, 0x5f,
+	0x57, 0x6e, 0xf5, 0x02, 0x05, 0xd0, 0x29, 0x38, 0x9e, 0x91, 0x08, 0x8b, 0x88, 0x11, 0x2e, 0xfc,
+	0x8d, 0xa1, 0x33, 0x72, 0xc2, 0x96, 0x02, 0x8f, 0x44, 0x48, 0xb8, 0x40, 0x37, 0xc1, 0xd3, 0x9c,
+	0x19, 0x11, 0xfe, 0xe6, 0xd0, 0x19, 0x35, 0x42, 0x57, 0x01, 0x8f, 0x48, 0xcd, 0x98, 0x17, 0xc2,
+	0x6f, 0xd6, 0x8c, 0x93, 0x42, 0xa0, 0x11, 0xf4, 0x4b, 0x65, 0xc4, 0x48, 0x8e, 0x13, 0xe6, 0xbb,
+	0x8a, 0xd3, 0xb5, 0x0e, 0x42, 0x85, 0x56, 0xcc, 0xbc, 0x28, 0x99, 0x5e, 0x8d, 0x39, 0x29, 0x2c,
+	0xf3, 0x0e, 0xf4, 0x2a, 0x9f, 0xb8, 0x88, 0x13, 0xe1, 0x83, 0x22, 0x76, 0xac, 0xcb, 0x23, 0x09,
+	0xa2, 0x21, 0xb4, 0xa7, 0x74, 0x91, 0x97, 0x81, 0xb5, 0x14, 0x09, 0x24, 0x66, 0xe2, 0xba, 0x01,
+	0xae, 0x62, 0xc8, 0xb0, 0xda, 0xca, 0xda, 0x94, 0xeb, 0x47, 0xa4, 0x32, 0xc9, 0xa0, 0x3a, 0x95,
+	0x49, 0xc6, 0x74, 0x07, 0x7a, 0x56, 0x65, 0x0f, 0xda, 0xd5, 0xfb, 0x1b, 0x71, 0x75, 0x4e, 0xeb,
+	0xc2, 0xf2, 0x7a, 0x15, 0xaf, 0x8a, 0xe7, 0x6d, 0xe8, 0x96, 0xfe, 0x74, 0x38, 0x7d, 0x45, 0x6b,
+	0x1b, 0x77, 0x3a, 0x9a, 0xb7, 0xa0, 0xc3, 0x0b, 0x26, 0xef, 0x87, 0xb0, 0x29, 0xc9, 0x84, 0x7f,
+	0x4d, 0x93, 0x14, 0x38, 0xd1, 0x18, 0x42, 0xb0, 0x3e, 0x27, 0x69, 0xec, 0x23, 0x65, 0x53, 0xdf,
+	0x12, 0xa3, 0x2f, 0x48, 0xec, 0x6f, 0x69, 0x4c, 0x7e, 0xa3, 0x01, 0xb8, 0x71, 0xc2, 0x73, 0xca,
+	0x49, 0xec, 0x6f, 0xeb, 0x94, 0xd9, 0xb5, 0xe4, 0xe7, 0x38, 0x89, 0xfd, 0x1d, 0xcd, 0x97, 0xdf,
+	0xc1, 0x0f, 0x0e, 0xf4, 0x4c, 0x45, 0xf3, 0x90, 0x7c, 0x57, 0xc8, 0xcb, 0xbb, 0x0d, 0x6d, 0x5d,
+	0x98, 0x11, 0x17, 0x98, 0x09, 0x55, 0xd9, 0x5e, 0xd8, 0xd2, 0xd8, 0x89, 0x84, 0xd0, 0x1e, 0x80,
+	0xa1, 0x90, 0xcc, 0x56, 0xb3, 0xa7, 0x91, 0x07, 0x59, 0x7c, 0xe9, 0x6d, 0x34, 0xfe, 0xf5, 0x6d,
+	0x04, 0x47, 0xd0, 0xaf, 0xce, 0xc1, 0x73, 0x9a, 0x71, 0x82, 0xde, 0x07, 0x2f, 0xc7, 0xe7, 0x11,
+	0x97, 0xa0, 0xef, 0x0c, 0x1b, 0xa3, 0xd6, 0x61, 0x7f, 0x6c, 0xdf, 0xb1, 0x61, 0x87, 0x6e, 0x6e,
+	0x64, 0xc1, 0x36, 0xa0, 0xc7, 0x24, 0x8d, 0x1f, 0x27, 0x5c, 0x50, 0x76, 0x6e, 0xa2, 0x09, 0x7e,
+	0x6c, 0x40, 0xab, 0x06, 0xbf, 0xc9, 0xbb, 0x7d, 0x07, 0xba, 0x95, 0x24, 0xc3, 0x0b, 0x62, 0x22,
+	0xee, 0x94, 0xe8, 0x53, 0xbc, 0x20, 0xe8, 0x2e, 0xec, 0xcc, 0x69, 0x1a, 0x47, 0x67, 0x94, 0x45,
+	0x67, 0x09, 0xe3, 0x22, 0x32, 0xaf, 0xbd, 0xa1, 0x2e, 0x1c, 0x49, 0xe3, 0x43, 0xca, 0x1e, 0x4a,
+	0xd3, 0x44, 0x59, 0xd0, 0x3d, 0xd8, 0x2d, 0x25, 0x9c, 0x4c, 0x69, 0x16, 0x5b, 0xcd, 0xba, 0xd2,
+	0x6c, 0x19, 0xcd, 0x89, 0xb2, 0x19, 0x51, 0x7d, 0x1f, 0x31, 0x4f, 0x58, 0xa9, 0xd9, 0xb8, 0xb0,
+	0xcf, 0x33, 0x69, 0x32, 0x92, 0x3d, 0x00, 0x41, 0x05, 0x4e, 0x23, 0x55, 0x44, 0xfa, 0xa1, 0x7b,
+	0x0a, 0x91, 0x57, 0x23, 0x03, 0xd4, 0xe6, 0xb2, 0x76, 0xf4, 0x73, 0xef, 0x28, 0xf4, 0xbe, 0x01,
+	0xd1, 0x11, 0x78, 0xcf, 0x69, 0x92, 0x91, 0x38, 0xc2, 0xc2, 0x77, 0x5f, 0xa3, 0x53, 0xb9, 0x5a,
+	0x76, 0x24, 0x82, 0xa7, 0xb0, 0x75, 0x21, 0x47, 0x26, 0xd3, 0x1f, 0x41, 0x5b, 0x9e, 0x2c, 0x9a,
+	0x6b, 0xdc, 0x24, 0x7b, 0xbb, 0x4c, 0x76, 0x5d, 0xd3, 0x9a, 0x57, 0x8b, 0xe0, 0x73, 0xd8, 0x7d,
+	0xc0, 0x45, 0xb2, 0x90, 0x7d, 0x70, 0xa2, 0xc8, 0xb6, 0x8a, 0x5f, 0x3f, 0xcf, 0xc1, 0x5f, 0x6b,
+	0x35, 0x6f, 0x5f, 0xd0, 0x4c, 0xcc, 0xd3, 0x73, 0xed, 0x14, 0xbd, 0x0b, 0x7d, 0x32, 0x63, 0x84,
+	0xf3, 0xe8, 0x14, 0x67, 0xf1, 0x8b, 0x24, 0x16, 0x73, 0xe5, 0xb1, 0x11, 0xf6, 0x34, 0x7e, 0x6c,
+	0x61, 0xf4, 0x21, 0x5c, 0x5f, 0xa6, 0x46, 0x3a, 0x0e, 0x55, 0x36, 0x4e, 0xb8, 0xb3, 0xa4, 0x30,
+	0x5b, 0x8c, 0x61, 0xcb, 0xe8, 0x74, 0x4f, 0x31, 0x2d, 0x43, 0x17, 0xcf, 0x35, 0x6d, 0xd2, 0x8d,
+	0x45, 0xf7, 0x8d, 0x8f, 0x61, 0xb0, 0x82, 0x6f, 0xb7, 0x5a, 0x57, 0x5b, 0x5d, 0xbf, 0x24, 0x33,
+	0x9b, 0xed, 0x01, 0xc4, 0x09, 0xff, 0x36, 0xe2, 0x39, 0x9e, 0x12, 0x33, 0x19, 0x3c, 0x89, 0x9c,
+	0x48, 0x00, 0xbd, 0x07, 0xd7, 0x2a, 0xb3, 0x75, 0xb9, 0xa9, 0x58, 0xbd, 0x92, 0x65, 0x5c, 0xdd,
+	0x04, 0x4f, 0xe5, 0x8e, 0x61, 0x41, 0x54, 0xdd, 0x38, 0xa1, 0x2b, 0x81, 0x10, 0x0b, 0xa2, 0x46,
+	0x9e, 0x56, 0xbb, 0xca, 0x62, 0x56, 0x65, 0x3f, 0xf3, 0x14, 0xaa, 0xbe, 0x83, 0x5f, 0x1c, 0xb8,
+	0x7e, 0x29, 0x99, 0xa6, 0x40, 0xee, 0x43, 0x67, 0x5a, 0x30, 0x46, 0x32, 0x11, 0x2d, 0x68, 0x66,
+	0x2e, 0xbf, 0x75, 0xb8, 0x5f, 0x56, 0xc8, 0xea, 0xbc, 0x85, 0x6d, 0xa3, 0x52, 0x28, 0x7a, 0x08,
+	0xdd, 0x9c, 0x91, 0xef, 0x13, 0x5a, 0x70, 0xe3, 0x66, 0xed, 0xbf, 0xb9, 0xe9, 0x58, 0x99, 0x82,
+	0x83, 0x03, 0xd8, 0x99, 0xe0, 0xf3, 0x05, 0xc9, 0xc4, 0xc5, 0x66, 0x53, 0x9b, 0xf0, 0x4e, 0x7d,
+	0xc2, 0x07, 0x3f, 0x37, 0xa0, 0x7f, 0x62, 0x2b, 0xcd, 0x48, 0xdf, 0xa4, 0x13, 0xc9, 0x59, 0x51,
+	0x4a, 0x0a, 0x96, 0x9a, 0x46, 0x54, 0xf9, 0xf9, 0x9a, 0xa5, 0xa8, 0x0f, 0x0d, 0x3c, 0x23, 0xa6,
+	0x70, 0xe4, 0xa7, 0x3c, 0x16, 0xc1, 0x2c, 0x23, 0xb6, 0xad, 0x98, 0x95, 0xfc, 0x71, 0x50, 0x53,
+	0xc6, 0x74, 0x0e, 0xbd, 0xb8, 0x3c, 0x90, 0x36, 0xff, 0x61, 0x20, 0x35, 0x6b, 0x03, 0xe9, 0xb6,
+	0x79, 0xc5, 0x56, 0xa7, 0x53, 0xae, 0xde, 0xab, 0x95, 0xed, 0x01, 0xe0, 0x33, 0x41, 0x58, 0x54,
+	0x66, 0xbf, 0x11, 0x7a, 0x0a, 0x51, 0x8d, 0xa8, 0x3e, 0xbe, 0xe0, 0x8a, 0xf1, 0xd5, 0xaa, 0xc6,
+	0x17, 0xf2, 0xa1, 0xc9, 0xc8, 0x94, 0x24, 0xb9, 0x1e, 0xf3, 0x5e, 0x68, 0x97, 0xf2, 0xaf, 0x23,
+	0xe1, 0x11, 0x79, 0x99, 0x88, 0x48, 0x4e, 0xdb, 0x94, 0x08, 0xa2, 0xc6, 0xbd, 0x1b, 0x76, 0x13,
+	0xfe, 0xe0, 0x65, 0x22, 0x3e, 0x33, 0x68, 0xf0, 0x25, 0xec, 0x2e, 0x27, 0xd3, 0x14, 0xdd, 0x07,
+	0xe0, 0xe6, 0xda, 0x62, 0xc7, 0xcf, 0x8d, 0xb2, 0x50, 0x96, 0xb3, 0x19, 0x96, 0xd4, 0xc3, 0x5f,
+	0xd7, 0xd4, 0x5f, 0xa2, 0xa4, 0xa1, 0x4f, 0xc1, 0xb5, 0x63, 0x0d, 0xf9, 0xcb, 0xb3, 0xcb, 0x4e,
+	0xdc, 0xc1, 0x8d, 0x15, 0x16, 0x73, 0x86, 0xc7, 0x17, 0xa7, 0xd7, 0xcd, 0x95, 0x2d, 0xd1, 0xb8,
+	0xb9, 0xb5, 0xda, 0x68, 0x3c, 0x3d, 0x83, 0xde, 0xd2, 0xeb, 0x42, 0x2b, 0xea, 0xfe, 0x42, 0x13,
+	0x1d, 0x0c, 0xaf, 0x26, 0x18, 0xaf, 0x5f, 0x41, 0xf7, 0xe2, 0xed, 0xa1, 0xff, 0xd7, 0x83, 0xb9,
+	0xfc, 0x46, 0x06, 0xfb, 0x57, 0xda, 0xb5, 0xcb, 0xe3, 0x5b, 0xdf, 0x0c, 0x24, 0xf0, 0x7c, 0x9c,
+	0xd0, 0x03, 0xf5, 0x71, 0xb0, 0x28, 0x52, 0x91, 0x64, 0x34, 0x26, 0xf9, 0xe9, 0xe9, 0xa6, 0x9a,
+	0x34, 0xf7, 0xfe, 0x1e, 0x00, 0x8d, 0xf8, 0x13, 0xf2, 0xd1, 0x0b, 0x00, 0x00,
+}
+
+// --- DRPC BEGIN ---
+
+type DRPCPayoutsClient interface {
+	DRPCConn() drpc.Conn
+
+	PayStubs(ctx context.Context, in *PayStubsRequest) (*PayStubsResponse, error)
+	HeldHistory(ctx context.Context, in *HeldHistoryRequest) (*HeldHistoryResponse, error)
+	EstimatedPayout(ctx context.Context, in *EstimatedPayoutRequest) (*EstimatedPayoutResponse, error)
+	PaymentHistory(ctx context.Context, in *PaymentHistoryRequest) (*PaymentHistoryResponse, error)
+}
+
+type drpcPayoutsClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCPayoutsClient(cc drpc.Conn) DRPCPayoutsClient {
+	return &drpcPayoutsClient{cc}
+}
+
+func (c *drpcPayoutsClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcPayoutsClient) PayStubs(ctx context.Context, in *PayStubsRequest) (*PayStubsResponse, error) {
+	out := new(PayStubsResponse)
+	err := c.cc.Invoke(ctx, "/payouts.Payouts/PayStubs", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutsClient) HeldHistory(ctx context.Context, in *HeldHistoryRequest) (*HeldHistoryResponse, error) {
+	out := new(HeldHistoryResponse)
+	err := c.cc.Invoke(ctx, "/payouts.Payouts/HeldHistory", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutsClient) EstimatedPayout(ctx context.Context, in *EstimatedPayoutRequest) (*EstimatedPayoutResponse, error) {
+	out := new(EstimatedPayoutResponse)
+	err := c.cc.Invoke(ctx, "/payouts.Payouts/EstimatedPayout", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcPayoutsClient) PaymentHistory(ctx context.Context, in *PaymentHistoryRequest) (*PaymentHistoryResponse, error) {
+	out := new(PaymentHistoryResponse)
+	err := c.cc.Invoke(ctx, "/payouts.Payouts/PaymentHistory", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCPayoutsServer interface {
+	PayStubs(context.Context, *PayStubsRequest) (*PayStubsResponse, error)
+	HeldHistory(context.Context, *HeldHistoryRequest) (*HeldHistoryResponse, error)
+	EstimatedPayout(context.Context, *EstimatedPayoutRequest) (*EstimatedPayoutResponse, error)
+	PaymentHistory(context.Context, *PaymentHistoryRequest) (*PaymentHistoryResponse, error)
+}
+
+type DRPCPayoutsDescription struct{}
+
+func (DRPCPayoutsDescription) NumMethods() int { return 4 }
+
+func (DRPCPayoutsDescription) Method(n int) (string, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/payouts.Payouts/PayStubs",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutsServer).
+					PayStubs(
+						ctx,
+						in1.(*PayStubsRequest),
+					)
+			}, DRPCPayoutsServer.PayStubs, true
+	case 1:
+		return "/payouts.Payouts/HeldHistory",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutsServer).
+					HeldHistory(
+						ctx,
+						in1.(*HeldHistoryRequest),
+					)
+			}, DRPCPayoutsServer.HeldHistory, true
+	case 2:
+		return "/payouts.Payouts/EstimatedPayout",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutsServer).
+					EstimatedPayout(
+						ctx,
+						in1.(*EstimatedPayoutRequest),
+					)
+			}, DRPCPayoutsServer.EstimatedPayout, true
+	case 3:
+		return "/payouts.Payouts/PaymentHistory",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPayoutsServer).
+					PaymentHistory(
+						ctx,
+						in1.(*PaymentHistoryRequest),
+					)
+			}, DRPCPayoutsServer.PaymentHistory, true
+	default:
+		return "", nil, nil, false
+	}
+}
+
+func DRPCRegisterPayouts(mux drpc.Mux, impl DRPCPayoutsServer) error {
+	return mux.Register(impl, DRPCPayoutsDescription{})
+}
+
+type DRPCPayouts_PayStubsStream interface {
+	drpc.Stream
+	SendAndClose(*PayStubsResponse) error
+}
+
+type drpcPayoutsPayStubsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayoutsPayStubsStream) SendAndClose(m *PayStubsResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayouts_HeldHistoryStream interface {
+	drpc.Stream
+	SendAndClose(*HeldHistoryResponse) error
+}
+
+type drpcPayoutsHeldHistoryStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayoutsHeldHistoryStream) SendAndClose(m *HeldHistoryResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayouts_EstimatedPayoutStream interface {
+	drpc.Stream
+	SendAndClose(*EstimatedPayoutResponse) error
+}
+
+type drpcPayoutsEstimatedPayoutStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayoutsEstimatedPayoutStream) SendAndClose(m *EstimatedPayoutResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCPayouts_PaymentHistoryStream interface {
+	drpc.Stream
+	SendAndClose(*PaymentHistoryResponse) error
+}
+
+type drpcPayoutsPaymentHistoryStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPayoutsPaymentHistoryStream) SendAndClose(m *PaymentHistoryResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+// --- DRPC END ---
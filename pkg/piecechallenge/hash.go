@@ -0,0 +1,33 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package piecechallenge implements hash challenges, which allow satellites
+// to verify that a storage node stores a whole range of a piece without
+// downloading it.
+package piecechallenge
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+)
+
+// NonceSize is the size of the nonces used by satellites.
+const NonceSize = 32
+
+// NewHash returns the hash used for answering a challenge with the nonce.
+func NewHash(nonce []byte) hash.Hash {
+	return hmac.New(sha256.New, nonce)
+}
+
+// Hash returns the expected response to a challenge with the nonce for data.
+func Hash(nonce, data []byte) []byte {
+	h := NewHash(nonce)
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
+
+// Equal compares challenge responses in constant time.
+func Equal(a, b []byte) bool {
+	return hmac.Equal(a, b)
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package piecechallenge_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testrand"
+	"storj.io/storj/pkg/piecechallenge"
+)
+
+func TestHash(t *testing.T) {
+	data := testrand.BytesInt(1024)
+	nonce := testrand.BytesInt(piecechallenge.NonceSize)
+
+	hash := piecechallenge.Hash(nonce, data)
+	require.True(t, piecechallenge.Equal(hash, piecechallenge.Hash(nonce, data)))
+
+	// streaming the data results in the same hash.
+	h := piecechallenge.NewHash(nonce)
+	_, _ = h.Write(data[:100])
+	_, _ = h.Write(data[100:])
+	require.True(t, piecechallenge.Equal(hash, h.Sum(nil)))
+
+	// responses can't be reused for other nonces or data.
+	require.False(t, piecechallenge.Equal(hash, piecechallenge.Hash(testrand.BytesInt(piecechallenge.NonceSize), data)))
+	require.False(t, piecechallenge.Equal(hash, piecechallenge.Hash(nonce, data[1:])))
+}
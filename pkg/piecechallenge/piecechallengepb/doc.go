@@ -0,0 +1,8 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package piecechallengepb contains protobuf definitions for challenging
+// storage nodes to prove that they store a piece.
+package piecechallengepb
+
+//go:generate go run gen.go
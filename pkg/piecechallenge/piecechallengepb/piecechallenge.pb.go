@@ -0,0 +1,226 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: piecechallenge.proto
+
+package piecechallengepb
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type HashRangeRequest struct {
+	PieceId []byte `protobuf:"bytes,1,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	// offset and length of the challenged range within the piece.
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Length int64 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	// nonce is used as the key of the hash, so the response can't be precomputed.
+	Nonce                []byte   `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashRangeRequest) Reset()         { *m = HashRangeRequest{} }
+func (m *HashRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*HashRangeRequest) ProtoMessage()    {}
+func (*HashRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d746c3e3662f0d15, []int{0}
+}
+func (m *HashRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashRangeRequest.Unmarshal(m, b)
+}
+func (m *HashRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *HashRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashRangeRequest.Merge(m, src)
+}
+func (m *HashRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_HashRangeRequest.Size(m)
+}
+func (m *HashRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashRangeRequest proto.InternalMessageInfo
+
+func (m *HashRangeRequest) GetPieceId() []byte {
+	if m != nil {
+		return m.PieceId
+	}
+	return nil
+}
+
+func (m *HashRangeRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *HashRangeRequest) GetLength() int64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *HashRangeRequest) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+type HashRangeResponse struct {
+	// HMAC-SHA256 of the range keyed with the nonce.
+	Hash                 []byte   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashRangeResponse) Reset()         { *m = HashRangeResponse{} }
+func (m *HashRangeResponse) String() string { return proto.CompactTextString(m) }
+func (*HashRangeResponse) ProtoMessage()    {}
+func (*HashRangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d746c3e3662f0d15, []int{1}
+}
+func (m *HashRangeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashRangeResponse.Unmarshal(m, b)
+}
+func (m *HashRangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashRangeResponse.Marshal(b, m, deterministic)
+}
+func (m *HashRangeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashRangeResponse.Merge(m, src)
+}
+func (m *HashRangeResponse) XXX_Size() int {
+	return xxx_messageInfo_HashRangeResponse.Size(m)
+}
+func (m *HashRangeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashRangeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashRangeResponse proto.InternalMessageInfo
+
+func (m *HashRangeResponse) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*HashRangeRequest)(nil), "piecechallenge.HashRangeRequest")
+	proto.RegisterType((*HashRangeResponse)(nil), "piecechallenge.HashRangeResponse")
+}
+
+func init() { proto.RegisterFile("piecechallenge.proto", fileDescriptor_d746c3e3662f0d15) }
+
+var fileDescriptor_d746c3e3662f0d15 = []byte{
+	// 219 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x29, 0xc8, 0x4c, 0x4d,
+	0x4e, 0x4d, 0xce, 0x48, 0xcc, 0xc9, 0x49, 0xcd, 0x4b, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9,
+	0x17, 0xe2, 0x43, 0x15, 0x55, 0x2a, 0xe6, 0x12, 0xf0, 0x48, 0x2c, 0xce, 0x08, 0x4a, 0xcc, 0x4b,
+	0x4f, 0x0d, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d, 0x2e, 0x11, 0x92, 0xe4, 0xe2, 0x00, 0xab, 0x8a, 0xcf,
+	0x4c, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x09, 0x62, 0x07, 0xf3, 0x3d, 0x53, 0x84, 0xc4, 0xb8,
+	0xd8, 0xf2, 0xd3, 0xd2, 0x8a, 0x53, 0x4b, 0x24, 0x98, 0x14, 0x18, 0x35, 0x98, 0x83, 0xa0, 0x3c,
+	0x90, 0x38, 0xc8, 0xbc, 0x92, 0x0c, 0x09, 0x66, 0x88, 0x38, 0x84, 0x27, 0x24, 0xc2, 0xc5, 0x9a,
+	0x97, 0x9f, 0x97, 0x9c, 0x2a, 0xc1, 0x02, 0x36, 0x07, 0xc2, 0x51, 0x52, 0xe7, 0x12, 0x44, 0xb2,
+	0xb4, 0xb8, 0x20, 0x3f, 0xaf, 0x38, 0x55, 0x48, 0x88, 0x8b, 0x25, 0x23, 0xb1, 0x38, 0x03, 0x6a,
+	0x23, 0x98, 0x6d, 0x94, 0xc4, 0xc5, 0x17, 0x00, 0xb2, 0xd9, 0x19, 0xe6, 0x5e, 0xa1, 0x00, 0x2e,
+	0x4e, 0xb8, 0x56, 0x21, 0x05, 0x3d, 0x34, 0x3f, 0xa2, 0x7b, 0x45, 0x4a, 0x11, 0x8f, 0x0a, 0x88,
+	0xbd, 0x4e, 0x26, 0x51, 0x46, 0xc5, 0x25, 0xf9, 0x45, 0x59, 0x7a, 0x99, 0xf9, 0xfa, 0x60, 0x86,
+	0x7e, 0x41, 0x76, 0xba, 0x3e, 0xaa, 0x36, 0x34, 0x6e, 0x41, 0x52, 0x12, 0x1b, 0x38, 0x38, 0x8d,
+	0x01, 0x03, 0x00, 0x42, 0xc8, 0x3a, 0x2c, 0x66, 0x01, 0x00, 0x00,
+}
+
+// --- DRPC BEGIN ---
+
+type DRPCPieceChallengeClient interface {
+	DRPCConn() drpc.Conn
+
+	HashRange(ctx context.Context, in *HashRangeRequest) (*HashRangeResponse, error)
+}
+
+type drpcPieceChallengeClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCPieceChallengeClient(cc drpc.Conn) DRPCPieceChallengeClient {
+	return &drpcPieceChallengeClient{cc}
+}
+
+func (c *drpcPieceChallengeClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcPieceChallengeClient) HashRange(ctx context.Context, in *HashRangeRequest) (*HashRangeResponse, error) {
+	out := new(HashRangeResponse)
+	err := c.cc.Invoke(ctx, "/piecechallenge.PieceChallenge/HashRange", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCPieceChallengeServer interface {
+	HashRange(context.Context, *HashRangeRequest) (*HashRangeResponse, error)
+}
+
+type DRPCPieceChallengeDescription struct{}
+
+func (DRPCPieceChallengeDescription) NumMethods() int { return 1 }
+
+func (DRPCPieceChallengeDescription) Method(n int) (string, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/piecechallenge.PieceChallenge/HashRange",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCPieceChallengeServer).
+					HashRange(
+						ctx,
+						in1.(*HashRangeRequest),
+					)
+			}, DRPCPieceChallengeServer.HashRange, true
+	default:
+		return "", nil, nil, false
+	}
+}
+
+func DRPCRegisterPieceChallenge(mux drpc.Mux, impl DRPCPieceChallengeServer) error {
+	return mux.Register(impl, DRPCPieceChallengeDescription{})
+}
+
+type DRPCPieceChallenge_HashRangeStream interface {
+	drpc.Stream
+	SendAndClose(*HashRangeResponse) error
+}
+
+type drpcPieceChallengeHashRangeStream struct {
+	drpc.Stream
+}
+
+func (x *drpcPieceChallengeHashRangeStream) SendAndClose(m *HashRangeResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+// --- DRPC END ---
@@ -0,0 +1,45 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package quic implements DRPC connections over QUIC streams.
+//
+// Nodes don't advertise QUIC to satellites and uplinks, because the node
+// transport enum in storj.io/common has no value for it yet. Until it does,
+// only clients which know that a node listens on QUIC dial it this way.
+package quic
+
+import (
+	"crypto/tls"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is a pkg/quic error.
+	Error = errs.Class("quic error")
+)
+
+// applicationProtocol is negotiated with ALPN, as QUIC requires.
+const applicationProtocol = "drpc"
+
+// defaultIdleTimeout is how long a connection can be idle before it's closed.
+const defaultIdleTimeout = 60 * time.Second
+
+// defaultConfig returns the QUIC configuration used when none is provided.
+func defaultConfig() *quicgo.Config {
+	return &quicgo.Config{
+		MaxIdleTimeout: defaultIdleTimeout,
+	}
+}
+
+// withApplicationProtocol returns a copy of config which negotiates the DRPC protocol.
+func withApplicationProtocol(config *tls.Config) *tls.Config {
+	config = config.Clone()
+	config.NextProtos = []string{applicationProtocol}
+	return config
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package quic
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+	"github.com/zeebo/errs"
+)
+
+// Conn is a single QUIC connection carrying a single bidirectional stream.
+// It implements net.Conn and rpc.ConnectorConn.
+type Conn struct {
+	conn   quicgo.Connection
+	stream quicgo.Stream
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func newConn(conn quicgo.Connection, stream quicgo.Stream) *Conn {
+	return &Conn{conn: conn, stream: stream}
+}
+
+// Read reads data from the stream.
+func (c *Conn) Read(p []byte) (int, error) { return c.stream.Read(p) }
+
+// Write writes data to the stream.
+func (c *Conn) Write(p []byte) (int, error) { return c.stream.Write(p) }
+
+// Close closes the stream and the connection.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		c.closeErr = errs.Combine(c.stream.Close(), c.conn.CloseWithError(0, ""))
+	})
+	return c.closeErr
+}
+
+// LocalAddr returns the local address of the connection.
+func (c *Conn) LocalAddr() net.Addr { return c.conn.LocalAddr() }
+
+// RemoteAddr returns the remote address of the connection.
+func (c *Conn) RemoteAddr() net.Addr { return c.conn.RemoteAddr() }
+
+// SetDeadline sets the read and write deadlines of the stream.
+func (c *Conn) SetDeadline(t time.Time) error { return c.stream.SetDeadline(t) }
+
+// SetReadDeadline sets the read deadline of the stream.
+func (c *Conn) SetReadDeadline(t time.Time) error { return c.stream.SetReadDeadline(t) }
+
+// SetWriteDeadline sets the write deadline of the stream.
+func (c *Conn) SetWriteDeadline(t time.Time) error { return c.stream.SetWriteDeadline(t) }
+
+// ConnectionState returns the tls state of the connection.
+func (c *Conn) ConnectionState() tls.ConnectionState {
+	return c.conn.ConnectionState().TLS
+}
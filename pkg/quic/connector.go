@@ -0,0 +1,51 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+
+	quicgo "github.com/quic-go/quic-go"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/rpc"
+)
+
+// Connector implements rpc.Connector by dialing QUIC connections.
+type Connector struct {
+	config *quicgo.Config
+}
+
+// NewDefaultConnector creates a new Connector. If config is nil, a default
+// configuration is used.
+func NewDefaultConnector(config *quicgo.Config) Connector {
+	if config == nil {
+		config = defaultConfig()
+	}
+	return Connector{config: config}
+}
+
+// DialContext dials address and opens a stream for DRPC on the connection.
+func (c Connector) DialContext(ctx context.Context, tlsConfig *tls.Config, address string) (_ rpc.ConnectorConn, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := quicgo.DialAddr(ctx, address, withApplicationProtocol(tlsConfig), c.config)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, Error.Wrap(errs.Combine(err, conn.CloseWithError(0, "")))
+	}
+
+	return newConn(conn, stream), nil
+}
+
+// NewDialer returns a copy of dialer which connects over QUIC.
+func NewDialer(dialer rpc.Dialer) rpc.Dialer {
+	dialer.Connector = NewDefaultConnector(nil)
+	return dialer
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+
+	quicgo "github.com/quic-go/quic-go"
+)
+
+// Listener implements net.Listener by accepting QUIC connections and returning
+// the first stream opened on each as a Conn.
+type Listener struct {
+	listener *quicgo.Listener
+
+	ctx    context.Context
+	cancel func()
+	conns  chan *Conn
+
+	once sync.Once
+	err  error
+	done chan struct{}
+}
+
+// NewListener returns a Listener accepting QUIC connections on conn. If config is
+// nil, a default configuration is used.
+func NewListener(conn net.PacketConn, tlsConfig *tls.Config, config *quicgo.Config) (*Listener, error) {
+	if config == nil {
+		config = defaultConfig()
+	}
+
+	listener, err := quicgo.Listen(conn, withApplicationProtocol(tlsConfig), config)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lis := &Listener{
+		listener: listener,
+		ctx:      ctx,
+		cancel:   cancel,
+		conns:    make(chan *Conn),
+		done:     make(chan struct{}),
+	}
+	go lis.acceptConns()
+	return lis, nil
+}
+
+// acceptConns accepts connections until the listener is closed. Waiting for the
+// first stream happens concurrently, so that clients which never open a stream
+// don't block other connections.
+func (lis *Listener) acceptConns() {
+	for {
+		conn, err := lis.listener.Accept(lis.ctx)
+		if err != nil {
+			lis.closeWithError(err)
+			return
+		}
+		go lis.acceptStream(conn)
+	}
+}
+
+// acceptStream waits for the first stream of conn and hands it to Accept.
+func (lis *Listener) acceptStream(conn quicgo.Connection) {
+	stream, err := conn.AcceptStream(lis.ctx)
+	if err != nil {
+		_ = conn.CloseWithError(0, "")
+		return
+	}
+
+	select {
+	case lis.conns <- newConn(conn, stream):
+	case <-lis.done:
+		_ = stream.Close()
+		_ = conn.CloseWithError(0, "")
+	}
+}
+
+// Accept waits for and returns the next connection to the listener.
+func (lis *Listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-lis.conns:
+		return conn, nil
+	case <-lis.done:
+		return nil, lis.err
+	}
+}
+
+// Close closes the listener.
+func (lis *Listener) Close() error {
+	lis.closeWithError(Error.New("listener closed"))
+	return nil
+}
+
+// closeWithError closes the listener, making Accept return err.
+func (lis *Listener) closeWithError(err error) {
+	lis.once.Do(func() {
+		lis.err = err
+		lis.cancel()
+		_ = lis.listener.Close()
+		close(lis.done)
+	})
+}
+
+// Addr returns the listener's network address.
+func (lis *Listener) Addr() net.Addr {
+	return lis.listener.Addr()
+}
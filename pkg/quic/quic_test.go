@@ -0,0 +1,93 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package quic_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity"
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/pb"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/pkg/quic"
+	"storj.io/storj/pkg/server"
+)
+
+type pingEndpoint struct {
+	peers chan storj.NodeID
+}
+
+func (endpoint *pingEndpoint) PingNode(ctx context.Context, req *pb.ContactPingRequest) (*pb.ContactPingResponse, error) {
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.peers <- peer.ID
+	return &pb.ContactPingResponse{}, nil
+}
+
+func TestServerQUIC(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	serverIdent := testidentity.MustPregeneratedIdentity(0, storj.LatestIDVersion())
+	clientIdent := testidentity.MustPregeneratedIdentity(1, storj.LatestIDVersion())
+
+	tlsConfig := tlsopts.Config{PeerIDVersions: "*"}
+	serverTLS, err := tlsopts.NewOptions(serverIdent, tlsConfig, nil)
+	require.NoError(t, err)
+	clientTLS, err := tlsopts.NewOptions(clientIdent, tlsConfig, nil)
+	require.NoError(t, err)
+
+	srv, err := server.New(zaptest.NewLogger(t), serverTLS, server.Config{
+		Address:        "127.0.0.1:0",
+		PrivateAddress: "127.0.0.1:0",
+		EnableQUIC:     true,
+	})
+	require.NoError(t, err)
+	defer ctx.Check(srv.Close)
+
+	endpoint := &pingEndpoint{peers: make(chan storj.NodeID, 1)}
+	require.NoError(t, pb.DRPCRegisterContact(srv.DRPC(), endpoint))
+
+	require.NotNil(t, srv.QUICAddr())
+	require.Equal(t, srv.Addr().String(), srv.QUICAddr().String())
+
+	ctx.Go(func() error { return srv.Run(ctx) })
+
+	dialer := quic.NewDialer(rpc.NewDefaultDialer(clientTLS))
+	conn, err := dialer.DialNodeURL(ctx, storj.NodeURL{ID: serverIdent.ID, Address: srv.QUICAddr().String()})
+	require.NoError(t, err)
+	defer ctx.Check(conn.Close)
+
+	peer, err := identity.PeerIdentityFromChain(conn.ConnectionState().PeerCertificates)
+	require.NoError(t, err)
+	require.Equal(t, serverIdent.ID, peer.ID)
+
+	_, err = pb.NewDRPCContactClient(conn).PingNode(ctx, &pb.ContactPingRequest{})
+	require.NoError(t, err)
+	require.Equal(t, clientIdent.ID, <-endpoint.peers)
+
+	{ // the public address still accepts TCP connections
+		conn, err := rpc.NewDefaultDialer(clientTLS).DialNodeURL(ctx, storj.NodeURL{ID: serverIdent.ID, Address: srv.Addr().String()})
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		_, err = pb.NewDRPCContactClient(conn).PingNode(ctx, &pb.ContactPingRequest{})
+		require.NoError(t, err)
+		require.Equal(t, clientIdent.ID, <-endpoint.peers)
+	}
+
+	{ // dialing QUIC to the wrong node fails
+		_, err := dialer.DialNodeURL(ctx, storj.NodeURL{ID: clientIdent.ID, Address: srv.QUICAddr().String()})
+		require.Error(t, err)
+	}
+}
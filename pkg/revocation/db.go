@@ -4,6 +4,7 @@
 package revocation
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -14,6 +15,7 @@ import (
 	"storj.io/common/identity"
 	"storj.io/common/peertls"
 	"storj.io/common/peertls/extensions"
+	"storj.io/common/storj"
 	"storj.io/storj/storage"
 )
 
@@ -24,6 +26,10 @@ var (
 	Error = errs.Class("revocation error")
 )
 
+// caKeyPrefix prefixes the keys of the CA certificates, which signed the
+// stored revocations.
+const caKeyPrefix = "ca:"
+
 // DB stores the most recently seen revocation for each nodeID
 // (i.e. nodeID [CA certificate's public key hash] is the key, values is
 // the most recently seen revocation).
@@ -36,11 +42,15 @@ type DB struct {
 func (db *DB) Get(ctx context.Context, chain []*x509.Certificate) (_ *extensions.Revocation, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return db.get(ctx, chain[peertls.CAIndex])
+}
+
+func (db *DB) get(ctx context.Context, ca *x509.Certificate) (_ *extensions.Revocation, err error) {
 	if db.store == nil {
 		return nil, nil
 	}
 
-	nodeID, err := identity.NodeIDFromCert(chain[peertls.CAIndex])
+	nodeID, err := identity.NodeIDFromCert(ca)
 	if err != nil {
 		return nil, extensions.ErrRevocation.Wrap(err)
 	}
@@ -66,13 +76,29 @@ func (db *DB) Get(ctx context.Context, chain []*x509.Certificate) (_ *extensions
 func (db *DB) Put(ctx context.Context, chain []*x509.Certificate, revExt pkix.Extension) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	return db.put(ctx, chain[peertls.CAIndex], revExt.Value)
+}
+
+// Merge stores a revocation learned from another peer IF it is signed by ca and
+// its timestamp is newer than the current value. It returns whether the
+// revocation was stored.
+func (db *DB) Merge(ctx context.Context, ca *x509.Certificate, revocation []byte) (stored bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = db.put(ctx, ca, revocation)
+	if errs.Is(err, extensions.ErrRevocationTimestamp) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (db *DB) put(ctx context.Context, ca *x509.Certificate, revBytes []byte) (err error) {
 	if db.store == nil {
 		return extensions.ErrRevocationDB.New("not supported")
 	}
 
-	ca := chain[peertls.CAIndex]
 	var rev extensions.Revocation
-	if err := rev.Unmarshal(revExt.Value); err != nil {
+	if err := rev.Unmarshal(revBytes); err != nil {
 		return err
 	}
 
@@ -83,7 +109,7 @@ func (db *DB) Put(ctx context.Context, chain []*x509.Certificate, revExt pkix.Ex
 		return err
 	}
 
-	lastRev, err := db.Get(ctx, chain)
+	lastRev, err := db.get(ctx, ca)
 	if err != nil {
 		return err
 	} else if lastRev != nil && lastRev.Timestamp >= rev.Timestamp {
@@ -94,7 +120,12 @@ func (db *DB) Put(ctx context.Context, chain []*x509.Certificate, revExt pkix.Ex
 	if err != nil {
 		return extensions.ErrRevocationDB.Wrap(err)
 	}
-	if err := db.store.Put(ctx, nodeID.Bytes(), revExt.Value); err != nil {
+	if err := db.store.Put(ctx, nodeID.Bytes(), revBytes); err != nil {
+		return extensions.ErrRevocationDB.Wrap(err)
+	}
+	// NB: the CA certificate is kept, so that the revocation can be verified
+	// by the peers it's distributed to.
+	if err := db.store.Put(ctx, caKey(nodeID), ca.Raw); err != nil {
 		return extensions.ErrRevocationDB.Wrap(err)
 	}
 	return nil
@@ -108,9 +139,12 @@ func (db *DB) List(ctx context.Context) (revs []*extensions.Revocation, err erro
 		return nil, nil
 	}
 
-	keys, err := db.store.List(ctx, []byte{}, 0)
+	keys, err := db.revocationKeys(ctx)
 	if err != nil {
-		return nil, extensions.ErrRevocationDB.Wrap(err)
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
 	}
 
 	marshaledRevs, err := db.store.GetAll(ctx, keys)
@@ -129,6 +163,87 @@ func (db *DB) List(ctx context.Context) (revs []*extensions.Revocation, err erro
 	return revs, nil
 }
 
+// Record is a serialized revocation together with the CA certificate, whose
+// key signed it.
+type Record struct {
+	CA         *x509.Certificate
+	Revocation []byte
+}
+
+// Records lists all revocations in the store, whose CA certificate is known.
+func (db *DB) Records(ctx context.Context) (records []Record, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if db.store == nil {
+		return nil, nil
+	}
+
+	keys, err := db.revocationKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		nodeID, err := storj.NodeIDFromBytes(key)
+		if err != nil {
+			return nil, extensions.ErrRevocationDB.Wrap(err)
+		}
+
+		caBytes, err := db.store.Get(ctx, caKey(nodeID))
+		if storage.ErrKeyNotFound.Has(err) {
+			// NB: revocations stored before the CA certificates were kept
+			// can't be verified by other peers.
+			continue
+		}
+		if err != nil {
+			return nil, extensions.ErrRevocationDB.Wrap(err)
+		}
+
+		ca, err := x509.ParseCertificate(caBytes)
+		if err != nil {
+			return nil, extensions.ErrRevocationDB.Wrap(err)
+		}
+
+		revBytes, err := db.store.Get(ctx, key)
+		if err != nil {
+			return nil, extensions.ErrRevocationDB.Wrap(err)
+		}
+
+		records = append(records, Record{
+			CA:         ca,
+			Revocation: revBytes,
+		})
+	}
+	return records, nil
+}
+
+// revocationKeys lists the keys of all revocations in the store.
+func (db *DB) revocationKeys(ctx context.Context) (_ storage.Keys, err error) {
+	keys, err := db.store.List(ctx, []byte{}, 0)
+	if err != nil {
+		return nil, extensions.ErrRevocationDB.Wrap(err)
+	}
+
+	revocationKeys := keys[:0]
+	for _, key := range keys {
+		if !isCAKey(key) {
+			revocationKeys = append(revocationKeys, key)
+		}
+	}
+	return revocationKeys, nil
+}
+
+// caKey returns the key of the CA certificate of the node.
+func caKey(nodeID storj.NodeID) storage.Key {
+	return append(storage.Key(caKeyPrefix), nodeID.Bytes()...)
+}
+
+// isCAKey returns whether key is the key of a CA certificate. Revocations are
+// keyed by the bare node ID and are never of the same length.
+func isCAKey(key storage.Key) bool {
+	return len(key) == len(caKeyPrefix)+len(storj.NodeID{}) && bytes.HasPrefix(key, []byte(caKeyPrefix))
+}
+
 // TestGetStore returns the internal store for testing.
 func (db *DB) TestGetStore() storage.KeyValueStore {
 	return db.store
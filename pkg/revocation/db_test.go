@@ -18,6 +18,7 @@ import (
 	"storj.io/common/peertls/testpeertls"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
+	"storj.io/storj/pkg/revocation"
 	"storj.io/storj/private/testrevocation"
 	"storj.io/storj/storage"
 )
@@ -196,3 +197,87 @@ func TestRevocationDB_List(t *testing.T) {
 		}
 	})
 }
+
+func TestRevocationDB_Records(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	testrevocation.RunDBs(t, func(t *testing.T, revDB extensions.RevocationDB, db storage.KeyValueStore) {
+		keys, chain, err := testpeertls.NewCertChain(2, storj.LatestIDVersion().Number)
+		require.NoError(t, err)
+		keys2, chain2, err := testpeertls.NewCertChain(2, storj.LatestIDVersion().Number)
+		require.NoError(t, err)
+
+		records, err := revDB.(*revocation.DB).Records(ctx)
+		require.NoError(t, err)
+		require.Empty(t, records)
+
+		firstRevocation, err := extensions.NewRevocationExt(keys[peertls.CAIndex], chain[peertls.LeafIndex])
+		require.NoError(t, err)
+		require.NoError(t, revDB.Put(ctx, chain, firstRevocation))
+
+		// revocations stored without their CA certificate can't be distributed
+		secondRevocation, err := extensions.NewRevocationExt(keys2[peertls.CAIndex], chain2[peertls.LeafIndex])
+		require.NoError(t, err)
+		nodeID, err := identity.NodeIDFromCert(chain2[peertls.CAIndex])
+		require.NoError(t, err)
+		require.NoError(t, db.Put(ctx, nodeID.Bytes(), secondRevocation.Value))
+
+		records, err = revDB.(*revocation.DB).Records(ctx)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, chain[peertls.CAIndex].Raw, records[0].CA.Raw)
+		assert.Equal(t, firstRevocation.Value, records[0].Revocation)
+
+		// the CA certificates aren't listed as revocations
+		revs, err := revDB.List(ctx)
+		require.NoError(t, err)
+		assert.Len(t, revs, 2)
+	})
+}
+
+func TestRevocationDB_Merge(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	testrevocation.RunDBs(t, func(t *testing.T, revDB extensions.RevocationDB, db storage.KeyValueStore) {
+		keys, chain, err := testpeertls.NewCertChain(2, storj.LatestIDVersion().Number)
+		require.NoError(t, err)
+		otherKeys, _, err := testpeertls.NewCertChain(2, storj.LatestIDVersion().Number)
+		require.NoError(t, err)
+
+		ca := chain[peertls.CAIndex]
+		merger := revDB.(*revocation.DB)
+
+		olderRevocation, err := extensions.NewRevocationExt(keys[peertls.CAIndex], chain[peertls.LeafIndex])
+		require.NoError(t, err)
+		time.Sleep(time.Second)
+		newerRevocation, err := extensions.NewRevocationExt(keys[peertls.CAIndex], chain[peertls.LeafIndex])
+		require.NoError(t, err)
+
+		forgedRevocation, err := extensions.NewRevocationExt(otherKeys[peertls.CAIndex], chain[peertls.LeafIndex])
+		require.NoError(t, err)
+
+		stored, err := merger.Merge(ctx, ca, forgedRevocation.Value)
+		require.Error(t, err)
+		require.False(t, stored)
+
+		stored, err = merger.Merge(ctx, ca, newerRevocation.Value)
+		require.NoError(t, err)
+		require.True(t, stored)
+
+		stored, err = merger.Merge(ctx, ca, newerRevocation.Value)
+		require.NoError(t, err)
+		require.False(t, stored)
+
+		stored, err = merger.Merge(ctx, ca, olderRevocation.Value)
+		require.NoError(t, err)
+		require.False(t, stored)
+
+		rev, err := revDB.Get(ctx, chain)
+		require.NoError(t, err)
+		revBytes, err := rev.Marshal()
+		require.NoError(t, err)
+		assert.Equal(t, newerRevocation.Value, revBytes)
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package revocation
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/pkg/revocation/revocationpb"
+)
+
+var _ revocationpb.DRPCRevocationsServer = (*Endpoint)(nil)
+
+// Endpoint serves the known revocations to other peers, so that they can
+// reject revoked identities, which they haven't seen yet.
+//
+// architecture: Endpoint
+type Endpoint struct {
+	log *zap.Logger
+	db  *DB
+}
+
+// NewEndpoint creates a new revocations endpoint.
+func NewEndpoint(log *zap.Logger, db *DB) *Endpoint {
+	return &Endpoint{
+		log: log,
+		db:  db,
+	}
+}
+
+// List returns all known revocations together with the CA certificates which signed them.
+func (endpoint *Endpoint) List(ctx context.Context, req *revocationpb.ListRequest) (_ *revocationpb.ListResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	records, err := endpoint.db.Records(ctx)
+	if err != nil {
+		endpoint.log.Error("unable to list revocations", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to list revocations")
+	}
+
+	response := &revocationpb.ListResponse{}
+	for _, record := range records {
+		response.Revocations = append(response.Revocations, &revocationpb.Revocation{
+			CaCertificate: record.CA.Raw,
+			Revocation:    record.Revocation,
+		})
+	}
+	return response, nil
+}
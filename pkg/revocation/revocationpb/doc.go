@@ -0,0 +1,8 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package revocationpb contains protobuf definitions for distributing
+// certificate revocations between peers.
+package revocationpb
+
+//go:generate go run gen.go
@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: revocations.proto
+
+package revocationpb
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type ListRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eda6376202c3b902, []int{0}
+}
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRequest.Unmarshal(m, b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(m, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRequest.Size(m)
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+type ListResponse struct {
+	Revocations          []*Revocation `protobuf:"bytes,1,rep,name=revocations,proto3" json:"revocations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListResponse) Reset()         { *m = ListResponse{} }
+func (m *ListResponse) String() string { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()    {}
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eda6376202c3b902, []int{1}
+}
+func (m *ListResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListResponse.Unmarshal(m, b)
+}
+func (m *ListResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListResponse.Marshal(b, m, deterministic)
+}
+func (m *ListResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListResponse.Merge(m, src)
+}
+func (m *ListResponse) XXX_Size() int {
+	return xxx_messageInfo_ListResponse.Size(m)
+}
+func (m *ListResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListResponse proto.InternalMessageInfo
+
+func (m *ListResponse) GetRevocations() []*Revocation {
+	if m != nil {
+		return m.Revocations
+	}
+	return nil
+}
+
+type Revocation struct {
+	// DER encoded CA certificate, whose key signed the revocation.
+	CaCertificate []byte `protobuf:"bytes,1,opt,name=ca_certificate,json=caCertificate,proto3" json:"ca_certificate,omitempty"`
+	// serialized revocation, as stored in the revocation extension.
+	Revocation           []byte   `protobuf:"bytes,2,opt,name=revocation,proto3" json:"revocation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Revocation) Reset()         { *m = Revocation{} }
+func (m *Revocation) String() string { return proto.CompactTextString(m) }
+func (*Revocation) ProtoMessage()    {}
+func (*Revocation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eda6376202c3b902, []int{2}
+}
+func (m *Revocation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Revocation.Unmarshal(m, b)
+}
+func (m *Revocation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Revocation.Marshal(b, m, deterministic)
+}
+func (m *Revocation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Revocation.Merge(m, src)
+}
+func (m *Revocation) XXX_Size() int {
+	return xxx_messageInfo_Revocation.Size(m)
+}
+func (m *Revocation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Revocation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Revocation proto.InternalMessageInfo
+
+func (m *Revocation) GetCaCertificate() []byte {
+	if m != nil {
+		return m.CaCertificate
+	}
+	return nil
+}
+
+func (m *Revocation) GetRevocation() []byte {
+	if m != nil {
+		return m.Revocation
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ListRequest)(nil), "revocation.ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "revocation.ListResponse")
+	proto.RegisterType((*Revocation)(nil), "revocation.Revocation")
+}
+
+func init() { proto.RegisterFile("revocations.proto", fileDescriptor_eda6376202c3b902) }
+
+var fileDescriptor_eda6376202c3b902 = []byte{
+	// 200 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2c, 0x4a, 0x2d, 0xcb,
+	0x4f, 0x4e, 0x2c, 0xc9, 0xcc, 0xcf, 0x2b, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x42,
+	0x08, 0x29, 0xf1, 0x72, 0x71, 0xfb, 0x64, 0x16, 0x97, 0x04, 0xa5, 0x16, 0x96, 0xa6, 0x16, 0x97,
+	0x28, 0x79, 0x70, 0xf1, 0x40, 0xb8, 0xc5, 0x05, 0xf9, 0x79, 0xc5, 0xa9, 0x42, 0x16, 0x5c, 0xdc,
+	0x48, 0xfa, 0x25, 0x18, 0x15, 0x98, 0x35, 0xb8, 0x8d, 0xc4, 0xf4, 0x10, 0x62, 0x7a, 0x41, 0x70,
+	0x66, 0x10, 0xb2, 0x52, 0xa5, 0x60, 0x2e, 0x2e, 0x84, 0x94, 0x90, 0x2a, 0x17, 0x5f, 0x72, 0x62,
+	0x7c, 0x72, 0x6a, 0x51, 0x49, 0x66, 0x5a, 0x66, 0x72, 0x62, 0x49, 0xaa, 0x04, 0xa3, 0x02, 0xa3,
+	0x06, 0x4f, 0x10, 0x6f, 0x72, 0xa2, 0x33, 0x42, 0x50, 0x48, 0x8e, 0x0b, 0xc9, 0x6d, 0x12, 0x4c,
+	0x60, 0x25, 0x48, 0x22, 0x46, 0x1e, 0x5c, 0xdc, 0x08, 0x43, 0x8b, 0x85, 0x2c, 0xb9, 0x58, 0x40,
+	0xae, 0x15, 0x12, 0x47, 0x76, 0x10, 0x92, 0x77, 0xa4, 0x24, 0x30, 0x25, 0x20, 0x1e, 0x73, 0xd2,
+	0x89, 0xd2, 0x2a, 0x2e, 0xc9, 0x2f, 0xca, 0xd2, 0xcb, 0xcc, 0xd7, 0x07, 0x33, 0xf4, 0x0b, 0xb2,
+	0xd3, 0xf5, 0x11, 0xaa, 0x91, 0x98, 0x05, 0x49, 0x49, 0x6c, 0xe0, 0x80, 0x33, 0x06, 0x0c, 0x00,
+	0x03, 0xf3, 0x29, 0xe8, 0x4d, 0x01, 0x00, 0x00,
+}
+
+// --- DRPC BEGIN ---
+
+type DRPCRevocationsClient interface {
+	DRPCConn() drpc.Conn
+
+	List(ctx context.Context, in *ListRequest) (*ListResponse, error)
+}
+
+type drpcRevocationsClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCRevocationsClient(cc drpc.Conn) DRPCRevocationsClient {
+	return &drpcRevocationsClient{cc}
+}
+
+func (c *drpcRevocationsClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcRevocationsClient) List(ctx context.Context, in *ListRequest) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/revocation.Revocations/List", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCRevocationsServer interface {
+	List(context.Context, *ListRequest) (*ListResponse, error)
+}
+
+type DRPCRevocationsDescription struct{}
+
+func (DRPCRevocationsDescription) NumMethods() int { return 1 }
+
+func (DRPCRevocationsDescription) Method(n int) (string, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/revocation.Revocations/List",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCRevocationsServer).
+					List(
+						ctx,
+						in1.(*ListRequest),
+					)
+			}, DRPCRevocationsServer.List, true
+	default:
+		return "", nil, nil, false
+	}
+}
+
+func DRPCRegisterRevocations(mux drpc.Mux, impl DRPCRevocationsServer) error {
+	return mux.Register(impl, DRPCRevocationsDescription{})
+}
+
+type DRPCRevocations_ListStream interface {
+	drpc.Stream
+	SendAndClose(*ListResponse) error
+}
+
+type drpcRevocationsListStream struct {
+	drpc.Stream
+}
+
+func (x *drpcRevocationsListStream) SendAndClose(m *ListResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+// --- DRPC END ---
@@ -0,0 +1,50 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package revocation
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+
+	"storj.io/common/peertls"
+	"storj.io/common/peertls/extensions"
+)
+
+// VerifyNotRevoked returns a peer certificate verification function, which
+// rejects peers whose leaf or CA certificate is revoked in db.
+//
+// Unlike the revocation extension handlers, which only check peers presenting
+// a revocation themselves, it also rejects peers using a revoked identity
+// without a revocation extension, e.g. when the identity was compromised.
+func VerifyNotRevoked(db extensions.RevocationDB) peertls.PeerCertVerificationFunc {
+	return func(_ [][]byte, parsedChains [][]*x509.Certificate) error {
+		if len(parsedChains) == 0 || len(parsedChains[0]) <= peertls.CAIndex {
+			return nil
+		}
+		chain := parsedChains[0]
+
+		lastRev, err := db.Get(context.TODO(), chain)
+		if err != nil {
+			return extensions.Error.Wrap(err)
+		}
+		if lastRev == nil {
+			return nil
+		}
+
+		caKeyHash, err := peertls.DoubleSHA256PublicKey(chain[peertls.CAIndex].PublicKey)
+		if err != nil {
+			return err
+		}
+		leafKeyHash, err := peertls.DoubleSHA256PublicKey(chain[peertls.LeafIndex].PublicKey)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(lastRev.KeyHash, caKeyHash[:]) || bytes.Equal(lastRev.KeyHash, leafKeyHash[:]) {
+			return extensions.ErrRevokedCert
+		}
+		return nil
+	}
+}
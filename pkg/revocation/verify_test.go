@@ -0,0 +1,49 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package revocation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/identity"
+	"storj.io/common/peertls"
+	"storj.io/common/peertls/extensions"
+	"storj.io/common/peertls/testpeertls"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/pkg/revocation"
+	"storj.io/storj/private/testrevocation"
+	"storj.io/storj/storage"
+)
+
+func TestVerifyNotRevoked(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	testrevocation.RunDBs(t, func(t *testing.T, revDB extensions.RevocationDB, _ storage.KeyValueStore) {
+		keys, chain, err := testpeertls.NewCertChain(2, storj.LatestIDVersion().Number)
+		require.NoError(t, err)
+		_, otherChain, err := testpeertls.NewCertChain(2, storj.LatestIDVersion().Number)
+		require.NoError(t, err)
+
+		verify := revocation.VerifyNotRevoked(revDB)
+
+		require.NoError(t, verify(nil, identity.ToChains(chain)))
+
+		// revoke the leaf, the peer doesn't present the revocation
+		revokedLeafChain, leafRevocation, err := testpeertls.RevokeLeaf(keys[peertls.CAIndex], chain)
+		require.NoError(t, err)
+		require.NoError(t, revDB.Put(ctx, revokedLeafChain, leafRevocation))
+
+		err = verify(nil, identity.ToChains(chain))
+		assert.Equal(t, extensions.ErrRevokedCert, err)
+
+		// the new leaf and other identities are still accepted
+		assert.NoError(t, verify(nil, identity.ToChains(revokedLeafChain)))
+		assert.NoError(t, verify(nil, identity.ToChains(otherChain)))
+	})
+}
@@ -0,0 +1,303 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// ProxyProtocolError is the error class for invalid PROXY protocol headers.
+var ProxyProtocolError = errs.Class("proxy protocol error")
+
+// proxyHeaderTimeout is how long a trusted proxy has to send the PROXY protocol header.
+const proxyHeaderTimeout = 10 * time.Second
+
+const (
+	// proxyV1Prefix starts a human readable PROXY protocol header.
+	proxyV1Prefix = "PROXY "
+	// proxyV1MaxLength is the maximum length of a version 1 header including the CRLF.
+	proxyV1MaxLength = 107
+
+	// proxyV2HeaderLength is the length of the fixed part of a version 2 header.
+	proxyV2HeaderLength = 16
+)
+
+// proxyV2Signature starts a binary PROXY protocol header.
+var proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// ParseTrustedProxies parses a comma separated list of networks in CIDR notation.
+// Single IP addresses are accepted as well.
+func ParseTrustedProxies(s string) (networks []*net.IPNet, err error) {
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		if !strings.Contains(field, "/") {
+			ip := net.ParseIP(field)
+			if ip == nil {
+				return nil, Error.New("invalid trusted proxy address %q", field)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(field)
+		if err != nil {
+			return nil, Error.New("invalid trusted proxy network %q: %v", field, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// NewProxyListener wraps lis so that connections coming from one of the trusted
+// networks must start with a PROXY protocol v1 or v2 header. The addresses from
+// the header are returned as the remote and local address of the connection.
+// Connections from other addresses are returned unchanged.
+//
+// The header is parsed on the first Read, RemoteAddr or LocalAddr call, so a
+// slow proxy doesn't block accepting other connections.
+func NewProxyListener(lis net.Listener, trusted []*net.IPNet) net.Listener {
+	if len(trusted) == 0 {
+		return lis
+	}
+	return &proxyListener{Listener: lis, trusted: trusted}
+}
+
+// proxyListener implements PROXY protocol parsing for trusted connections.
+type proxyListener struct {
+	net.Listener
+	trusted []*net.IPNet
+}
+
+// Accept waits for and returns the next connection to the listener.
+func (lis *proxyListener) Accept() (net.Conn, error) {
+	conn, err := lis.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if !lis.isTrusted(conn.RemoteAddr()) {
+		return conn, nil
+	}
+	return newProxyConn(conn), nil
+}
+
+// isTrusted returns whether addr is in one of the trusted networks.
+func (lis *proxyListener) isTrusted(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, network := range lis.trusted {
+		if network.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyConn is a connection from a trusted proxy.
+type proxyConn struct {
+	net.Conn
+	reader *bufio.Reader
+
+	once   sync.Once
+	err    error
+	remote net.Addr
+	local  net.Addr
+}
+
+func newProxyConn(conn net.Conn) *proxyConn {
+	return &proxyConn{
+		Conn:   conn,
+		reader: bufio.NewReaderSize(conn, 256),
+	}
+}
+
+// init parses the PROXY protocol header, if that has not happened yet.
+func (conn *proxyConn) init() error {
+	conn.once.Do(func() {
+		if err := conn.Conn.SetReadDeadline(time.Now().Add(proxyHeaderTimeout)); err != nil {
+			conn.err = err
+			return
+		}
+		conn.err = conn.readHeader()
+		if err := conn.Conn.SetReadDeadline(time.Time{}); err != nil && conn.err == nil {
+			conn.err = err
+		}
+	})
+	return conn.err
+}
+
+// Read reads data following the PROXY protocol header.
+func (conn *proxyConn) Read(p []byte) (int, error) {
+	if err := conn.init(); err != nil {
+		return 0, err
+	}
+	return conn.reader.Read(p)
+}
+
+// RemoteAddr returns the client address sent by the proxy.
+func (conn *proxyConn) RemoteAddr() net.Addr {
+	if conn.init() == nil && conn.remote != nil {
+		return conn.remote
+	}
+	return conn.Conn.RemoteAddr()
+}
+
+// LocalAddr returns the destination address sent by the proxy.
+func (conn *proxyConn) LocalAddr() net.Addr {
+	if conn.init() == nil && conn.local != nil {
+		return conn.local
+	}
+	return conn.Conn.LocalAddr()
+}
+
+// readHeader reads either version of the header.
+func (conn *proxyConn) readHeader() error {
+	prefix, err := conn.reader.Peek(len(proxyV1Prefix))
+	if err != nil {
+		return ProxyProtocolError.Wrap(err)
+	}
+	if string(prefix) == proxyV1Prefix {
+		return conn.readHeaderV1()
+	}
+
+	prefix, err = conn.reader.Peek(len(proxyV2Signature))
+	if err != nil {
+		return ProxyProtocolError.Wrap(err)
+	}
+	if bytes.Equal(prefix, proxyV2Signature) {
+		return conn.readHeaderV2()
+	}
+
+	return ProxyProtocolError.New("missing header from trusted proxy %s", conn.Conn.RemoteAddr())
+}
+
+// readHeaderV1 reads a header such as "PROXY TCP4 192.0.2.1 192.0.2.2 56324 7777\r\n".
+func (conn *proxyConn) readHeaderV1() error {
+	var line []byte
+	for !bytes.HasSuffix(line, []byte("\r\n")) {
+		b, err := conn.reader.ReadByte()
+		if err != nil {
+			return ProxyProtocolError.Wrap(err)
+		}
+		line = append(line, b)
+		if len(line) > proxyV1MaxLength {
+			return ProxyProtocolError.New("header too long")
+		}
+	}
+
+	fields := strings.Split(strings.TrimSuffix(string(line), "\r\n"), " ")
+	if len(fields) < 2 {
+		return ProxyProtocolError.New("invalid header %q", line)
+	}
+
+	switch fields[1] {
+	case "UNKNOWN":
+		// the proxy couldn't determine the addresses, so we keep the ones of the connection.
+		return nil
+	case "TCP4", "TCP6":
+	default:
+		return ProxyProtocolError.New("unsupported protocol %q", fields[1])
+	}
+
+	if len(fields) != 6 {
+		return ProxyProtocolError.New("invalid header %q", line)
+	}
+
+	remote, err := parseV1Addr(fields[2], fields[4])
+	if err != nil {
+		return err
+	}
+	local, err := parseV1Addr(fields[3], fields[5])
+	if err != nil {
+		return err
+	}
+
+	conn.remote, conn.local = remote, local
+	return nil
+}
+
+// parseV1Addr parses the address and port from a version 1 header.
+func parseV1Addr(host, port string) (*net.TCPAddr, error) {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, ProxyProtocolError.New("invalid address %q", host)
+	}
+	portNumber, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, ProxyProtocolError.New("invalid port %q", port)
+	}
+	return &net.TCPAddr{IP: ip, Port: int(portNumber)}, nil
+}
+
+// readHeaderV2 reads the binary version of the header.
+func (conn *proxyConn) readHeaderV2() error {
+	var header [proxyV2HeaderLength]byte
+	if _, err := io.ReadFull(conn.reader, header[:]); err != nil {
+		return ProxyProtocolError.Wrap(err)
+	}
+
+	version, command := header[12]>>4, header[12]&0xf
+	if version != 2 {
+		return ProxyProtocolError.New("unsupported version %d", version)
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint16(header[14:]))
+	if _, err := io.ReadFull(conn.reader, payload); err != nil {
+		return ProxyProtocolError.Wrap(err)
+	}
+
+	switch command {
+	case 0x0:
+		// LOCAL connections are health checks done by the proxy itself.
+		return nil
+	case 0x1:
+	default:
+		return ProxyProtocolError.New("unsupported command %d", command)
+	}
+
+	var addrLen int
+	switch family := header[13] >> 4; family {
+	case 0x1:
+		addrLen = net.IPv4len
+	case 0x2:
+		addrLen = net.IPv6len
+	default:
+		// unspecified or unix socket addresses, which we can't represent as a TCP address.
+		return nil
+	}
+
+	if len(payload) < 2*addrLen+4 {
+		return ProxyProtocolError.New("address block too short")
+	}
+
+	// the remaining payload contains optional TLVs which we ignore.
+	conn.remote = &net.TCPAddr{
+		IP:   net.IP(payload[:addrLen]),
+		Port: int(binary.BigEndian.Uint16(payload[2*addrLen:])),
+	}
+	conn.local = &net.TCPAddr{
+		IP:   net.IP(payload[addrLen : 2*addrLen]),
+		Port: int(binary.BigEndian.Uint16(payload[2*addrLen+2:])),
+	}
+	return nil
+}
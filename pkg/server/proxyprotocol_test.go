@@ -0,0 +1,111 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package server_test
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/pkg/server"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	networks, err := server.ParseTrustedProxies("")
+	require.NoError(t, err)
+	require.Empty(t, networks)
+
+	networks, err = server.ParseTrustedProxies("10.0.0.0/8, 192.0.2.1,2001:db8::/32")
+	require.NoError(t, err)
+	require.Len(t, networks, 3)
+	require.True(t, networks[0].Contains(net.ParseIP("10.1.2.3")))
+	require.True(t, networks[1].Contains(net.ParseIP("192.0.2.1")))
+	require.False(t, networks[1].Contains(net.ParseIP("192.0.2.2")))
+	require.True(t, networks[2].Contains(net.ParseIP("2001:db8::1")))
+
+	_, err = server.ParseTrustedProxies("10.0.0.0/33")
+	require.Error(t, err)
+	_, err = server.ParseTrustedProxies("not-an-address")
+	require.Error(t, err)
+}
+
+func TestProxyListener(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	v2Header := func(command byte, remote, local *net.TCPAddr) []byte {
+		header := []byte("\r\n\r\n\x00\r\nQUIT\n")
+		header = append(header, 0x20|command, 0x11, 0, 12)
+		header = append(header, remote.IP.To4()...)
+		header = append(header, local.IP.To4()...)
+		header = append(header, 0, 0, 0, 0)
+		binary.BigEndian.PutUint16(header[len(header)-4:], uint16(remote.Port))
+		binary.BigEndian.PutUint16(header[len(header)-2:], uint16(local.Port))
+		return header
+	}
+
+	remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.1").To4(), Port: 56324}
+	local := &net.TCPAddr{IP: net.ParseIP("198.51.100.1").To4(), Port: 7777}
+
+	for _, test := range []struct {
+		name    string
+		trusted string
+		header  []byte
+		remote  string
+		invalid bool
+	}{
+		{name: "v1", trusted: "127.0.0.0/8", header: []byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 7777\r\n"), remote: remote.String()},
+		{name: "v1 ipv6", trusted: "127.0.0.1", header: []byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 7777\r\n"), remote: "[2001:db8::1]:56324"},
+		{name: "v1 unknown", trusted: "127.0.0.1", header: []byte("PROXY UNKNOWN\r\n")},
+		{name: "v2", trusted: "127.0.0.0/8", header: v2Header(0x1, remote, local), remote: remote.String()},
+		{name: "v2 local", trusted: "127.0.0.0/8", header: v2Header(0x0, remote, local)},
+		{name: "untrusted", trusted: "10.0.0.0/8", header: nil},
+		{name: "missing header", trusted: "127.0.0.0/8", header: nil, invalid: true},
+		{name: "invalid v1", trusted: "127.0.0.0/8", header: []byte("PROXY TCP4 192.0.2.1\r\n"), invalid: true},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			trusted, err := server.ParseTrustedProxies(test.trusted)
+			require.NoError(t, err)
+
+			base, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			lis := server.NewProxyListener(base, trusted)
+			defer ctx.Check(lis.Close)
+
+			payload := []byte("DRPC!!!1 and some more data")
+
+			client, err := net.Dial("tcp", lis.Addr().String())
+			require.NoError(t, err)
+			defer ctx.Check(client.Close)
+
+			_, err = client.Write(append(append([]byte{}, test.header...), payload...))
+			require.NoError(t, err)
+			require.NoError(t, client.(*net.TCPConn).CloseWrite())
+
+			conn, err := lis.Accept()
+			require.NoError(t, err)
+			defer ctx.Check(conn.Close)
+
+			data, err := ioutil.ReadAll(conn)
+			if test.invalid {
+				require.Error(t, err)
+				require.True(t, server.ProxyProtocolError.Has(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, payload, data)
+
+			expectedRemote := test.remote
+			if expectedRemote == "" {
+				expectedRemote = client.LocalAddr().String()
+			}
+			require.Equal(t, expectedRemote, conn.RemoteAddr().String())
+		})
+	}
+}
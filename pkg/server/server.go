@@ -21,6 +21,7 @@ import (
 	"storj.io/drpc/drpcserver"
 	jaeger "storj.io/monkit-jaeger"
 	"storj.io/storj/pkg/listenmux"
+	"storj.io/storj/pkg/quic"
 )
 
 // Config holds server specific configuration parameters.
@@ -29,6 +30,9 @@ type Config struct {
 	Address        string `user:"true" help:"public address to listen on" default:":7777"`
 	PrivateAddress string `user:"true" help:"private address to listen on" default:"127.0.0.1:7778"`
 
+	TrustedProxies string `help:"comma separated list of networks (CIDR) of proxies, which must send a PROXY protocol header when connecting to the public address" default:""`
+	EnableQUIC     bool   `help:"also accept connections over QUIC (UDP) on the public port" default:"false"`
+
 	DebugLogTraffic bool `hidden:"true" default:"false"` // Deprecated
 }
 
@@ -36,6 +40,9 @@ type public struct {
 	listener net.Listener
 	drpc     *drpcserver.Server
 	mux      *drpcmux.Mux
+
+	udpConn      net.PacketConn
+	quicListener net.Listener
 }
 
 type private struct {
@@ -60,7 +67,12 @@ type Server struct {
 
 // New creates a Server out of an Identity, a net.Listener,
 // and interceptors.
-func New(log *zap.Logger, tlsOptions *tlsopts.Options, publicAddr, privateAddr string) (*Server, error) {
+func New(log *zap.Logger, tlsOptions *tlsopts.Options, config Config) (*Server, error) {
+	trustedProxies, err := ParseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
 	server := &Server{
 		log:        log,
 		tlsOptions: tlsOptions,
@@ -71,7 +83,7 @@ func New(log *zap.Logger, tlsOptions *tlsopts.Options, publicAddr, privateAddr s
 		Manager: rpc.NewDefaultManagerOptions(),
 	}
 
-	publicListener, err := net.Listen("tcp", publicAddr)
+	publicListener, err := net.Listen("tcp", config.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -79,14 +91,20 @@ func New(log *zap.Logger, tlsOptions *tlsopts.Options, publicAddr, privateAddr s
 	publicMux := drpcmux.New()
 	publicTracingHandler := rpctracing.NewHandler(publicMux, jaeger.RemoteTraceHandler)
 	server.public = public{
-		listener: wrapListener(publicListener),
+		listener: NewProxyListener(wrapListener(publicListener), trustedProxies),
 		drpc:     drpcserver.NewWithOptions(publicTracingHandler, serverOptions),
 		mux:      publicMux,
 	}
 
-	privateListener, err := net.Listen("tcp", privateAddr)
+	if config.EnableQUIC {
+		if err := server.listenQUIC(config.Address); err != nil {
+			return nil, errs.Combine(err, publicListener.Close())
+		}
+	}
+
+	privateListener, err := net.Listen("tcp", config.PrivateAddress)
 	if err != nil {
-		return nil, errs.Combine(err, publicListener.Close())
+		return nil, errs.Combine(err, publicListener.Close(), server.closeQUIC())
 	}
 	privateMux := drpcmux.New()
 	privateTracingHandler := rpctracing.NewHandler(privateMux, jaeger.RemoteTraceHandler)
@@ -99,12 +117,52 @@ func New(log *zap.Logger, tlsOptions *tlsopts.Options, publicAddr, privateAddr s
 	return server, nil
 }
 
+// listenQUIC starts listening for QUIC connections on the UDP port matching the
+// public TCP listener.
+func (p *Server) listenQUIC(address string) (err error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	_, port, err := net.SplitHostPort(p.public.listener.Addr().String())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	p.public.udpConn, err = net.ListenPacket("udp", net.JoinHostPort(host, port))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	p.public.quicListener, err = quic.NewListener(p.public.udpConn, p.tlsOptions.ServerTLSConfig(), nil)
+	if err != nil {
+		return errs.Combine(Error.Wrap(err), p.public.udpConn.Close())
+	}
+	return nil
+}
+
+// closeQUIC closes the QUIC listener, if there is one.
+func (p *Server) closeQUIC() error {
+	if p.public.quicListener == nil {
+		return nil
+	}
+	return errs.Combine(p.public.quicListener.Close(), p.public.udpConn.Close())
+}
+
 // Identity returns the server's identity.
 func (p *Server) Identity() *identity.FullIdentity { return p.tlsOptions.Ident }
 
 // Addr returns the server's public listener address.
 func (p *Server) Addr() net.Addr { return p.public.listener.Addr() }
 
+// QUICAddr returns the server's QUIC listener address, or nil if QUIC is disabled.
+func (p *Server) QUICAddr() net.Addr {
+	if p.public.quicListener == nil {
+		return nil
+	}
+	return p.public.quicListener.Addr()
+}
+
 // PrivateAddr returns the server's private listener address.
 func (p *Server) PrivateAddr() net.Addr { return p.private.listener.Addr() }
 
@@ -129,6 +187,7 @@ func (p *Server) Close() error {
 	// closes anyway.
 	_ = p.public.listener.Close()
 	_ = p.private.listener.Close()
+	_ = p.closeQUIC()
 	return nil
 }
 
@@ -201,6 +260,12 @@ func (p *Server) Run(ctx context.Context) (err error) {
 		defer cancel()
 		return p.private.drpc.Serve(ctx, privateDRPCListener)
 	})
+	if p.public.quicListener != nil {
+		group.Go(func() error {
+			defer cancel()
+			return p.public.drpc.Serve(ctx, p.public.quicListener)
+		})
+	}
 
 	// Now we wait for all the stuff using the listeners to exit.
 	err = group.Wait()
@@ -216,6 +216,14 @@ func (bad *BadBlobs) CheckWritability() error {
 	return bad.blobs.CheckWritability()
 }
 
+// ProbeWrite tests that data reaches the disk of the storage directory by writing, syncing and deleting a file.
+func (bad *BadBlobs) ProbeWrite() error {
+	if err := bad.err.Err(); err != nil {
+		return err
+	}
+	return bad.blobs.ProbeWrite()
+}
+
 // SpaceUsedForBlobs adds up how much is used in all namespaces.
 func (bad *BadBlobs) SpaceUsedForBlobs(ctx context.Context) (int64, error) {
 	if err := bad.err.Err(); err != nil {
@@ -159,6 +159,12 @@ func (slow *SlowBlobs) CheckWritability() error {
 	return slow.blobs.CheckWritability()
 }
 
+// ProbeWrite tests that data reaches the disk of the storage directory by writing, syncing and deleting a file.
+func (slow *SlowBlobs) ProbeWrite() error {
+	slow.sleep()
+	return slow.blobs.ProbeWrite()
+}
+
 // SpaceUsedForBlobs adds up how much is used in all namespaces.
 func (slow *SlowBlobs) SpaceUsedForBlobs(ctx context.Context) (int64, error) {
 	slow.sleep()
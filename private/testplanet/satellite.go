@@ -40,6 +40,7 @@ import (
 	"storj.io/storj/satellite/admin"
 	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/auditlogcleanup"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/console/consoleweb"
 	"storj.io/storj/satellite/contact"
@@ -61,6 +62,7 @@ import (
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/repair/checker"
 	"storj.io/storj/satellite/repair/irreparable"
+	"storj.io/storj/satellite/repair/reencode"
 	"storj.io/storj/satellite/repair/repairer"
 	"storj.io/storj/satellite/satellitedb/satellitedbtest"
 	"storj.io/storj/storage/redis/redisserver"
@@ -117,9 +119,11 @@ type Satellite struct {
 	}
 
 	Repair struct {
-		Checker   *checker.Checker
-		Repairer  *repairer.Service
-		Inspector *irreparable.Inspector
+		Checker       *checker.Checker
+		Repairer      *repairer.Service
+		Inspector     *irreparable.Inspector
+		ReencodeChore *reencode.Chore
+		Reencoder     *reencode.Service
 	}
 	Audit struct {
 		Queues   *audit.Queues
@@ -141,6 +145,10 @@ type Satellite struct {
 		Chore *dbcleanup.Chore
 	}
 
+	AuditLogCleanup struct {
+		Chore *auditlogcleanup.Chore
+	}
+
 	Accounting struct {
 		Tally            *tally.Service
 		Rollup           *rollup.Service
@@ -530,6 +538,15 @@ func (planet *Planet) newSatellite(ctx context.Context, prefix string, index int
 			MaxExcessRateOptimalThreshold: 0.05,
 			InMemoryRepair:                false,
 		},
+		Reencode: reencode.Config{
+			Enabled:       false,
+			Interval:      defaultInterval,
+			MinSegmentAge: 0,
+			QueueInterval: defaultInterval,
+			MaxConcurrent: 2,
+			Timeout:       1 * time.Minute,
+			TotalTimeout:  10 * time.Minute,
+		},
 		Audit: audit.Config{
 			MaxRetriesStatDB:   0,
 			MinBytesPerSecond:  1 * memory.KB,
@@ -538,7 +555,9 @@ func (planet *Planet) newSatellite(ctx context.Context, prefix string, index int
 			ChoreInterval:      defaultInterval,
 			QueueInterval:      defaultInterval,
 			Slots:              3,
+			PrioritizedSlots:   3,
 			WorkerConcurrency:  2,
+			NodeAuditInterval:  defaultInterval,
 		},
 		GarbageCollection: gc.Config{
 			Interval:          defaultInterval,
@@ -595,6 +614,15 @@ func (planet *Planet) newSatellite(ctx context.Context, prefix string, index int
 				NumLimits: 10,
 			},
 		},
+		APIKeyUsage: console.APIKeyUsageConfig{
+			FlushInterval: defaultInterval,
+			BatchSize:     1000,
+		},
+		AuditLogCleanup: auditlogcleanup.Config{
+			Interval:  defaultInterval,
+			Retention: 24 * time.Hour,
+			BatchSize: 1000,
+		},
 		Marketing: marketingweb.Config{
 			Address:   "127.0.0.1:0",
 			StaticDir: filepath.Join(developmentRoot, "web/marketing"),
@@ -647,7 +675,7 @@ func (planet *Planet) newSatellite(ctx context.Context, prefix string, index int
 	rollupsWriteCache := orders.NewRollupsWriteCache(log.Named("orders-write-cache"), db.Orders(), config.Orders.FlushBatchSize)
 	planet.databases = append(planet.databases, rollupsWriteCacheCloser{rollupsWriteCache})
 
-	peer, err := satellite.New(log, identity, db, pointerDB, revocationDB, liveAccounting, rollupsWriteCache, versionInfo, &config, nil)
+	peer, err := satellite.New(log, identity, db, pointerDB, nil, revocationDB, liveAccounting, rollupsWriteCache, versionInfo, &config, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -722,6 +750,8 @@ func createNewSystem(name string, log *zap.Logger, config satellite.Config, peer
 	system.Repair.Checker = peer.Repair.Checker
 	system.Repair.Repairer = repairerPeer.Repairer
 	system.Repair.Inspector = api.Repair.Inspector
+	system.Repair.ReencodeChore = peer.Repair.ReencodeChore
+	system.Repair.Reencoder = repairerPeer.Reencode.Service
 
 	system.Audit.Queues = peer.Audit.Queues
 	system.Audit.Worker = peer.Audit.Worker
@@ -735,6 +765,8 @@ func createNewSystem(name string, log *zap.Logger, config satellite.Config, peer
 
 	system.DBCleanup.Chore = peer.DBCleanup.Chore
 
+	system.AuditLogCleanup.Chore = peer.AuditLogCleanup.Chore
+
 	system.Accounting.Tally = peer.Accounting.Tally
 	system.Accounting.Rollup = peer.Accounting.Rollup
 	system.Accounting.ProjectUsage = api.Accounting.ProjectUsage
@@ -776,7 +808,7 @@ func (planet *Planet) newAPI(ctx context.Context, index int, identity *identity.
 	rollupsWriteCache := orders.NewRollupsWriteCache(log.Named("orders-write-cache"), db.Orders(), config.Orders.FlushBatchSize)
 	planet.databases = append(planet.databases, rollupsWriteCacheCloser{rollupsWriteCache})
 
-	return satellite.NewAPI(log, identity, db, pointerDB, revocationDB, liveAccounting, rollupsWriteCache, &config, versionInfo, nil)
+	return satellite.NewAPI(log, identity, db, pointerDB, nil, revocationDB, liveAccounting, rollupsWriteCache, &config, versionInfo, nil)
 }
 
 func (planet *Planet) newAdmin(ctx context.Context, index int, identity *identity.FullIdentity, db satellite.DB, config satellite.Config, versionInfo version.Info) (*satellite.Admin, error) {
@@ -799,7 +831,7 @@ func (planet *Planet) newRepairer(ctx context.Context, index int, identity *iden
 	rollupsWriteCache := orders.NewRollupsWriteCache(log.Named("orders-write-cache"), db.Orders(), config.Orders.FlushBatchSize)
 	planet.databases = append(planet.databases, rollupsWriteCacheCloser{rollupsWriteCache})
 
-	return satellite.NewRepairer(log, identity, pointerDB, revocationDB, db.RepairQueue(), db.Buckets(), db.OverlayCache(), rollupsWriteCache, db.Irreparable(), versionInfo, &config, nil)
+	return satellite.NewRepairer(log, identity, pointerDB, nil, revocationDB, db.RepairQueue(), db.ReencodeQueue(), db.Buckets(), db.OverlayCache(), rollupsWriteCache, db.Irreparable(), versionInfo, &config, nil)
 }
 
 type rollupsWriteCacheCloser struct {
@@ -819,7 +851,7 @@ func (planet *Planet) newGarbageCollection(ctx context.Context, index int, ident
 		return nil, errs.Wrap(err)
 	}
 	planet.databases = append(planet.databases, revocationDB)
-	return satellite.NewGarbageCollection(log, identity, db, pointerDB, revocationDB, versionInfo, &config, nil)
+	return satellite.NewGarbageCollection(log, identity, db, pointerDB, nil, revocationDB, versionInfo, &config, nil)
 }
 
 // atLeastOne returns 1 if value < 1, or value otherwise.
@@ -168,6 +168,13 @@ func (planet *Planet) newStorageNode(ctx context.Context, prefix string, index,
 				NotifyLowDiskCooldown:     defaultInterval,
 				VerifyDirReadableInterval: defaultInterval,
 				VerifyDirWritableInterval: defaultInterval,
+				DiskHealth: monitor.DiskHealthConfig{
+					Interval:       defaultInterval,
+					MinimumSamples: 100,
+					ReadLatency:    10 * time.Second,
+					WriteLatency:   30 * time.Second,
+					ErrorRate:      0.05,
+				},
 			},
 			Trust: trust.Config{
 				Sources:         sources,
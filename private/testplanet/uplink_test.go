@@ -215,7 +215,10 @@ func TestDownloadFromUnresponsiveNode(t *testing.T) {
 		tlsOptions, err := tlsopts.NewOptions(storageNode.Identity, tlscfg, revocationDB)
 		require.NoError(t, err)
 
-		server, err := server.New(storageNode.Log.Named("mock-server"), tlsOptions, storageNode.Addr(), storageNode.PrivateAddr())
+		server, err := server.New(storageNode.Log.Named("mock-server"), tlsOptions, server.Config{
+			Address:        storageNode.Addr(),
+			PrivateAddress: storageNode.PrivateAddr(),
+		})
 		require.NoError(t, err)
 
 		err = pb.DRPCRegisterPiecestore(server.DRPC(), &piecestoreMock{})
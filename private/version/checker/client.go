@@ -16,7 +16,9 @@ import (
 	"github.com/spacemonkeygo/monkit/v3"
 	"github.com/zeebo/errs"
 
+	"storj.io/common/storj"
 	"storj.io/private/version"
+	"storj.io/storj/private/version/manifest"
 )
 
 var (
@@ -80,6 +82,74 @@ func (client *Client) All(ctx context.Context) (ver version.AllowedVersions, err
 	return ver, Error.Wrap(err)
 }
 
+// Manifest handles the HTTP request to gather the signed release manifest.
+// The caller is responsible for verifying the signature.
+func (client *Client) Manifest(ctx context.Context) (signed manifest.Signed, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	httpClient := http.Client{
+		Timeout: client.config.RequestTimeout,
+	}
+
+	url := strings.TrimSuffix(client.config.ServerAddress, "/") + "/manifest"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return manifest.Signed{}, Error.Wrap(err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return manifest.Signed{}, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(resp.Body.Close())) }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return manifest.Signed{}, Error.Wrap(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return manifest.Signed{}, Error.New("non-success http status code: %d; body: %s\n", resp.StatusCode, body)
+	}
+
+	err = json.Unmarshal(body, &signed)
+	return signed, Error.Wrap(err)
+}
+
+// Satellite handles the HTTP request to gather the version information for
+// nodes of the satellite, whose policy may require higher minimum versions.
+func (client *Client) Satellite(ctx context.Context, satelliteID storj.NodeID) (ver version.AllowedVersions, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	httpClient := http.Client{
+		Timeout: client.config.RequestTimeout,
+	}
+
+	url := strings.TrimSuffix(client.config.ServerAddress, "/") + "/satellites/" + satelliteID.String()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return version.AllowedVersions{}, Error.Wrap(err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return version.AllowedVersions{}, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(resp.Body.Close())) }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return version.AllowedVersions{}, Error.Wrap(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return version.AllowedVersions{}, Error.New("non-success http status code: %d; body: %s\n", resp.StatusCode, body)
+	}
+
+	err = json.Unmarshal(body, &ver)
+	return ver, Error.Wrap(err)
+}
+
 // OldMinimum returns the version with the given name at the root-level of the version control response.
 // NB: This will be deprecated eventually in favor of what is currently the `processes` root-level object.
 func (client *Client) OldMinimum(ctx context.Context, serviceName string) (ver version.OldSemVer, err error) {
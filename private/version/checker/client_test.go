@@ -4,17 +4,23 @@
 package checker_test
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
 	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
 	"storj.io/private/version"
 	"storj.io/storj/private/version/checker"
+	"storj.io/storj/private/version/manifest"
 	"storj.io/storj/versioncontrol"
 )
 
@@ -86,6 +92,128 @@ func TestClient_Process(t *testing.T) {
 	}
 }
 
+func TestClient_Manifest(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	signingKey := ctx.File("signing.key")
+	require.NoError(t, manifest.SavePrivateKey(signingKey, private))
+
+	versions := newTestVersions(t)
+	releases := map[string]manifest.Process{
+		"storagenode": {
+			Version: versions.Storagenode.Suggested.Version,
+			Binaries: []manifest.Binary{{
+				OS:     "linux",
+				Arch:   "amd64",
+				URL:    "https://example.test/storagenode_linux_amd64.zip",
+				SHA256: hex.EncodeToString(make([]byte, 32)),
+				Size:   1024,
+			}},
+		},
+	}
+	data, err := json.Marshal(releases)
+	require.NoError(t, err)
+	releasesPath := ctx.File("releases.json")
+	require.NoError(t, ioutil.WriteFile(releasesPath, data, 0644))
+
+	peer, err := versioncontrol.New(zaptest.NewLogger(t), &versioncontrol.Config{
+		Address: "127.0.0.1:0",
+		Versions: versioncontrol.OldVersionConfig{
+			Satellite:   "v0.0.1",
+			Storagenode: "v0.0.1",
+			Uplink:      "v0.0.1",
+			Gateway:     "v0.0.1",
+			Identity:    "v0.0.1",
+		},
+		Binary: versions,
+		Manifest: versioncontrol.ManifestConfig{
+			SigningKey: signingKey,
+			Releases:   releasesPath,
+		},
+	})
+	require.NoError(t, err)
+	ctx.Go(func() error { return peer.Run(ctx) })
+	defer ctx.Check(peer.Close)
+
+	client := checker.New(checker.ClientConfig{ServerAddress: "http://" + peer.Addr() + "/"})
+
+	signed, err := client.Manifest(ctx)
+	require.NoError(t, err)
+
+	verified, err := manifest.Verify(public, signed)
+	require.NoError(t, err)
+	require.Equal(t, releases, verified.Processes)
+	require.WithinDuration(t, time.Now(), verified.Timestamp, time.Minute)
+
+	// the manifest is signed on every request
+	signed, err = client.Manifest(ctx)
+	require.NoError(t, err)
+	again, err := manifest.Verify(public, signed)
+	require.NoError(t, err)
+	require.False(t, again.Timestamp.Before(verified.Timestamp))
+
+	t.Run("not configured", func(t *testing.T) {
+		peer := newTestPeer(t, ctx)
+		defer ctx.Check(peer.Close)
+
+		_, err := checker.New(checker.ClientConfig{ServerAddress: "http://" + peer.Addr()}).Manifest(ctx)
+		require.Error(t, err)
+	})
+}
+
+func TestService_CheckSatellite(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	strict, other := testrand.NodeID(), testrand.NodeID()
+
+	testVersions := newTestVersions(t)
+	testVersions.Storagenode.Suggested.Version = "v3.0.0"
+
+	policies := ctx.File("policies.json")
+	data, err := json.Marshal(map[string]versioncontrol.SatellitePolicy{
+		strict.String(): {Minimum: map[string]string{"storagenode": "v2.5.0"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(policies, data, 0644))
+
+	peer, err := versioncontrol.New(zaptest.NewLogger(t), &versioncontrol.Config{
+		Address: "127.0.0.1:0",
+		Versions: versioncontrol.OldVersionConfig{
+			Satellite:   "v0.0.1",
+			Storagenode: "v0.0.1",
+			Uplink:      "v0.0.1",
+			Gateway:     "v0.0.1",
+			Identity:    "v0.0.1",
+		},
+		Binary:            testVersions,
+		SatellitePolicies: policies,
+	})
+	require.NoError(t, err)
+	ctx.Go(func() error { return peer.Run(ctx) })
+	defer ctx.Check(peer.Close)
+
+	running, err := version.NewSemVer("v2.4.0")
+	require.NoError(t, err)
+
+	service := checker.NewService(zaptest.NewLogger(t), checker.Config{
+		ClientConfig: checker.ClientConfig{ServerAddress: "http://" + peer.Addr()},
+	}, version.Info{Version: running, Release: true}, "Storagenode")
+
+	minimum, allowed, err := service.CheckSatellite(ctx, strict)
+	require.NoError(t, err)
+	require.False(t, allowed)
+	require.Equal(t, "v2.5.0", minimum.String())
+
+	minimum, allowed, err = service.CheckSatellite(ctx, other)
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.Equal(t, "v2.3.4", minimum.String())
+}
+
 func newTestPeer(t *testing.T, ctx *testcontext.Context) *versioncontrol.Peer {
 	t.Helper()
 
@@ -6,11 +6,13 @@ package checker
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
+	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/private/version"
 )
@@ -147,6 +149,41 @@ func (service *Service) checkVersion(ctx context.Context) (_ version.SemVer, all
 	return suggestedVersion, false
 }
 
+// CheckSatellite checks whether the running version is accepted by the
+// satellite, whose policy may require a higher minimum version than the one of
+// all satellites. It returns the minimum version of the satellite.
+func (service *Service) CheckSatellite(ctx context.Context, satelliteID storj.NodeID) (minimum version.SemVer, allowed bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	allowedVersions, err := service.client.Satellite(ctx, satelliteID)
+	if err != nil {
+		return version.SemVer{}, false, err
+	}
+
+	processesValue := reflect.ValueOf(allowedVersions.Processes)
+	field := processesValue.FieldByName(kebabToPascal(service.service))
+	if field == (reflect.Value{}) {
+		return version.SemVer{}, false, Error.New("invalid process name: %s", service.service)
+	}
+	process, ok := field.Interface().(version.Process)
+	if !ok {
+		return version.SemVer{}, false, Error.New("invalid process name: %s", service.service)
+	}
+
+	if process.Minimum.Version == "" {
+		return version.SemVer{}, true, nil
+	}
+	minimum, err = process.Minimum.SemVer()
+	if err != nil {
+		return version.SemVer{}, false, Error.Wrap(err)
+	}
+
+	if !service.Info.Release {
+		return minimum, true, nil
+	}
+	return minimum, service.Info.Version.Compare(minimum) >= 0, nil
+}
+
 // GetCursor returns storagenode rollout cursor value.
 func (service *Service) GetCursor(ctx context.Context) (_ version.RolloutBytes, err error) {
 	allowedVersions, err := service.client.All(ctx)
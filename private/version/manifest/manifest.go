@@ -0,0 +1,184 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package manifest implements the signed release manifest, which
+// authenticates the binaries announced by the version control server.
+package manifest
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the error class for release manifest errors.
+var Error = errs.Class("release manifest error")
+
+// Manifest describes the released binaries of every process.
+type Manifest struct {
+	Timestamp time.Time          `json:"timestamp"`
+	Processes map[string]Process `json:"processes"`
+}
+
+// Process describes the released binaries of a single process version.
+type Process struct {
+	Version  string   `json:"version"`
+	Binaries []Binary `json:"binaries"`
+}
+
+// Binary describes the downloadable archive of a process for an OS and architecture.
+type Binary struct {
+	OS     string `json:"os"`
+	Arch   string `json:"arch"`
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Signed is a JSON encoded manifest and its signature.
+//
+// The manifest is kept encoded, so that the signature is verified
+// over the exact bytes that were signed.
+type Signed struct {
+	Manifest  []byte `json:"manifest"`
+	Signature []byte `json:"signature"`
+}
+
+// Sign encodes and signs the manifest with the private key.
+func Sign(privateKey ed25519.PrivateKey, manifest Manifest) (Signed, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return Signed{}, Error.New("invalid private key length: %d", len(privateKey))
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return Signed{}, Error.Wrap(err)
+	}
+
+	return Signed{
+		Manifest:  data,
+		Signature: ed25519.Sign(privateKey, data),
+	}, nil
+}
+
+// Verify verifies the signature of the signed manifest with the public key
+// and returns the decoded manifest.
+func Verify(publicKey ed25519.PublicKey, signed Signed) (Manifest, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return Manifest{}, Error.New("invalid public key length: %d", len(publicKey))
+	}
+
+	if !ed25519.Verify(publicKey, signed.Manifest, signed.Signature) {
+		return Manifest{}, Error.New("invalid signature")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(signed.Manifest, &manifest); err != nil {
+		return Manifest{}, Error.Wrap(err)
+	}
+	return manifest, nil
+}
+
+// Binary returns the binary of the process version for the OS and architecture.
+func (manifest Manifest) Binary(process, version, os, arch string) (Binary, error) {
+	release, ok := manifest.Processes[process]
+	if !ok {
+		return Binary{}, Error.New("no release of %s", process)
+	}
+	if release.Version != version {
+		return Binary{}, Error.New("released version of %s is %s, not %s", process, release.Version, version)
+	}
+
+	for _, binary := range release.Binaries {
+		if binary.OS == os && binary.Arch == arch {
+			return binary, nil
+		}
+	}
+	return Binary{}, Error.New("no release of %s %s for %s/%s", process, version, os, arch)
+}
+
+// Validate checks that the binary description is complete.
+func (binary Binary) Validate() error {
+	if binary.OS == "" || binary.Arch == "" {
+		return Error.New("missing os or arch")
+	}
+	if binary.URL == "" {
+		return Error.New("missing url for %s/%s", binary.OS, binary.Arch)
+	}
+	if binary.Size <= 0 {
+		return Error.New("invalid size for %s/%s: %d", binary.OS, binary.Arch, binary.Size)
+	}
+	if _, err := binary.Hash(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Hash returns the decoded SHA-256 hash of the binary.
+func (binary Binary) Hash() ([]byte, error) {
+	hash, err := hex.DecodeString(binary.SHA256)
+	if err != nil {
+		return nil, Error.New("invalid sha256 for %s/%s: %v", binary.OS, binary.Arch, err)
+	}
+	if len(hash) != sha256.Size {
+		return nil, Error.New("invalid sha256 length for %s/%s: %d", binary.OS, binary.Arch, len(hash))
+	}
+	return hash, nil
+}
+
+// ParsePublicKey parses a hex-encoded ed25519 public key.
+func ParsePublicKey(value string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, Error.New("invalid public key length: %d", len(key))
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// LoadPrivateKey loads an ed25519 private key from a file containing its hex-encoded seed.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	seed, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, Error.New("invalid private key seed length: %d", len(seed))
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// SavePrivateKey saves the hex-encoded seed of the ed25519 private key to a new file.
+func SavePrivateKey(path string, privateKey ed25519.PrivateKey) error {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return Error.New("invalid private key length: %d", len(privateKey))
+	}
+	data := []byte(hex.EncodeToString(privateKey.Seed()) + "\n")
+	return Error.Wrap(writeNewFile(path, data))
+}
+
+// writeNewFile writes data to a new file, which is readable only by the owner.
+func writeNewFile(path string, data []byte) (err error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	_, err = file.Write(data)
+	return err
+}
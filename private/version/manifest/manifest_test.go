@@ -0,0 +1,119 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package manifest_test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/version/manifest"
+)
+
+func TestSignVerify(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	binary := manifest.Binary{
+		OS:     "linux",
+		Arch:   "amd64",
+		URL:    "https://example.test/storagenode_linux_amd64.zip",
+		SHA256: hex.EncodeToString(make([]byte, 32)),
+		Size:   1024,
+	}
+	releases := manifest.Manifest{
+		Timestamp: time.Now().UTC().Truncate(time.Second),
+		Processes: map[string]manifest.Process{
+			"storagenode": {Version: "v1.2.3", Binaries: []manifest.Binary{binary}},
+		},
+	}
+
+	signed, err := manifest.Sign(private, releases)
+	require.NoError(t, err)
+
+	verified, err := manifest.Verify(public, signed)
+	require.NoError(t, err)
+	require.Equal(t, releases, verified)
+
+	found, err := verified.Binary("storagenode", "v1.2.3", "linux", "amd64")
+	require.NoError(t, err)
+	require.Equal(t, binary, found)
+
+	_, err = verified.Binary("storagenode", "v1.2.4", "linux", "amd64")
+	require.Error(t, err)
+	_, err = verified.Binary("storagenode", "v1.2.3", "windows", "amd64")
+	require.Error(t, err)
+	_, err = verified.Binary("storagenode-updater", "v1.2.3", "linux", "amd64")
+	require.Error(t, err)
+
+	t.Run("other key", func(t *testing.T) {
+		otherPublic, _, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+
+		_, err = manifest.Verify(otherPublic, signed)
+		require.Error(t, err)
+	})
+
+	t.Run("tampered manifest", func(t *testing.T) {
+		tampered := manifest.Signed{
+			Manifest:  append([]byte{}, signed.Manifest...),
+			Signature: signed.Signature,
+		}
+		tampered.Manifest[len(tampered.Manifest)/2] ^= 1
+
+		_, err = manifest.Verify(public, tampered)
+		require.Error(t, err)
+	})
+}
+
+func TestPrivateKeyFile(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	public, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	path := ctx.File("signing.key")
+	require.NoError(t, manifest.SavePrivateKey(path, private))
+	// existing keys are never overwritten
+	require.Error(t, manifest.SavePrivateKey(path, private))
+
+	loaded, err := manifest.LoadPrivateKey(path)
+	require.NoError(t, err)
+	require.Equal(t, private, loaded)
+
+	parsed, err := manifest.ParsePublicKey(hex.EncodeToString(public))
+	require.NoError(t, err)
+	require.Equal(t, public, parsed)
+
+	_, err = manifest.ParsePublicKey("abcd")
+	require.Error(t, err)
+}
+
+func TestBinaryValidate(t *testing.T) {
+	valid := manifest.Binary{
+		OS:     "linux",
+		Arch:   "amd64",
+		URL:    "https://example.test/storagenode_linux_amd64.zip",
+		SHA256: hex.EncodeToString(make([]byte, 32)),
+		Size:   1,
+	}
+	require.NoError(t, valid.Validate())
+
+	invalid := valid
+	invalid.SHA256 = "abcd"
+	require.Error(t, invalid.Validate())
+
+	invalid = valid
+	invalid.Size = 0
+	require.Error(t, invalid.Validate())
+
+	invalid = valid
+	invalid.URL = ""
+	require.Error(t, invalid.Validate())
+}
@@ -24,6 +24,7 @@ type IPRateLimiterConfig struct {
 // IPRateLimiter imposes a rate limit per HTTP user IP.
 type IPRateLimiter struct {
 	config   IPRateLimiterConfig
+	clientIP func(r *http.Request) (string, error)
 	mu       sync.Mutex
 	ipLimits map[string]*userLimit
 }
@@ -38,6 +39,19 @@ type userLimit struct {
 func NewIPRateLimiter(config IPRateLimiterConfig) *IPRateLimiter {
 	return &IPRateLimiter{
 		config:   config,
+		clientIP: getRequestIP,
+		ipLimits: make(map[string]*userLimit),
+	}
+}
+
+// NewProxiedIPRateLimiter constructs an IPRateLimiter, which only uses the
+// X-Real-IP and X-Forwarded-For headers of requests from the trusted proxies.
+func NewProxiedIPRateLimiter(config IPRateLimiterConfig, trustedProxies []*net.IPNet) *IPRateLimiter {
+	return &IPRateLimiter{
+		config: config,
+		clientIP: func(r *http.Request) (string, error) {
+			return getProxiedRequestIP(r, trustedProxies)
+		},
 		ipLimits: make(map[string]*userLimit),
 	}
 }
@@ -70,7 +84,7 @@ func (rl *IPRateLimiter) cleanupLimiters() {
 // Limit applies a per IP rate limiting as an HTTP Handler.
 func (rl *IPRateLimiter) Limit(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ip, err := getRequestIP(r)
+		ip, err := rl.clientIP(r)
 		if err != nil {
 			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 			return
@@ -104,6 +118,53 @@ func getRequestIP(r *http.Request) (ip string, err error) {
 	return ip, err
 }
 
+// getProxiedRequestIP gets the original IP address of the request. The
+// X-Real-IP and X-Forwarded-For headers can be set by anyone, so they are only
+// used when the request comes from one of the trusted proxies.
+func getProxiedRequestIP(r *http.Request, trustedProxies []*net.IPNet) (ip string, err error) {
+	ip, _, err = net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "", err
+	}
+	if !isTrustedProxy(ip, trustedProxies) {
+		return ip, nil
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+		return realIP, nil
+	}
+
+	// every proxy appends the address it received the request from, so the
+	// client is the last address that wasn't added by one of our proxies.
+	forwardedIPs := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(forwardedIPs) - 1; i >= 0; i-- {
+		forwardedIP := strings.TrimSpace(forwardedIPs[i])
+		if forwardedIP == "" {
+			break
+		}
+		ip = forwardedIP
+		if !isTrustedProxy(forwardedIP, trustedProxies) {
+			break
+		}
+	}
+
+	return ip, nil
+}
+
+// isTrustedProxy returns whether ip belongs to one of the trusted proxies.
+func isTrustedProxy(ip string, trustedProxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
 // getUserLimit returns a rate limiter for an IP.
 func (rl *IPRateLimiter) getUserLimit(ip string) *rate.Limiter {
 	rl.mu.Lock()
@@ -5,9 +5,11 @@ package web_test
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
@@ -68,3 +70,47 @@ func testWithAddress(t *testing.T, remoteAddress string, burst int, handler http
 	handler.ServeHTTP(rr, req)
 	assert.Equal(t, rr.Code, http.StatusTooManyRequests, remoteAddress)
 }
+
+func TestProxiedIPRateLimiter(t *testing.T) {
+	_, trustedProxies, err := net.ParseCIDR("10.0.0.0/24")
+	require.NoError(t, err)
+
+	rateLimiter := web.NewProxiedIPRateLimiter(web.IPRateLimiterConfig{
+		Duration:  time.Hour,
+		Burst:     1,
+		NumLimits: 10,
+	}, []*net.IPNet{trustedProxies})
+
+	handler := rateLimiter.Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(remoteAddress string, header http.Header) int {
+		req, err := http.NewRequest("GET", "", nil)
+		require.NoError(t, err)
+		req.RemoteAddr = remoteAddress
+		req.Header = header
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	// headers of untrusted clients are ignored
+	require.Equal(t, http.StatusOK, serve("192.168.1.1:5000", http.Header{"X-Real-Ip": {"1.1.1.1"}}))
+	require.Equal(t, http.StatusTooManyRequests, serve("192.168.1.1:5000", http.Header{"X-Real-Ip": {"2.2.2.2"}}))
+	require.Equal(t, http.StatusTooManyRequests, serve("192.168.1.1:5000", http.Header{"X-Forwarded-For": {"3.3.3.3"}}))
+
+	// the trusted proxy sets the client IP
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:5000", http.Header{"X-Real-Ip": {"1.1.1.1"}}))
+	require.Equal(t, http.StatusTooManyRequests, serve("10.0.0.1:5000", http.Header{"X-Real-Ip": {"1.1.1.1"}}))
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:5000", http.Header{"X-Real-Ip": {"2.2.2.2"}}))
+
+	// addresses the client added in front of the one the proxy appended are ignored
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:5000", http.Header{"X-Forwarded-For": {"1.1.1.1, 4.4.4.4"}}))
+	require.Equal(t, http.StatusTooManyRequests, serve("10.0.0.1:5000", http.Header{"X-Forwarded-For": {"5.5.5.5, 4.4.4.4"}}))
+
+	// addresses appended by other trusted proxies are skipped
+	require.Equal(t, http.StatusOK, serve("10.0.0.1:5000", http.Header{"X-Forwarded-For": {"6.6.6.6, 10.0.0.2"}}))
+	require.Equal(t, http.StatusTooManyRequests, serve("10.0.0.2:5000", http.Header{"X-Forwarded-For": {"6.6.6.6"}}))
+}
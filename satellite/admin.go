@@ -17,9 +17,11 @@ import (
 	"storj.io/common/storj"
 	"storj.io/private/debug"
 	"storj.io/private/version"
+	"storj.io/storj/pkg/server"
 	"storj.io/storj/private/lifecycle"
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/satellite/admin"
+	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 )
@@ -131,6 +133,7 @@ func NewAdmin(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.DB.StripeCoinPayments(),
 			peer.DB.Console().Projects(),
 			peer.DB.ProjectAccounting(),
+			metainfo.NewBucketPriceMultipliers(peer.DB.Buckets(), config.Metainfo.RedundancyProfiles),
 			pc.StorageTBPrice,
 			pc.EgressTBPrice,
 			pc.ObjectPrice,
@@ -154,9 +157,15 @@ func NewAdmin(log *zap.Logger, full *identity.FullIdentity, db DB,
 		if err != nil {
 			return nil, err
 		}
+		trustedProxies, err := server.ParseTrustedProxies(config.Admin.TrustedProxies)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Admin.Listener.Close())
+		}
+		peer.Admin.Listener = server.NewProxyListener(peer.Admin.Listener, trustedProxies)
 
 		adminConfig := config.Admin
 		adminConfig.AuthorizationToken = config.Console.AuthToken
+		adminConfig.RedundancyProfiles = config.Metainfo.RedundancyProfiles
 
 		peer.Admin.Server = admin.NewServer(log.Named("admin"), peer.Admin.Listener, peer.DB, peer.Payments.Accounts, adminConfig)
 		peer.Servers.Add(lifecycle.Item{
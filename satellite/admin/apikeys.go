@@ -7,6 +7,9 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
@@ -181,3 +184,177 @@ func (server *Server) deleteAPIKeyByName(w http.ResponseWriter, r *http.Request)
 		return
 	}
 }
+
+func (server *Server) listAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	projectUUIDString, ok := vars["project"]
+	if !ok {
+		httpJSONError(w, "project-uuid missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	projectUUID, err := uuid.FromString(projectUUIDString)
+	if err != nil {
+		httpJSONError(w, "invalid project-uuid",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	keys := []console.APIKeyInfo{}
+	cursor := console.APIKeyCursor{
+		Limit:          50,
+		Page:           1,
+		Order:          console.CreationDate,
+		OrderDirection: console.Ascending,
+	}
+	for {
+		page, err := server.db.Console().APIKeys().GetPagedByProjectID(ctx, projectUUID, cursor)
+		if err != nil {
+			httpJSONError(w, "unable to list api-keys",
+				err.Error(), http.StatusInternalServerError)
+			return
+		}
+		keys = append(keys, page.APIKeys...)
+		if cursor.Page >= page.PageCount {
+			break
+		}
+		cursor.Page++
+	}
+
+	data, err := json.Marshal(keys)
+	if err != nil {
+		httpJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data) // nothing to do with the error response, probably the client requesting disappeared
+}
+
+// parseUnusedQuery parses the number of days api keys have to be unused and
+// the maximum number of keys to return.
+func parseUnusedQuery(r *http.Request, now time.Time) (before time.Time, limit int, err error) {
+	query := r.URL.Query()
+
+	days, err := strconv.Atoi(query.Get("days"))
+	if err != nil || days <= 0 {
+		return time.Time{}, 0, Error.New("days must be a positive number")
+	}
+
+	limit = 1000
+	if query.Get("limit") != "" {
+		limit, err = strconv.Atoi(query.Get("limit"))
+		if err != nil || limit <= 0 {
+			return time.Time{}, 0, Error.New("limit must be a positive number")
+		}
+	}
+
+	return now.AddDate(0, 0, -days), limit, nil
+}
+
+func (server *Server) listUnusedAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	before, limit, err := parseUnusedQuery(r, server.nowFn())
+	if err != nil {
+		httpJSONError(w, "invalid query",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	keys, err := server.db.Console().APIKeys().GetUnused(ctx, before, limit)
+	if console.ErrUsageNotTracked.Has(err) {
+		httpJSONError(w, "api-key usage isn't known for the given days",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		httpJSONError(w, "unable to list unused api-keys",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if keys == nil {
+		keys = []console.APIKeyInfo{}
+	}
+
+	data, err := json.Marshal(keys)
+	if err != nil {
+		httpJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data) // nothing to do with the error response, probably the client requesting disappeared
+}
+
+func (server *Server) deleteUnusedAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	before, limit, err := parseUnusedQuery(r, server.nowFn())
+	if err != nil {
+		httpJSONError(w, "invalid query",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	keys, err := server.db.Console().APIKeys().GetUnused(ctx, before, limit)
+	if console.ErrUsageNotTracked.Has(err) {
+		httpJSONError(w, "api-key usage isn't known for the given days",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		httpJSONError(w, "unable to list unused api-keys",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var output struct {
+		DryRun  bool        `json:"dryRun,omitempty"`
+		Unused  []uuid.UUID `json:"unused,omitempty"`
+		Deleted []uuid.UUID `json:"deleted"`
+	}
+	output.Deleted = []uuid.UUID{}
+
+	// keys are only deleted, after the operator saw which keys are deleted.
+	if r.URL.Query().Get("confirm") != "true" {
+		output.DryRun = true
+		output.Unused = []uuid.UUID{}
+		for _, key := range keys {
+			output.Unused = append(output.Unused, key.ID)
+		}
+	} else {
+		deleted := make([]string, 0, len(keys))
+		defer func() {
+			if entry := auditEntry(ctx); entry != nil {
+				entry.TargetID = strings.Join(deleted, ",")
+			}
+		}()
+
+		for _, key := range keys {
+			err = server.db.Console().APIKeys().Delete(ctx, key.ID)
+			if err != nil {
+				httpJSONError(w, "unable to delete apikey",
+					err.Error(), http.StatusInternalServerError)
+				return
+			}
+			output.Deleted = append(output.Deleted, key.ID)
+			deleted = append(deleted, key.ID.String())
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		httpJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data) // nothing to do with the error response, probably the client requesting disappeared
+}
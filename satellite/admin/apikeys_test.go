@@ -10,12 +10,14 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"storj.io/common/macaroon"
 	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
 	"storj.io/storj/private/testplanet"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/console"
@@ -144,3 +146,103 @@ func TestDeleteApiKeyByName(t *testing.T) {
 		require.Len(t, keys.APIKeys, 0)
 	})
 }
+
+func TestUnusedApiKeys(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		address := planet.Satellites[0].Admin.Admin.Listener.Addr()
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		do := func(method, path string) (int, []byte) {
+			req, err := http.NewRequest(method, "http://"+address.String()+path, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", planet.Satellites[0].Config.Console.AuthToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			responseBody, err := ioutil.ReadAll(response.Body)
+			require.NoError(t, err)
+			require.NoError(t, response.Body.Close())
+			return response.StatusCode, responseBody
+		}
+
+		// the uplink's key is listed with its usage
+		status, body := do(http.MethodGet, fmt.Sprintf("/api/project/%s/apikey", projectID.String()))
+		require.Equal(t, http.StatusOK, status)
+		var keys []console.APIKeyInfo
+		require.NoError(t, json.Unmarshal(body, &keys))
+		require.Len(t, keys, 1)
+
+		status, _ = do(http.MethodGet, "/api/apikey/unused")
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = do(http.MethodDelete, "/api/apikey/unused?days=0")
+		require.Equal(t, http.StatusBadRequest, status)
+
+		// the usage wasn't tracked for a day yet
+		start, err := planet.Satellites[0].DB.Console().APIKeys().StartUsageTracking(ctx, time.Now())
+		require.NoError(t, err)
+
+		status, _ = do(http.MethodGet, "/api/apikey/unused?days=1")
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = do(http.MethodDelete, "/api/apikey/unused?days=1&confirm=true")
+		require.Equal(t, http.StatusBadRequest, status)
+
+		// the key wasn't used for a day
+		planet.Satellites[0].Admin.Admin.Server.SetNow(func() time.Time {
+			return start.Add(49 * time.Hour)
+		})
+		status, body = do(http.MethodGet, "/api/apikey/unused?days=1")
+		require.Equal(t, http.StatusOK, status)
+		keys = nil
+		require.NoError(t, json.Unmarshal(body, &keys))
+		require.Len(t, keys, 1)
+		keyID := keys[0].ID
+
+		type deleteOutput struct {
+			DryRun  bool        `json:"dryRun"`
+			Unused  []uuid.UUID `json:"unused"`
+			Deleted []uuid.UUID `json:"deleted"`
+		}
+
+		// keys are only deleted with confirmation
+		status, body = do(http.MethodDelete, "/api/apikey/unused?days=1")
+		require.Equal(t, http.StatusOK, status)
+		var output deleteOutput
+		require.NoError(t, json.Unmarshal(body, &output))
+		require.True(t, output.DryRun)
+		require.Equal(t, []uuid.UUID{keyID}, output.Unused)
+		require.Empty(t, output.Deleted)
+
+		page, err := planet.Satellites[0].DB.Console().APIKeys().GetPagedByProjectID(ctx, projectID, console.APIKeyCursor{Page: 1, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.APIKeys, 1)
+
+		status, body = do(http.MethodDelete, "/api/apikey/unused?days=1&confirm=true")
+		require.Equal(t, http.StatusOK, status)
+		output = deleteOutput{}
+		require.NoError(t, json.Unmarshal(body, &output))
+		require.False(t, output.DryRun)
+		require.Equal(t, []uuid.UUID{keyID}, output.Deleted)
+
+		page, err = planet.Satellites[0].DB.Console().APIKeys().GetPagedByProjectID(ctx, projectID, console.APIKeyCursor{Page: 1, Limit: 10})
+		require.NoError(t, err)
+		require.Empty(t, page.APIKeys)
+
+		// the audit log keeps the deleted keys
+		entries, err := planet.Satellites[0].DB.Console().AuditLogs().List(ctx, console.AuditLogFilter{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, "DELETE /api/apikey/unused", entries[0].Operation)
+		require.Equal(t, keyID.String(), entries[0].TargetID)
+	})
+}
@@ -0,0 +1,232 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+// maxAuditErrorSize is the maximum size of an error response, which is kept in the audit log.
+const maxAuditErrorSize = 1024
+
+// auditResponseWriter keeps the status and the error of a response.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *auditResponseWriter) Write(data []byte) (int, error) {
+	if w.status >= http.StatusBadRequest && w.body.Len() < maxAuditErrorSize {
+		_, _ = w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// auditError returns the error message of a failed response.
+func (w *auditResponseWriter) auditError() string {
+	var response struct {
+		Error  string `json:"error"`
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(w.body.Bytes(), &response); err != nil || response.Error == "" {
+		return http.StatusText(w.status)
+	}
+	if response.Detail != "" {
+		return response.Error + ": " + response.Detail
+	}
+	return response.Error
+}
+
+// auditLog persists the outcome of every mutation made with the admin API in the audit log.
+func (server *Server) auditLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+
+		entry := console.AuditLogEntry{
+			Actor:        console.AuditActorAdmin,
+			SourceIP:     r.RemoteAddr,
+			ForwardedFor: r.Header.Get("X-Forwarded-For"),
+		}
+		// the target is resolved before the mutation, because deleted objects can't be found anymore.
+		server.auditTarget(ctx, r, &entry)
+
+		recorder := &auditResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(ctx, auditEntryKey{}, &entry)))
+
+		entry.Outcome = console.AuditSuccess
+		if recorder.status >= http.StatusBadRequest {
+			entry.Outcome = console.AuditFailure
+			entry.Error = recorder.auditError()
+		}
+		entry.CreatedAt = server.nowFn()
+
+		// the mutation already happened, so failing to persist the entry must not fail it.
+		if err := server.db.Console().AuditLogs().Insert(ctx, entry); err != nil {
+			server.log.Error("failed to persist audit log entry", zap.String("operation", entry.Operation), zap.Error(err))
+		}
+	})
+}
+
+// auditEntryKey is the context key of the audit log entry of a request.
+type auditEntryKey struct{}
+
+// auditEntry returns the audit log entry of the request, so handlers can fill
+// in targets, which aren't known before the mutation, like the keys deleted in bulk.
+func auditEntry(ctx context.Context) *console.AuditLogEntry {
+	entry, _ := ctx.Value(auditEntryKey{}).(*console.AuditLogEntry)
+	return entry
+}
+
+// auditTarget fills the target of the request into the audit log entry.
+func (server *Server) auditTarget(ctx context.Context, r *http.Request, entry *console.AuditLogEntry) {
+	template := r.URL.Path
+	if route := mux.CurrentRoute(r); route != nil {
+		if pathTemplate, err := route.GetPathTemplate(); err == nil {
+			template = pathTemplate
+		}
+	}
+	// the template doesn't contain secrets, like the serialized api key.
+	entry.Operation = r.Method + " " + template
+
+	vars := mux.Vars(r)
+
+	if projectID, err := uuid.FromString(vars["project"]); err == nil {
+		entry.ProjectID = &projectID
+	}
+
+	switch {
+	case strings.Contains(template, "/apikey"):
+		entry.TargetType = console.AuditTargetAPIKey
+		entry.TargetID = vars["name"]
+		if serialized, ok := vars["apikey"]; ok {
+			key, err := macaroon.ParseAPIKey(serialized)
+			if err != nil {
+				return
+			}
+			info, err := server.db.Console().APIKeys().GetByHead(ctx, key.Head())
+			if err != nil {
+				return
+			}
+			entry.TargetID = info.ID.String()
+			entry.ProjectID = &info.ProjectID
+		}
+	case strings.HasPrefix(template, "/api/project"):
+		entry.TargetType = console.AuditTargetProject
+		entry.TargetID = vars["project"]
+	case strings.HasPrefix(template, "/api/user"):
+		entry.TargetType = console.AuditTargetUser
+		entry.TargetID = vars["useremail"]
+		if entry.TargetID == "" {
+			return
+		}
+		user, err := server.db.Console().Users().GetByEmail(ctx, entry.TargetID)
+		if err != nil {
+			return
+		}
+		entry.UserID = &user.ID
+	case strings.HasPrefix(template, "/api/coupon"):
+		entry.TargetType = console.AuditTargetCoupon
+		entry.TargetID = vars["couponid"]
+	}
+}
+
+func (server *Server) listAuditLog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	filter := console.AuditLogFilter{Limit: 1000}
+
+	if user := query.Get("user"); user != "" {
+		userID, err := uuid.FromString(user)
+		if err != nil {
+			// users can also be identified by their email, while their account exists.
+			found, err := server.db.Console().Users().GetByEmail(ctx, user)
+			if err != nil {
+				httpJSONError(w, "user not found",
+					err.Error(), http.StatusNotFound)
+				return
+			}
+			userID = found.ID
+		}
+		filter.UserID = &userID
+	}
+
+	if project := query.Get("project"); project != "" {
+		projectID, err := uuid.FromString(project)
+		if err != nil {
+			httpJSONError(w, "invalid project-uuid",
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.ProjectID = &projectID
+	}
+
+	for name, value := range map[string]*time.Time{"since": &filter.Since, "before": &filter.Before} {
+		if query.Get(name) == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, query.Get(name))
+		if err != nil {
+			httpJSONError(w, fmt.Sprintf("invalid %s", name),
+				err.Error(), http.StatusBadRequest)
+			return
+		}
+		*value = parsed
+	}
+
+	if query.Get("limit") != "" {
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit <= 0 {
+			httpJSONError(w, "invalid limit",
+				"limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	entries, err := server.db.Console().AuditLogs().List(ctx, filter)
+	if err != nil {
+		httpJSONError(w, "unable to list audit log",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if entries == nil {
+		entries = []console.AuditLogEntry{}
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		httpJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data) // nothing to do with the error response, probably the client requesting disappeared
+}
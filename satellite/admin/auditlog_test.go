@@ -0,0 +1,99 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+)
+
+func TestAuditLog(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		address := sat.Admin.Admin.Listener.Addr()
+		projectID := planet.Uplinks[0].Projects[0].ID
+		apiKey := planet.Uplinks[0].APIKey[sat.ID()]
+
+		do := func(method, path string) (int, []byte) {
+			req, err := http.NewRequest(method, "http://"+address.String()+path, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", sat.Config.Console.AuthToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			responseBody, err := ioutil.ReadAll(response.Body)
+			require.NoError(t, err)
+			require.NoError(t, response.Body.Close())
+			return response.StatusCode, responseBody
+		}
+
+		since := time.Now()
+
+		// the deletion with an existing API key fails
+		status, _ := do(http.MethodDelete, fmt.Sprintf("/api/project/%s", projectID))
+		require.Equal(t, http.StatusConflict, status)
+
+		keyInfo, err := sat.DB.Console().APIKeys().GetByHead(ctx, apiKey.Head())
+		require.NoError(t, err)
+
+		status, _ = do(http.MethodDelete, "/api/apikey/"+apiKey.Serialize())
+		require.Equal(t, http.StatusOK, status)
+
+		status, body := do(http.MethodGet, fmt.Sprintf("/api/auditlog?project=%s&since=%s", projectID, url.QueryEscape(since.Format(time.RFC3339Nano))))
+		require.Equal(t, http.StatusOK, status)
+
+		var entries []console.AuditLogEntry
+		require.NoError(t, json.Unmarshal(body, &entries))
+		require.Len(t, entries, 2)
+
+		// the serialized api key isn't persisted
+		require.Equal(t, "DELETE /api/apikey/{apikey}", entries[0].Operation)
+		require.Equal(t, console.AuditActorAdmin, entries[0].Actor)
+		require.Equal(t, console.AuditTargetAPIKey, entries[0].TargetType)
+		require.Equal(t, keyInfo.ID.String(), entries[0].TargetID)
+		require.Equal(t, console.AuditSuccess, entries[0].Outcome)
+
+		require.Equal(t, "DELETE /api/project/{project}", entries[1].Operation)
+		require.Equal(t, console.AuditTargetProject, entries[1].TargetType)
+		require.Equal(t, projectID.String(), entries[1].TargetID)
+		require.Equal(t, console.AuditFailure, entries[1].Outcome)
+		require.NotEmpty(t, entries[1].Error)
+
+		// reads aren't persisted
+		status, body = do(http.MethodGet, "/api/auditlog?limit=1")
+		require.Equal(t, http.StatusOK, status)
+		entries = nil
+		require.NoError(t, json.Unmarshal(body, &entries))
+		require.Len(t, entries, 1)
+		require.Equal(t, "DELETE /api/apikey/{apikey}", entries[0].Operation)
+
+		status, _ = do(http.MethodGet, "/api/auditlog?since=yesterday")
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = do(http.MethodGet, "/api/auditlog?user=unknown@mail.test")
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
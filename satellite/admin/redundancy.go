@@ -0,0 +1,120 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+func (server *Server) getRedundancyProfiles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectUUID, ok := projectUUIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	profiles, err := server.db.Buckets().ListBucketRedundancyProfiles(ctx, projectUUID)
+	if err != nil {
+		httpJSONError(w, "failed to get redundancy profiles",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.Marshal(profiles)
+	if err != nil {
+		httpJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data) // nothing to do with the error response, probably the client requesting disappeared
+}
+
+func (server *Server) putRedundancyProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectUUID, ok := projectUUIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		httpJSONError(w, "invalid form",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bucket := metabase.BucketLocation{ProjectID: projectUUID, BucketName: r.Form.Get("bucket")}
+	if bucket.BucketName == "" {
+		httpJSONError(w, "bucket missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	profile := r.Form.Get("profile")
+	if profile == "" {
+		httpJSONError(w, "profile missing",
+			"", http.StatusBadRequest)
+		return
+	}
+	if err := server.redundancyProfiles.Verify(profile); err != nil {
+		httpJSONError(w, "invalid profile",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_, err := server.db.Buckets().GetBucket(ctx, []byte(bucket.BucketName), bucket.ProjectID)
+	if storj.ErrBucketNotFound.Has(err) {
+		httpJSONError(w, "bucket does not exist",
+			"", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		httpJSONError(w, "error getting bucket",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = server.db.Buckets().SetBucketRedundancyProfile(ctx, bucket, profile)
+	if err != nil {
+		httpJSONError(w, "failed to update redundancy profile",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (server *Server) deleteRedundancyProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectUUID, ok := projectUUIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		httpJSONError(w, "invalid form",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bucket := metabase.BucketLocation{ProjectID: projectUUID, BucketName: r.Form.Get("bucket")}
+	if bucket.BucketName == "" {
+		httpJSONError(w, "bucket missing",
+			"", http.StatusBadRequest)
+		return
+	}
+
+	err := server.db.Buckets().SetBucketRedundancyProfile(ctx, bucket, "")
+	if err != nil {
+		httpJSONError(w, "failed to delete redundancy profile",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
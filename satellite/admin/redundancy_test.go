@@ -0,0 +1,62 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+)
+
+func TestRedundancyProfile(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+				require.NoError(t, config.Metainfo.RedundancyProfiles.Set("archive:2/3/4/6-256B:1.5"))
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		authToken := sat.Config.Console.AuthToken
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, sat, "backups"))
+
+		link := "http://" + sat.Admin.Admin.Listener.Addr().String() + "/api/project/" + projectID.String() + "/redundancy-profile"
+
+		assertGet(t, link, `{}`, authToken)
+
+		do := func(method, link string, expectedStatus int) {
+			req, err := http.NewRequest(method, link, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", authToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, expectedStatus, response.StatusCode)
+			require.NoError(t, response.Body.Close())
+		}
+
+		do(http.MethodPut, link+"?profile=archive", http.StatusBadRequest)
+		do(http.MethodPut, link+"?profile=unknown&bucket=backups", http.StatusBadRequest)
+		do(http.MethodPut, link+"?profile=archive&bucket=missing", http.StatusNotFound)
+		do(http.MethodPut, link+"?profile=archive&bucket=backups", http.StatusOK)
+
+		assertGet(t, link, `{"backups":"archive"}`, authToken)
+
+		do(http.MethodDelete, link+"?bucket=backups", http.StatusOK)
+
+		assertGet(t, link, `{}`, authToken)
+	})
+}
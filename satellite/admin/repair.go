@@ -0,0 +1,127 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/repair"
+)
+
+func (server *Server) getRepairPriority(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectUUID, ok := projectUUIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	priorities, err := server.db.RepairPriorities().ListProject(ctx, projectUUID)
+	if err != nil {
+		httpJSONError(w, "failed to get repair priorities",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if priorities == nil {
+		priorities = []repair.BucketPriority{}
+	}
+
+	data, err := json.Marshal(priorities)
+	if err != nil {
+		httpJSONError(w, "json encoding failed",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data) // nothing to do with the error response, probably the client requesting disappeared
+}
+
+func (server *Server) putRepairPriority(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectUUID, ok := projectUUIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		httpJSONError(w, "invalid form",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	class, err := repair.ParsePriorityClass(r.Form.Get("class"))
+	if err != nil {
+		httpJSONError(w, "invalid class",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_, err = server.db.Console().Projects().Get(ctx, projectUUID)
+	if errors.Is(err, sql.ErrNoRows) {
+		httpJSONError(w, "project with specified uuid does not exist",
+			"", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		httpJSONError(w, "error getting project",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = server.db.RepairPriorities().Set(ctx, projectUUID, r.Form.Get("bucket"), class)
+	if err != nil {
+		httpJSONError(w, "failed to update repair priority",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (server *Server) deleteRepairPriority(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectUUID, ok := projectUUIDFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		httpJSONError(w, "invalid form",
+			err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err := server.db.RepairPriorities().Delete(ctx, projectUUID, r.Form.Get("bucket"))
+	if err != nil {
+		httpJSONError(w, "failed to delete repair priority",
+			err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// projectUUIDFromRequest parses the project id of the request path and
+// responds with an error when it's invalid.
+func projectUUIDFromRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	projectUUIDString, ok := mux.Vars(r)["project"]
+	if !ok {
+		httpJSONError(w, "project-uuid missing",
+			"", http.StatusBadRequest)
+		return uuid.UUID{}, false
+	}
+
+	projectUUID, err := uuid.FromString(projectUUIDString)
+	if err != nil {
+		httpJSONError(w, "invalid project-uuid",
+			err.Error(), http.StatusBadRequest)
+		return uuid.UUID{}, false
+	}
+	return projectUUID, true
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package admin_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+)
+
+func TestRepairPriority(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 0,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Admin.Address = "127.0.0.1:0"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		authToken := sat.Config.Console.AuthToken
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		link := "http://" + sat.Admin.Admin.Listener.Addr().String() + "/api/project/" + projectID.String() + "/repair-priority"
+
+		assertGet(t, link, `[]`, authToken)
+
+		do := func(method, link string, expectedStatus int) {
+			req, err := http.NewRequest(method, link, nil)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", authToken)
+
+			response, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.Equal(t, expectedStatus, response.StatusCode)
+			require.NoError(t, response.Body.Close())
+		}
+
+		do(http.MethodPut, link+"?class=high", http.StatusOK)
+		do(http.MethodPut, link+"?class=critical&bucket=backups", http.StatusOK)
+		do(http.MethodPut, link+"?class=urgent", http.StatusBadRequest)
+
+		assertGet(t, link,
+			`[{"projectId":"`+projectID.String()+`","bucketName":"","class":"high"},`+
+				`{"projectId":"`+projectID.String()+`","bucketName":"backups","class":"critical"}]`,
+			authToken)
+
+		do(http.MethodDelete, link, http.StatusOK)
+
+		assertGet(t, link,
+			`[{"projectId":"`+projectID.String()+`","bucketName":"backups","class":"critical"}]`,
+			authToken)
+	})
+}
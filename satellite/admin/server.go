@@ -22,13 +22,16 @@ import (
 	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
+	"storj.io/storj/satellite/repair"
 )
 
 // Config defines configuration for debug server.
 type Config struct {
-	Address string `help:"admin peer http listening address" releaseDefault:"" devDefault:""`
+	Address        string `help:"admin peer http listening address" releaseDefault:"" devDefault:""`
+	TrustedProxies string `help:"comma separated list of networks (CIDR) of proxies, which must send a PROXY protocol header when connecting to the admin server" default:""`
 
-	AuthorizationToken string `internal:"true"`
+	AuthorizationToken string                      `internal:"true"`
+	RedundancyProfiles metainfo.RedundancyProfiles `internal:"true"`
 }
 
 // DB is databases needed for the admin server.
@@ -41,6 +44,8 @@ type DB interface {
 	StripeCoinPayments() stripecoinpayments.DB
 	// Buckets returns database for satellite buckets
 	Buckets() metainfo.BucketsDB
+	// RepairPriorities returns database for repair priority classes of projects and buckets
+	RepairPriorities() repair.PriorityClasses
 }
 
 // Server provides endpoints for administrative tasks.
@@ -51,8 +56,9 @@ type Server struct {
 	server   http.Server
 	mux      *mux.Router
 
-	db       DB
-	payments payments.Accounts
+	db                 DB
+	payments           payments.Accounts
+	redundancyProfiles metainfo.RedundancyProfiles
 
 	nowFn func() time.Time
 }
@@ -65,8 +71,9 @@ func NewServer(log *zap.Logger, listener net.Listener, db DB, accounts payments.
 		listener: listener,
 		mux:      mux.NewRouter(),
 
-		db:       db,
-		payments: accounts,
+		db:                 db,
+		payments:           accounts,
+		redundancyProfiles: config.RedundancyProfiles,
 
 		nowFn: time.Now,
 	}
@@ -88,12 +95,24 @@ func NewServer(log *zap.Logger, listener net.Listener, db DB, accounts payments.
 	server.mux.HandleFunc("/api/project/{project}/usage", server.checkProjectUsage).Methods("GET")
 	server.mux.HandleFunc("/api/project/{project}/limit", server.getProjectLimit).Methods("GET")
 	server.mux.HandleFunc("/api/project/{project}/limit", server.putProjectLimit).Methods("PUT", "POST")
+	server.mux.HandleFunc("/api/project/{project}/repair-priority", server.getRepairPriority).Methods("GET")
+	server.mux.HandleFunc("/api/project/{project}/repair-priority", server.putRepairPriority).Methods("PUT", "POST")
+	server.mux.HandleFunc("/api/project/{project}/repair-priority", server.deleteRepairPriority).Methods("DELETE")
+	server.mux.HandleFunc("/api/project/{project}/redundancy-profile", server.getRedundancyProfiles).Methods("GET")
+	server.mux.HandleFunc("/api/project/{project}/redundancy-profile", server.putRedundancyProfile).Methods("PUT", "POST")
+	server.mux.HandleFunc("/api/project/{project}/redundancy-profile", server.deleteRedundancyProfile).Methods("DELETE")
 	server.mux.HandleFunc("/api/project/{project}", server.getProject).Methods("GET")
 	server.mux.HandleFunc("/api/project/{project}", server.renameProject).Methods("PUT")
 	server.mux.HandleFunc("/api/project/{project}", server.deleteProject).Methods("DELETE")
+	server.mux.HandleFunc("/api/project/{project}/apikey", server.listAPIKeys).Methods("GET")
 	server.mux.HandleFunc("/api/project/{project}/apikey", server.addAPIKey).Methods("POST")
 	server.mux.HandleFunc("/api/project/{project}/apikey/{name}", server.deleteAPIKeyByName).Methods("DELETE")
+	server.mux.HandleFunc("/api/apikey/unused", server.listUnusedAPIKeys).Methods("GET")
+	server.mux.HandleFunc("/api/apikey/unused", server.deleteUnusedAPIKeys).Methods("DELETE")
 	server.mux.HandleFunc("/api/apikey/{apikey}", server.deleteAPIKey).Methods("DELETE")
+	server.mux.HandleFunc("/api/auditlog", server.listAuditLog).Methods("GET")
+
+	server.mux.Use(server.auditLog)
 
 	return server
 }
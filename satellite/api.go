@@ -18,19 +18,21 @@ import (
 
 	"storj.io/common/identity"
 	"storj.io/common/pb"
-	"storj.io/common/peertls/extensions"
 	"storj.io/common/peertls/tlsopts"
 	"storj.io/common/rpc"
 	"storj.io/common/signing"
 	"storj.io/common/storj"
 	"storj.io/private/debug"
 	"storj.io/private/version"
+	"storj.io/storj/pkg/revocation"
+	"storj.io/storj/pkg/revocation/revocationpb"
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/private/lifecycle"
 	"storj.io/storj/private/post"
 	"storj.io/storj/private/post/oauth2"
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/satellite/accounting"
+	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/console/consoleweb"
@@ -98,8 +100,10 @@ type API struct {
 
 	Metainfo struct {
 		Database      metainfo.PointerDB
+		Metabase      metainfo.MetabaseDB
 		Service       *metainfo.Service
 		PieceDeletion *piecedeletion.Service
+		APIKeyUsage   *console.APIKeyUsageCache
 		Endpoint2     *metainfo.Endpoint
 	}
 
@@ -111,6 +115,10 @@ type API struct {
 		Inspector *irreparable.Inspector
 	}
 
+	Audit struct {
+		Inspector *audit.Inspector
+	}
+
 	Accounting struct {
 		ProjectUsage *accounting.Service
 	}
@@ -164,11 +172,15 @@ type API struct {
 	GracefulExit struct {
 		Endpoint *gracefulexit.Endpoint
 	}
+
+	Revocation struct {
+		Endpoint *revocation.Endpoint
+	}
 }
 
 // NewAPI creates a new satellite API process.
 func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
-	pointerDB metainfo.PointerDB, revocationDB extensions.RevocationDB, liveAccounting accounting.Cache, rollupsWriteCache *orders.RollupsWriteCache,
+	pointerDB metainfo.PointerDB, metabaseDB metainfo.MetabaseDB, revocationDB *revocation.DB, liveAccounting accounting.Cache, rollupsWriteCache *orders.RollupsWriteCache,
 	config *Config, versionInfo version.Info, atomicLogLevel *zap.AtomicLevel) (*API, error) {
 	peer := &API{
 		Log:      log,
@@ -228,7 +240,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 
 		peer.Dialer = rpc.NewDefaultDialer(tlsOptions)
 
-		peer.Server, err = server.New(log.Named("server"), tlsOptions, sc.Address, sc.PrivateAddress)
+		peer.Server, err = server.New(log.Named("server"), tlsOptions, sc)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
@@ -395,8 +407,10 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 
 	{ // setup metainfo
 		peer.Metainfo.Database = pointerDB
+		peer.Metainfo.Metabase = metabaseDB
 		peer.Metainfo.Service = metainfo.NewService(peer.Log.Named("metainfo:service"),
 			peer.Metainfo.Database,
+			peer.Metainfo.Metabase,
 			peer.DB.Buckets(),
 		)
 
@@ -415,6 +429,18 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			Close: peer.Metainfo.PieceDeletion.Close,
 		})
 
+		peer.Metainfo.APIKeyUsage = console.NewAPIKeyUsageCache(peer.Log.Named("metainfo:apikeyusage"),
+			peer.DB.Console().APIKeys(),
+			config.APIKeyUsage,
+		)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "metainfo:apikeyusage",
+			Run:   peer.Metainfo.APIKeyUsage.Run,
+			Close: peer.Metainfo.APIKeyUsage.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Metainfo API Key Usage", peer.Metainfo.APIKeyUsage.Loop))
+
 		peer.Metainfo.Endpoint2, err = metainfo.NewEndpoint(
 			peer.Log.Named("metainfo:endpoint"),
 			peer.Metainfo.Service,
@@ -425,6 +451,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.Marketing.PartnersService,
 			peer.DB.PeerIdentities(),
 			peer.DB.Console().APIKeys(),
+			peer.Metainfo.APIKeyUsage,
 			peer.Accounting.ProjectUsage,
 			peer.DB.Console().Projects(),
 			signing.SignerFromFullIdentity(peer.Identity),
@@ -452,6 +479,13 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 		}
 	}
 
+	{ // setup audit inspector
+		peer.Audit.Inspector = audit.NewInspector(peer.DB.NodeAudits(), config.Audit.NodeAuditInterval)
+		if err := internalpb.DRPCRegisterAuditInspector(peer.Server.PrivateDRPC(), peer.Audit.Inspector); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+	}
+
 	{ // setup inspector
 		peer.Inspector.Endpoint = inspector.NewEndpoint(
 			peer.Log.Named("inspector"),
@@ -556,6 +590,7 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.DB.StripeCoinPayments(),
 			peer.DB.Console().Projects(),
 			peer.DB.ProjectAccounting(),
+			metainfo.NewBucketPriceMultipliers(peer.DB.Buckets(), config.Metainfo.RedundancyProfiles),
 			pc.StorageTBPrice,
 			pc.EgressTBPrice,
 			pc.ObjectPrice,
@@ -590,6 +625,11 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+		trustedProxies, err := server.ParseTrustedProxies(consoleConfig.TrustedProxies)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Console.Listener.Close(), peer.Close())
+		}
+		peer.Console.Listener = server.NewProxyListener(peer.Console.Listener, trustedProxies)
 		if consoleConfig.AuthTokenSecret == "" {
 			return nil, errs.New("Auth token secret required")
 		}
@@ -665,6 +705,13 @@ func NewAPI(log *zap.Logger, full *identity.FullIdentity, db DB,
 		}
 	}
 
+	{ // setup revocation distribution
+		peer.Revocation.Endpoint = revocation.NewEndpoint(peer.Log.Named("revocation:endpoint"), revocationDB)
+		if err := revocationpb.DRPCRegisterRevocations(peer.Server.DRPC(), peer.Revocation.Endpoint); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+	}
+
 	{ // setup graceful exit
 		if config.GracefulExit.Enabled {
 			peer.GracefulExit.Endpoint = gracefulexit.NewEndpoint(
@@ -0,0 +1,433 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	mathrand "math/rand"
+	"time"
+
+	"github.com/vivint/infectious"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/errs2"
+	"storj.io/common/pb"
+	"storj.io/common/rpc"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/storj/pkg/piecechallenge"
+	"storj.io/storj/pkg/piecechallenge/piecechallengepb"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/uplink/private/eestream"
+)
+
+// challengeExtraSources is the number of pieces downloaded in addition to the
+// required ones, so that corrupted downloads can be detected and corrected.
+const challengeExtraSources = 2
+
+// challengeTarget is a node, which is challenged to hash the range of its piece.
+type challengeTarget struct {
+	NodeID     storj.NodeID
+	Address    string
+	LastIPPort string
+	PieceID    storj.PieceID
+}
+
+// challengeResponse is the response of a node to a hash challenge.
+type challengeResponse struct {
+	Error    error
+	PieceNum int
+	NodeID   storj.NodeID
+	Hash     []byte
+}
+
+// ChallengePieces verifies that the nodes store a whole block of consecutive
+// stripes of their pieces, which is up to maxStripes long.
+//
+// The block is downloaded only from enough nodes to reconstruct it. The other
+// nodes are challenged to return a hash of their block keyed with a random
+// nonce, which is compared to the hash of the reconstructed block.
+func (verifier *Verifier) ChallengePieces(ctx context.Context, path storj.Path, skip map[storj.NodeID]bool, maxStripes int) (report Report, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pointerBytes, pointer, err := verifier.metainfo.GetWithBytes(ctx, metabase.SegmentKey(path))
+	if err != nil {
+		if storj.ErrObjectNotFound.Has(err) {
+			verifier.log.Debug("segment deleted before ChallengePieces")
+			return Report{}, nil
+		}
+		return Report{}, err
+	}
+	if !pointer.ExpirationDate.IsZero() && pointer.ExpirationDate.Before(time.Now()) {
+		verifier.log.Debug("segment expired before ChallengePieces")
+		return Report{}, nil
+	}
+
+	blockIndex, blockStripes, err := getRandomBlock(ctx, pointer, maxStripes)
+	if err != nil {
+		return Report{}, err
+	}
+
+	redundancy := pointer.GetRemote().GetRedundancy()
+	required := int(redundancy.GetMinReq())
+	total := int(redundancy.GetTotal())
+	shareSize := int(redundancy.GetErasureShareSize())
+	blockSize := int64(blockStripes * shareSize)
+
+	segmentLocation, err := metabase.ParseSegmentKey(metabase.SegmentKey(path))
+	if err != nil {
+		return Report{}, err
+	}
+
+	nodeIDs := make([]storj.NodeID, len(pointer.GetRemote().GetRemotePieces()))
+	for i, piece := range pointer.GetRemote().GetRemotePieces() {
+		nodeIDs[i] = piece.NodeId
+	}
+	nodes, err := verifier.overlay.GetOnlineNodesForGetDelete(ctx, nodeIDs)
+	if err != nil {
+		return Report{}, err
+	}
+
+	// challenged nodes don't download anything, so order limits are only
+	// created for the sources.
+	targets := selectChallengeTargets(pointer, nodes, skip, required+challengeExtraSources)
+	limitSkip := make(map[storj.NodeID]bool, len(skip)+len(targets))
+	for nodeID := range skip {
+		limitSkip[nodeID] = true
+	}
+	for _, target := range targets {
+		limitSkip[target.NodeID] = true
+	}
+
+	sourceLimits, privateKey, cachedIPsAndPorts, err := verifier.orders.CreateAuditRangeOrderLimits(ctx, segmentLocation.Bucket(), pointer, limitSkip, blockSize)
+	if err != nil {
+		return Report{}, err
+	}
+
+	// NOTE offlineNodes will include disqualified nodes because they aren't in
+	// the skip list
+	offlineNodes := getOfflineNodes(pointer, sourceLimits, limitSkip)
+	if len(offlineNodes) > 0 {
+		verifier.log.Debug("ChallengePieces: order limits not created for some nodes (offline/disqualified)",
+			zap.Strings("Node IDs", offlineNodes.Strings()))
+	}
+
+	nonce := make([]byte, piecechallenge.NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return Report{Offlines: offlineNodes}, Error.Wrap(err)
+	}
+
+	shares, err := verifier.DownloadShares(ctx, sourceLimits, privateKey, cachedIPsAndPorts, blockIndex, int32(blockSize))
+	if err != nil {
+		return Report{Offlines: offlineNodes}, err
+	}
+	responses := verifier.challengeNodes(ctx, targets, nonce, blockIndex*blockSize, blockSize)
+
+	err = verifier.checkIfSegmentAltered(ctx, path, pointer, pointerBytes)
+	if err != nil {
+		if ErrSegmentDeleted.Has(err) {
+			verifier.log.Debug("segment deleted during ChallengePieces")
+			return Report{}, nil
+		}
+		if ErrSegmentModified.Has(err) {
+			verifier.log.Debug("segment modified during ChallengePieces")
+			return Report{}, nil
+		}
+		return Report{Offlines: offlineNodes}, err
+	}
+
+	var failedNodes storj.NodeIDList
+	var unknownNodes storj.NodeIDList
+	containedNodes := make(map[int]storj.NodeID)
+
+	classify := func(pieceNum int, nodeID storj.NodeID, err error) {
+		switch {
+		case rpc.Error.Has(err) && (errs.Is(err, context.DeadlineExceeded) || errs2.IsRPC(err, rpcstatus.Unknown)):
+			offlineNodes = append(offlineNodes, nodeID)
+			verifier.log.Debug("ChallengePieces: dial failed (offline)", zap.Stringer("Node ID", nodeID), zap.Error(err))
+		case rpc.Error.Has(err):
+			unknownNodes = append(unknownNodes, nodeID)
+			verifier.log.Info("ChallengePieces: unknown transport error (skipped)", zap.Stringer("Node ID", nodeID), zap.Error(err))
+		case errs2.IsRPC(err, rpcstatus.NotFound):
+			failedNodes = append(failedNodes, nodeID)
+			verifier.log.Info("ChallengePieces: piece not found (audit failed)", zap.Stringer("Node ID", nodeID), zap.Error(err))
+		case errs2.IsRPC(err, rpcstatus.DeadlineExceeded) || errs.Is(err, context.DeadlineExceeded):
+			containedNodes[pieceNum] = nodeID
+			verifier.log.Info("ChallengePieces: timeout (contained)", zap.Stringer("Node ID", nodeID), zap.Error(err))
+		default:
+			unknownNodes = append(unknownNodes, nodeID)
+			verifier.log.Info("ChallengePieces: unknown error (skipped)", zap.Stringer("Node ID", nodeID), zap.Error(err))
+		}
+	}
+
+	downloaded := make(map[int]Share)
+	for pieceNum, share := range shares {
+		if share.Error != nil {
+			classify(pieceNum, share.NodeID, share.Error)
+			continue
+		}
+		downloaded[pieceNum] = share
+	}
+	answered := make(map[int]challengeResponse)
+	for pieceNum, response := range responses {
+		if response.Error != nil {
+			classify(pieceNum, response.NodeID, response.Error)
+			continue
+		}
+		answered[pieceNum] = response
+	}
+
+	mon.IntVal("challenge_blocks_downloaded_successfully").Observe(int64(len(downloaded)))
+
+	// without any redundancy in the downloaded blocks, corrupted downloads
+	// would go undetected and the challenged nodes would be blamed for them.
+	if len(downloaded) <= required {
+		mon.Counter("not_enough_shares_for_challenge").Inc(1)
+		return Report{
+			Fails:    failedNodes,
+			Offlines: offlineNodes,
+			Unknown:  unknownNodes,
+		}, ErrNotEnoughShares.New("got %d, required more than %d", len(downloaded), required)
+	}
+	mon.Counter("not_enough_shares_for_challenge").Inc(0)
+
+	fec, err := infectious.NewFEC(required, total)
+	if err != nil {
+		return Report{Offlines: offlineNodes}, Error.Wrap(err)
+	}
+
+	corruptedSources := make(map[int]bool)
+	expected := make(map[int][]byte, len(answered))
+	var firstStripe []infectious.Share
+	for stripe := 0; stripe < blockStripes; stripe++ {
+		stripeShares := make(map[int]Share, len(downloaded))
+		for pieceNum, share := range downloaded {
+			stripeShares[pieceNum] = Share{
+				PieceNum: pieceNum,
+				NodeID:   share.NodeID,
+				Data:     share.Data[stripe*shareSize : (stripe+1)*shareSize],
+			}
+		}
+
+		pieceNums, corrected, err := auditShares(ctx, required, total, stripeShares)
+		if err != nil {
+			return Report{
+				Fails:    failedNodes,
+				Offlines: offlineNodes,
+				Unknown:  unknownNodes,
+			}, err
+		}
+		for _, pieceNum := range pieceNums {
+			corruptedSources[pieceNum] = true
+		}
+		if stripe == 0 {
+			firstStripe = corrected
+		}
+
+		stripeData, err := rebuildStripe(ctx, fec, corrected, shareSize)
+		if err != nil {
+			return Report{Offlines: offlineNodes}, Error.Wrap(err)
+		}
+		for pieceNum := range answered {
+			share := make([]byte, shareSize)
+			if err := fec.EncodeSingle(stripeData, share, pieceNum); err != nil {
+				return Report{Offlines: offlineNodes}, Error.Wrap(err)
+			}
+			expected[pieceNum] = append(expected[pieceNum], share...)
+		}
+	}
+
+	var successNodes storj.NodeIDList
+	for pieceNum, share := range downloaded {
+		if corruptedSources[pieceNum] {
+			failedNodes = append(failedNodes, share.NodeID)
+			continue
+		}
+		successNodes = append(successNodes, share.NodeID)
+	}
+
+	var mismatches int64
+	for pieceNum, response := range answered {
+		if !piecechallenge.Equal(response.Hash, piecechallenge.Hash(nonce, expected[pieceNum])) {
+			mismatches++
+			failedNodes = append(failedNodes, response.NodeID)
+			verifier.log.Info("ChallengePieces: hash mismatch (audit failed)", zap.Stringer("Node ID", response.NodeID))
+			continue
+		}
+		successNodes = append(successNodes, response.NodeID)
+	}
+
+	mon.Meter("audit_challenge_nodes_global").Mark(len(responses))
+	mon.Meter("audit_challenge_mismatch_nodes_global").Mark64(mismatches)
+	mon.Meter("audit_challenge_corrupted_sources_global").Mark(len(corruptedSources))
+
+	// contained nodes are reverified by downloading the first stripe of the block.
+	pendingAudits, err := createPendingAudits(ctx, containedNodes, firstStripe, pointer, blockIndex*int64(blockStripes), path)
+	if err != nil {
+		return Report{
+			Successes: successNodes,
+			Fails:     failedNodes,
+			Offlines:  offlineNodes,
+			Unknown:   unknownNodes,
+		}, err
+	}
+
+	return Report{
+		Successes:     successNodes,
+		Fails:         failedNodes,
+		Offlines:      offlineNodes,
+		PendingAudits: pendingAudits,
+		Unknown:       unknownNodes,
+	}, nil
+}
+
+// challengeNodes challenges the nodes to hash the range of their pieces.
+func (verifier *Verifier) challengeNodes(ctx context.Context, targets map[int]challengeTarget, nonce []byte, offset, length int64) (responses map[int]challengeResponse) {
+	defer mon.Task()(&ctx)(nil)
+
+	responses = make(map[int]challengeResponse, len(targets))
+	ch := make(chan challengeResponse, len(targets))
+
+	for pieceNum, target := range targets {
+		go func(pieceNum int, target challengeTarget) {
+			hash, err := verifier.challengeNode(ctx, target, nonce, offset, length)
+			ch <- challengeResponse{
+				Error:    err,
+				PieceNum: pieceNum,
+				NodeID:   target.NodeID,
+				Hash:     hash,
+			}
+		}(pieceNum, target)
+	}
+
+	for range targets {
+		response := <-ch
+		responses[response.PieceNum] = response
+	}
+
+	return responses
+}
+
+// challengeNode challenges a single node to hash the range of its piece.
+func (verifier *Verifier) challengeNode(ctx context.Context, target challengeTarget, nonce []byte, offset, length int64) (hash []byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// the node has to read the whole range, so allow the same time as for downloading it.
+	timedCtx := ctx
+	if verifier.minBytesPerSecond > 0 {
+		maxTransferTime := time.Duration(int64(time.Second) * length / verifier.minBytesPerSecond.Int64())
+		if maxTransferTime < verifier.minDownloadTimeout {
+			maxTransferTime = verifier.minDownloadTimeout
+		}
+		var cancel func()
+		timedCtx, cancel = context.WithTimeout(ctx, maxTransferTime)
+		defer cancel()
+	}
+
+	var conn *rpc.Conn
+
+	// if cached IP is given, try connecting there first
+	if target.LastIPPort != "" {
+		conn, err = verifier.dialer.DialNodeURL(timedCtx, storj.NodeURL{
+			ID:      target.NodeID,
+			Address: target.LastIPPort,
+		})
+		if err != nil {
+			verifier.log.Debug("failed to connect to challenged node at cached IP",
+				zap.Stringer("Node ID", target.NodeID),
+				zap.String("cached-ip-and-port", target.LastIPPort),
+				zap.Error(err))
+		}
+	}
+
+	// if no cached IP was given, or connecting to cached IP failed, use node address
+	if conn == nil {
+		conn, err = verifier.dialer.DialNodeURL(timedCtx, storj.NodeURL{
+			ID:      target.NodeID,
+			Address: target.Address,
+		})
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+	defer func() {
+		err := conn.Close()
+		if err != nil {
+			verifier.log.Error("audit verifier failed to close conn to node", zap.Error(err))
+		}
+	}()
+
+	response, err := piecechallengepb.NewDRPCPieceChallengeClient(conn).HashRange(timedCtx, &piecechallengepb.HashRangeRequest{
+		PieceId: target.PieceID.Bytes(),
+		Offset:  offset,
+		Length:  length,
+		Nonce:   nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Hash, nil
+}
+
+// selectChallengeTargets randomly selects up to sources online nodes, which
+// are used for downloading, and returns the remaining online nodes as
+// challenge targets by piece number. Nodes in skip are neither.
+func selectChallengeTargets(pointer *pb.Pointer, nodes map[storj.NodeID]*overlay.SelectedNode, skip map[storj.NodeID]bool, sources int) map[int]challengeTarget {
+	pieces := pointer.GetRemote().GetRemotePieces()
+	rootPieceID := pointer.GetRemote().RootPieceId
+
+	targets := make(map[int]challengeTarget)
+	rnd := mathrand.New(cryptoSource{})
+	for _, i := range rnd.Perm(len(pieces)) {
+		piece := pieces[i]
+		node, ok := nodes[piece.NodeId]
+		if !ok || skip[piece.NodeId] {
+			continue
+		}
+		if sources > 0 {
+			sources--
+			continue
+		}
+		targets[int(piece.PieceNum)] = challengeTarget{
+			NodeID:     piece.NodeId,
+			Address:    node.Address.GetAddress(),
+			LastIPPort: node.LastIPPort,
+			PieceID:    rootPieceID.Derive(piece.NodeId, piece.PieceNum),
+		}
+	}
+	return targets
+}
+
+// getRandomBlock returns a random block of up to maxStripes consecutive
+// stripes within the pointer. Blocks are aligned to their size, so the index
+// is in units of blocks.
+func getRandomBlock(ctx context.Context, pointer *pb.Pointer, maxStripes int) (index int64, stripes int, err error) {
+	defer mon.Task()(&ctx)(&err)
+	redundancy, err := eestream.NewRedundancyStrategyFromProto(pointer.GetRemote().GetRedundancy())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the last segment could be smaller than stripe size
+	numStripes := pointer.GetSegmentSize() / int64(redundancy.StripeSize())
+	if numStripes < 1 {
+		numStripes = 1
+	}
+
+	stripes = maxStripes
+	if stripes < 1 {
+		stripes = 1
+	}
+	if int64(stripes) > numStripes {
+		stripes = int(numStripes)
+	}
+
+	rnd := mathrand.New(cryptoSource{})
+	index = rnd.Int63n(numStripes / int64(stripes))
+
+	return index, stripes, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+func TestChallengePieces(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 10, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		audits := satellite.Audit
+
+		audits.Worker.Loop.Pause()
+		audits.Chore.Loop.Pause()
+
+		ul := planet.Uplinks[0]
+		testData := testrand.Bytes(8 * memory.KiB)
+
+		err := ul.Upload(ctx, satellite, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		audits.Chore.Loop.TriggerWait()
+		queue := audits.Queues.Fetch()
+		path, err := queue.Next()
+		require.NoError(t, err)
+
+		pointer, err := satellite.Metainfo.Service.Get(ctx, metabase.SegmentKey(path))
+		require.NoError(t, err)
+
+		pieces := pointer.GetRemote().GetRemotePieces()
+		// more pieces than downloaded, so some nodes are challenged
+		require.Greater(t, len(pieces), int(pointer.GetRemote().GetRedundancy().GetMinReq())+2)
+
+		report, err := audits.Verifier.ChallengePieces(ctx, path, nil, 4)
+		require.NoError(t, err)
+
+		assert.Len(t, report.Successes, len(pieces))
+		assert.Len(t, report.Fails, 0)
+		assert.Len(t, report.Offlines, 0)
+		assert.Len(t, report.PendingAudits, 0)
+
+		// delete the piece from the first node
+		piece := pieces[0]
+		pieceID := pointer.GetRemote().RootPieceId.Derive(piece.NodeId, piece.PieceNum)
+		node := planet.FindNode(piece.NodeId)
+		err = node.Storage2.Store.Delete(ctx, satellite.ID(), pieceID)
+		require.NoError(t, err)
+
+		report, err = audits.Verifier.ChallengePieces(ctx, path, nil, 4)
+		require.NoError(t, err)
+
+		assert.Len(t, report.Successes, len(pieces)-1)
+		require.Len(t, report.Fails, 1)
+		assert.Equal(t, piece.NodeId, report.Fails[0])
+		assert.Len(t, report.Offlines, 0)
+		assert.Len(t, report.PendingAudits, 0)
+	})
+}
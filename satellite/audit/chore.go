@@ -25,11 +25,12 @@ type Chore struct {
 	Loop   *sync2.Cycle
 
 	metainfoLoop *metainfo.Loop
+	nodeAudits   NodeAuditsDB
 	config       Config
 }
 
 // NewChore instantiates Chore.
-func NewChore(log *zap.Logger, queues *Queues, metaLoop *metainfo.Loop, config Config) *Chore {
+func NewChore(log *zap.Logger, queues *Queues, metaLoop *metainfo.Loop, nodeAudits NodeAuditsDB, config Config) *Chore {
 	return &Chore{
 		log:    log,
 		rand:   rand.New(rand.NewSource(time.Now().Unix())),
@@ -37,6 +38,7 @@ func NewChore(log *zap.Logger, queues *Queues, metaLoop *metainfo.Loop, config C
 		Loop:   sync2.NewCycle(config.ChoreInterval),
 
 		metainfoLoop: metaLoop,
+		nodeAudits:   nodeAudits,
 		config:       config,
 	}
 }
@@ -53,39 +55,86 @@ func (chore *Chore) Run(ctx context.Context) (err error) {
 			return err
 		}
 
+		var schedule *Schedule
+		audits, err := chore.nodeAudits.GetAll(ctx)
+		if err != nil {
+			// fall back to the pseudorandom order of all nodes.
+			chore.log.Error("error getting node audits", zap.Error(err))
+		} else {
+			schedule = NewSchedule(audits, chore.config.NodeAuditInterval, time.Now())
+		}
+
 		pathCollector := NewPathCollector(chore.config.Slots, chore.rand)
+		if schedule != nil {
+			pathCollector = NewScheduledPathCollector(func(nodeID storj.NodeID) int {
+				return schedule.Slots(nodeID, chore.config.Slots, chore.config.PrioritizedSlots)
+			}, chore.rand)
+		}
 		err = chore.metainfoLoop.Join(ctx, pathCollector)
 		if err != nil {
 			chore.log.Error("error joining metainfoloop", zap.Error(err))
 			return nil
 		}
 
-		var newQueue []storj.Path
-		queuePaths := make(map[storj.Path]struct{})
-
-		// Add reservoir paths to queue in pseudorandom order.
-		for i := 0; i < chore.config.Slots; i++ {
-			for _, res := range pathCollector.Reservoirs {
-				// Skip reservoir if no path at this index.
-				if len(res.Paths) <= i {
-					continue
-				}
-				path := res.Paths[i]
-				if path == "" {
-					continue
-				}
-				if _, ok := queuePaths[path]; !ok {
-					newQueue = append(newQueue, path)
-					queuePaths[path] = struct{}{}
-				}
-			}
-		}
+		newQueue := newAuditQueue(pathCollector.Reservoirs, schedule)
 
 		// Push new queue to queues struct so it can be fetched by worker.
 		return chore.queues.Push(newQueue)
 	})
 }
 
+// newAuditQueue returns the paths of the reservoirs in the order they are
+// audited. When there is a schedule, all paths of the prioritized nodes come
+// first, so they are audited regardless of the queue length. The remaining
+// paths are added in rounds of one path per node in pseudorandom order.
+func newAuditQueue(reservoirs map[storj.NodeID]*Reservoir, schedule *Schedule) []storj.Path {
+	nodeIDs := make(storj.NodeIDList, 0, len(reservoirs))
+	for nodeID := range reservoirs {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	var queue []storj.Path
+	queuePaths := make(map[storj.Path]struct{})
+	addPath := func(path storj.Path) {
+		if path == "" {
+			return
+		}
+		if _, ok := queuePaths[path]; !ok {
+			queue = append(queue, path)
+			queuePaths[path] = struct{}{}
+		}
+	}
+
+	if schedule != nil {
+		schedule.Sort(nodeIDs)
+
+		var prioritized int
+		for _, nodeID := range nodeIDs {
+			if schedule.Priority(nodeID) >= normalPriority {
+				break
+			}
+			prioritized++
+			for _, path := range reservoirs[nodeID].Paths {
+				addPath(path)
+			}
+		}
+		mon.IntVal("audit_prioritized_nodes").Observe(int64(prioritized))
+	}
+
+	for i := 0; i < maxReservoirSize; i++ {
+		for _, nodeID := range nodeIDs {
+			res := reservoirs[nodeID]
+			// Skip reservoir if no path at this index.
+			if len(res.Paths) <= i {
+				continue
+			}
+			addPath(res.Paths[i])
+		}
+	}
+
+	return queue
+}
+
 // Close closes chore.
 func (chore *Chore) Close() error {
 	chore.Loop.Close()
@@ -0,0 +1,64 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"storj.io/storj/satellite/internalpb"
+)
+
+// Inspector is a RPC service for inspecting the audit schedule of nodes.
+//
+// architecture: Endpoint
+type Inspector struct {
+	nodeAudits NodeAuditsDB
+	interval   time.Duration
+}
+
+// NewInspector creates an Inspector.
+func NewInspector(nodeAudits NodeAuditsDB, interval time.Duration) *Inspector {
+	return &Inspector{
+		nodeAudits: nodeAudits,
+		interval:   interval,
+	}
+}
+
+// NodeAudits returns when the requested nodes were audited and their audit rates.
+// All nodes are returned when no node is requested.
+func (srv *Inspector) NodeAudits(ctx context.Context, req *internalpb.NodeAuditsRequest) (_ *internalpb.NodeAuditsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var audits []NodeAudits
+	if len(req.NodeIds) == 0 {
+		audits, err = srv.nodeAudits.GetAll(ctx)
+	} else {
+		audits, err = srv.nodeAudits.Get(ctx, req.NodeIds)
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	now := time.Now()
+	response := &internalpb.NodeAuditsResponse{}
+	for _, info := range audits {
+		node := &internalpb.NodeAudits{
+			NodeId:       info.NodeID,
+			AuditCount:   info.AuditCount,
+			AuditsPerDay: info.AuditsPerDay(now),
+			Vetted:       info.Vetted,
+			Suspended:    info.Suspended,
+			Overdue:      info.Overdue(now, srv.interval),
+		}
+		if info.FirstAudited != nil {
+			node.FirstAudited = info.FirstAudited.Unix()
+		}
+		if info.LastAudited != nil {
+			node.LastAudited = info.LastAudited.Unix()
+		}
+		response.Nodes = append(response.Nodes, node)
+	}
+	return response, nil
+}
@@ -18,15 +18,22 @@ var _ metainfo.Observer = (*PathCollector)(nil)
 // architecture: Observer
 type PathCollector struct {
 	Reservoirs map[storj.NodeID]*Reservoir
-	slotCount  int
+	slotCount  func(storj.NodeID) int
 	rand       *rand.Rand
 }
 
-// NewPathCollector instantiates a path collector.
+// NewPathCollector instantiates a path collector, which allots reservoirSlots
+// slots for every node.
 func NewPathCollector(reservoirSlots int, r *rand.Rand) *PathCollector {
+	return NewScheduledPathCollector(func(storj.NodeID) int { return reservoirSlots }, r)
+}
+
+// NewScheduledPathCollector instantiates a path collector, which allots the
+// number of slots returned by slotCount for each node.
+func NewScheduledPathCollector(slotCount func(storj.NodeID) int, r *rand.Rand) *PathCollector {
 	return &PathCollector{
 		Reservoirs: make(map[storj.NodeID]*Reservoir),
-		slotCount:  reservoirSlots,
+		slotCount:  slotCount,
 		rand:       r,
 	}
 }
@@ -37,7 +44,7 @@ func (collector *PathCollector) RemoteSegment(ctx context.Context, segment *meta
 	key := string(segment.Location.Encode())
 	for _, piece := range segment.Pieces {
 		if _, ok := collector.Reservoirs[piece.StorageNode]; !ok {
-			collector.Reservoirs[piece.StorageNode] = NewReservoir(collector.slotCount)
+			collector.Reservoirs[piece.StorageNode] = NewReservoir(collector.slotCount(piece.StorageNode))
 		}
 		collector.Reservoirs[piece.StorageNode].Sample(collector.rand, key)
 	}
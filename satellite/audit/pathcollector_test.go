@@ -28,7 +28,7 @@ import (
 //
 // Then for every node in testplanet:
 //    - expect that there is a reservoir for that node on the audit observer
-//    - that the reservoir size is <= 2 (the maxReservoirSize)
+//    - that the reservoir size is <= 4 (the number of slots)
 //    - that every item in the reservoir is unique
 func TestAuditPathCollector(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
@@ -60,9 +60,8 @@ func TestAuditPathCollector(t *testing.T) {
 			require.NotNil(t, observer.Reservoirs[node.ID()])
 			require.True(t, len(observer.Reservoirs[node.ID()].Paths) > 1)
 
-			// Require that len paths are <= 3 even though the PathCollector was instantiated with 4
-			// because the maxReservoirSize is currently 3.
-			require.True(t, len(observer.Reservoirs[node.ID()].Paths) <= 3)
+			// Require that len paths are <= 4, the number of slots the PathCollector was instantiated with.
+			require.True(t, len(observer.Reservoirs[node.ID()].Paths) <= 4)
 
 			repeats := make(map[storj.Path]bool)
 			for _, path := range observer.Reservoirs[node.ID()].Paths {
@@ -5,6 +5,7 @@ package audit
 
 import (
 	"context"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -20,6 +21,7 @@ type Reporter struct {
 	log              *zap.Logger
 	overlay          *overlay.Service
 	containment      Containment
+	nodeAudits       NodeAuditsDB
 	maxRetries       int
 	maxReverifyCount int32
 }
@@ -34,11 +36,12 @@ type Report struct {
 }
 
 // NewReporter instantiates a reporter.
-func NewReporter(log *zap.Logger, overlay *overlay.Service, containment Containment, maxRetries int, maxReverifyCount int32) *Reporter {
+func NewReporter(log *zap.Logger, overlay *overlay.Service, containment Containment, nodeAudits NodeAuditsDB, maxRetries int, maxReverifyCount int32) *Reporter {
 	return &Reporter{
 		log:              log,
 		overlay:          overlay,
 		containment:      containment,
+		nodeAudits:       nodeAudits,
 		maxRetries:       maxRetries,
 		maxReverifyCount: maxReverifyCount}
 }
@@ -63,6 +66,8 @@ func (reporter *Reporter) RecordAudits(ctx context.Context, req Report, path sto
 		zap.Int("pending", len(pendingAudits)),
 	)
 
+	reporter.recordAuditTimes(ctx, req)
+
 	var errlist errs.Group
 
 	tries := 0
@@ -120,6 +125,28 @@ func (reporter *Reporter) RecordAudits(ctx context.Context, req Report, path sto
 	return Report{}, nil
 }
 
+// recordAuditTimes records the audit time of nodes, which were contacted
+// during the audit. Failing to do so only affects the audit schedule, so
+// the error is only logged.
+func (reporter *Reporter) recordAuditTimes(ctx context.Context, req Report) {
+	defer mon.Task()(&ctx)(nil)
+
+	audited := make(storj.NodeIDList, 0, len(req.Successes)+len(req.Fails)+len(req.Unknown)+len(req.PendingAudits))
+	audited = append(audited, req.Successes...)
+	audited = append(audited, req.Fails...)
+	audited = append(audited, req.Unknown...)
+	for _, pending := range req.PendingAudits {
+		audited = append(audited, pending.NodeID)
+	}
+	if len(audited) == 0 {
+		return
+	}
+
+	if err := reporter.nodeAudits.RecordAudits(ctx, audited, time.Now()); err != nil {
+		reporter.log.Warn("failed to record audit times", zap.Error(err))
+	}
+}
+
 // recordAuditFailStatus updates nodeIDs in overlay with isup=true, auditoutcome=fail.
 func (reporter *Reporter) recordAuditFailStatus(ctx context.Context, failedAuditNodeIDs storj.NodeIDList) (failed storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -9,11 +9,11 @@ import (
 	"storj.io/common/storj"
 )
 
-const maxReservoirSize = 3
+const maxReservoirSize = 10
 
 // Reservoir holds a certain number of segments to reflect a random sample.
 type Reservoir struct {
-	Paths [maxReservoirSize]storj.Path
+	Paths []storj.Path
 	size  int8
 	index int64
 }
@@ -35,8 +35,8 @@ func NewReservoir(size int) *Reservoir {
 // pick a random number r = rand(0..i), and if r < size, replace reservoir.Segments[r] with segment.
 func (reservoir *Reservoir) Sample(r *rand.Rand, path storj.Path) {
 	reservoir.index++
-	if reservoir.index < int64(reservoir.size) {
-		reservoir.Paths[reservoir.index] = path
+	if len(reservoir.Paths) < int(reservoir.size) {
+		reservoir.Paths = append(reservoir.Paths, path)
 	} else {
 		random := r.Int63n(reservoir.index)
 		if random < int64(reservoir.size) {
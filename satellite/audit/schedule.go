@@ -0,0 +1,154 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"storj.io/common/storj"
+)
+
+// NodeAuditsDB keeps track of when storage nodes were audited.
+//
+// architecture: Database
+type NodeAuditsDB interface {
+	// RecordAudits records that the nodes were audited at the time.
+	RecordAudits(ctx context.Context, nodeIDs storj.NodeIDList, auditedAt time.Time) error
+	// GetAll returns the audit information of all nodes, which are neither
+	// disqualified nor have exited. Nodes, which weren't audited yet, are included.
+	GetAll(ctx context.Context) ([]NodeAudits, error)
+	// Get returns the audit information of the nodes.
+	Get(ctx context.Context, nodeIDs storj.NodeIDList) ([]NodeAudits, error)
+}
+
+// NodeAudits contains the audit information of a node, which is used for scheduling audits.
+type NodeAudits struct {
+	NodeID storj.NodeID
+	// FirstAudited and LastAudited are nil when the node wasn't audited yet.
+	FirstAudited *time.Time
+	LastAudited  *time.Time
+	AuditCount   int64
+
+	Vetted    bool
+	Suspended bool
+}
+
+// AuditsPerDay returns the average number of audits per day since the first audit.
+func (audits NodeAudits) AuditsPerDay(now time.Time) float64 {
+	if audits.FirstAudited == nil || audits.AuditCount == 0 {
+		return 0
+	}
+	days := now.Sub(*audits.FirstAudited).Hours() / 24
+	if days < 1 {
+		// avoid reporting huge rates for nodes which were audited only recently.
+		days = 1
+	}
+	return float64(audits.AuditCount) / days
+}
+
+// Overdue returns whether the node wasn't audited within the interval.
+func (audits NodeAudits) Overdue(now time.Time, interval time.Duration) bool {
+	return audits.LastAudited == nil || audits.LastAudited.Before(now.Add(-interval))
+}
+
+// Behind returns whether the node was audited less than once per interval on
+// average. Nodes storing only a few segments are rarely audited along with the
+// segments of other nodes, so they fall behind.
+func (audits NodeAudits) Behind(now time.Time, interval time.Duration) bool {
+	if audits.FirstAudited == nil || now.Sub(*audits.FirstAudited) < interval {
+		// too early to tell
+		return false
+	}
+	perDay := float64(24*time.Hour) / float64(interval)
+	return audits.AuditsPerDay(now) < perDay
+}
+
+// priority returns the scheduling priority of the node, lower is audited first.
+//
+// Unvetted and suspended nodes are prioritized, since there's a pending
+// decision about them, which depends on audits.
+func (audits NodeAudits) priority(now time.Time, interval time.Duration) int {
+	pending := !audits.Vetted || audits.Suspended
+	overdue := audits.Overdue(now, interval) || audits.Behind(now, interval)
+	switch {
+	case overdue && pending:
+		return 0
+	case overdue:
+		return 1
+	case pending:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// normalPriority is the priority of nodes, which don't need to be audited before the others.
+const normalPriority = 3
+
+// Schedule orders nodes for auditing.
+type Schedule struct {
+	now      time.Time
+	interval time.Duration
+	audits   map[storj.NodeID]NodeAudits
+}
+
+// NewSchedule creates a schedule, which prioritizes nodes that weren't audited
+// within the interval, were audited less than once per interval on average,
+// weren't audited yet, aren't vetted or are suspended. Prioritized nodes are
+// audited first and get more reservoir slots, so that they are audited more
+// often than the other nodes.
+//
+// Every node storing pieces is audited at least once per chore cycle, which is
+// at least once per interval, as long as the chore interval isn't longer and the
+// workers get through the audit queue of a cycle within the interval.
+func NewSchedule(audits []NodeAudits, interval time.Duration, now time.Time) *Schedule {
+	schedule := &Schedule{
+		now:      now,
+		interval: interval,
+		audits:   make(map[storj.NodeID]NodeAudits, len(audits)),
+	}
+	for _, info := range audits {
+		schedule.audits[info.NodeID] = info
+	}
+	return schedule
+}
+
+// Priority returns the scheduling priority of the node, lower is audited first.
+// Nodes unknown to the schedule are treated as new nodes, which weren't audited yet.
+func (schedule *Schedule) Priority(nodeID storj.NodeID) int {
+	info, ok := schedule.audits[nodeID]
+	if !ok {
+		info = NodeAudits{NodeID: nodeID}
+	}
+	return info.priority(schedule.now, schedule.interval)
+}
+
+// Slots returns the number of reservoir slots allotted for the node, which is
+// prioritizedSlots for prioritized nodes and slots for the other nodes.
+func (schedule *Schedule) Slots(nodeID storj.NodeID, slots, prioritizedSlots int) int {
+	if schedule.Priority(nodeID) < normalPriority && prioritizedSlots > slots {
+		return prioritizedSlots
+	}
+	return slots
+}
+
+// Sort sorts the nodes by their priority and the time they were last audited.
+func (schedule *Schedule) Sort(nodeIDs storj.NodeIDList) {
+	lastAudited := func(nodeID storj.NodeID) time.Time {
+		if last := schedule.audits[nodeID].LastAudited; last != nil {
+			return *last
+		}
+		return time.Time{}
+	}
+
+	sort.SliceStable(nodeIDs, func(i, k int) bool {
+		pi, pk := schedule.Priority(nodeIDs[i]), schedule.Priority(nodeIDs[k])
+		if pi != pk {
+			return pi < pk
+		}
+		return lastAudited(nodeIDs[i]).Before(lastAudited(nodeIDs[k]))
+	})
+}
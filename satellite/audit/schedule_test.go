@@ -0,0 +1,122 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/internalpb"
+)
+
+func TestScheduleSort(t *testing.T) {
+	now := time.Now()
+	interval := 24 * time.Hour
+	recent := now.Add(-time.Hour)
+	old := now.Add(-48 * time.Hour)
+	older := now.Add(-72 * time.Hour)
+
+	recentVetted := audit.NodeAudits{NodeID: testrand.NodeID(), LastAudited: &recent, Vetted: true}
+	recentUnvetted := audit.NodeAudits{NodeID: testrand.NodeID(), LastAudited: &recent}
+	recentSuspended := audit.NodeAudits{NodeID: testrand.NodeID(), LastAudited: &recent, Vetted: true, Suspended: true}
+	overdueVetted := audit.NodeAudits{NodeID: testrand.NodeID(), LastAudited: &old, Vetted: true}
+	moreOverdueVetted := audit.NodeAudits{NodeID: testrand.NodeID(), LastAudited: &older, Vetted: true}
+	overdueUnvetted := audit.NodeAudits{NodeID: testrand.NodeID(), LastAudited: &old}
+	neverAudited := audit.NodeAudits{NodeID: testrand.NodeID()}
+
+	schedule := audit.NewSchedule([]audit.NodeAudits{
+		recentVetted, recentUnvetted, recentSuspended, overdueVetted, moreOverdueVetted, overdueUnvetted, neverAudited,
+	}, interval, now)
+
+	unknown := testrand.NodeID()
+	nodeIDs := storj.NodeIDList{
+		recentVetted.NodeID, overdueVetted.NodeID, recentSuspended.NodeID, moreOverdueVetted.NodeID,
+		recentUnvetted.NodeID, overdueUnvetted.NodeID, neverAudited.NodeID, unknown,
+	}
+	schedule.Sort(nodeIDs)
+
+	require.ElementsMatch(t, storj.NodeIDList{neverAudited.NodeID, unknown}, nodeIDs[:2])
+	require.Equal(t, storj.NodeIDList{
+		overdueUnvetted.NodeID,
+		moreOverdueVetted.NodeID,
+		overdueVetted.NodeID,
+	}, nodeIDs[2:5])
+	require.ElementsMatch(t, storj.NodeIDList{recentUnvetted.NodeID, recentSuspended.NodeID}, nodeIDs[5:7])
+	require.Equal(t, recentVetted.NodeID, nodeIDs[7])
+
+	require.False(t, recentVetted.Overdue(now, interval))
+	require.True(t, overdueVetted.Overdue(now, interval))
+	require.True(t, neverAudited.Overdue(now, interval))
+}
+
+func TestNodeAuditsAuditsPerDay(t *testing.T) {
+	now := time.Now()
+	firstAudited := now.Add(-10 * 24 * time.Hour)
+
+	require.Zero(t, audit.NodeAudits{}.AuditsPerDay(now))
+	require.InDelta(t, 2.5, audit.NodeAudits{FirstAudited: &firstAudited, AuditCount: 25}.AuditsPerDay(now), 0.01)
+
+	// rates of recently audited nodes are calculated over at least one day.
+	justAudited := now.Add(-time.Minute)
+	require.InDelta(t, 3, audit.NodeAudits{FirstAudited: &justAudited, AuditCount: 3}.AuditsPerDay(now), 0.01)
+
+	// nodes audited less than once per interval on average are behind.
+	interval := 24 * time.Hour
+	require.False(t, audit.NodeAudits{FirstAudited: &firstAudited, AuditCount: 25}.Behind(now, interval))
+	require.True(t, audit.NodeAudits{FirstAudited: &firstAudited, AuditCount: 5}.Behind(now, interval))
+	require.False(t, audit.NodeAudits{FirstAudited: &justAudited, AuditCount: 0}.Behind(now, interval))
+}
+
+func TestRecordAuditTimes(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 2, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		audits := satellite.Audit
+		audits.Worker.Loop.Pause()
+		audits.Chore.Loop.Pause()
+
+		audited := planet.StorageNodes[0].ID()
+		offline := planet.StorageNodes[1].ID()
+
+		report := audit.Report{Successes: storj.NodeIDList{audited}, Offlines: storj.NodeIDList{offline}}
+		_, err := audits.Reporter.RecordAudits(ctx, report, "")
+		require.NoError(t, err)
+		_, err = audits.Reporter.RecordAudits(ctx, report, "")
+		require.NoError(t, err)
+
+		inspector := audit.NewInspector(satellite.DB.NodeAudits(), satellite.Config.Audit.NodeAuditInterval)
+		response, err := inspector.NodeAudits(ctx, &internalpb.NodeAuditsRequest{})
+		require.NoError(t, err)
+		require.Len(t, response.Nodes, 2)
+
+		for _, node := range response.Nodes {
+			switch node.NodeId {
+			case audited:
+				require.EqualValues(t, 2, node.AuditCount)
+				require.NotZero(t, node.LastAudited)
+				require.False(t, node.Overdue)
+			case offline:
+				// offline nodes weren't audited
+				require.Zero(t, node.AuditCount)
+				require.Zero(t, node.LastAudited)
+				require.True(t, node.Overdue)
+			default:
+				t.Fatalf("unexpected node %v", node.NodeId)
+			}
+		}
+
+		response, err = inspector.NodeAudits(ctx, &internalpb.NodeAuditsRequest{NodeIds: []storj.NodeID{audited}})
+		require.NoError(t, err)
+		require.Len(t, response.Nodes, 1)
+		require.Equal(t, audited, response.Nodes[0].NodeId)
+	})
+}
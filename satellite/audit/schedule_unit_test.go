@@ -0,0 +1,158 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// TestScheduleAuditFrequency simulates chore cycles, where the workers only get
+// through a part of the audit queue within the interval, and measures how often
+// every node is audited.
+func TestScheduleAuditFrequency(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	const (
+		largeNodes  = 40
+		segments    = 2000
+		piecesCount = 10
+		// number of queued segments audited per interval
+		budget = 30
+		cycles = 20
+
+		slots            = 1
+		prioritizedSlots = 5
+	)
+	interval := 24 * time.Hour
+
+	r := rand.New(rand.NewSource(1))
+
+	var large storj.NodeIDList
+	for i := 0; i < largeNodes; i++ {
+		large = append(large, testrand.NodeID())
+	}
+	// stores only a few segments, so it's rarely audited along with the other nodes
+	small := testrand.NodeID()
+	// joins halfway through the simulation
+	joining := testrand.NodeID()
+
+	projectID := testrand.UUID()
+	newSegment := func(index int, nodeIDs storj.NodeIDList) *metainfo.Segment {
+		segment := &metainfo.Segment{
+			Location: metabase.SegmentLocation{ProjectID: projectID, BucketName: "bucket", ObjectKey: metabase.ObjectKey(fmt.Sprint(index))},
+		}
+		for number, nodeID := range nodeIDs {
+			segment.Pieces = append(segment.Pieces, metabase.Piece{Number: uint16(number), StorageNode: nodeID})
+		}
+		return segment
+	}
+	randomNodes := func(n int) storj.NodeIDList {
+		var nodeIDs storj.NodeIDList
+		for _, k := range r.Perm(len(large))[:n] {
+			nodeIDs = append(nodeIDs, large[k])
+		}
+		return nodeIDs
+	}
+
+	var stored []*metainfo.Segment
+	for i := 0; i < segments; i++ {
+		nodeIDs := randomNodes(piecesCount)
+		if i%1000 == 0 {
+			nodeIDs[0] = small
+		}
+		stored = append(stored, newSegment(i, nodeIDs))
+	}
+	var joined []*metainfo.Segment
+	for i := 0; i < 50; i++ {
+		nodeIDs := randomNodes(piecesCount)
+		nodeIDs[0] = joining
+		joined = append(joined, newSegment(segments+i, nodeIDs))
+	}
+
+	now := time.Now()
+	firstAudited, lastAudited := now.Add(-30*interval), now.Add(-time.Hour)
+	audits := make(map[storj.NodeID]*NodeAudits)
+	for _, nodeID := range append(large, small) {
+		audits[nodeID] = &NodeAudits{NodeID: nodeID, FirstAudited: &firstAudited, LastAudited: &lastAudited, AuditCount: 30, Vetted: true}
+	}
+
+	perCycle := make(map[storj.NodeID][]int)
+	for cycle := 0; cycle < cycles; cycle++ {
+		if cycle == cycles/2 {
+			stored = append(stored, joined...)
+		}
+
+		var all []NodeAudits
+		for _, info := range audits {
+			all = append(all, *info)
+		}
+		schedule := NewSchedule(all, interval, now)
+
+		collector := NewScheduledPathCollector(func(nodeID storj.NodeID) int {
+			return schedule.Slots(nodeID, slots, prioritizedSlots)
+		}, r)
+		paths := make(map[storj.Path]*metainfo.Segment)
+		for _, segment := range stored {
+			require.NoError(t, collector.RemoteSegment(ctx, segment))
+			paths[string(segment.Location.Encode())] = segment
+		}
+
+		queue := newAuditQueue(collector.Reservoirs, schedule)
+		if len(queue) > budget {
+			queue = queue[:budget]
+		}
+
+		counts := make(map[storj.NodeID]int)
+		auditedAt := now.Add(time.Hour)
+		for _, path := range queue {
+			for _, piece := range paths[path].Pieces {
+				counts[piece.StorageNode]++
+
+				info, ok := audits[piece.StorageNode]
+				if !ok {
+					// new nodes are vetted by their audits
+					info = &NodeAudits{NodeID: piece.StorageNode}
+					audits[piece.StorageNode] = info
+				}
+				if info.FirstAudited == nil {
+					info.FirstAudited = &auditedAt
+				}
+				info.LastAudited = &auditedAt
+				info.AuditCount++
+			}
+		}
+		for nodeID := range collector.Reservoirs {
+			perCycle[nodeID] = append(perCycle[nodeID], counts[nodeID])
+		}
+
+		now = now.Add(interval)
+	}
+
+	require.Len(t, perCycle[small], cycles)
+	require.Len(t, perCycle[joining], cycles/2)
+
+	// the joining node wasn't audited yet, so its whole reservoir is audited first.
+	require.GreaterOrEqual(t, perCycle[joining][0], prioritizedSlots)
+
+	for nodeID, counts := range perCycle {
+		for cycle, count := range counts {
+			// a node, which wasn't audited in a cycle, is overdue and audited first in the next one.
+			if cycle > 0 {
+				require.NotZero(t, counts[cycle-1]+count, "node %v wasn't audited in cycles %d and %d", nodeID, cycle-1, cycle)
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ package audit
 import (
 	"context"
 	"math/rand"
+	"strconv"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"storj.io/common/pkcrypto"
 	"storj.io/common/storj"
 	"storj.io/common/testrand"
+	"storj.io/storj/satellite/overlay"
 )
 
 func TestFailingAudit(t *testing.T) {
@@ -155,3 +157,44 @@ func TestCreatePendingAudits(t *testing.T) {
 	assert.Equal(t, pkcrypto.SHA256Hash(shares[1].Data), pending[0].ExpectedShareHash)
 	assert.EqualValues(t, 0, pending[0].ReverifyCount)
 }
+
+func TestSelectChallengeTargets(t *testing.T) {
+	pointer := &pb.Pointer{
+		Remote: &pb.RemoteSegment{
+			RootPieceId: testrand.PieceID(),
+		},
+	}
+	nodes := map[storj.NodeID]*overlay.SelectedNode{}
+	for i := 0; i < 8; i++ {
+		nodeID := testrand.NodeID()
+		pointer.Remote.RemotePieces = append(pointer.Remote.RemotePieces, &pb.RemotePiece{
+			PieceNum: int32(i),
+			NodeId:   nodeID,
+		})
+		// the last node is offline
+		if i < 7 {
+			nodes[nodeID] = &overlay.SelectedNode{
+				ID:      nodeID,
+				Address: &pb.NodeAddress{Address: "127.0.0.1:" + strconv.Itoa(10000+i)},
+			}
+		}
+	}
+	skipped := pointer.Remote.RemotePieces[0].NodeId
+	skip := map[storj.NodeID]bool{skipped: true}
+
+	targets := selectChallengeTargets(pointer, nodes, skip, 4)
+
+	// 6 online nodes, which aren't skipped, of which 4 are sources
+	require.Len(t, targets, 2)
+	for pieceNum, target := range targets {
+		piece := pointer.Remote.RemotePieces[pieceNum]
+		require.Equal(t, piece.NodeId, target.NodeID)
+		require.NotEqual(t, skipped, target.NodeID)
+		require.Contains(t, nodes, target.NodeID)
+		require.Equal(t, nodes[target.NodeID].Address.Address, target.Address)
+		require.Equal(t, pointer.Remote.RootPieceId.Derive(piece.NodeId, piece.PieceNum), target.PieceID)
+	}
+
+	// there aren't nodes left to challenge
+	require.Empty(t, selectChallengeTargets(pointer, nodes, skip, 6))
+}
@@ -5,6 +5,7 @@ package audit
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -27,8 +28,14 @@ type Config struct {
 
 	ChoreInterval     time.Duration `help:"how often to run the reservoir chore" releaseDefault:"24h" devDefault:"1m"`
 	QueueInterval     time.Duration `help:"how often to recheck an empty audit queue" releaseDefault:"1h" devDefault:"1m"`
-	Slots             int           `help:"number of reservoir slots allotted for nodes, currently capped at 3" default:"3"`
+	Slots             int           `help:"number of reservoir slots allotted for nodes, currently capped at 10" default:"3"`
+	PrioritizedSlots  int           `help:"number of reservoir slots allotted for new, overdue, unvetted and suspended nodes and nodes audited less than once per node audit interval, currently capped at 10" default:"10"`
 	WorkerConcurrency int           `help:"number of workers to run audits on paths" default:"2"`
+
+	NodeAuditInterval time.Duration `help:"nodes, which weren't audited within the interval or less than once per interval on average, are audited before and more often than the other nodes" releaseDefault:"24h" devDefault:"1m"`
+
+	ChallengeRatio   float64 `help:"fraction of audits, which challenge nodes to hash whole blocks of their pieces instead of downloading a single stripe" default:"0"`
+	ChallengeStripes int     `help:"maximum number of consecutive stripes covered by a hash challenge" default:"32"`
 }
 
 // Worker contains information for populating audit queue and processing audits.
@@ -39,6 +46,9 @@ type Worker struct {
 	reporter *Reporter
 	Loop     *sync2.Cycle
 	limiter  *sync2.Limiter
+
+	challengeRatio   float64
+	challengeStripes int
 }
 
 // NewWorker instantiates Worker.
@@ -51,6 +61,9 @@ func NewWorker(log *zap.Logger, queues *Queues, verifier *Verifier, reporter *Re
 		reporter: reporter,
 		Loop:     sync2.NewCycle(config.QueueInterval),
 		limiter:  sync2.NewLimiter(config.WorkerConcurrency),
+
+		challengeRatio:   config.ChallengeRatio,
+		challengeStripes: config.ChallengeStripes,
 	}, nil
 }
 
@@ -144,7 +157,11 @@ func (worker *Worker) work(ctx context.Context, path storj.Path) (err error) {
 	}
 
 	// Next, audit the the remaining nodes that are not in containment mode.
-	report, err = worker.verifier.Verify(ctx, path, skip)
+	if worker.challengeRatio > 0 && rand.Float64() < worker.challengeRatio {
+		report, err = worker.verifier.ChallengePieces(ctx, path, skip, worker.challengeStripes)
+	} else {
+		report, err = worker.verifier.Verify(ctx, path, skip)
+	}
 	if err != nil {
 		errlist.Add(err)
 	}
@@ -7,6 +7,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/zeebo/errs"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/pb"
 	"storj.io/common/uuid"
 )
 
@@ -28,8 +32,18 @@ type APIKeys interface {
 	Update(ctx context.Context, key APIKeyInfo) error
 	// Delete deletes APIKeyInfo from store
 	Delete(ctx context.Context, id uuid.UUID) error
+	// UpdateUsage adds the usage to the usage of the api keys
+	UpdateUsage(ctx context.Context, usage map[uuid.UUID]APIKeyUsage) error
+	// StartUsageTracking records now as the start of the usage tracking, unless a start was recorded before, and returns the start
+	StartUsageTracking(ctx context.Context, now time.Time) (time.Time, error)
+	// GetUnused returns api keys, which were created before the given time and weren't used since.
+	// It fails with ErrUsageNotTracked, when the usage wasn't tracked since the given time.
+	GetUnused(ctx context.Context, before time.Time, limit int) ([]APIKeyInfo, error)
 }
 
+// ErrUsageNotTracked is error type of api key usage, which isn't known for the requested period.
+var ErrUsageNotTracked = errs.Class("api key usage not tracked")
+
 // APIKeyInfo describing api key model in the database.
 type APIKeyInfo struct {
 	ID        uuid.UUID `json:"id"`
@@ -38,6 +52,101 @@ type APIKeyInfo struct {
 	Name      string    `json:"name"`
 	Secret    []byte    `json:"-"`
 	CreatedAt time.Time `json:"createdAt"`
+	// Restrictions is nil for keys, which can access the whole project.
+	Restrictions *APIKeyRestrictions `json:"restrictions,omitempty"`
+	// LastUsedAt is nil for keys, which were never used.
+	LastUsedAt   *time.Time `json:"lastUsedAt"`
+	RequestCount int64      `json:"requestCount"`
+}
+
+// APIKeyRestrictions describes the caveat, which restricts the access of an api key.
+type APIKeyRestrictions struct {
+	DisallowReads   bool `json:"disallowReads"`
+	DisallowWrites  bool `json:"disallowWrites"`
+	DisallowLists   bool `json:"disallowLists"`
+	DisallowDeletes bool `json:"disallowDeletes"`
+	// AllowedPaths limits the access to the buckets, when not empty.
+	AllowedPaths []APIKeyPath `json:"allowedPaths"`
+	NotBefore    *time.Time   `json:"notBefore"`
+	NotAfter     *time.Time   `json:"notAfter"`
+}
+
+// APIKeyPath is a bucket or a prefix within a bucket an api key is allowed to access.
+type APIKeyPath struct {
+	Bucket string `json:"bucket"`
+	// EncryptedPrefix is compared with the encrypted object key. The satellite
+	// doesn't have the encryption key of the project, so the client encrypts
+	// the prefix and the satellite stores it as is.
+	EncryptedPrefix []byte `json:"encryptedPrefix,omitempty"`
+}
+
+// Caveat converts the restrictions to a macaroon caveat with a random nonce.
+func (restrictions *APIKeyRestrictions) Caveat() (macaroon.Caveat, error) {
+	caveat, err := macaroon.NewCaveat()
+	if err != nil {
+		return macaroon.Caveat{}, err
+	}
+
+	caveat.DisallowReads = restrictions.DisallowReads
+	caveat.DisallowWrites = restrictions.DisallowWrites
+	caveat.DisallowLists = restrictions.DisallowLists
+	caveat.DisallowDeletes = restrictions.DisallowDeletes
+	for _, path := range restrictions.AllowedPaths {
+		caveat.AllowedPaths = append(caveat.AllowedPaths, &macaroon.Caveat_Path{
+			Bucket:              []byte(path.Bucket),
+			EncryptedPathPrefix: path.EncryptedPrefix,
+		})
+	}
+	caveat.NotBefore = restrictions.NotBefore
+	caveat.NotAfter = restrictions.NotAfter
+
+	return caveat, nil
+}
+
+// MarshalAPIKeyRestrictions encodes the restrictions as a macaroon caveat.
+// Nil restrictions are encoded as nil.
+func MarshalAPIKeyRestrictions(restrictions *APIKeyRestrictions) ([]byte, error) {
+	if restrictions == nil {
+		return nil, nil
+	}
+
+	caveat, err := restrictions.Caveat()
+	if err != nil {
+		return nil, err
+	}
+	// the nonce only matters for the key itself.
+	caveat.Nonce = nil
+
+	return pb.Marshal(&caveat)
+}
+
+// UnmarshalAPIKeyRestrictions decodes the restrictions from a macaroon caveat.
+func UnmarshalAPIKeyRestrictions(data []byte) (*APIKeyRestrictions, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var caveat macaroon.Caveat
+	if err := pb.Unmarshal(data, &caveat); err != nil {
+		return nil, err
+	}
+
+	restrictions := &APIKeyRestrictions{
+		DisallowReads:   caveat.DisallowReads,
+		DisallowWrites:  caveat.DisallowWrites,
+		DisallowLists:   caveat.DisallowLists,
+		DisallowDeletes: caveat.DisallowDeletes,
+		NotBefore:       caveat.NotBefore,
+		NotAfter:        caveat.NotAfter,
+	}
+	for _, path := range caveat.AllowedPaths {
+		restrictions.AllowedPaths = append(restrictions.AllowedPaths, APIKeyPath{
+			Bucket:          string(path.Bucket),
+			EncryptedPrefix: path.EncryptedPathPrefix,
+		})
+	}
+
+	return restrictions, nil
 }
 
 // APIKeyCursor holds info for api keys cursor pagination.
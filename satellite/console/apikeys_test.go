@@ -6,11 +6,15 @@ package console_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"storj.io/common/macaroon"
 	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/satellitedb/satellitedbtest"
@@ -156,5 +160,176 @@ func TestApiKeysRepository(t *testing.T) {
 			assert.Error(t, err)
 		})
 
+		t.Run("Restrictions are stored", func(t *testing.T) {
+			notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+			restrictions := &console.APIKeyRestrictions{
+				DisallowWrites:  true,
+				DisallowDeletes: true,
+				AllowedPaths:    []console.APIKeyPath{{Bucket: "bucket"}},
+				NotAfter:        &notAfter,
+			}
+
+			key, err := macaroon.NewAPIKey([]byte("testSecret"))
+			require.NoError(t, err)
+
+			created, err := apikeys.Create(ctx, key.Head(), console.APIKeyInfo{
+				Name:         "restricted key",
+				ProjectID:    project.ID,
+				Secret:       []byte("testSecret"),
+				Restrictions: restrictions,
+			})
+			require.NoError(t, err)
+			require.Equal(t, restrictions, created.Restrictions)
+
+			info, err := apikeys.GetByHead(ctx, key.Head())
+			require.NoError(t, err)
+			require.Equal(t, restrictions, info.Restrictions)
+
+			page, err := apikeys.GetPagedByProjectID(ctx, project.ID, console.APIKeyCursor{
+				Page:   1,
+				Limit:  10,
+				Search: "restricted",
+			})
+			require.NoError(t, err)
+			require.Len(t, page.APIKeys, 1)
+			require.Equal(t, restrictions, page.APIKeys[0].Restrictions)
+		})
+
+		t.Run("Usage is stored", func(t *testing.T) {
+			key, err := macaroon.NewAPIKey([]byte("testSecret"))
+			require.NoError(t, err)
+
+			used, err := apikeys.Create(ctx, key.Head(), console.APIKeyInfo{
+				Name:      "used key",
+				ProjectID: project.ID,
+				Secret:    []byte("testSecret"),
+			})
+			require.NoError(t, err)
+			require.Nil(t, used.LastUsedAt)
+			require.Zero(t, used.RequestCount)
+
+			// the key is used in the future, so it's used after every cutoff before that
+			usedAt := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+			err = apikeys.UpdateUsage(ctx, map[uuid.UUID]console.APIKeyUsage{
+				used.ID:         {LastUsedAt: usedAt, Requests: 3},
+				testrand.UUID(): {LastUsedAt: usedAt, Requests: 1},
+			})
+			require.NoError(t, err)
+
+			// older usage doesn't move the last used time back
+			err = apikeys.UpdateUsage(ctx, map[uuid.UUID]console.APIKeyUsage{
+				used.ID: {LastUsedAt: usedAt.Add(-time.Hour), Requests: 2},
+			})
+			require.NoError(t, err)
+
+			info, err := apikeys.Get(ctx, used.ID)
+			require.NoError(t, err)
+			require.NotNil(t, info.LastUsedAt)
+			require.True(t, usedAt.Equal(*info.LastUsedAt))
+			require.EqualValues(t, 5, info.RequestCount)
+
+			page, err := apikeys.GetPagedByProjectID(ctx, project.ID, console.APIKeyCursor{
+				Page:   1,
+				Limit:  10,
+				Search: "used key",
+			})
+			require.NoError(t, err)
+			require.Len(t, page.APIKeys, 1)
+			require.EqualValues(t, 5, page.APIKeys[0].RequestCount)
+
+			// keys without usage can't be unused, until the usage is tracked
+			_, err = apikeys.GetUnused(ctx, time.Now().Add(time.Hour), 100)
+			require.True(t, console.ErrUsageNotTracked.Has(err))
+
+			start := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+			recorded, err := apikeys.StartUsageTracking(ctx, start)
+			require.NoError(t, err)
+			require.True(t, start.Equal(recorded))
+
+			// the first start is kept
+			recorded, err = apikeys.StartUsageTracking(ctx, time.Now())
+			require.NoError(t, err)
+			require.True(t, start.Equal(recorded))
+
+			_, err = apikeys.GetUnused(ctx, start.Add(-time.Minute), 100)
+			require.True(t, console.ErrUsageNotTracked.Has(err))
+
+			// keys, which weren't used since the cutoff, are unused
+			unused, err := apikeys.GetUnused(ctx, time.Now().Add(time.Hour), 100)
+			require.NoError(t, err)
+			require.NotEmpty(t, unused)
+			for _, info := range unused {
+				require.NotEqual(t, used.ID, info.ID)
+			}
+
+			// keys used before the cutoff are unused
+			unused, err = apikeys.GetUnused(ctx, usedAt.Add(time.Second), 100)
+			require.NoError(t, err)
+			ids := make(map[uuid.UUID]bool)
+			for _, info := range unused {
+				ids[info.ID] = true
+			}
+			require.True(t, ids[used.ID])
+
+			// keys created after the cutoff are never unused
+			unused, err = apikeys.GetUnused(ctx, time.Now().Add(-time.Hour), 100)
+			require.NoError(t, err)
+			require.Empty(t, unused)
+		})
 	})
 }
+
+func TestAPIKeyRestrictions(t *testing.T) {
+	now := time.Now()
+	notBefore := now.Add(-time.Hour).UTC()
+	notAfter := now.Add(time.Hour).UTC()
+
+	restrictions := &console.APIKeyRestrictions{
+		DisallowLists: true,
+		AllowedPaths: []console.APIKeyPath{
+			{Bucket: "bucket1"},
+			{Bucket: "bucket2"},
+			{Bucket: "bucket3", EncryptedPrefix: []byte("encrypted-prefix")},
+		},
+		NotBefore: &notBefore,
+		NotAfter:  &notAfter,
+	}
+	require.NoError(t, console.ValidateAPIKeyRestrictions(restrictions, now))
+
+	data, err := console.MarshalAPIKeyRestrictions(restrictions)
+	require.NoError(t, err)
+
+	decoded, err := console.UnmarshalAPIKeyRestrictions(data)
+	require.NoError(t, err)
+	require.Equal(t, restrictions, decoded)
+
+	// unrestricted keys don't store a caveat
+	data, err = console.MarshalAPIKeyRestrictions(nil)
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	decoded, err = console.UnmarshalAPIKeyRestrictions(nil)
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+
+	// the caveat of the key enforces the restrictions
+	caveat, err := restrictions.Caveat()
+	require.NoError(t, err)
+	require.True(t, caveat.Allows(macaroon.Action{Op: macaroon.ActionRead, Bucket: []byte("bucket2"), EncryptedPath: []byte("prefix/object"), Time: now}))
+	require.True(t, caveat.Allows(macaroon.Action{Op: macaroon.ActionRead, Bucket: []byte("bucket3"), EncryptedPath: []byte("encrypted-prefix/object"), Time: now}))
+	require.False(t, caveat.Allows(macaroon.Action{Op: macaroon.ActionRead, Bucket: []byte("bucket3"), EncryptedPath: []byte("object"), Time: now}))
+	require.False(t, caveat.Allows(macaroon.Action{Op: macaroon.ActionRead, Bucket: []byte("bucket4"), EncryptedPath: []byte("object"), Time: now}))
+	require.False(t, caveat.Allows(macaroon.Action{Op: macaroon.ActionList, Bucket: []byte("bucket1"), Time: now}))
+	require.False(t, caveat.Allows(macaroon.Action{Op: macaroon.ActionRead, Bucket: []byte("bucket1"), EncryptedPath: []byte("object"), Time: now.Add(2 * time.Hour)}))
+
+	invalid := []*console.APIKeyRestrictions{
+		{DisallowReads: true, DisallowWrites: true, DisallowLists: true, DisallowDeletes: true},
+		{AllowedPaths: []console.APIKeyPath{{EncryptedPrefix: []byte("encrypted-prefix")}}},
+		{NotAfter: &notBefore},
+		{NotBefore: &notAfter, NotAfter: &notAfter},
+	}
+	for _, restrictions := range invalid {
+		err := console.ValidateAPIKeyRestrictions(restrictions, now)
+		require.True(t, console.ErrValidation.Has(err), "%+v", restrictions)
+	}
+}
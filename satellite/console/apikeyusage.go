@@ -0,0 +1,168 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/common/uuid"
+)
+
+// APIKeyUsageConfig contains configurable values for api key usage tracking.
+type APIKeyUsageConfig struct {
+	FlushInterval time.Duration `help:"how often the api key usage is written to the database" releaseDefault:"5m" devDefault:"10s"`
+	BatchSize     int           `help:"number of api keys, which usage is kept in memory, before it's written to the database (0 writes only on the interval)" default:"1000"`
+}
+
+// APIKeyUsage contains the last time an api key was used and the number of requests made with it.
+type APIKeyUsage struct {
+	LastUsedAt time.Time
+	Requests   int64
+}
+
+// APIKeyUsageCache collects the usage of api keys in memory and writes it to
+// the database in batches, so requests don't cause database writes.
+//
+// architecture: Service
+type APIKeyUsageCache struct {
+	log       *zap.Logger
+	db        APIKeys
+	batchSize int
+	Loop      *sync2.Cycle
+
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	pending  map[uuid.UUID]APIKeyUsage
+	flushing bool
+	stopped  bool
+
+	trackingStarted bool
+}
+
+// NewAPIKeyUsageCache creates a new api key usage cache.
+func NewAPIKeyUsageCache(log *zap.Logger, db APIKeys, config APIKeyUsageConfig) *APIKeyUsageCache {
+	return &APIKeyUsageCache{
+		log:       log,
+		db:        db,
+		batchSize: config.BatchSize,
+		Loop:      sync2.NewCycle(config.FlushInterval),
+		pending:   make(map[uuid.UUID]APIKeyUsage),
+	}
+}
+
+// Run periodically flushes the usage to the database.
+func (cache *APIKeyUsageCache) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.Loop.Run(ctx, func(ctx context.Context) error {
+		// keys can only be considered unused, once it's known since when their usage is tracked.
+		if !cache.trackingStarted {
+			if _, err := cache.db.StartUsageTracking(ctx, time.Now()); err != nil {
+				cache.log.Warn("failed to record the start of api key usage tracking", zap.Error(err))
+			} else {
+				cache.trackingStarted = true
+			}
+		}
+
+		cache.Flush(ctx)
+		return nil
+	})
+}
+
+// Record adds a request made with the api key at usedAt.
+func (cache *APIKeyUsageCache) Record(ctx context.Context, keyID uuid.UUID, usedAt time.Time) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cache.stopped {
+		return
+	}
+
+	usage := cache.pending[keyID]
+	if usedAt.After(usage.LastUsedAt) {
+		usage.LastUsedAt = usedAt
+	}
+	usage.Requests++
+	cache.pending[keyID] = usage
+
+	// while a batch is written, the usage keeps being collected and is
+	// written by the same flusher afterwards.
+	if cache.flushing || !cache.batchFullLocked() {
+		return
+	}
+
+	pending := cache.pending
+	cache.pending = make(map[uuid.UUID]APIKeyUsage)
+
+	cache.flushing = true
+	cache.wg.Add(1)
+	go func() {
+		defer cache.wg.Done()
+		// the request context may be canceled before the flush finishes.
+		cache.flushBatches(context.Background(), pending)
+	}()
+}
+
+// flushBatches writes the batch to the database and then the batches, which
+// became full meanwhile.
+func (cache *APIKeyUsageCache) flushBatches(ctx context.Context, pending map[uuid.UUID]APIKeyUsage) {
+	for {
+		cache.flush(ctx, pending)
+
+		cache.mu.Lock()
+		if cache.stopped || !cache.batchFullLocked() {
+			cache.flushing = false
+			cache.mu.Unlock()
+			return
+		}
+		pending = cache.pending
+		cache.pending = make(map[uuid.UUID]APIKeyUsage)
+		cache.mu.Unlock()
+	}
+}
+
+// batchFullLocked returns whether the collected usage reached the batch size.
+func (cache *APIKeyUsageCache) batchFullLocked() bool {
+	return cache.batchSize > 0 && len(cache.pending) >= cache.batchSize
+}
+
+// Flush writes the collected usage to the database.
+func (cache *APIKeyUsageCache) Flush(ctx context.Context) {
+	defer mon.Task()(&ctx)(nil)
+
+	cache.mu.Lock()
+	pending := cache.pending
+	cache.pending = make(map[uuid.UUID]APIKeyUsage)
+	cache.mu.Unlock()
+
+	cache.flush(ctx, pending)
+}
+
+func (cache *APIKeyUsageCache) flush(ctx context.Context, pending map[uuid.UUID]APIKeyUsage) {
+	defer mon.Task()(&ctx)(nil)
+
+	if len(pending) == 0 {
+		return
+	}
+
+	if err := cache.db.UpdateUsage(ctx, pending); err != nil {
+		cache.log.Warn("failed to update api key usage", zap.Int("keys", len(pending)), zap.Error(err))
+	}
+}
+
+// Close stops collecting the usage and flushes the collected usage to the database.
+func (cache *APIKeyUsageCache) Close() error {
+	cache.mu.Lock()
+	cache.stopped = true
+	cache.mu.Unlock()
+
+	cache.Loop.Close()
+	cache.wg.Wait()
+	cache.Flush(context.Background())
+	return nil
+}
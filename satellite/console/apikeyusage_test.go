@@ -0,0 +1,167 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+// usageDB records the usage written by the api key usage cache.
+type usageDB struct {
+	console.APIKeys
+
+	mu      sync.Mutex
+	flushes []map[uuid.UUID]console.APIKeyUsage
+}
+
+func (db *usageDB) UpdateUsage(ctx context.Context, usage map[uuid.UUID]console.APIKeyUsage) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.flushes = append(db.flushes, usage)
+	return nil
+}
+
+func (db *usageDB) Flushes() []map[uuid.UUID]console.APIKeyUsage {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return append([]map[uuid.UUID]console.APIKeyUsage{}, db.flushes...)
+}
+
+func TestAPIKeyUsageCache(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := &usageDB{}
+	cache := console.NewAPIKeyUsageCache(zaptest.NewLogger(t), db, console.APIKeyUsageConfig{
+		FlushInterval: time.Hour,
+		BatchSize:     3,
+	})
+
+	now := time.Now()
+	key1, key2, key3 := testrand.UUID(), testrand.UUID(), testrand.UUID()
+
+	cache.Record(ctx, key1, now)
+	cache.Record(ctx, key1, now.Add(-time.Minute))
+	cache.Record(ctx, key2, now)
+
+	// nothing is written before a flush
+	require.Empty(t, db.Flushes())
+
+	cache.Flush(ctx)
+	flushes := db.Flushes()
+	require.Len(t, flushes, 1)
+	require.Equal(t, map[uuid.UUID]console.APIKeyUsage{
+		key1: {LastUsedAt: now, Requests: 2},
+		key2: {LastUsedAt: now, Requests: 1},
+	}, flushes[0])
+
+	// flushing without usage doesn't write anything
+	cache.Flush(ctx)
+	require.Len(t, db.Flushes(), 1)
+
+	// reaching the batch size flushes the usage
+	cache.Record(ctx, key1, now)
+	cache.Record(ctx, key2, now)
+	cache.Record(ctx, key3, now)
+
+	cache.Record(ctx, key1, now)
+	require.NoError(t, cache.Close())
+
+	flushes = db.Flushes()
+	require.Len(t, flushes, 3)
+	require.Len(t, flushes[1], 3)
+	require.Equal(t, map[uuid.UUID]console.APIKeyUsage{
+		key1: {LastUsedAt: now, Requests: 1},
+	}, flushes[2])
+
+	// usage isn't collected after close
+	cache.Record(ctx, key1, now)
+	cache.Flush(ctx)
+	require.Len(t, db.Flushes(), 3)
+}
+
+func TestAPIKeyUsageCacheWithoutBatchSize(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := &usageDB{}
+	cache := console.NewAPIKeyUsageCache(zaptest.NewLogger(t), db, console.APIKeyUsageConfig{
+		FlushInterval: time.Hour,
+	})
+
+	// the usage is only written on the interval
+	for i := 0; i < 10; i++ {
+		cache.Record(ctx, testrand.UUID(), time.Now())
+	}
+	require.Empty(t, db.Flushes())
+
+	require.NoError(t, cache.Close())
+	flushes := db.Flushes()
+	require.Len(t, flushes, 1)
+	require.Len(t, flushes[0], 10)
+}
+
+// blockingUsageDB blocks writing the usage until it's released.
+type blockingUsageDB struct {
+	usageDB
+
+	started chan struct{}
+	release chan struct{}
+}
+
+func (db *blockingUsageDB) UpdateUsage(ctx context.Context, usage map[uuid.UUID]console.APIKeyUsage) error {
+	db.started <- struct{}{}
+	<-db.release
+	return db.usageDB.UpdateUsage(ctx, usage)
+}
+
+func TestAPIKeyUsageCacheSingleFlusher(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	db := &blockingUsageDB{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	cache := console.NewAPIKeyUsageCache(zaptest.NewLogger(t), db, console.APIKeyUsageConfig{
+		FlushInterval: time.Hour,
+		BatchSize:     2,
+	})
+
+	cache.Record(ctx, testrand.UUID(), time.Now())
+	cache.Record(ctx, testrand.UUID(), time.Now())
+	<-db.started
+
+	// full batches don't start another flush, while the first one is written
+	for i := 0; i < 10; i++ {
+		cache.Record(ctx, testrand.UUID(), time.Now())
+	}
+	select {
+	case <-db.started:
+		t.Fatal("second flush started concurrently")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the same flusher writes the usage collected meanwhile
+	db.release <- struct{}{}
+	<-db.started
+	db.release <- struct{}{}
+
+	require.NoError(t, cache.Close())
+	flushes := db.Flushes()
+	require.Len(t, flushes, 2)
+	require.Len(t, flushes[0], 2)
+	require.Len(t, flushes[1], 10)
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package auditlogcleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"go.uber.org/zap"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/console"
+)
+
+var mon = monkit.Package()
+
+// Config is a configuration struct for the Chore.
+type Config struct {
+	Interval  time.Duration `help:"how often to remove expired audit log entries" default:"24h"`
+	Retention time.Duration `help:"how long audit log entries are retained, 0 keeps them forever" default:"8760h"`
+	BatchSize int           `help:"number of audit log entries to remove in a single query" default:"1000"`
+}
+
+// Chore to remove expired audit log entries.
+//
+// architecture: Chore
+type Chore struct {
+	log    *zap.Logger
+	db     console.AuditLogs
+	config Config
+
+	nowFn func() time.Time
+	Loop  *sync2.Cycle
+}
+
+// NewChore creates new chore for removing expired audit log entries.
+func NewChore(log *zap.Logger, db console.AuditLogs, config Config) *Chore {
+	return &Chore{
+		log:    log,
+		db:     db,
+		config: config,
+
+		nowFn: time.Now,
+		Loop:  sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run starts the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		err := chore.RunOnce(ctx)
+		if err != nil {
+			chore.log.Error("error removing expired audit log entries", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// RunOnce removes expired audit log entries.
+func (chore *Chore) RunOnce(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if chore.config.Retention <= 0 {
+		return nil
+	}
+
+	before := chore.nowFn().Add(-chore.config.Retention)
+
+	var total int64
+	for {
+		deleted, err := chore.db.DeleteBefore(ctx, before, chore.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		total += deleted
+		if deleted < int64(chore.config.BatchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		chore.log.Debug("removed expired audit log entries", zap.Int64("count", total), zap.Time("before", before))
+	}
+	return nil
+}
+
+// SetNow allows tests to have the chore act as if the current time is whatever they want.
+func (chore *Chore) SetNow(nowFn func() time.Time) {
+	chore.nowFn = nowFn
+}
+
+// Close stops the chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
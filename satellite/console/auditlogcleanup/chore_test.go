@@ -0,0 +1,58 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package auditlogcleanup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+)
+
+func TestAuditLogRetention(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.AuditLogCleanup.Retention = 24 * time.Hour
+				config.AuditLogCleanup.BatchSize = 2
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		auditLogs := planet.Satellites[0].DB.Console().AuditLogs()
+		chore := planet.Satellites[0].AuditLogCleanup.Chore
+		chore.Loop.Pause()
+
+		now := time.Now()
+		for i := 0; i < 5; i++ {
+			err := auditLogs.Insert(ctx, console.AuditLogEntry{
+				Actor:      console.AuditActorAdmin,
+				Operation:  "POST /api/project",
+				TargetType: console.AuditTargetProject,
+				Outcome:    console.AuditSuccess,
+				CreatedAt:  now.Add(-time.Duration(i) * 12 * time.Hour),
+			})
+			require.NoError(t, err)
+		}
+
+		// entries created more than a day ago are removed in several batches
+		require.NoError(t, chore.RunOnce(ctx))
+		entries, err := auditLogs.List(ctx, console.AuditLogFilter{})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		// a day later all entries are expired
+		chore.SetNow(func() time.Time { return now.Add(25 * time.Hour) })
+		require.NoError(t, chore.RunOnce(ctx))
+		entries, err = auditLogs.List(ctx, console.AuditLogFilter{})
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
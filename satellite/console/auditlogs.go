@@ -0,0 +1,119 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+)
+
+// AuditLogs exposes methods to persist and query the audit log of console and admin mutations.
+//
+// architecture: Database
+type AuditLogs interface {
+	// Insert persists an audit log entry.
+	Insert(ctx context.Context, entry AuditLogEntry) error
+	// List returns the entries matching the filter, newest first.
+	List(ctx context.Context, filter AuditLogFilter) ([]AuditLogEntry, error)
+	// DeleteBefore deletes up to limit entries created before the given time and returns the number of deleted entries.
+	DeleteBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// AuditTarget is the type of the object a mutation was applied to.
+type AuditTarget string
+
+const (
+	// AuditTargetUser is a user account.
+	AuditTargetUser AuditTarget = "user"
+	// AuditTargetProject is a project.
+	AuditTargetProject AuditTarget = "project"
+	// AuditTargetAPIKey is an api key.
+	AuditTargetAPIKey AuditTarget = "api_key"
+	// AuditTargetCoupon is a coupon.
+	AuditTargetCoupon AuditTarget = "coupon"
+	// AuditTargetPaymentAccount is the payment account of a user.
+	AuditTargetPaymentAccount AuditTarget = "payment_account"
+)
+
+// AuditOutcome is the outcome of an audited mutation.
+type AuditOutcome string
+
+const (
+	// AuditSuccess is the outcome of a successful mutation.
+	AuditSuccess AuditOutcome = "success"
+	// AuditFailure is the outcome of a failed mutation.
+	AuditFailure AuditOutcome = "failure"
+)
+
+// AuditActorAdmin is the actor of mutations made with the admin API.
+const AuditActorAdmin = "admin"
+
+// AuditLogEntry describes a single mutation made through the console or the admin API.
+type AuditLogEntry struct {
+	ID uuid.UUID `json:"id"`
+	// Actor is the email of the console user or AuditActorAdmin.
+	Actor     string `json:"actor"`
+	Operation string `json:"operation"`
+
+	TargetType AuditTarget `json:"targetType"`
+	TargetID   string      `json:"targetId,omitempty"`
+	// UserID is the user, who made the mutation in the console, or whose account was changed by an admin.
+	UserID    *uuid.UUID `json:"userId,omitempty"`
+	ProjectID *uuid.UUID `json:"projectId,omitempty"`
+
+	SourceIP     string `json:"sourceIp"`
+	ForwardedFor string `json:"forwardedFor,omitempty"`
+
+	Outcome AuditOutcome `json:"outcome"`
+	Error   string       `json:"error,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AuditLogFilter selects the audit log entries to list.
+type AuditLogFilter struct {
+	UserID    *uuid.UUID
+	ProjectID *uuid.UUID
+	// Since and Before limit the entries to the ones created in [Since, Before), when not zero.
+	Since  time.Time
+	Before time.Time
+	Limit  int
+}
+
+// auditMutation persists the outcome of a console mutation in the audit log.
+// It's meant to be deferred with the address of the mutation's error, so the outcome is known.
+func (s *Service) auditMutation(ctx context.Context, operation string, user *User, target AuditTarget, targetID string, projectID *uuid.UUID, errp *error) {
+	sourceIP, forwardedForIP := getRequestingIP(ctx)
+
+	entry := AuditLogEntry{
+		Operation:    operation,
+		TargetType:   target,
+		TargetID:     targetID,
+		ProjectID:    projectID,
+		SourceIP:     sourceIP,
+		ForwardedFor: forwardedForIP,
+		Outcome:      AuditSuccess,
+		CreatedAt:    time.Now(),
+	}
+	if user != nil {
+		entry.Actor = user.Email
+		if !user.ID.IsZero() {
+			userID := user.ID
+			entry.UserID = &userID
+		}
+	}
+	if errp != nil && *errp != nil {
+		entry.Outcome = AuditFailure
+		entry.Error = (*errp).Error()
+	}
+
+	// the mutation already happened, so failing to persist the entry must not fail it.
+	if err := s.store.AuditLogs().Insert(ctx, entry); err != nil {
+		s.log.Error("failed to persist audit log entry", zap.String("operation", operation), zap.Error(err))
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestAuditLogsRepository(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		auditLogs := db.Console().AuditLogs()
+
+		now := time.Now().UTC().Truncate(time.Second)
+		userID := testrand.UUID()
+		projectID := testrand.UUID()
+
+		entries := []console.AuditLogEntry{
+			{
+				Actor:      "user@mail.test",
+				Operation:  "create project",
+				TargetType: console.AuditTargetProject,
+				TargetID:   projectID.String(),
+				UserID:     &userID,
+				ProjectID:  &projectID,
+				SourceIP:   "127.0.0.1:1234",
+				Outcome:    console.AuditSuccess,
+				CreatedAt:  now.Add(-2 * time.Hour),
+			},
+			{
+				Actor:        console.AuditActorAdmin,
+				Operation:    "DELETE /api/project/{project}",
+				TargetType:   console.AuditTargetProject,
+				TargetID:     projectID.String(),
+				ProjectID:    &projectID,
+				SourceIP:     "127.0.0.1:1235",
+				ForwardedFor: "10.0.0.1",
+				Outcome:      console.AuditFailure,
+				Error:        "buckets still exist",
+				CreatedAt:    now.Add(-time.Hour),
+			},
+			{
+				Actor:      "user@mail.test",
+				Operation:  "change password",
+				TargetType: console.AuditTargetUser,
+				TargetID:   userID.String(),
+				UserID:     &userID,
+				SourceIP:   "127.0.0.1:1236",
+				Outcome:    console.AuditSuccess,
+				CreatedAt:  now,
+			},
+		}
+		for _, entry := range entries {
+			require.NoError(t, auditLogs.Insert(ctx, entry))
+		}
+
+		all, err := auditLogs.List(ctx, console.AuditLogFilter{})
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		for i, entry := range all {
+			// entries are listed newest first
+			expected := entries[len(entries)-1-i]
+			require.False(t, entry.ID.IsZero())
+			expected.ID = entry.ID
+			require.True(t, expected.CreatedAt.Equal(entry.CreatedAt))
+			expected.CreatedAt = entry.CreatedAt
+			require.Equal(t, expected, entry)
+		}
+
+		byUser, err := auditLogs.List(ctx, console.AuditLogFilter{UserID: &userID})
+		require.NoError(t, err)
+		require.Len(t, byUser, 2)
+		require.Equal(t, "change password", byUser[0].Operation)
+		require.Equal(t, "create project", byUser[1].Operation)
+
+		byProject, err := auditLogs.List(ctx, console.AuditLogFilter{ProjectID: &projectID})
+		require.NoError(t, err)
+		require.Len(t, byProject, 2)
+		require.Equal(t, "DELETE /api/project/{project}", byProject[0].Operation)
+
+		byTime, err := auditLogs.List(ctx, console.AuditLogFilter{
+			UserID: &userID,
+			Since:  now.Add(-3 * time.Hour),
+			Before: now,
+		})
+		require.NoError(t, err)
+		require.Len(t, byTime, 1)
+		require.Equal(t, "create project", byTime[0].Operation)
+
+		limited, err := auditLogs.List(ctx, console.AuditLogFilter{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, limited, 1)
+		require.Equal(t, "change password", limited[0].Operation)
+
+		deleted, err := auditLogs.DeleteBefore(ctx, now.Add(-30*time.Minute), 1)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		deleted, err = auditLogs.DeleteBefore(ctx, now.Add(-30*time.Minute), 10)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		all, err = auditLogs.List(ctx, console.AuditLogFilter{})
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		require.Equal(t, "change password", all[0].Operation)
+	})
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+var (
+	// ErrAPIKeysAPI - console api keys api error type.
+	ErrAPIKeysAPI = errs.Class("console api keys api error")
+)
+
+// APIKeys is an api controller that exposes api keys related functionality.
+type APIKeys struct {
+	log     *zap.Logger
+	service *console.Service
+}
+
+// NewAPIKeys is a constructor for api keys controller.
+func NewAPIKeys(log *zap.Logger, service *console.Service) *APIKeys {
+	return &APIKeys{
+		log:     log,
+		service: service,
+	}
+}
+
+// CreateAPIKeyRequest contains the parameters of a new api key.
+type CreateAPIKeyRequest struct {
+	ProjectID uuid.UUID `json:"projectId"`
+	Name      string    `json:"name"`
+	// Restrictions is nil for keys, which can access the whole project.
+	Restrictions *console.APIKeyRestrictions `json:"restrictions"`
+}
+
+// CreateAPIKeyResponse contains the serialized api key and its info.
+type CreateAPIKeyResponse struct {
+	Key     string              `json:"key"`
+	KeyInfo *console.APIKeyInfo `json:"keyInfo"`
+}
+
+// Create creates a new api key, which is restricted server-side when restrictions are provided.
+func (a *APIKeys) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request CreateAPIKeyRequest
+	if err = json.NewDecoder(r.Body).Decode(&request); err != nil {
+		a.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	info, key, err := a.service.CreateRestrictedAPIKey(ctx, request.ProjectID, request.Name, request.Restrictions)
+	if err != nil {
+		a.serveServiceError(w, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(CreateAPIKeyResponse{
+		Key:     key.Serialize(),
+		KeyInfo: info,
+	})
+	if err != nil {
+		a.log.Error("failed to write json create api key response", zap.Error(ErrAPIKeysAPI.Wrap(err)))
+	}
+}
+
+// List returns a page of the api keys of a project with their restrictions.
+func (a *APIKeys) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+
+	projectID, err := uuid.FromString(query.Get("projectID"))
+	if err != nil {
+		a.serveJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	cursor := console.APIKeyCursor{
+		Search:         query.Get("search"),
+		Limit:          10,
+		Page:           1,
+		Order:          console.KeyName,
+		OrderDirection: console.Ascending,
+	}
+	for name, value := range map[string]*uint{"limit": &cursor.Limit, "page": &cursor.Page} {
+		if query.Get(name) == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(query.Get(name), 10, 32)
+		if err != nil {
+			a.serveJSONError(w, http.StatusBadRequest, errs.New("invalid %s: %v", name, err))
+			return
+		}
+		*value = uint(parsed)
+	}
+	if query.Get("order") == "createdAt" {
+		cursor.Order = console.CreationDate
+	}
+	if query.Get("orderDirection") == "desc" {
+		cursor.OrderDirection = console.Descending
+	}
+
+	page, err := a.service.GetAPIKeys(ctx, projectID, cursor)
+	if err != nil {
+		a.serveServiceError(w, err)
+		return
+	}
+
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		a.log.Error("failed to write json api keys response", zap.Error(ErrAPIKeysAPI.Wrap(err)))
+	}
+}
+
+// serveServiceError writes an error returned by the console service.
+func (a *APIKeys) serveServiceError(w http.ResponseWriter, err error) {
+	switch {
+	case console.ErrUnauthorized.Has(err):
+		a.serveJSONError(w, http.StatusUnauthorized, err)
+	case console.ErrValidation.Has(err):
+		a.serveJSONError(w, http.StatusBadRequest, err)
+	default:
+		a.serveJSONError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (a *APIKeys) serveJSONError(w http.ResponseWriter, status int, err error) {
+	if status == http.StatusInternalServerError {
+		a.log.Error("returning error to client", zap.Int("code", status), zap.Error(err))
+	} else {
+		a.log.Debug("returning error to client", zap.Int("code", status), zap.Error(err))
+	}
+
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		a.log.Error("failed to write json error response", zap.Error(ErrAPIKeysAPI.Wrap(err)))
+	}
+}
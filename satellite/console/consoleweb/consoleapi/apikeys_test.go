@@ -0,0 +1,132 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/macaroon"
+	"storj.io/common/testcontext"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleweb/consoleapi"
+)
+
+func TestAPIKeys(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.OpenRegistrationEnabled = true
+				config.Console.RateLimit.Burst = 10
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Jack-apikeys",
+			Email:    "apikeystest@test.test",
+		}, 1)
+		require.NoError(t, err)
+
+		project, err := sat.AddProject(ctx, user.ID, "apikeystest")
+		require.NoError(t, err)
+
+		// we are using full name as a password
+		token, err := sat.API.Console.Service.Token(ctx, user.Email, user.FullName)
+		require.NoError(t, err)
+
+		address := "http://" + sat.API.Console.Listener.Addr().String() + "/api/v0/api-keys"
+		client := http.Client{}
+
+		do := func(method, url string, body interface{}, response interface{}) int {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(data))
+			require.NoError(t, err)
+			req.AddCookie(&http.Cookie{
+				Name:    "_tokenKey",
+				Path:    "/",
+				Value:   token,
+				Expires: time.Now().AddDate(0, 0, 1),
+			})
+
+			result, err := client.Do(req)
+			require.NoError(t, err)
+			defer func() { require.NoError(t, result.Body.Close()) }()
+
+			if result.StatusCode == http.StatusOK {
+				require.NoError(t, json.NewDecoder(result.Body).Decode(response))
+			}
+			return result.StatusCode
+		}
+
+		notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		restrictions := &console.APIKeyRestrictions{
+			DisallowDeletes: true,
+			AllowedPaths:    []console.APIKeyPath{{Bucket: "bucket"}, {Bucket: "other", EncryptedPrefix: []byte("encrypted-prefix")}},
+			NotAfter:        &notAfter,
+		}
+
+		var created consoleapi.CreateAPIKeyResponse
+		status := do(http.MethodPost, address, consoleapi.CreateAPIKeyRequest{
+			ProjectID:    project.ID,
+			Name:         "restricted",
+			Restrictions: restrictions,
+		}, &created)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, restrictions, created.KeyInfo.Restrictions)
+
+		key, err := macaroon.ParseAPIKey(created.Key)
+		require.NoError(t, err)
+		stored, err := sat.DB.Console().APIKeys().GetByHead(ctx, key.Head())
+		require.NoError(t, err)
+		require.Equal(t, created.KeyInfo.ID, stored.ID)
+
+		status = do(http.MethodPost, address, consoleapi.CreateAPIKeyRequest{
+			ProjectID: project.ID,
+			Name:      "unrestricted",
+		}, &created)
+		require.Equal(t, http.StatusOK, status)
+		require.Nil(t, created.KeyInfo.Restrictions)
+
+		// expiration in the past is rejected
+		expired := time.Now().Add(-time.Hour)
+		status = do(http.MethodPost, address, consoleapi.CreateAPIKeyRequest{
+			ProjectID:    project.ID,
+			Name:         "expired",
+			Restrictions: &console.APIKeyRestrictions{NotAfter: &expired},
+		}, nil)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		// prefixes must belong to a bucket
+		status = do(http.MethodPost, address, consoleapi.CreateAPIKeyRequest{
+			ProjectID: project.ID,
+			Name:      "prefix",
+			Restrictions: &console.APIKeyRestrictions{
+				AllowedPaths: []console.APIKeyPath{{EncryptedPrefix: []byte("encrypted-prefix")}},
+			},
+		}, nil)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		var page console.APIKeyPage
+		status = do(http.MethodGet, address+"?projectID="+project.ID.String(), nil, &page)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, page.APIKeys, 2)
+		require.Equal(t, "restricted", page.APIKeys[0].Name)
+		require.Equal(t, restrictions, page.APIKeys[0].Restrictions)
+		require.Equal(t, "unrestricted", page.APIKeys[1].Name)
+		require.Nil(t, page.APIKeys[1].Restrictions)
+	})
+}
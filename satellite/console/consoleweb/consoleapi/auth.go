@@ -430,6 +430,8 @@ func (a *Auth) getStatusCode(err error) int {
 		return http.StatusUnauthorized
 	case console.ErrEmailUsed.Has(err):
 		return http.StatusConflict
+	case console.ErrSSORequired.Has(err):
+		return http.StatusForbidden
 	case errors.Is(err, errNotImplemented):
 		return http.StatusNotImplemented
 	default:
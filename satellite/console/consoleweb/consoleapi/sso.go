@@ -0,0 +1,271 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleweb/consolewebauth"
+	"storj.io/storj/satellite/console/oidc"
+)
+
+var (
+	// ErrSSOAPI - console single sign-on api error type.
+	ErrSSOAPI = errs.Class("console sso api error")
+
+	errUnknownProvider = errs.New("unknown identity provider")
+	errInvalidState    = errs.New("invalid single sign-on state")
+)
+
+const (
+	// ssoStateCookie keeps the state of a login, while the user is at the provider.
+	ssoStateCookie = "_sso_state"
+	// ssoStateExpiration is the time a user has to log in at the provider.
+	ssoStateExpiration = 10 * time.Minute
+)
+
+// ssoState is the state of a login, which is kept in a signed cookie.
+type ssoState struct {
+	Provider string    `json:"provider"`
+	State    string    `json:"state"`
+	Nonce    string    `json:"nonce"`
+	Verifier string    `json:"verifier"`
+	Expires  time.Time `json:"expires"`
+}
+
+// SSO is an api controller, which logs users in with OpenID Connect identity providers.
+type SSO struct {
+	log             *zap.Logger
+	service         *console.Service
+	cookieAuth      *consolewebauth.CookieAuth
+	providers       map[string]*oidc.Provider
+	externalAddress string
+}
+
+// NewSSO is a constructor for api single sign-on controller.
+func NewSSO(log *zap.Logger, service *console.Service, cookieAuth *consolewebauth.CookieAuth, config oidc.Config, externalAddress string) *SSO {
+	providers := make(map[string]*oidc.Provider, len(config.Providers))
+	for _, provider := range config.Providers {
+		providers[provider.Name] = oidc.NewProvider(provider, nil)
+	}
+
+	return &SSO{
+		log:             log,
+		service:         service,
+		cookieAuth:      cookieAuth,
+		providers:       providers,
+		externalAddress: externalAddress,
+	}
+}
+
+// Login redirects the user to the identity provider.
+func (s *SSO) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	provider, ok := s.providers[mux.Vars(r)["provider"]]
+	if !ok {
+		s.serveJSONError(w, errUnknownProvider)
+		return
+	}
+
+	state := ssoState{
+		Provider: provider.Name(),
+		Expires:  time.Now().Add(ssoStateExpiration),
+	}
+	for _, value := range []*string{&state.State, &state.Nonce, &state.Verifier} {
+		*value, err = oidc.NewRandom()
+		if err != nil {
+			s.serveJSONError(w, err)
+			return
+		}
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, s.redirectURL(provider), state.State, state.Nonce, state.Verifier)
+	if err != nil {
+		s.log.Error("unable to reach identity provider", zap.String("provider", provider.Name()), zap.Error(ErrSSOAPI.Wrap(err)))
+		s.serveJSONError(w, err)
+		return
+	}
+
+	cookie, err := s.encodeState(state)
+	if err != nil {
+		s.serveJSONError(w, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookie,
+		Value:    cookie,
+		Path:     "/api/v0/auth/sso/",
+		Expires:  state.Expires,
+		HttpOnly: true,
+		// the callback is a navigation from the provider, which doesn't send strict cookies.
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Callback logs the user in, who was redirected back from the identity provider.
+func (s *SSO) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	provider, ok := s.providers[mux.Vars(r)["provider"]]
+	if !ok {
+		s.serveJSONError(w, errUnknownProvider)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookie,
+		Value:    "",
+		Path:     "/api/v0/auth/sso/",
+		Expires:  time.Unix(0, 0),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	query := r.URL.Query()
+	if query.Get("error") != "" {
+		err = console.ErrUnauthorized.New("%s: %s", query.Get("error"), query.Get("error_description"))
+		s.serveJSONError(w, err)
+		return
+	}
+
+	cookie, err := r.Cookie(ssoStateCookie)
+	if err != nil {
+		s.serveJSONError(w, errInvalidState)
+		return
+	}
+	state, err := s.decodeState(cookie.Value)
+	if err != nil || state.Provider != provider.Name() || state.State != query.Get("state") || time.Now().After(state.Expires) {
+		err = errInvalidState
+		s.serveJSONError(w, err)
+		return
+	}
+
+	claims, err := provider.Exchange(ctx, s.redirectURL(provider), query.Get("code"), state.Verifier, state.Nonce)
+	if err != nil {
+		s.log.Info("single sign-on failed", zap.String("provider", provider.Name()), zap.Error(ErrSSOAPI.Wrap(err)))
+		err = console.ErrUnauthorized.Wrap(err)
+		s.serveJSONError(w, err)
+		return
+	}
+
+	token, err := s.service.SSOToken(ctx, console.SSOIdentity{
+		Provider:      provider.Name(),
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		FullName:      claims.Name,
+	})
+	if err != nil {
+		s.log.Info("Error authenticating single sign-on", zap.String("provider", provider.Name()), zap.String("email", claims.Email), zap.Error(ErrSSOAPI.Wrap(err)))
+		s.serveJSONError(w, err)
+		return
+	}
+
+	s.cookieAuth.SetTokenCookie(w, token)
+	http.Redirect(w, r, s.externalAddress, http.StatusFound)
+}
+
+// redirectURL returns the callback url of the provider.
+func (s *SSO) redirectURL(provider *oidc.Provider) string {
+	return s.externalAddress + "api/v0/auth/sso/" + provider.Name() + "/callback"
+}
+
+// encodeState encodes and signs the login state.
+func (s *SSO) encodeState(state ssoState) (string, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", ErrSSOAPI.Wrap(err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+
+	signature, err := s.signState(encoded)
+	if err != nil {
+		return "", ErrSSOAPI.Wrap(err)
+	}
+
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// decodeState verifies and decodes the login state.
+func (s *SSO) decodeState(value string) (state ssoState, err error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 2 {
+		return state, errInvalidState
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return state, errInvalidState
+	}
+	expected, err := s.signState(parts[0])
+	if err != nil {
+		return state, ErrSSOAPI.Wrap(err)
+	}
+	if !hmac.Equal(signature, expected) {
+		return state, errInvalidState
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return state, errInvalidState
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, errInvalidState
+	}
+	return state, nil
+}
+
+// ssoStateSignaturePrefix separates the signatures of the login state from the
+// signatures of auth tokens, which are made with the same key.
+const ssoStateSignaturePrefix = "sso-state:"
+
+// signState signs the encoded login state.
+func (s *SSO) signState(encoded string) ([]byte, error) {
+	return s.service.Sign([]byte(ssoStateSignaturePrefix + encoded))
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (s *SSO) serveJSONError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errUnknownProvider):
+		status = http.StatusNotFound
+	case errors.Is(err, errInvalidState):
+		status = http.StatusBadRequest
+	case console.ErrUnauthorized.Has(err):
+		status = http.StatusUnauthorized
+	case console.ErrSSORequired.Has(err):
+		status = http.StatusForbidden
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		s.log.Error("failed to write json error response", zap.Error(ErrSSOAPI.Wrap(err)))
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/console/oidc"
+	"storj.io/storj/satellite/console/oidc/oidctest"
+)
+
+func TestSSO(t *testing.T) {
+	provider, err := oidctest.NewServer("satellite", "secret")
+	require.NoError(t, err)
+	defer provider.Close()
+
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Console.SSO = oidc.Config{
+					Providers:   oidc.Providers{provider.Config("test", "corp.test")},
+					CreateUsers: true,
+				}
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+		consoleURL := "http://" + sat.API.Console.Listener.Addr().String()
+
+		user, err := sat.AddUser(ctx, console.CreateUser{
+			FullName: "Alice",
+			Email:    "alice@example.test",
+		}, 1)
+		require.NoError(t, err)
+
+		// login follows the redirects between the console and the provider and
+		// returns the last response of the console.
+		login := func(providerName string) *http.Response {
+			jar, err := cookiejar.New(nil)
+			require.NoError(t, err)
+			client := &http.Client{
+				Jar: jar,
+				CheckRedirect: func(req *http.Request, via []*http.Request) error {
+					if req.URL.Path == "/" {
+						return http.ErrUseLastResponse
+					}
+					return nil
+				},
+			}
+
+			resp, err := client.Get(consoleURL + "/api/v0/auth/sso/" + providerName)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			return resp
+		}
+		// authorize checks that the login response authenticates the user.
+		authorize := func(resp *http.Response, userID uuid.UUID) {
+			require.Equal(t, http.StatusFound, resp.StatusCode)
+			require.Equal(t, consoleURL+"/", resp.Header.Get("Location"))
+
+			var token string
+			for _, cookie := range resp.Cookies() {
+				if cookie.Name == "_tokenKey" {
+					token = cookie.Value
+				}
+			}
+			require.NotEmpty(t, token)
+
+			auth, err := sat.API.Console.Service.Authorize(consoleauth.WithAPIKey(ctx, []byte(token)))
+			require.NoError(t, err)
+			require.Equal(t, userID, auth.User.ID)
+		}
+
+		t.Run("unknown provider", func(t *testing.T) {
+			resp := login("unknown")
+			require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+
+		t.Run("existing user is linked", func(t *testing.T) {
+			provider.SetUser(oidctest.User{Subject: "alice", Email: user.Email, EmailVerified: true, Name: "Alice"})
+
+			authorize(login("test"), user.ID)
+
+			userID, err := sat.DB.Console().SSOIdentities().GetUserID(ctx, "test", "alice")
+			require.NoError(t, err)
+			require.Equal(t, user.ID, userID)
+
+			// the linked identity is used, even when the email changed at the provider.
+			provider.SetUser(oidctest.User{Subject: "alice", Email: "alice@changed.test"})
+			authorize(login("test"), user.ID)
+		})
+
+		t.Run("unverified email", func(t *testing.T) {
+			provider.SetUser(oidctest.User{Subject: "mallory", Email: user.Email, EmailVerified: false})
+
+			resp := login("test")
+			require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+
+		t.Run("user is created", func(t *testing.T) {
+			provider.SetUser(oidctest.User{Subject: "bob", Email: "bob@corp.test", EmailVerified: true, Name: "Bob"})
+
+			resp := login("test")
+
+			created, err := sat.DB.Console().Users().GetByEmail(ctx, "bob@corp.test")
+			require.NoError(t, err)
+			require.Equal(t, "Bob", created.FullName)
+			require.Equal(t, console.Active, created.Status)
+			authorize(resp, created.ID)
+		})
+
+		t.Run("password login of enforced domain", func(t *testing.T) {
+			// the user was created before the domain enforced the provider.
+			carol, err := sat.AddUser(ctx, console.CreateUser{
+				FullName: "Carol",
+				Email:    "carol@example.test",
+			}, 1)
+			require.NoError(t, err)
+			carol.Email = "carol@corp.test"
+			require.NoError(t, sat.DB.Console().Users().Update(ctx, carol))
+
+			token := func(password string) int {
+				body, err := json.Marshal(map[string]string{"email": carol.Email, "password": password})
+				require.NoError(t, err)
+				resp, err := http.Post(consoleURL+"/api/v0/auth/token", "application/json", bytes.NewReader(body))
+				require.NoError(t, err)
+				require.NoError(t, resp.Body.Close())
+				return resp.StatusCode
+			}
+
+			// the provider isn't revealed without the password.
+			require.Equal(t, http.StatusUnauthorized, token("wrong"))
+			// users of enforced domains can't log in with a password.
+			require.Equal(t, http.StatusForbidden, token("Carol"))
+		})
+
+		t.Run("invalid state", func(t *testing.T) {
+			resp, err := http.Get(consoleURL + "/api/v0/auth/sso/test/callback?code=code&state=state")
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	})
+}
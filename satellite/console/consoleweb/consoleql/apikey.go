@@ -4,6 +4,9 @@
 package consoleql
 
 import (
+	"encoding/base64"
+	"time"
+
 	"github.com/graphql-go/graphql"
 
 	"storj.io/storj/satellite/console"
@@ -17,10 +20,42 @@ const (
 	CreateAPIKeyType = "graphqlCreateAPIKey"
 	// FieldKey is field name for the actual key in createAPIKey.
 	FieldKey = "key"
+	// APIKeyRestrictionsType is graphql type name for api key restrictions.
+	APIKeyRestrictionsType = "apiKeyRestrictions"
+	// APIKeyRestrictionsInputType is graphql type name for api key restrictions input.
+	APIKeyRestrictionsInputType = "apiKeyRestrictionsInput"
+	// APIKeyPathType is graphql type name for the path an api key is allowed to access.
+	APIKeyPathType = "apiKeyPath"
+	// APIKeyPathInputType is graphql type name for api key path input.
+	APIKeyPathInputType = "apiKeyPathInput"
+	// FieldRestrictions is field name for api key restrictions.
+	FieldRestrictions = "restrictions"
+	// FieldDisallowReads is field name for disallowing reads.
+	FieldDisallowReads = "disallowReads"
+	// FieldDisallowWrites is field name for disallowing writes.
+	FieldDisallowWrites = "disallowWrites"
+	// FieldDisallowLists is field name for disallowing lists.
+	FieldDisallowLists = "disallowLists"
+	// FieldDisallowDeletes is field name for disallowing deletes.
+	FieldDisallowDeletes = "disallowDeletes"
+	// FieldAllowedPaths is field name for the paths an api key is allowed to access.
+	FieldAllowedPaths = "allowedPaths"
+	// FieldBucket is field name for bucket.
+	FieldBucket = "bucket"
+	// FieldEncryptedPrefix is field name for the base64 encoded encrypted prefix.
+	FieldEncryptedPrefix = "encryptedPrefix"
+	// FieldNotBefore is field name for the time an api key becomes valid.
+	FieldNotBefore = "notBefore"
+	// FieldNotAfter is field name for the time an api key expires.
+	FieldNotAfter = "notAfter"
+	// FieldLastUsedAt is field name for the last time an api key was used.
+	FieldLastUsedAt = "lastUsedAt"
+	// FieldRequestCount is field name for the number of requests made with an api key.
+	FieldRequestCount = "requestCount"
 )
 
 // graphqlAPIKeyInfo creates satellite.APIKeyInfo graphql object.
-func graphqlAPIKeyInfo() *graphql.Object {
+func graphqlAPIKeyInfo(types *TypeCreator) *graphql.Object {
 	return graphql.NewObject(graphql.ObjectConfig{
 		Name: APIKeyInfoType,
 		Fields: graphql.Fields{
@@ -39,10 +74,149 @@ func graphqlAPIKeyInfo() *graphql.Object {
 			FieldPartnerID: &graphql.Field{
 				Type: graphql.String,
 			},
+			FieldRestrictions: &graphql.Field{
+				Type: types.apiKeyRestrictions,
+			},
+			FieldLastUsedAt: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			FieldRequestCount: &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	})
+}
+
+// graphqlAPIKeyPath creates console.APIKeyPath graphql object.
+func graphqlAPIKeyPath() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: APIKeyPathType,
+		Fields: graphql.Fields{
+			FieldBucket: &graphql.Field{
+				Type: graphql.String,
+			},
+			FieldEncryptedPrefix: &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					path, _ := p.Source.(console.APIKeyPath)
+					if len(path.EncryptedPrefix) == 0 {
+						return nil, nil
+					}
+					return base64.StdEncoding.EncodeToString(path.EncryptedPrefix), nil
+				},
+			},
+		},
+	})
+}
+
+// graphqlAPIKeyRestrictions creates console.APIKeyRestrictions graphql object.
+func graphqlAPIKeyRestrictions(types *TypeCreator) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: APIKeyRestrictionsType,
+		Fields: graphql.Fields{
+			FieldDisallowReads: &graphql.Field{
+				Type: graphql.Boolean,
+			},
+			FieldDisallowWrites: &graphql.Field{
+				Type: graphql.Boolean,
+			},
+			FieldDisallowLists: &graphql.Field{
+				Type: graphql.Boolean,
+			},
+			FieldDisallowDeletes: &graphql.Field{
+				Type: graphql.Boolean,
+			},
+			FieldAllowedPaths: &graphql.Field{
+				Type: graphql.NewList(types.apiKeyPath),
+			},
+			FieldNotBefore: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			FieldNotAfter: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+		},
+	})
+}
+
+// graphqlAPIKeyPathInput creates graphql.InputObject type needed to restrict an api key to a path.
+func graphqlAPIKeyPathInput() *graphql.InputObject {
+	return graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: APIKeyPathInputType,
+		Fields: graphql.InputObjectConfigFieldMap{
+			FieldBucket: &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(graphql.String),
+			},
+			FieldEncryptedPrefix: &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
 		},
 	})
 }
 
+// graphqlAPIKeyRestrictionsInput creates graphql.InputObject type needed to create restricted api keys.
+func graphqlAPIKeyRestrictionsInput(types *TypeCreator) *graphql.InputObject {
+	return graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: APIKeyRestrictionsInputType,
+		Fields: graphql.InputObjectConfigFieldMap{
+			FieldDisallowReads: &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			FieldDisallowWrites: &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			FieldDisallowLists: &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			FieldDisallowDeletes: &graphql.InputObjectFieldConfig{
+				Type: graphql.Boolean,
+			},
+			FieldAllowedPaths: &graphql.InputObjectFieldConfig{
+				Type: graphql.NewList(types.apiKeyPathInput),
+			},
+			FieldNotBefore: &graphql.InputObjectFieldConfig{
+				Type: graphql.DateTime,
+			},
+			FieldNotAfter: &graphql.InputObjectFieldConfig{
+				Type: graphql.DateTime,
+			},
+		},
+	})
+}
+
+// fromMapAPIKeyRestrictions creates console.APIKeyRestrictions from input args.
+func fromMapAPIKeyRestrictions(args map[string]interface{}) (_ *console.APIKeyRestrictions, err error) {
+	restrictions := &console.APIKeyRestrictions{}
+	restrictions.DisallowReads, _ = args[FieldDisallowReads].(bool)
+	restrictions.DisallowWrites, _ = args[FieldDisallowWrites].(bool)
+	restrictions.DisallowLists, _ = args[FieldDisallowLists].(bool)
+	restrictions.DisallowDeletes, _ = args[FieldDisallowDeletes].(bool)
+
+	paths, _ := args[FieldAllowedPaths].([]interface{})
+	for _, path := range paths {
+		pathArgs, _ := path.(map[string]interface{})
+
+		var allowed console.APIKeyPath
+		allowed.Bucket, _ = pathArgs[FieldBucket].(string)
+		if encryptedPrefix, _ := pathArgs[FieldEncryptedPrefix].(string); encryptedPrefix != "" {
+			allowed.EncryptedPrefix, err = base64.StdEncoding.DecodeString(encryptedPrefix)
+			if err != nil {
+				return nil, console.ErrValidation.New("invalid encrypted prefix: %v", err)
+			}
+		}
+		restrictions.AllowedPaths = append(restrictions.AllowedPaths, allowed)
+	}
+
+	if notBefore, ok := args[FieldNotBefore].(time.Time); ok {
+		restrictions.NotBefore = &notBefore
+	}
+	if notAfter, ok := args[FieldNotAfter].(time.Time); ok {
+		restrictions.NotAfter = &notAfter
+	}
+
+	return restrictions, nil
+}
+
 // graphqlCreateAPIKey creates createAPIKey graphql object.
 func graphqlCreateAPIKey(types *TypeCreator) *graphql.Object {
 	return graphql.NewObject(graphql.ObjectConfig{
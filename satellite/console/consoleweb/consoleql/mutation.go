@@ -236,6 +236,9 @@ func rootMutation(log *zap.Logger, service *console.Service, mailService *mailse
 					FieldName: &graphql.ArgumentConfig{
 						Type: graphql.NewNonNull(graphql.String),
 					},
+					FieldRestrictions: &graphql.ArgumentConfig{
+						Type: types.apiKeyRestrictionsInput,
+					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					projectIDField, _ := p.Args[FieldProjectID].(string)
@@ -246,7 +249,15 @@ func rootMutation(log *zap.Logger, service *console.Service, mailService *mailse
 						return nil, err
 					}
 
-					info, key, err := service.CreateAPIKey(p.Context, projectID, name)
+					var restrictions *console.APIKeyRestrictions
+					if restrictionsArgs, ok := p.Args[FieldRestrictions].(map[string]interface{}); ok {
+						restrictions, err = fromMapAPIKeyRestrictions(restrictionsArgs)
+						if err != nil {
+							return nil, err
+						}
+					}
+
+					info, key, err := service.CreateRestrictedAPIKey(p.Context, projectID, name, restrictions)
 					if err != nil {
 						return nil, err
 					}
@@ -88,6 +88,7 @@ func TestGraphqlMutation(t *testing.T) {
 			db.StripeCoinPayments(),
 			db.Console().Projects(),
 			db.ProjectAccounting(),
+			nil,
 			pc.StorageTBPrice,
 			pc.EgressTBPrice,
 			pc.ObjectPrice,
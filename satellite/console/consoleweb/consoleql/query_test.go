@@ -72,6 +72,7 @@ func TestGraphqlQuery(t *testing.T) {
 			db.StripeCoinPayments(),
 			db.Console().Projects(),
 			db.ProjectAccounting(),
+			nil,
 			pc.StorageTBPrice,
 			pc.EgressTBPrice,
 			pc.ObjectPrice,
@@ -16,24 +16,28 @@ type TypeCreator struct {
 	query    *graphql.Object
 	mutation *graphql.Object
 
-	user              *graphql.Object
-	reward            *graphql.Object
-	creditUsage       *graphql.Object
-	project           *graphql.Object
-	projectUsage      *graphql.Object
-	bucketUsage       *graphql.Object
-	bucketUsagePage   *graphql.Object
-	projectMember     *graphql.Object
-	projectMemberPage *graphql.Object
-	apiKeyPage        *graphql.Object
-	apiKeyInfo        *graphql.Object
-	createAPIKey      *graphql.Object
-
-	userInput            *graphql.InputObject
-	projectInput         *graphql.InputObject
-	bucketUsageCursor    *graphql.InputObject
-	projectMembersCursor *graphql.InputObject
-	apiKeysCursor        *graphql.InputObject
+	user               *graphql.Object
+	reward             *graphql.Object
+	creditUsage        *graphql.Object
+	project            *graphql.Object
+	projectUsage       *graphql.Object
+	bucketUsage        *graphql.Object
+	bucketUsagePage    *graphql.Object
+	projectMember      *graphql.Object
+	projectMemberPage  *graphql.Object
+	apiKeyPage         *graphql.Object
+	apiKeyInfo         *graphql.Object
+	apiKeyPath         *graphql.Object
+	apiKeyRestrictions *graphql.Object
+	createAPIKey       *graphql.Object
+
+	userInput               *graphql.InputObject
+	projectInput            *graphql.InputObject
+	bucketUsageCursor       *graphql.InputObject
+	projectMembersCursor    *graphql.InputObject
+	apiKeysCursor           *graphql.InputObject
+	apiKeyPathInput         *graphql.InputObject
+	apiKeyRestrictionsInput *graphql.InputObject
 }
 
 // Create create types and check for error.
@@ -64,6 +68,16 @@ func (c *TypeCreator) Create(log *zap.Logger, service *console.Service, mailServ
 		return err
 	}
 
+	c.apiKeyPathInput = graphqlAPIKeyPathInput()
+	if err := c.apiKeyPathInput.Error(); err != nil {
+		return err
+	}
+
+	c.apiKeyRestrictionsInput = graphqlAPIKeyRestrictionsInput(c)
+	if err := c.apiKeyRestrictionsInput.Error(); err != nil {
+		return err
+	}
+
 	// entities
 	c.user = graphqlUser()
 	if err := c.user.Error(); err != nil {
@@ -95,7 +109,17 @@ func (c *TypeCreator) Create(log *zap.Logger, service *console.Service, mailServ
 		return err
 	}
 
-	c.apiKeyInfo = graphqlAPIKeyInfo()
+	c.apiKeyPath = graphqlAPIKeyPath()
+	if err := c.apiKeyPath.Error(); err != nil {
+		return err
+	}
+
+	c.apiKeyRestrictions = graphqlAPIKeyRestrictions(c)
+	if err := c.apiKeyRestrictions.Error(); err != nil {
+		return err
+	}
+
+	c.apiKeyInfo = graphqlAPIKeyInfo(c)
 	if err := c.apiKeyInfo.Error(); err != nil {
 		return err
 	}
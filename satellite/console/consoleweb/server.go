@@ -59,6 +59,7 @@ var (
 // Config contains configuration for console web server.
 type Config struct {
 	Address         string `help:"server address of the graphql api gateway and frontend app" devDefault:"127.0.0.1:8081" releaseDefault:":10100"`
+	TrustedProxies  string `help:"comma separated list of networks (CIDR) of proxies, which must send a PROXY protocol header when connecting to the console" default:""`
 	StaticDir       string `help:"path to static resources" default:""`
 	ExternalAddress string `help:"external endpoint of the satellite if hosted" default:""`
 
@@ -183,6 +184,10 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, mail
 	authRouter.Handle("/forgot-password/{email}", server.rateLimiter.Limit(http.HandlerFunc(authController.ForgotPassword))).Methods(http.MethodPost)
 	authRouter.Handle("/resend-email/{id}", server.rateLimiter.Limit(http.HandlerFunc(authController.ResendEmail))).Methods(http.MethodPost)
 
+	ssoController := consoleapi.NewSSO(logger, service, server.cookieAuth, config.SSO, server.config.ExternalAddress)
+	authRouter.HandleFunc("/sso/{provider}", ssoController.Login).Methods(http.MethodGet)
+	authRouter.HandleFunc("/sso/{provider}/callback", ssoController.Callback).Methods(http.MethodGet)
+
 	paymentController := consoleapi.NewPayments(logger, service)
 	paymentsRouter := router.PathPrefix("/api/v0/payments").Subrouter()
 	paymentsRouter.Use(server.withAuth)
@@ -202,6 +207,12 @@ func NewServer(logger *zap.Logger, config Config, service *console.Service, mail
 	bucketsRouter.Use(server.withAuth)
 	bucketsRouter.HandleFunc("/bucket-names", bucketsController.AllBucketNames).Methods(http.MethodGet)
 
+	apiKeysController := consoleapi.NewAPIKeys(logger, service)
+	apiKeysRouter := router.PathPrefix("/api/v0/api-keys").Subrouter()
+	apiKeysRouter.Use(server.withAuth)
+	apiKeysRouter.HandleFunc("", apiKeysController.List).Methods(http.MethodGet)
+	apiKeysRouter.HandleFunc("", apiKeysController.Create).Methods(http.MethodPost)
+
 	if server.config.StaticDir != "" {
 		router.HandleFunc("/activation/", server.accountActivationHandler)
 		router.HandleFunc("/password-recovery/", server.passwordRecoveryHandler)
@@ -25,6 +25,10 @@ type DB interface {
 	ResetPasswordTokens() ResetPasswordTokens
 	// UserCredits is a getter for UserCredits repository.
 	UserCredits() UserCredits
+	// AuditLogs is a getter for AuditLogs repository.
+	AuditLogs() AuditLogs
+	// SSOIdentities is a getter for SSOIdentities repository.
+	SSOIdentities() SSOIdentities
 
 	// WithTx is a method for executing transactions with retrying as necessary.
 	WithTx(ctx context.Context, fn func(ctx context.Context, tx DBTx) error) error
@@ -0,0 +1,130 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package oidc
+
+import (
+	"strings"
+)
+
+// Config contains the configuration of single sign-on with OpenID Connect.
+type Config struct {
+	Providers   Providers `help:"semicolon separated identity providers in the format name=...,issuer=...,client-id=...,client-secret=...,domains=example.com|example.org" default:""`
+	CreateUsers bool      `help:"create console users on their first single sign-on login" default:"false"`
+}
+
+// ProviderConfig contains the configuration of an OpenID Connect identity provider.
+type ProviderConfig struct {
+	// Name identifies the provider in the console urls and the linked identities.
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// Domains are the email domains, whose users must log in with the provider.
+	Domains []string
+}
+
+// Providers is a list of identity providers.
+//
+// Can be used as a flag.
+type Providers []ProviderConfig
+
+// Type implements pflag.Value.
+func (Providers) Type() string { return "oidc.Providers" }
+
+// String is required for pflag.Value.
+func (providers *Providers) String() string {
+	var s strings.Builder
+	for i, provider := range *providers {
+		if i > 0 {
+			s.WriteString(";")
+		}
+		s.WriteString("name=" + provider.Name)
+		s.WriteString(",issuer=" + provider.Issuer)
+		s.WriteString(",client-id=" + provider.ClientID)
+		s.WriteString(",client-secret=" + provider.ClientSecret)
+		if len(provider.Domains) > 0 {
+			s.WriteString(",domains=" + strings.Join(provider.Domains, "|"))
+		}
+	}
+	return s.String()
+}
+
+// Set sets the value from a semicolon separated list of providers in the format
+// name=...,issuer=...,client-id=...,client-secret=...,domains=example.com|example.org.
+func (providers *Providers) Set(s string) error {
+	list := Providers{}
+	for _, info := range strings.Split(s, ";") {
+		info = strings.TrimSpace(info)
+		if info == "" {
+			continue
+		}
+
+		var provider ProviderConfig
+		for _, field := range strings.Split(info, ",") {
+			parts := strings.SplitN(strings.TrimSpace(field), "=", 2)
+			if len(parts) != 2 {
+				return Error.New("invalid identity provider field (expect format key=value, got %s)", field)
+			}
+
+			switch key, value := parts[0], parts[1]; key {
+			case "name":
+				provider.Name = value
+			case "issuer":
+				provider.Issuer = strings.TrimSuffix(value, "/")
+			case "client-id":
+				provider.ClientID = value
+			case "client-secret":
+				provider.ClientSecret = value
+			case "domains":
+				for _, domain := range strings.Split(value, "|") {
+					if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+						provider.Domains = append(provider.Domains, domain)
+					}
+				}
+			default:
+				return Error.New("invalid identity provider field (unknown key): %s", key)
+			}
+		}
+
+		if provider.Name == "" || provider.Issuer == "" || provider.ClientID == "" {
+			return Error.New("invalid identity provider (name, issuer and client-id are required): %s", info)
+		}
+		if _, ok := list.Find(provider.Name); ok {
+			return Error.New("invalid identity provider (duplicate name): %s", provider.Name)
+		}
+
+		list = append(list, provider)
+	}
+
+	*providers = list
+	return nil
+}
+
+// Find returns the provider with the name.
+func (providers Providers) Find(name string) (ProviderConfig, bool) {
+	for _, provider := range providers {
+		if provider.Name == name {
+			return provider, true
+		}
+	}
+	return ProviderConfig{}, false
+}
+
+// Enforced returns the provider, which users with the email must log in with.
+func (providers Providers) Enforced(email string) (ProviderConfig, bool) {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ProviderConfig{}, false
+	}
+	domain := strings.ToLower(email[at+1:])
+
+	for _, provider := range providers {
+		for _, enforced := range provider.Domains {
+			if domain == enforced {
+				return provider, true
+			}
+		}
+	}
+	return ProviderConfig{}, false
+}
@@ -0,0 +1,370 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class of single sign-on.
+	Error = errs.Class("oidc")
+)
+
+// maxResponseSize limits the size of the responses read from providers.
+const maxResponseSize = 1 << 20
+
+// Claims are the claims about the user in a verified ID token.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider is an OpenID Connect identity provider, which authenticates users
+// with the authorization code flow.
+type Provider struct {
+	config ProviderConfig
+	client *http.Client
+	nowFn  func() time.Time
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      map[string]*rsa.PublicKey
+}
+
+// discovery is the part of the provider metadata used for logins.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewProvider creates a new identity provider.
+func NewProvider(config ProviderConfig, client *http.Client) *Provider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Provider{
+		config: config,
+		client: client,
+		nowFn:  time.Now,
+	}
+}
+
+// Name returns the name of the provider.
+func (provider *Provider) Name() string { return provider.config.Name }
+
+// AuthCodeURL returns the url, which the user is redirected to for authentication.
+func (provider *Provider) AuthCodeURL(ctx context.Context, redirectURL, state, nonce, verifier string) (_ string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	discovery, err := provider.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {provider.config.ClientID},
+		"redirect_uri":          {redirectURL},
+		"scope":                 {"openid email profile"},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {CodeChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange exchanges the authorization code for an ID token and returns its verified claims.
+func (provider *Provider) Exchange(ctx context.Context, redirectURL, code, verifier, nonce string) (_ Claims, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	discovery, err := provider.discover(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"code_verifier": {verifier},
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Claims{}, Error.Wrap(err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(url.QueryEscape(provider.config.ClientID), url.QueryEscape(provider.config.ClientSecret))
+
+	var response struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := provider.do(request, &response); err != nil {
+		return Claims{}, err
+	}
+	if response.IDToken == "" {
+		return Claims{}, Error.New("token response doesn't contain an id token")
+	}
+
+	return provider.verify(ctx, response.IDToken, nonce)
+}
+
+// verify verifies the signature and the claims of the ID token.
+func (provider *Provider) verify(ctx context.Context, token, nonce string) (_ Claims, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, Error.New("malformed id token")
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Claims{}, err
+	}
+	if header.Algorithm != "RS256" {
+		return Claims{}, Error.New("unsupported id token algorithm %q", header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, Error.Wrap(err)
+	}
+	key, err := provider.key(ctx, header.KeyID)
+	if err != nil {
+		return Claims{}, err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return Claims{}, Error.New("invalid id token signature")
+	}
+
+	var claims struct {
+		Issuer        string          `json:"iss"`
+		Audience      audience        `json:"aud"`
+		Expiration    int64           `json:"exp"`
+		Nonce         string          `json:"nonce"`
+		Subject       string          `json:"sub"`
+		Email         string          `json:"email"`
+		EmailVerified json.RawMessage `json:"email_verified"`
+		Name          string          `json:"name"`
+	}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Claims{}, err
+	}
+
+	discovery, err := provider.discover(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+	switch {
+	case claims.Issuer != discovery.Issuer:
+		return Claims{}, Error.New("unexpected id token issuer %q", claims.Issuer)
+	case !claims.Audience.contains(provider.config.ClientID):
+		return Claims{}, Error.New("id token wasn't issued for the client")
+	case !provider.nowFn().Before(time.Unix(claims.Expiration, 0)):
+		return Claims{}, Error.New("id token expired")
+	case claims.Nonce != nonce:
+		return Claims{}, Error.New("unexpected id token nonce")
+	case claims.Subject == "":
+		return Claims{}, Error.New("id token doesn't contain a subject")
+	}
+
+	// some providers send the verification as a string.
+	verified, _ := strconv.ParseBool(strings.Trim(string(claims.EmailVerified), `"`))
+
+	return Claims{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: verified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// discover fetches the provider metadata once.
+func (provider *Provider) discover(ctx context.Context) (_ *discovery, err error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if provider.discovery != nil {
+		return provider.discovery, nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.config.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var discovered discovery
+	if err := provider.do(request, &discovered); err != nil {
+		return nil, err
+	}
+	if discovered.Issuer != provider.config.Issuer {
+		return nil, Error.New("provider metadata is for issuer %q", discovered.Issuer)
+	}
+	if discovered.AuthorizationEndpoint == "" || discovered.TokenEndpoint == "" || discovered.JWKSURI == "" {
+		return nil, Error.New("provider metadata is incomplete")
+	}
+
+	provider.discovery = &discovered
+	return provider.discovery, nil
+}
+
+// key returns the signing key with the id. The keys are fetched again
+// for unknown ids, because providers rotate their keys.
+func (provider *Provider) key(ctx context.Context, id string) (_ *rsa.PublicKey, err error) {
+	discovery, err := provider.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if key, ok := provider.keys[id]; ok {
+		return key, nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JWKSURI, nil)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var set struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			Use     string `json:"use"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := provider.do(request, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+		keys[jwk.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	provider.keys = keys
+
+	key, ok := keys[id]
+	if !ok {
+		return nil, Error.New("unknown id token signing key %q", id)
+	}
+	return key, nil
+}
+
+// do sends the request and decodes the JSON response.
+func (provider *Provider) do(request *http.Request, response interface{}) (err error) {
+	resp, err := provider.client.Do(request)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(resp.Body.Close())) }()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Error.New("%s %s: %s", request.Method, request.URL.Path, resp.Status)
+	}
+
+	return Error.Wrap(json.Unmarshal(body, response))
+}
+
+// audience is the audience claim, which is either a string or a list of strings.
+type audience []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (aud *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*aud = audience{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(aud))
+}
+
+func (aud audience) contains(clientID string) bool {
+	for _, id := range aud {
+		if id == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeSegment decodes a base64 encoded JSON segment of a token.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return Error.Wrap(json.Unmarshal(data, v))
+}
+
+// NewRandom returns a random url safe string, which is used for states, nonces and verifiers.
+func NewRandom() (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", Error.Wrap(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b[:]), nil
+}
+
+// CodeChallenge returns the S256 PKCE code challenge of the verifier.
+func CodeChallenge(verifier string) string {
+	digest := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package oidc_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/storj/satellite/console/oidc"
+	"storj.io/storj/satellite/console/oidc/oidctest"
+)
+
+func TestProviders(t *testing.T) {
+	var providers oidc.Providers
+	require.NoError(t, providers.Set("name=corp,issuer=https://idp.example.com/,client-id=id,client-secret=secret,domains=Example.com|example.org; name=other,issuer=https://other.example.com,client-id=other"))
+	require.Equal(t, oidc.Providers{
+		{Name: "corp", Issuer: "https://idp.example.com", ClientID: "id", ClientSecret: "secret", Domains: []string{"example.com", "example.org"}},
+		{Name: "other", Issuer: "https://other.example.com", ClientID: "other"},
+	}, providers)
+
+	var parsed oidc.Providers
+	require.NoError(t, parsed.Set(providers.String()))
+	require.Equal(t, providers, parsed)
+
+	enforced, ok := providers.Enforced("alice@EXAMPLE.com")
+	require.True(t, ok)
+	require.Equal(t, "corp", enforced.Name)
+	_, ok = providers.Enforced("alice@example.net")
+	require.False(t, ok)
+
+	for _, invalid := range []string{
+		"name=corp,issuer=https://idp.example.com",
+		"name=corp,issuer=https://idp.example.com,client-id=id,unknown=value",
+		"name=corp,issuer=https://idp.example.com,client-id=id;name=corp,issuer=https://idp.example.com,client-id=id",
+	} {
+		require.Error(t, new(oidc.Providers).Set(invalid), invalid)
+	}
+}
+
+func TestProviderLogin(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	server, err := oidctest.NewServer("client", "secret")
+	require.NoError(t, err)
+	defer server.Close()
+
+	server.SetUser(oidctest.User{Subject: "1234", Email: "alice@example.com", EmailVerified: true, Name: "Alice"})
+
+	// the redirects are followed by hand, like a browser would.
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	authorize := func(provider *oidc.Provider, redirectURL, state, nonce, verifier string) string {
+		authURL, err := provider.AuthCodeURL(ctx, redirectURL, state, nonce, verifier)
+		require.NoError(t, err)
+
+		resp, err := client.Get(authURL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusFound, resp.StatusCode)
+
+		location, err := url.Parse(resp.Header.Get("Location"))
+		require.NoError(t, err)
+		require.Equal(t, state, location.Query().Get("state"))
+		return location.Query().Get("code")
+	}
+
+	const redirectURL = "https://satellite.example.com/callback"
+
+	provider := oidc.NewProvider(server.Config("test"), nil)
+	require.Equal(t, "test", provider.Name())
+
+	code := authorize(provider, redirectURL, "state", "nonce", "verifier")
+	claims, err := provider.Exchange(ctx, redirectURL, code, "verifier", "nonce")
+	require.NoError(t, err)
+	require.Equal(t, oidc.Claims{Subject: "1234", Email: "alice@example.com", EmailVerified: true, Name: "Alice"}, claims)
+
+	// codes can only be used once
+	_, err = provider.Exchange(ctx, redirectURL, code, "verifier", "nonce")
+	require.Error(t, err)
+
+	// the verifier must match the challenge
+	code = authorize(provider, redirectURL, "state", "nonce", "verifier")
+	_, err = provider.Exchange(ctx, redirectURL, code, "other verifier", "nonce")
+	require.Error(t, err)
+
+	// the nonce must match the one of the authorization
+	code = authorize(provider, redirectURL, "state", "nonce", "verifier")
+	_, err = provider.Exchange(ctx, redirectURL, code, "verifier", "other nonce")
+	require.Error(t, err)
+
+	// id tokens issued for other clients are rejected
+	config := server.Config("test")
+	config.ClientID = "other"
+	other := oidc.NewProvider(config, nil)
+	code = authorize(provider, redirectURL, "state", "nonce", "verifier")
+	_, err = other.Exchange(ctx, redirectURL, code, "verifier", "nonce")
+	require.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net/mail"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -25,6 +26,7 @@ import (
 	"storj.io/common/uuid"
 	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/console/consoleauth"
+	"storj.io/storj/satellite/console/oidc"
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/rewards"
 )
@@ -74,6 +76,9 @@ var (
 
 	// ErrEmailUsed is error type that occurs on repeating auth attempts with email.
 	ErrEmailUsed = errs.Class("email used")
+
+	// ErrSSORequired is error type that occurs, when a user must log in with single sign-on.
+	ErrSSORequired = errs.Class("single sign-on required")
 )
 
 // Service is handling accounts related logic.
@@ -101,6 +106,8 @@ type Config struct {
 	PasswordCost            int  `help:"password hashing cost (0=automatic)" internal:"true" default:"0"`
 	OpenRegistrationEnabled bool `help:"enable open registration" default:"false"`
 	DefaultProjectLimit     int  `help:"default project limits for users" default:"10"`
+
+	SSO oidc.Config
 }
 
 // PaymentsService separates all payment related functionality.
@@ -195,6 +202,7 @@ func (paymentService PaymentsService) SetupAccount(ctx context.Context) (err err
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer paymentService.service.auditMutation(ctx, "setup payment account", &auth.User, AuditTargetPaymentAccount, auth.User.ID.String(), nil, &err)
 
 	return paymentService.service.accounts.Setup(ctx, auth.User.ID, auth.User.Email)
 }
@@ -219,6 +227,7 @@ func (paymentService PaymentsService) AddCreditCard(ctx context.Context, creditC
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer paymentService.service.auditMutation(ctx, "add credit card", &auth.User, AuditTargetPaymentAccount, auth.User.ID.String(), nil, &err)
 
 	err = paymentService.service.accounts.CreditCards().Add(ctx, auth.User.ID, creditCardToken)
 	if err != nil {
@@ -246,6 +255,7 @@ func (paymentService PaymentsService) MakeCreditCardDefault(ctx context.Context,
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer paymentService.service.auditMutation(ctx, "make credit card default", &auth.User, AuditTargetPaymentAccount, auth.User.ID.String(), nil, &err)
 
 	return paymentService.service.accounts.CreditCards().MakeDefault(ctx, auth.User.ID, cardID)
 }
@@ -282,6 +292,7 @@ func (paymentService PaymentsService) RemoveCreditCard(ctx context.Context, card
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer paymentService.service.auditMutation(ctx, "remove credit card", &auth.User, AuditTargetPaymentAccount, auth.User.ID.String(), nil, &err)
 
 	return paymentService.service.accounts.CreditCards().Remove(ctx, auth.User.ID, cardID)
 }
@@ -425,6 +436,7 @@ func (paymentService PaymentsService) TokenDeposit(ctx context.Context, amount i
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
+	defer paymentService.service.auditMutation(ctx, "token deposit", &auth.User, AuditTargetPaymentAccount, auth.User.ID.String(), nil, &err)
 
 	tx, err := paymentService.service.accounts.StorjTokens().Deposit(ctx, auth.User.ID, amount)
 	return tx, Error.Wrap(err)
@@ -525,9 +537,19 @@ func (s *Service) checkRegistrationSecret(ctx context.Context, tokenSecret Regis
 // CreateUser gets password hash value and creates new inactive User.
 func (s *Service) CreateUser(ctx context.Context, user CreateUser, tokenSecret RegistrationSecret, refUserID string) (u *User, err error) {
 	defer mon.Task()(&ctx)(&err)
+	defer func() {
+		actor, targetID := &User{Email: user.Email}, ""
+		if u != nil {
+			actor, targetID = u, u.ID.String()
+		}
+		s.auditMutation(ctx, "create user", actor, AuditTargetUser, targetID, nil, &err)
+	}()
 	if err := user.IsValid(); err != nil {
 		return nil, Error.Wrap(err)
 	}
+	if provider, ok := s.config.SSO.Providers.Enforced(user.Email); ok {
+		return nil, ErrSSORequired.New("sign up with %s", provider.Name)
+	}
 
 	offerType := rewards.FreeCredit
 	if user.PartnerID != "" {
@@ -656,6 +678,7 @@ func (s *Service) GenerateActivationToken(ctx context.Context, id uuid.UUID, ema
 // GeneratePasswordRecoveryToken - is a method for generating password recovery token.
 func (s *Service) GeneratePasswordRecoveryToken(ctx context.Context, id uuid.UUID) (token string, err error) {
 	defer mon.Task()(&ctx)(&err)
+	defer s.auditMutation(ctx, "generate password recovery token", &User{ID: id}, AuditTargetUser, id.String(), nil, &err)
 
 	resetPasswordToken, err := s.store.ResetPasswordTokens().GetByOwnerID(ctx, id)
 	if err == nil {
@@ -698,6 +721,7 @@ func (s *Service) ActivateAccount(ctx context.Context, activationToken string) (
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "activate account", user, AuditTargetUser, user.ID.String(), nil, &err)
 
 	now := time.Now()
 
@@ -747,6 +771,7 @@ func (s *Service) ResetPassword(ctx context.Context, resetPasswordToken, passwor
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "password reset", user, AuditTargetUser, user.ID.String(), nil, &err)
 
 	if err := ValidatePassword(password); err != nil {
 		return Error.Wrap(err)
@@ -796,12 +821,18 @@ func (s *Service) Token(ctx context.Context, email, password string) (token stri
 	if err != nil {
 		return "", ErrUnauthorized.New(credentialsErrMsg)
 	}
+	defer s.auditMutation(ctx, "login", user, AuditTargetUser, user.ID.String(), nil, &err)
 
 	err = bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(password))
 	if err != nil {
 		return "", ErrUnauthorized.New(credentialsErrMsg)
 	}
 
+	// only reveal the identity provider to the owner of the account.
+	if provider, ok := s.config.SSO.Providers.Enforced(user.Email); ok {
+		return "", ErrSSORequired.New("log in with %s", provider.Name)
+	}
+
 	claims := consoleauth.Claims{
 		ID:         user.ID,
 		Expiration: time.Now().Add(tokenExpirationTime),
@@ -847,6 +878,7 @@ func (s *Service) UpdateAccount(ctx context.Context, fullName string, shortName
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "update account", &auth.User, AuditTargetUser, auth.User.ID.String(), nil, &err)
 
 	// validate fullName
 	err = ValidateFullName(fullName)
@@ -876,6 +908,7 @@ func (s *Service) ChangeEmail(ctx context.Context, newEmail string) (err error)
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "change email", &auth.User, AuditTargetUser, auth.User.ID.String(), nil, &err)
 
 	if _, err := mail.ParseAddress(newEmail); err != nil {
 		return ErrValidation.Wrap(err)
@@ -902,6 +935,7 @@ func (s *Service) ChangePassword(ctx context.Context, pass, newPass string) (err
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "change password", &auth.User, AuditTargetUser, auth.User.ID.String(), nil, &err)
 
 	err = bcrypt.CompareHashAndPassword(auth.User.PasswordHash, []byte(pass))
 	if err != nil {
@@ -933,6 +967,7 @@ func (s *Service) DeleteAccount(ctx context.Context, password string) (err error
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "delete account", &auth.User, AuditTargetUser, auth.User.ID.String(), nil, &err)
 
 	err = bcrypt.CompareHashAndPassword(auth.User.PasswordHash, []byte(password))
 	if err != nil {
@@ -1029,6 +1064,14 @@ func (s *Service) CreateProject(ctx context.Context, projectInfo ProjectInfo) (p
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
+	defer func() {
+		var projectID *uuid.UUID
+		var targetID string
+		if p != nil {
+			projectID, targetID = &p.ID, p.ID.String()
+		}
+		s.auditMutation(ctx, "create project", &auth.User, AuditTargetProject, targetID, projectID, &err)
+	}()
 
 	err = s.checkProjectLimit(ctx, auth.User.ID)
 	if err != nil {
@@ -1101,6 +1144,7 @@ func (s *Service) DeleteProject(ctx context.Context, projectID uuid.UUID) (err e
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "delete project", &auth.User, AuditTargetProject, projectID.String(), &projectID, &err)
 
 	_, err = s.isProjectOwner(ctx, auth.User.ID, projectID)
 	if err != nil {
@@ -1128,6 +1172,7 @@ func (s *Service) UpdateProject(ctx context.Context, projectID uuid.UUID, name s
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "update project name and description", &auth.User, AuditTargetProject, projectID.String(), &projectID, &err)
 
 	err = ValidateNameAndDescription(name, description)
 	if err != nil {
@@ -1157,6 +1202,7 @@ func (s *Service) AddProjectMembers(ctx context.Context, projectID uuid.UUID, em
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "add project members", &auth.User, AuditTargetProject, projectID.String(), &projectID, &err)
 
 	if _, err = s.isProjectMember(ctx, auth.User.ID, projectID); err != nil {
 		return nil, Error.Wrap(err)
@@ -1202,6 +1248,7 @@ func (s *Service) DeleteProjectMembers(ctx context.Context, projectID uuid.UUID,
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "delete project members", &auth.User, AuditTargetProject, projectID.String(), &projectID, &err)
 
 	if _, err = s.isProjectMember(ctx, auth.User.ID, projectID); err != nil {
 		return Error.Wrap(err)
@@ -1276,11 +1323,24 @@ func (s *Service) GetProjectMembers(ctx context.Context, projectID uuid.UUID, cu
 // CreateAPIKey creates new api key.
 func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name string) (_ *APIKeyInfo, _ *macaroon.APIKey, err error) {
 	defer mon.Task()(&ctx)(&err)
+	return s.CreateRestrictedAPIKey(ctx, projectID, name, nil)
+}
+
+// CreateRestrictedAPIKey creates new api key, which is restricted by a caveat
+// built from restrictions. Nil restrictions create an unrestricted key.
+func (s *Service) CreateRestrictedAPIKey(ctx context.Context, projectID uuid.UUID, name string, restrictions *APIKeyRestrictions) (_ *APIKeyInfo, _ *macaroon.APIKey, err error) {
+	defer mon.Task()(&ctx)(&err)
 
-	auth, err := s.getAuthAndAuditLog(ctx, "create api key", zap.String("projectID", projectID.String()))
+	auth, err := s.getAuthAndAuditLog(ctx, "create api key", zap.String("projectID", projectID.String()), zap.Bool("restricted", restrictions != nil))
 	if err != nil {
 		return nil, nil, Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "create api key", &auth.User, AuditTargetAPIKey, name, &projectID, &err)
+
+	err = ValidateAPIKeyRestrictions(restrictions, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
 
 	_, err = s.isProjectMember(ctx, auth.User.ID, projectID)
 	if err != nil {
@@ -1302,14 +1362,29 @@ func (s *Service) CreateAPIKey(ctx context.Context, projectID uuid.UUID, name st
 		return nil, nil, Error.Wrap(err)
 	}
 
+	// the head stays the same when restricting, so the key is still found by it.
+	head := key.Head()
+	if restrictions != nil {
+		caveat, err := restrictions.Caveat()
+		if err != nil {
+			return nil, nil, Error.Wrap(err)
+		}
+
+		key, err = key.Restrict(caveat)
+		if err != nil {
+			return nil, nil, Error.Wrap(err)
+		}
+	}
+
 	apikey := APIKeyInfo{
-		Name:      name,
-		ProjectID: projectID,
-		Secret:    secret,
-		PartnerID: auth.User.PartnerID,
+		Name:         name,
+		ProjectID:    projectID,
+		Secret:       secret,
+		PartnerID:    auth.User.PartnerID,
+		Restrictions: restrictions,
 	}
 
-	info, err := s.store.APIKeys().Create(ctx, key.Head(), apikey)
+	info, err := s.store.APIKeys().Create(ctx, head, apikey)
 	if err != nil {
 		return nil, nil, Error.Wrap(err)
 	}
@@ -1352,6 +1427,7 @@ func (s *Service) DeleteAPIKeys(ctx context.Context, ids []uuid.UUID) (err error
 	if err != nil {
 		return Error.Wrap(err)
 	}
+	defer s.auditMutation(ctx, "delete api keys", &auth.User, AuditTargetAPIKey, strings.Join(idStrings, ","), nil, &err)
 
 	var keysErr errs.Group
 
@@ -5,9 +5,11 @@ package console_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"storj.io/common/macaroon"
 	"storj.io/common/storj"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
@@ -64,6 +66,26 @@ func TestService(t *testing.T) {
 				require.Nil(t, updatedPro)
 			})
 
+			t.Run("TestAuditLog", func(t *testing.T) {
+				// both the successful and the failed update are persisted
+				entries, err := sat.API.DB.Console().AuditLogs().List(ctx, console.AuditLogFilter{ProjectID: &up1Pro1.ID})
+				require.NoError(t, err)
+				require.NotEmpty(t, entries)
+				require.Equal(t, "update project name and description", entries[0].Operation)
+				require.Equal(t, console.AuditTargetProject, entries[0].TargetType)
+				require.Equal(t, up1Pro1.ID.String(), entries[0].TargetID)
+				require.Equal(t, console.AuditSuccess, entries[0].Outcome)
+				require.Equal(t, up1Pro1.OwnerID, *entries[0].UserID)
+
+				entries, err = sat.API.DB.Console().AuditLogs().List(ctx, console.AuditLogFilter{ProjectID: &up2Pro1.ID})
+				require.NoError(t, err)
+				require.NotEmpty(t, entries)
+				require.Equal(t, "update project name and description", entries[0].Operation)
+				require.Equal(t, console.AuditFailure, entries[0].Outcome)
+				require.NotEmpty(t, entries[0].Error)
+				require.Equal(t, up1Pro1.OwnerID, *entries[0].UserID)
+			})
+
 			t.Run("TestAddProjectMembers", func(t *testing.T) {
 				// Adding members to own project should work
 				addedUsers, err := service.AddProjectMembers(authCtx1, up1Pro1.ID, []string{up2User.Email})
@@ -176,5 +198,46 @@ func TestService(t *testing.T) {
 				require.Error(t, err)
 				require.Nil(t, bucketsForUnauthorizedUser)
 			})
+
+			t.Run("TestCreateRestrictedAPIKey", func(t *testing.T) {
+				notAfter := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+				restrictions := &console.APIKeyRestrictions{
+					DisallowWrites: true,
+					AllowedPaths:   []console.APIKeyPath{{Bucket: "bucket"}},
+					NotAfter:       &notAfter,
+				}
+
+				info, key, err := service.CreateRestrictedAPIKey(authCtx1, up1Pro1.ID, "restricted", restrictions)
+				require.NoError(t, err)
+				require.Equal(t, restrictions, info.Restrictions)
+
+				stored, err := sat.API.DB.Console().APIKeys().GetByHead(ctx, key.Head())
+				require.NoError(t, err)
+				require.Equal(t, info.ID, stored.ID)
+
+				// the restrictions are enforced by the key itself
+				now := time.Now()
+				err = key.Check(ctx, stored.Secret, macaroon.Action{Op: macaroon.ActionRead, Bucket: []byte("bucket"), EncryptedPath: []byte("object"), Time: now}, nil)
+				require.NoError(t, err)
+				err = key.Check(ctx, stored.Secret, macaroon.Action{Op: macaroon.ActionWrite, Bucket: []byte("bucket"), EncryptedPath: []byte("object"), Time: now}, nil)
+				require.Error(t, err)
+				err = key.Check(ctx, stored.Secret, macaroon.Action{Op: macaroon.ActionRead, Bucket: []byte("other"), EncryptedPath: []byte("object"), Time: now}, nil)
+				require.Error(t, err)
+
+				page, err := service.GetAPIKeys(authCtx1, up1Pro1.ID, console.APIKeyCursor{Search: "restricted", Limit: 10, Page: 1})
+				require.NoError(t, err)
+				require.Len(t, page.APIKeys, 1)
+				require.Equal(t, restrictions, page.APIKeys[0].Restrictions)
+
+				// invalid restrictions are rejected
+				_, _, err = service.CreateRestrictedAPIKey(authCtx1, up1Pro1.ID, "invalid", &console.APIKeyRestrictions{
+					AllowedPaths: []console.APIKeyPath{{EncryptedPrefix: []byte("encrypted-prefix")}},
+				})
+				require.True(t, console.ErrValidation.Has(err))
+
+				// creating keys for someone else project should not work
+				_, _, err = service.CreateRestrictedAPIKey(authCtx1, up2Pro1.ID, "restricted", restrictions)
+				require.Error(t, err)
+			})
 		})
 }
@@ -0,0 +1,159 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package console
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console/consoleauth"
+)
+
+// SSOIdentities links the identities of single sign-on providers to users.
+//
+// architecture: Database
+type SSOIdentities interface {
+	// GetUserID returns the user linked to the subject of the provider.
+	GetUserID(ctx context.Context, provider, subject string) (uuid.UUID, error)
+	// Insert links the subject of the provider to the user.
+	Insert(ctx context.Context, provider, subject string, userID uuid.UUID) error
+}
+
+// SSOIdentity is the identity of a user, who logged in with a single sign-on provider.
+type SSOIdentity struct {
+	// Provider is the name of the provider.
+	Provider string
+	// Subject identifies the user at the provider.
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FullName      string
+}
+
+// SSOToken authenticates a user, who logged in with a single sign-on provider, and returns an auth token.
+//
+// Identities are linked to the user with the same email on the first login,
+// when the provider verified the email. Users, which don't exist yet, are
+// created when it's enabled in the config.
+func (s *Service) SSOToken(ctx context.Context, identity SSOIdentity) (token string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	user, err := s.ssoUser(ctx, identity)
+	if err != nil {
+		s.auditLog(ctx, "sso login failed", nil, identity.Email, zap.String("provider", identity.Provider), zap.Error(err))
+		return "", err
+	}
+	defer s.auditMutation(ctx, "sso login", user, AuditTargetUser, user.ID.String(), nil, &err)
+
+	if user.Status == Deleted {
+		return "", ErrUnauthorized.New(credentialsErrMsg)
+	}
+	// users of enforced domains can't bypass their provider with another one.
+	if provider, ok := s.config.SSO.Providers.Enforced(user.Email); ok && provider.Name != identity.Provider {
+		return "", ErrSSORequired.New("log in with %s", provider.Name)
+	}
+	if user.Status == Inactive {
+		// the provider verified the email, which is what the activation does.
+		user.Status = Active
+		err = s.store.Users().Update(ctx, user)
+		if err != nil {
+			return "", Error.Wrap(err)
+		}
+	}
+
+	claims := consoleauth.Claims{
+		ID:         user.ID,
+		Expiration: time.Now().Add(tokenExpirationTime),
+	}
+
+	token, err = s.createToken(ctx, &claims)
+	if err != nil {
+		return "", err
+	}
+	s.auditLog(ctx, "sso login", &user.ID, user.Email, zap.String("provider", identity.Provider))
+
+	return token, nil
+}
+
+// ssoUser returns the user linked to the identity, links it or creates the user.
+func (s *Service) ssoUser(ctx context.Context, identity SSOIdentity) (_ *User, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	userID, err := s.store.SSOIdentities().GetUserID(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		user, err := s.store.Users().Get(ctx, userID)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, Error.Wrap(err)
+	}
+
+	// unverified emails could belong to anyone.
+	if !identity.EmailVerified || identity.Email == "" {
+		return nil, ErrUnauthorized.New("single sign-on email is not verified")
+	}
+	if provider, ok := s.config.SSO.Providers.Enforced(identity.Email); ok && provider.Name != identity.Provider {
+		return nil, ErrSSORequired.New("log in with %s", provider.Name)
+	}
+
+	user, err := s.store.Users().GetByEmail(ctx, identity.Email)
+	if err == nil {
+		err = s.store.SSOIdentities().Insert(ctx, identity.Provider, identity.Subject, user.ID)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, Error.Wrap(err)
+	}
+
+	if !s.config.SSO.CreateUsers {
+		return nil, ErrUnauthorized.New("no account is registered for %s", identity.Email)
+	}
+
+	// users created by single sign-on can't log in with a password, until they reset it.
+	secret, err := NewRegistrationSecret()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret.String()), s.config.PasswordCost)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	err = s.store.WithTx(ctx, func(ctx context.Context, tx DBTx) error {
+		userID, err := uuid.New()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		user, err = tx.Users().Insert(ctx, &User{
+			ID:           userID,
+			Email:        identity.Email,
+			FullName:     identity.FullName,
+			PasswordHash: hash,
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		return Error.Wrap(tx.SSOIdentities().Insert(ctx, identity.Provider, identity.Subject, user.ID))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.auditLog(ctx, "create user", &user.ID, user.Email, zap.String("provider", identity.Provider))
+
+	return user, nil
+}
@@ -4,6 +4,8 @@
 package console
 
 import (
+	"time"
+
 	"github.com/zeebo/errs"
 )
 
@@ -51,3 +53,31 @@ func ValidateFullName(name string) error {
 
 	return nil
 }
+
+// ValidateAPIKeyRestrictions validates the restrictions of a new api key.
+func ValidateAPIKeyRestrictions(restrictions *APIKeyRestrictions, now time.Time) error {
+	if restrictions == nil {
+		return nil
+	}
+
+	var errs validationErrors
+
+	if restrictions.DisallowReads && restrictions.DisallowWrites && restrictions.DisallowLists && restrictions.DisallowDeletes {
+		errs.Addf("api key must allow at least one action")
+	}
+	for _, path := range restrictions.AllowedPaths {
+		if path.Bucket == "" {
+			errs.Addf("allowed path must contain a bucket name")
+		}
+	}
+	if restrictions.NotAfter != nil {
+		if !restrictions.NotAfter.After(now) {
+			errs.Addf("api key expiration must be in the future")
+		}
+		if restrictions.NotBefore != nil && !restrictions.NotAfter.After(*restrictions.NotBefore) {
+			errs.Addf("api key expiration must be after its start")
+		}
+	}
+
+	return errs.Combine()
+}
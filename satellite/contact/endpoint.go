@@ -12,6 +12,7 @@ import (
 
 	"storj.io/common/identity"
 	"storj.io/common/pb"
+	"storj.io/common/rpc/rpcpeer"
 	"storj.io/common/rpc/rpcstatus"
 	"storj.io/common/storj"
 	"storj.io/storj/satellite/overlay"
@@ -45,7 +46,12 @@ func NewEndpoint(log *zap.Logger, service *Service) *Endpoint {
 func (endpoint *Endpoint) CheckIn(ctx context.Context, req *pb.CheckInRequest) (_ *pb.CheckInResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	peerID, err := identity.PeerIdentityFromContext(ctx)
+	peer, err := rpcpeer.FromContext(ctx)
+	if err != nil {
+		endpoint.log.Info("failed to get peer from context", zap.String("node address", req.Address), zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Unknown, errCheckInIdentity.New("failed to get peer from context: %v", err).Error())
+	}
+	peerID, err := identity.PeerIdentityFromPeer(peer)
 	if err != nil {
 		endpoint.log.Info("failed to get node ID from context", zap.String("node address", req.Address), zap.Error(err))
 		return nil, rpcstatus.Error(rpcstatus.Unknown, errCheckInIdentity.New("failed to get ID from context: %v", err).Error())
@@ -97,7 +103,7 @@ func (endpoint *Endpoint) CheckIn(ctx context.Context, req *pb.CheckInRequest) (
 		return nil, rpcstatus.Error(rpcstatus.Internal, Error.Wrap(err).Error())
 	}
 
-	endpoint.log.Debug("checking in", zap.String("node addr", req.Address), zap.Bool("ping node success", pingNodeSuccess), zap.String("ping node err msg", pingErrorMessage))
+	endpoint.log.Debug("checking in", zap.String("node addr", req.Address), zap.Stringer("source addr", peer.Addr), zap.Bool("ping node success", pingNodeSuccess), zap.String("ping node err msg", pingErrorMessage))
 	return &pb.CheckInResponse{
 		PingNodeSuccess:  pingNodeSuccess,
 		PingErrorMessage: pingErrorMessage,
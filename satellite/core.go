@@ -30,6 +30,7 @@ import (
 	"storj.io/storj/satellite/accounting/rollup"
 	"storj.io/storj/satellite/accounting/tally"
 	"storj.io/storj/satellite/audit"
+	"storj.io/storj/satellite/console/auditlogcleanup"
 	"storj.io/storj/satellite/contact"
 	"storj.io/storj/satellite/dbcleanup"
 	"storj.io/storj/satellite/gc"
@@ -42,6 +43,7 @@ import (
 	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/repair/checker"
+	"storj.io/storj/satellite/repair/reencode"
 )
 
 // Core is the satellite core process that runs chores.
@@ -80,6 +82,7 @@ type Core struct {
 
 	Metainfo struct {
 		Database metainfo.PointerDB // TODO: move into pointerDB
+		Metabase metainfo.MetabaseDB
 		Service  *metainfo.Service
 		Loop     *metainfo.Loop
 	}
@@ -91,7 +94,8 @@ type Core struct {
 	}
 
 	Repair struct {
-		Checker *checker.Checker
+		Checker       *checker.Checker
+		ReencodeChore *reencode.Chore
 	}
 	Audit struct {
 		Queues   *audit.Queues
@@ -113,6 +117,10 @@ type Core struct {
 		Chore *dbcleanup.Chore
 	}
 
+	AuditLogCleanup struct {
+		Chore *auditlogcleanup.Chore
+	}
+
 	Accounting struct {
 		Tally                 *tally.Service
 		Rollup                *rollup.Service
@@ -140,7 +148,7 @@ type Core struct {
 
 // New creates a new satellite.
 func New(log *zap.Logger, full *identity.FullIdentity, db DB,
-	pointerDB metainfo.PointerDB, revocationDB extensions.RevocationDB, liveAccounting accounting.Cache,
+	pointerDB metainfo.PointerDB, metabaseDB metainfo.MetabaseDB, revocationDB extensions.RevocationDB, liveAccounting accounting.Cache,
 	rollupsWriteCache *orders.RollupsWriteCache,
 	versionInfo version.Info, config *Config, atomicLogLevel *zap.AtomicLevel) (*Core, error) {
 	peer := &Core{
@@ -265,11 +273,13 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 
 	{ // setup metainfo
 		peer.Metainfo.Database = pointerDB // for logging: storelogger.New(peer.Log.Named("pdb"), db)
+		peer.Metainfo.Metabase = metabaseDB
 		peer.Metainfo.Service = metainfo.NewService(peer.Log.Named("metainfo:service"),
 			peer.Metainfo.Database,
+			peer.Metainfo.Metabase,
 			peer.DB.Buckets(),
 		)
-		peer.Metainfo.Loop = metainfo.NewLoop(config.Metainfo.Loop, peer.Metainfo.Database)
+		peer.Metainfo.Loop = metainfo.NewLoop(config.Metainfo.Loop, peer.Metainfo.Database, peer.Metainfo.Metabase)
 		peer.Services.Add(lifecycle.Item{
 			Name:  "metainfo:loop",
 			Run:   peer.Metainfo.Loop.Run,
@@ -278,15 +288,19 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 	}
 
 	{ // setup datarepair
+		checkerConfig := config.Checker
+		checkerConfig.RepairOverrides = config.Checker.RepairOverrides.WithProfiles(config.Metainfo.RedundancyProfiles)
+
 		// TODO: simplify argument list somehow
 		peer.Repair.Checker = checker.NewChecker(
 			peer.Log.Named("repair:checker"),
 			peer.DB.RepairQueue(),
 			peer.DB.Irreparable(),
+			peer.DB.RepairPriorities(),
 			peer.Metainfo.Service,
 			peer.Metainfo.Loop,
 			peer.Overlay.Service,
-			config.Checker)
+			checkerConfig)
 		peer.Services.Add(lifecycle.Item{
 			Name:  "repair:checker",
 			Run:   peer.Repair.Checker.Run,
@@ -298,6 +312,22 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			debug.Cycle("Repair Checker Irreparable", peer.Repair.Checker.IrreparableLoop))
 	}
 
+	if config.Reencode.Enabled { // setup re-encode chore
+		peer.Repair.ReencodeChore = reencode.NewChore(
+			peer.Log.Named("repair:reencode-chore"),
+			peer.DB.ReencodeQueue(),
+			reencode.NewTargets(peer.DB.Buckets(), config.Metainfo),
+			peer.Metainfo.Loop,
+			config.Reencode)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "repair:reencode-chore",
+			Run:   peer.Repair.ReencodeChore.Run,
+			Close: peer.Repair.ReencodeChore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Repair Re-encode Chore", peer.Repair.ReencodeChore.Loop))
+	}
+
 	{ // setup audit
 		config := config.Audit
 
@@ -317,6 +347,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		peer.Audit.Reporter = audit.NewReporter(log.Named("audit:reporter"),
 			peer.Overlay.Service,
 			peer.DB.Containment(),
+			peer.DB.NodeAudits(),
 			config.MaxRetriesStatDB,
 			int32(config.MaxReverifyCount),
 		)
@@ -342,6 +373,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 		peer.Audit.Chore = audit.NewChore(peer.Log.Named("audit:chore"),
 			peer.Audit.Queues,
 			peer.Metainfo.Loop,
+			peer.DB.NodeAudits(),
 			config,
 		)
 		peer.Services.Add(lifecycle.Item{
@@ -397,6 +429,17 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			debug.Cycle("DB Cleanup Serials", peer.DBCleanup.Chore.Serials))
 	}
 
+	{ // setup audit log cleanup
+		peer.AuditLogCleanup.Chore = auditlogcleanup.NewChore(peer.Log.Named("auditlogcleanup"), peer.DB.Console().AuditLogs(), config.AuditLogCleanup)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "auditlogcleanup",
+			Run:   peer.AuditLogCleanup.Chore.Run,
+			Close: peer.AuditLogCleanup.Chore.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Audit Log Cleanup", peer.AuditLogCleanup.Chore.Loop))
+	}
+
 	{ // setup accounting
 		peer.Accounting.Tally = tally.New(peer.Log.Named("accounting:tally"), peer.DB.StoragenodeAccounting(), peer.DB.ProjectAccounting(), peer.LiveAccounting.Cache, peer.Metainfo.Loop, config.Tally.Interval)
 		peer.Services.Add(lifecycle.Item{
@@ -460,6 +503,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB,
 			peer.DB.StripeCoinPayments(),
 			peer.DB.Console().Projects(),
 			peer.DB.ProjectAccounting(),
+			metainfo.NewBucketPriceMultipliers(peer.DB.Buckets(), config.Metainfo.RedundancyProfiles),
 			pc.StorageTBPrice,
 			pc.EgressTBPrice,
 			pc.ObjectPrice,
@@ -57,6 +57,7 @@ type GarbageCollection struct {
 
 	Metainfo struct {
 		Database metainfo.PointerDB
+		Metabase metainfo.MetabaseDB
 		Loop     *metainfo.Loop
 	}
 
@@ -71,7 +72,7 @@ type GarbageCollection struct {
 
 // NewGarbageCollection creates a new satellite garbage collection process.
 func NewGarbageCollection(log *zap.Logger, full *identity.FullIdentity, db DB,
-	pointerDB metainfo.PointerDB, revocationDB extensions.RevocationDB,
+	pointerDB metainfo.PointerDB, metabaseDB metainfo.MetabaseDB, revocationDB extensions.RevocationDB,
 	versionInfo version.Info, config *Config, atomicLogLevel *zap.AtomicLevel) (*GarbageCollection, error) {
 	peer := &GarbageCollection{
 		Log:      log,
@@ -135,12 +136,13 @@ func NewGarbageCollection(log *zap.Logger, full *identity.FullIdentity, db DB,
 
 	{ // setup metainfo
 		peer.Metainfo.Database = pointerDB
+		peer.Metainfo.Metabase = metabaseDB
 
 		// Garbage Collection creates its own instance of the metainfo loop here. Since
 		// GC runs infrequently, this shouldn't add too much extra load on the metainfo db.
 		// As long as garbage collection is the only observer joining the metainfo loop, then by default
 		// the metainfo loop will only run when the garbage collection joins (which happens every GarbageCollection.Interval)
-		peer.Metainfo.Loop = metainfo.NewLoop(config.Metainfo.Loop, peer.Metainfo.Database)
+		peer.Metainfo.Loop = metainfo.NewLoop(config.Metainfo.Loop, peer.Metainfo.Database, peer.Metainfo.Metabase)
 		peer.Services.Add(lifecycle.Item{
 			Name:  "metainfo:loop",
 			Run:   peer.Metainfo.Loop.Run,
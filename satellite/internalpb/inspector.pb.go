@@ -315,6 +315,162 @@ func (m *IrreparableSegment) GetRepairAttemptCount() int64 {
 	return 0
 }
 
+type NodeAuditsRequest struct {
+	NodeIds              []NodeID `protobuf:"bytes,1,rep,name=node_ids,json=nodeIds,proto3,customtype=NodeID" json:"node_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeAuditsRequest) Reset()         { *m = NodeAuditsRequest{} }
+func (m *NodeAuditsRequest) String() string { return proto.CompactTextString(m) }
+func (*NodeAuditsRequest) ProtoMessage()    {}
+func (*NodeAuditsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{7}
+}
+func (m *NodeAuditsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeAuditsRequest.Unmarshal(m, b)
+}
+func (m *NodeAuditsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeAuditsRequest.Marshal(b, m, deterministic)
+}
+func (m *NodeAuditsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeAuditsRequest.Merge(m, src)
+}
+func (m *NodeAuditsRequest) XXX_Size() int {
+	return xxx_messageInfo_NodeAuditsRequest.Size(m)
+}
+func (m *NodeAuditsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeAuditsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeAuditsRequest proto.InternalMessageInfo
+
+type NodeAuditsResponse struct {
+	Nodes                []*NodeAudits `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *NodeAuditsResponse) Reset()         { *m = NodeAuditsResponse{} }
+func (m *NodeAuditsResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeAuditsResponse) ProtoMessage()    {}
+func (*NodeAuditsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{8}
+}
+func (m *NodeAuditsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeAuditsResponse.Unmarshal(m, b)
+}
+func (m *NodeAuditsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeAuditsResponse.Marshal(b, m, deterministic)
+}
+func (m *NodeAuditsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeAuditsResponse.Merge(m, src)
+}
+func (m *NodeAuditsResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeAuditsResponse.Size(m)
+}
+func (m *NodeAuditsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeAuditsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeAuditsResponse proto.InternalMessageInfo
+
+func (m *NodeAuditsResponse) GetNodes() []*NodeAudits {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type NodeAudits struct {
+	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	FirstAudited         int64    `protobuf:"varint,2,opt,name=first_audited,json=firstAudited,proto3" json:"first_audited,omitempty"`
+	LastAudited          int64    `protobuf:"varint,3,opt,name=last_audited,json=lastAudited,proto3" json:"last_audited,omitempty"`
+	AuditCount           int64    `protobuf:"varint,4,opt,name=audit_count,json=auditCount,proto3" json:"audit_count,omitempty"`
+	AuditsPerDay         float64  `protobuf:"fixed64,5,opt,name=audits_per_day,json=auditsPerDay,proto3" json:"audits_per_day,omitempty"`
+	Vetted               bool     `protobuf:"varint,6,opt,name=vetted,proto3" json:"vetted,omitempty"`
+	Suspended            bool     `protobuf:"varint,7,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	Overdue              bool     `protobuf:"varint,8,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeAudits) Reset()         { *m = NodeAudits{} }
+func (m *NodeAudits) String() string { return proto.CompactTextString(m) }
+func (*NodeAudits) ProtoMessage()    {}
+func (*NodeAudits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{9}
+}
+func (m *NodeAudits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeAudits.Unmarshal(m, b)
+}
+func (m *NodeAudits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeAudits.Marshal(b, m, deterministic)
+}
+func (m *NodeAudits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeAudits.Merge(m, src)
+}
+func (m *NodeAudits) XXX_Size() int {
+	return xxx_messageInfo_NodeAudits.Size(m)
+}
+func (m *NodeAudits) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeAudits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeAudits proto.InternalMessageInfo
+
+func (m *NodeAudits) GetFirstAudited() int64 {
+	if m != nil {
+		return m.FirstAudited
+	}
+	return 0
+}
+
+func (m *NodeAudits) GetLastAudited() int64 {
+	if m != nil {
+		return m.LastAudited
+	}
+	return 0
+}
+
+func (m *NodeAudits) GetAuditCount() int64 {
+	if m != nil {
+		return m.AuditCount
+	}
+	return 0
+}
+
+func (m *NodeAudits) GetAuditsPerDay() float64 {
+	if m != nil {
+		return m.AuditsPerDay
+	}
+	return 0
+}
+
+func (m *NodeAudits) GetVetted() bool {
+	if m != nil {
+		return m.Vetted
+	}
+	return false
+}
+
+func (m *NodeAudits) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
+	}
+	return false
+}
+
+func (m *NodeAudits) GetOverdue() bool {
+	if m != nil {
+		return m.Overdue
+	}
+	return false
+}
+
 type ObjectHealthRequest struct {
 	EncryptedPath        []byte   `protobuf:"bytes,1,opt,name=encrypted_path,json=encryptedPath,proto3" json:"encrypted_path,omitempty"`
 	Bucket               []byte   `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
@@ -331,7 +487,7 @@ func (m *ObjectHealthRequest) Reset()         { *m = ObjectHealthRequest{} }
 func (m *ObjectHealthRequest) String() string { return proto.CompactTextString(m) }
 func (*ObjectHealthRequest) ProtoMessage()    {}
 func (*ObjectHealthRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{7}
+	return fileDescriptor_a07d9034b2dd9d26, []int{10}
 }
 func (m *ObjectHealthRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ObjectHealthRequest.Unmarshal(m, b)
@@ -405,7 +561,7 @@ func (m *ObjectHealthResponse) Reset()         { *m = ObjectHealthResponse{} }
 func (m *ObjectHealthResponse) String() string { return proto.CompactTextString(m) }
 func (*ObjectHealthResponse) ProtoMessage()    {}
 func (*ObjectHealthResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{8}
+	return fileDescriptor_a07d9034b2dd9d26, []int{11}
 }
 func (m *ObjectHealthResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ObjectHealthResponse.Unmarshal(m, b)
@@ -453,7 +609,7 @@ func (m *SegmentHealthRequest) Reset()         { *m = SegmentHealthRequest{} }
 func (m *SegmentHealthRequest) String() string { return proto.CompactTextString(m) }
 func (*SegmentHealthRequest) ProtoMessage()    {}
 func (*SegmentHealthRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{9}
+	return fileDescriptor_a07d9034b2dd9d26, []int{12}
 }
 func (m *SegmentHealthRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentHealthRequest.Unmarshal(m, b)
@@ -513,7 +669,7 @@ func (m *SegmentHealthResponse) Reset()         { *m = SegmentHealthResponse{} }
 func (m *SegmentHealthResponse) String() string { return proto.CompactTextString(m) }
 func (*SegmentHealthResponse) ProtoMessage()    {}
 func (*SegmentHealthResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{10}
+	return fileDescriptor_a07d9034b2dd9d26, []int{13}
 }
 func (m *SegmentHealthResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentHealthResponse.Unmarshal(m, b)
@@ -561,7 +717,7 @@ func (m *SegmentHealth) Reset()         { *m = SegmentHealth{} }
 func (m *SegmentHealth) String() string { return proto.CompactTextString(m) }
 func (*SegmentHealth) ProtoMessage()    {}
 func (*SegmentHealth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{11}
+	return fileDescriptor_a07d9034b2dd9d26, []int{14}
 }
 func (m *SegmentHealth) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentHealth.Unmarshal(m, b)
@@ -596,6 +752,9 @@ func init() {
 	proto.RegisterType((*ListIrreparableSegmentsRequest)(nil), "satellite.inspector.ListIrreparableSegmentsRequest")
 	proto.RegisterType((*ListIrreparableSegmentsResponse)(nil), "satellite.inspector.ListIrreparableSegmentsResponse")
 	proto.RegisterType((*IrreparableSegment)(nil), "satellite.inspector.IrreparableSegment")
+	proto.RegisterType((*NodeAuditsRequest)(nil), "satellite.inspector.NodeAuditsRequest")
+	proto.RegisterType((*NodeAuditsResponse)(nil), "satellite.inspector.NodeAuditsResponse")
+	proto.RegisterType((*NodeAudits)(nil), "satellite.inspector.NodeAudits")
 	proto.RegisterType((*ObjectHealthRequest)(nil), "satellite.inspector.ObjectHealthRequest")
 	proto.RegisterType((*ObjectHealthResponse)(nil), "satellite.inspector.ObjectHealthResponse")
 	proto.RegisterType((*SegmentHealthRequest)(nil), "satellite.inspector.SegmentHealthRequest")
@@ -606,60 +765,71 @@ func init() {
 func init() { proto.RegisterFile("inspector.proto", fileDescriptor_a07d9034b2dd9d26) }
 
 var fileDescriptor_a07d9034b2dd9d26 = []byte{
-	// 833 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcd, 0x6e, 0x23, 0x45,
-	0x10, 0xa6, 0xe3, 0xc4, 0xb0, 0x65, 0x67, 0x93, 0x74, 0xcc, 0x62, 0x19, 0x41, 0xa2, 0x59, 0x65,
-	0xd7, 0xbb, 0x8b, 0xc6, 0xc8, 0x81, 0x03, 0x20, 0x21, 0x6d, 0x36, 0x07, 0x2c, 0x21, 0x36, 0x9a,
-	0xdc, 0x56, 0x42, 0xa3, 0xf1, 0x4c, 0x39, 0x9e, 0xdd, 0x71, 0xf7, 0xd0, 0xdd, 0x46, 0xf8, 0xce,
-	0x03, 0x20, 0x71, 0xe2, 0xc0, 0x5b, 0xf0, 0x1a, 0xbc, 0x00, 0x07, 0x0e, 0x1c, 0xe0, 0x35, 0x50,
-	0xff, 0xcc, 0x78, 0x6c, 0xcf, 0x12, 0xa3, 0xbd, 0x75, 0x57, 0x7d, 0x55, 0xfd, 0x55, 0x7d, 0x35,
-	0x65, 0xc3, 0x41, 0xca, 0x64, 0x8e, 0xb1, 0xe2, 0xc2, 0xcf, 0x05, 0x57, 0x9c, 0x1e, 0xcb, 0x48,
-	0x61, 0x96, 0xa5, 0x0a, 0xfd, 0xd2, 0xd5, 0x83, 0x1b, 0x7e, 0xc3, 0x2d, 0xa0, 0x07, 0x8c, 0x27,
-	0xe8, 0xce, 0x07, 0x39, 0x4f, 0x99, 0x42, 0x91, 0x8c, 0xad, 0xc1, 0x3b, 0x86, 0xa3, 0x67, 0x7c,
-	0xce, 0xd4, 0x37, 0x3c, 0x41, 0x19, 0xe0, 0x77, 0x73, 0x94, 0xca, 0x7b, 0x0c, 0xb4, 0x6a, 0x94,
-	0x39, 0x67, 0x12, 0x69, 0x07, 0xf6, 0x62, 0x6d, 0xed, 0x92, 0x53, 0xd2, 0x6f, 0x04, 0xf6, 0xe2,
-	0x51, 0x38, 0xbc, 0x9c, 0xcf, 0xf2, 0x95, 0xf8, 0x4f, 0xe1, 0xa8, 0x62, 0x73, 0xe1, 0xa7, 0xb0,
-	0xa7, 0x89, 0xc8, 0x2e, 0x39, 0x6d, 0xf4, 0x5b, 0x43, 0xf0, 0x0d, 0x2d, 0x8d, 0x09, 0xac, 0xc3,
-	0x7b, 0x05, 0x1f, 0x7e, 0x9d, 0x4a, 0x35, 0x12, 0x02, 0xf3, 0x48, 0x44, 0xe3, 0x0c, 0xaf, 0xf1,
-	0x66, 0x86, 0x4c, 0x15, 0x89, 0x35, 0x85, 0x2c, 0x9d, 0xa5, 0x96, 0xc2, 0x5e, 0x60, 0x2f, 0xf4,
-	0x1c, 0xee, 0x65, 0x91, 0x54, 0xa1, 0x44, 0x64, 0xa1, 0xb4, 0x21, 0x61, 0x1e, 0xa9, 0x69, 0x77,
-	0xe7, 0x94, 0xf4, 0xdb, 0xc1, 0xb1, 0xf6, 0x5e, 0x23, 0x32, 0x97, 0xee, 0x2a, 0x52, 0x53, 0x6f,
-	0x02, 0x27, 0xaf, 0x7d, 0xcc, 0x31, 0x7e, 0x06, 0xef, 0xb8, 0x6c, 0x05, 0xe9, 0x87, 0x7e, 0x4d,
-	0xb3, 0xfd, 0xcd, 0x1c, 0x41, 0x19, 0xe8, 0xfd, 0x4d, 0x80, 0x6e, 0x02, 0x28, 0x85, 0x5d, 0xc3,
-	0x90, 0x18, 0x86, 0xe6, 0x4c, 0x3f, 0x83, 0xbb, 0x05, 0xfb, 0x04, 0x55, 0x94, 0x66, 0x86, 0x7f,
-	0x6b, 0x48, 0xfd, 0xa5, 0x6a, 0x57, 0xf6, 0x14, 0xec, 0x3b, 0xe4, 0xa5, 0x01, 0xd2, 0x13, 0x68,
-	0x65, 0x5c, 0xaa, 0x30, 0x4f, 0x31, 0x46, 0xd9, 0x6d, 0x98, 0xf6, 0x80, 0x36, 0x5d, 0x19, 0x0b,
-	0xf5, 0xc1, 0x74, 0x21, 0xd4, 0x44, 0x52, 0x11, 0x46, 0x4a, 0xe1, 0x2c, 0x57, 0xdd, 0x5d, 0x23,
-	0xe5, 0x91, 0x76, 0x05, 0xc6, 0xf3, 0xd4, 0x3a, 0xe8, 0xc7, 0xd0, 0x59, 0x85, 0x86, 0x56, 0xfb,
-	0x3d, 0x13, 0x40, 0x45, 0x15, 0x6c, 0x66, 0xc5, 0xfb, 0x87, 0xc0, 0xf1, 0xf3, 0xf1, 0x4b, 0x8c,
-	0xd5, 0x57, 0x18, 0x65, 0x6a, 0x5a, 0x68, 0x76, 0x06, 0x77, 0x91, 0xc5, 0x62, 0x91, 0x2b, 0x4c,
-	0xc2, 0x4a, 0xcd, 0xfb, 0xa5, 0x55, 0xeb, 0x41, 0xef, 0x41, 0x73, 0x3c, 0x8f, 0x5f, 0xa1, 0x72,
-	0xa2, 0xb9, 0x1b, 0xfd, 0x00, 0x20, 0x17, 0x5c, 0xa7, 0x0d, 0xd3, 0xc4, 0x14, 0xd6, 0x0e, 0xee,
-	0x38, 0xcb, 0x28, 0xd1, 0x75, 0x49, 0x15, 0x09, 0x15, 0x46, 0x13, 0x85, 0xa2, 0x50, 0xbf, 0xa8,
-	0xcb, 0xb8, 0x9e, 0x6a, 0x4f, 0xd1, 0xf7, 0x8f, 0x80, 0x22, 0x4b, 0xc2, 0x31, 0x4e, 0xb8, 0xc0,
-	0x12, 0x6e, 0xab, 0x3a, 0x44, 0x96, 0x5c, 0x18, 0x47, 0x81, 0x2e, 0xe7, 0xad, 0x59, 0x99, 0x37,
-	0xef, 0x67, 0x02, 0x9d, 0xd5, 0x4a, 0xdd, 0xc0, 0x7c, 0xb9, 0x31, 0x30, 0x5e, 0xed, 0xc0, 0xb8,
-	0xf4, 0x2e, 0xba, 0x8c, 0xa1, 0x5f, 0x00, 0x08, 0x4c, 0xe6, 0x2c, 0x89, 0x58, 0xbc, 0x70, 0xe2,
-	0xbf, 0x5f, 0x11, 0x3f, 0x28, 0x9d, 0xd7, 0xf1, 0x14, 0x67, 0x18, 0x54, 0xe0, 0xde, 0x2f, 0x04,
-	0x3a, 0xab, 0x89, 0x9d, 0x00, 0xcb, 0xce, 0x92, 0x95, 0xce, 0x6e, 0x0a, 0xb3, 0x53, 0x27, 0xcc,
-	0x7d, 0x28, 0x66, 0x2d, 0x4c, 0x59, 0x82, 0x3f, 0x18, 0x0d, 0x1a, 0x41, 0xdb, 0x19, 0x47, 0xda,
-	0xb6, 0xa6, 0xd2, 0xee, 0x9a, 0x4a, 0xde, 0x4f, 0x04, 0xde, 0x5d, 0xe3, 0xe6, 0x5a, 0xf6, 0x39,
-	0x34, 0xa7, 0xc6, 0x62, 0xc8, 0x6d, 0xd7, 0x30, 0x17, 0xf1, 0x66, 0xed, 0xfa, 0x8d, 0xc0, 0xfe,
-	0x4a, 0x5a, 0xfa, 0x04, 0x5a, 0x36, 0xf1, 0x22, 0x4c, 0x13, 0x2b, 0x60, 0xfb, 0x02, 0xfe, 0xf8,
-	0xf3, 0xa4, 0xa9, 0x97, 0xd4, 0xe8, 0x32, 0x00, 0xe7, 0x1e, 0x25, 0x92, 0x0e, 0x60, 0x7f, 0xce,
-	0xaa, 0xf0, 0x9d, 0x0d, 0x78, 0xbb, 0x04, 0xe8, 0x80, 0x27, 0xd0, 0xe2, 0x93, 0x49, 0x96, 0x32,
-	0x34, 0xf0, 0xc6, 0x66, 0x76, 0xe7, 0xd6, 0xe0, 0x2e, 0xbc, 0x5d, 0x9d, 0xe4, 0x76, 0x50, 0x5c,
-	0x87, 0xbf, 0x13, 0x38, 0x7c, 0xfe, 0x3d, 0x8a, 0x2c, 0x5a, 0x8c, 0x8a, 0xf6, 0xd0, 0x6f, 0x01,
-	0x96, 0xfb, 0x9a, 0x3e, 0xa8, 0x6d, 0xe1, 0xc6, 0x96, 0xef, 0x3d, 0xbc, 0x15, 0xe7, 0x34, 0x7a,
-	0x01, 0x77, 0xca, 0x75, 0x4e, 0xcf, 0x6a, 0xa3, 0xd6, 0x7f, 0x02, 0x7a, 0x0f, 0x6e, 0x83, 0xd9,
-	0xdc, 0xc3, 0x5f, 0x09, 0x74, 0x2a, 0xeb, 0x71, 0x59, 0xd3, 0x8f, 0x04, 0xde, 0x7b, 0xcd, 0x82,
-	0xa6, 0xe7, 0xb5, 0xc9, 0xff, 0xfb, 0xb7, 0xa3, 0xf7, 0xc9, 0xff, 0x0b, 0x72, 0xfc, 0xfe, 0x22,
-	0x70, 0x60, 0xe7, 0x63, 0x49, 0x0d, 0xa1, 0x5d, 0xfd, 0xfc, 0x69, 0xbf, 0x36, 0x73, 0xcd, 0x2e,
-	0xec, 0x3d, 0xda, 0x02, 0x69, 0x1f, 0xf6, 0xde, 0xa2, 0xd3, 0xf5, 0x01, 0x7d, 0xb4, 0xc5, 0xb7,
-	0xe1, 0x1e, 0x7a, 0xbc, 0x0d, 0xb4, 0x78, 0xe9, 0xe2, 0xec, 0xc5, 0x7d, 0xa9, 0xb8, 0x78, 0xe9,
-	0xa7, 0x7c, 0x60, 0x0e, 0x83, 0x32, 0x7a, 0x60, 0x3e, 0x26, 0x16, 0x65, 0xf9, 0x78, 0xdc, 0x34,
-	0x7f, 0x19, 0xce, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xfd, 0xe6, 0x11, 0x41, 0x83, 0x08, 0x00,
-	0x00,
+	// 1018 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcd, 0x6e, 0x23, 0xc5,
+	0x13, 0xff, 0x4f, 0x9c, 0x38, 0x49, 0x79, 0xec, 0x24, 0x1d, 0xff, 0x17, 0xcb, 0x7c, 0xd8, 0x4c,
+	0x48, 0xe2, 0xdd, 0x45, 0x0e, 0x72, 0xd8, 0x03, 0x8b, 0x84, 0x94, 0x6c, 0x0e, 0x58, 0x20, 0x36,
+	0x9a, 0xdc, 0x56, 0x42, 0xa3, 0xb1, 0xbb, 0x1c, 0xcf, 0xee, 0x78, 0x66, 0xe8, 0xee, 0x89, 0xf0,
+	0x9d, 0x07, 0x40, 0x82, 0x0b, 0x07, 0xde, 0x82, 0x87, 0xe0, 0xc2, 0x0b, 0x70, 0xc8, 0x11, 0x5e,
+	0x03, 0xf5, 0xc7, 0x78, 0xc6, 0x1f, 0xd9, 0x04, 0x71, 0x9b, 0xae, 0xfa, 0x55, 0xf5, 0xcf, 0xbf,
+	0xaa, 0xea, 0x32, 0xec, 0x04, 0x11, 0x4f, 0x70, 0x28, 0x62, 0xd6, 0x4d, 0x58, 0x2c, 0x62, 0xb2,
+	0xcf, 0x7d, 0x81, 0x61, 0x18, 0x08, 0xec, 0xce, 0x5c, 0x4d, 0xb8, 0x8e, 0xaf, 0x63, 0x0d, 0x68,
+	0x42, 0x14, 0x53, 0x34, 0xdf, 0x3b, 0x49, 0x1c, 0x44, 0x02, 0x19, 0x1d, 0x68, 0x83, 0xb3, 0x0f,
+	0x7b, 0x2f, 0xe2, 0x34, 0x12, 0xdf, 0xc4, 0x14, 0xb9, 0x8b, 0xdf, 0xa5, 0xc8, 0x85, 0xf3, 0x04,
+	0x48, 0xd1, 0xc8, 0x93, 0x38, 0xe2, 0x48, 0xea, 0xb0, 0x31, 0x94, 0xd6, 0x86, 0xd5, 0xb6, 0x3a,
+	0x25, 0x57, 0x1f, 0x1c, 0x02, 0xbb, 0x17, 0xe9, 0x24, 0x99, 0x8b, 0x7f, 0x06, 0x7b, 0x05, 0x9b,
+	0x09, 0x6f, 0xc3, 0x86, 0x24, 0xc2, 0x1b, 0x56, 0xbb, 0xd4, 0xa9, 0xf4, 0xa0, 0xab, 0x68, 0x49,
+	0x8c, 0xab, 0x1d, 0xce, 0x1b, 0xf8, 0xe0, 0xeb, 0x80, 0x8b, 0x3e, 0x63, 0x98, 0xf8, 0xcc, 0x1f,
+	0x84, 0x78, 0x85, 0xd7, 0x13, 0x8c, 0x44, 0x96, 0x58, 0x52, 0x08, 0x83, 0x49, 0xa0, 0x29, 0x6c,
+	0xb8, 0xfa, 0x40, 0x4e, 0xe1, 0x51, 0xe8, 0x73, 0xe1, 0x71, 0xc4, 0xc8, 0xe3, 0x3a, 0xc4, 0x4b,
+	0x7c, 0x31, 0x6e, 0xac, 0xb5, 0xad, 0x8e, 0xed, 0xee, 0x4b, 0xef, 0x15, 0x62, 0x64, 0xd2, 0x5d,
+	0xfa, 0x62, 0xec, 0x8c, 0xa0, 0x75, 0xe7, 0x65, 0x86, 0xf1, 0x0b, 0xd8, 0x32, 0xd9, 0x32, 0xd2,
+	0xc7, 0xdd, 0x15, 0x62, 0x77, 0x97, 0x73, 0xb8, 0xb3, 0x40, 0xe7, 0x2f, 0x0b, 0xc8, 0x32, 0x80,
+	0x10, 0x58, 0x57, 0x0c, 0x2d, 0xc5, 0x50, 0x7d, 0x93, 0xcf, 0xa0, 0x96, 0xb1, 0xa7, 0x28, 0xfc,
+	0x20, 0x54, 0xfc, 0x2b, 0x3d, 0xd2, 0xcd, 0xab, 0x76, 0xa9, 0xbf, 0xdc, 0xaa, 0x41, 0x5e, 0x28,
+	0x20, 0x69, 0x41, 0x25, 0x8c, 0xb9, 0xf0, 0x92, 0x00, 0x87, 0xc8, 0x1b, 0x25, 0x25, 0x0f, 0x48,
+	0xd3, 0xa5, 0xb2, 0x90, 0x2e, 0x28, 0x15, 0x3c, 0x49, 0x24, 0x60, 0x9e, 0x2f, 0x04, 0x4e, 0x12,
+	0xd1, 0x58, 0x57, 0xa5, 0xdc, 0x93, 0x2e, 0x57, 0x79, 0xce, 0xb4, 0x83, 0x7c, 0x02, 0xf5, 0x79,
+	0xa8, 0xa7, 0x6b, 0xbf, 0xa1, 0x02, 0x08, 0x2b, 0x82, 0x55, 0xaf, 0x38, 0xcf, 0x61, 0x4f, 0x16,
+	0xf3, 0x2c, 0xa5, 0x41, 0x5e, 0xb0, 0x43, 0xd8, 0x92, 0xb5, 0xf5, 0x02, 0xaa, 0x25, 0xb4, 0xcf,
+	0xe1, 0xcf, 0xdb, 0x56, 0x59, 0x02, 0xfb, 0x17, 0xee, 0xa6, 0xf4, 0xf5, 0x29, 0x77, 0xbe, 0x02,
+	0x52, 0x8c, 0x35, 0xfa, 0x3f, 0x9b, 0xef, 0x98, 0xd6, 0x4a, 0xf1, 0x0b, 0x71, 0xa6, 0x8d, 0x7e,
+	0x5e, 0x03, 0xc8, 0xad, 0xe4, 0x18, 0x36, 0x0d, 0x05, 0x2d, 0xf6, 0x79, 0xed, 0xf7, 0xdb, 0xd6,
+	0xff, 0x0a, 0x2c, 0xca, 0x9a, 0x05, 0x39, 0x80, 0xea, 0x28, 0x60, 0x5c, 0x78, 0xbe, 0x0c, 0x44,
+	0xaa, 0xd4, 0x2f, 0xb9, 0xb6, 0x32, 0x9e, 0x69, 0x1b, 0xf9, 0x10, 0xec, 0xd0, 0x2f, 0x60, 0x4a,
+	0x0a, 0x53, 0x09, 0xfd, 0x1c, 0xd2, 0x82, 0x8a, 0xf2, 0x1a, 0xc5, 0xb4, 0xc4, 0xa0, 0x4c, 0x4a,
+	0x29, 0xf2, 0x11, 0xd4, 0xd4, 0x89, 0x7b, 0x09, 0x32, 0x8f, 0xfa, 0x53, 0xa5, 0xaa, 0xe5, 0xda,
+	0xda, 0x7a, 0x89, 0xec, 0xc2, 0x9f, 0x92, 0x47, 0x50, 0xbe, 0x41, 0x21, 0xef, 0x28, 0xb7, 0xad,
+	0xce, 0x96, 0x6b, 0x4e, 0xe4, 0x3d, 0xd8, 0xe6, 0x29, 0x4f, 0x30, 0xa2, 0x48, 0x1b, 0x9b, 0xca,
+	0x95, 0x1b, 0x48, 0x03, 0x36, 0xe3, 0x1b, 0x64, 0x34, 0xc5, 0xc6, 0x96, 0xf2, 0x65, 0x47, 0xe7,
+	0x6f, 0x0b, 0xf6, 0x5f, 0x0e, 0x5e, 0xe3, 0x50, 0x7c, 0x89, 0x7e, 0x28, 0xc6, 0x79, 0x89, 0x6a,
+	0x18, 0x0d, 0xd9, 0x34, 0x11, 0x48, 0xbd, 0x42, 0x4f, 0x56, 0x67, 0x56, 0x39, 0x2f, 0x92, 0xce,
+	0x20, 0x1d, 0xbe, 0x41, 0x61, 0x86, 0xca, 0x9c, 0xc8, 0xfb, 0x00, 0x09, 0x8b, 0x65, 0x5a, 0x2f,
+	0xd0, 0x72, 0xd8, 0xee, 0xb6, 0xb1, 0xf4, 0xa9, 0xec, 0x3b, 0x2e, 0x7c, 0x26, 0x3c, 0x7f, 0x24,
+	0x90, 0x65, 0xd3, 0x99, 0xf5, 0x9d, 0x72, 0x9d, 0x49, 0x4f, 0x36, 0x17, 0x1f, 0x03, 0xc1, 0x88,
+	0x7a, 0x03, 0x1c, 0xc5, 0x0c, 0x67, 0x70, 0xdd, 0x75, 0xbb, 0x18, 0xd1, 0x73, 0xe5, 0xc8, 0xd0,
+	0xb3, 0xf7, 0xa0, 0x5c, 0x78, 0x0f, 0x9c, 0x9f, 0x2c, 0xa8, 0xcf, 0xff, 0x52, 0xd3, 0x50, 0x5f,
+	0x2c, 0x0d, 0xb4, 0xb3, 0xb2, 0xa7, 0x4c, 0x7a, 0x13, 0x3d, 0x8b, 0x21, 0x9f, 0x03, 0x30, 0xa4,
+	0x69, 0x44, 0xfd, 0x68, 0x38, 0x35, 0xc3, 0xf9, 0x6e, 0x61, 0x38, 0xdd, 0x99, 0xf3, 0x6a, 0x38,
+	0xc6, 0x09, 0xba, 0x05, 0xb8, 0xf3, 0x8b, 0x05, 0xf5, 0xf9, 0xc4, 0xa6, 0x00, 0xb9, 0xb2, 0xd6,
+	0x9c, 0xb2, 0xcb, 0x85, 0x59, 0x5b, 0x55, 0x98, 0x03, 0xc8, 0xde, 0x02, 0x2f, 0x88, 0x28, 0x7e,
+	0x6f, 0x5a, 0xd2, 0x36, 0xc6, 0xbe, 0xb4, 0x2d, 0x54, 0x69, 0x7d, 0xa1, 0x4a, 0xce, 0x8f, 0x16,
+	0xfc, 0x7f, 0x81, 0x9b, 0x91, 0xec, 0x39, 0x94, 0xc7, 0xca, 0xa2, 0xc8, 0x3d, 0x4c, 0x30, 0x13,
+	0xf1, 0xdf, 0xe4, 0xfa, 0xcd, 0x82, 0xea, 0x5c, 0x5a, 0xf2, 0x14, 0x2a, 0x3a, 0xf1, 0xf4, 0x8e,
+	0xe7, 0x04, 0x8c, 0xbb, 0x4f, 0x39, 0x39, 0x81, 0x6a, 0x1a, 0x15, 0xe1, 0x6b, 0x4b, 0x70, 0x3b,
+	0x8d, 0x0a, 0x01, 0x4f, 0xa1, 0x12, 0x8f, 0x46, 0x61, 0x10, 0xe9, 0xc7, 0xaa, 0xb4, 0x9c, 0xdd,
+	0xb8, 0x25, 0xb8, 0x01, 0x9b, 0xc5, 0x4e, 0xb6, 0xdd, 0xec, 0xd8, 0xfb, 0xc3, 0x82, 0xdd, 0x97,
+	0x37, 0xc8, 0x42, 0x7f, 0xda, 0xcf, 0xe4, 0x21, 0xdf, 0x02, 0xe4, 0xfb, 0x94, 0x1c, 0xad, 0x94,
+	0x70, 0x69, 0x0b, 0x37, 0x8f, 0xef, 0xc5, 0x99, 0x1a, 0xbd, 0x82, 0xed, 0xd9, 0xba, 0x25, 0x87,
+	0x2b, 0xa3, 0x16, 0x57, 0x74, 0xf3, 0xe8, 0x3e, 0x98, 0xce, 0xdd, 0xfb, 0xd5, 0x82, 0x7a, 0x61,
+	0x7d, 0xe5, 0xbf, 0xe9, 0x07, 0x0b, 0xde, 0xb9, 0x63, 0x81, 0x92, 0xd3, 0x95, 0xc9, 0xdf, 0xbe,
+	0xdb, 0x9b, 0x9f, 0xfe, 0xbb, 0x20, 0xc3, 0x2f, 0x86, 0x9a, 0x7a, 0x77, 0xe7, 0xc4, 0x2e, 0xbc,
+	0xfe, 0x47, 0xf7, 0x2d, 0x8d, 0xb7, 0x8a, 0xbd, 0xbc, 0x94, 0x7a, 0xb7, 0x16, 0xec, 0xe8, 0x86,
+	0xcc, 0xaf, 0x1c, 0x82, 0x5d, 0x7c, 0x6f, 0x48, 0x67, 0x65, 0xb2, 0x15, 0x8f, 0x6f, 0xf3, 0xf1,
+	0x03, 0x90, 0xa6, 0xca, 0xa3, 0xc5, 0x79, 0x78, 0xfc, 0x80, 0x51, 0x34, 0xd7, 0x3c, 0x79, 0x08,
+	0x54, 0xdf, 0x73, 0x7e, 0xf8, 0xea, 0x80, 0x8b, 0x98, 0xbd, 0xee, 0x06, 0xf1, 0x89, 0xfa, 0x38,
+	0x99, 0xc5, 0x9e, 0xa8, 0xc9, 0x8d, 0xfc, 0x30, 0x19, 0x0c, 0xca, 0xea, 0xff, 0xe3, 0xe9, 0x3f,
+	0x03, 0x00, 0x05, 0x27, 0x42, 0xe5, 0x90, 0x0a, 0x00, 0x00,
 }
 
 // --- DRPC BEGIN ---
@@ -844,6 +1014,77 @@ func (x *drpcIrreparableInspectorListIrreparableSegmentsStream) SendAndClose(m *
 	return x.CloseSend()
 }
 
+type DRPCAuditInspectorClient interface {
+	DRPCConn() drpc.Conn
+
+	// NodeAudits returns when nodes were audited and their audit rates
+	NodeAudits(ctx context.Context, in *NodeAuditsRequest) (*NodeAuditsResponse, error)
+}
+
+type drpcAuditInspectorClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCAuditInspectorClient(cc drpc.Conn) DRPCAuditInspectorClient {
+	return &drpcAuditInspectorClient{cc}
+}
+
+func (c *drpcAuditInspectorClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcAuditInspectorClient) NodeAudits(ctx context.Context, in *NodeAuditsRequest) (*NodeAuditsResponse, error) {
+	out := new(NodeAuditsResponse)
+	err := c.cc.Invoke(ctx, "/satellite.inspector.AuditInspector/NodeAudits", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCAuditInspectorServer interface {
+	// NodeAudits returns when nodes were audited and their audit rates
+	NodeAudits(context.Context, *NodeAuditsRequest) (*NodeAuditsResponse, error)
+}
+
+type DRPCAuditInspectorDescription struct{}
+
+func (DRPCAuditInspectorDescription) NumMethods() int { return 1 }
+
+func (DRPCAuditInspectorDescription) Method(n int) (string, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/satellite.inspector.AuditInspector/NodeAudits",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCAuditInspectorServer).
+					NodeAudits(
+						ctx,
+						in1.(*NodeAuditsRequest),
+					)
+			}, DRPCAuditInspectorServer.NodeAudits, true
+	default:
+		return "", nil, nil, false
+	}
+}
+
+func DRPCRegisterAuditInspector(mux drpc.Mux, impl DRPCAuditInspectorServer) error {
+	return mux.Register(impl, DRPCAuditInspectorDescription{})
+}
+
+type DRPCAuditInspector_NodeAuditsStream interface {
+	drpc.Stream
+	SendAndClose(*NodeAuditsResponse) error
+}
+
+type drpcAuditInspectorNodeAuditsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcAuditInspectorNodeAuditsStream) SendAndClose(m *NodeAuditsResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
 type DRPCHealthInspectorClient interface {
 	DRPCConn() drpc.Conn
 
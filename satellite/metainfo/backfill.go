@@ -0,0 +1,149 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/storage"
+)
+
+// MigrateToMetabase copies all objects and segments of the pointer database
+// into the metabase and returns the number of migrated segments.
+//
+// It relies on last segments being listed before the other segments of the
+// same project, so that every segment is added to its committed object.
+// Segments of uploads which haven't been committed are added to pending
+// uploads, the same way as the dual writes do.
+func MigrateToMetabase(ctx context.Context, log *zap.Logger, pointerDB PointerDB, metabaseDB MetabaseDB, limit int) (migrated int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var skipped int64
+	err = pointerDB.IterateWithoutLookupLimit(ctx, storage.IterateOptions{
+		Recurse: true,
+		Limit:   limit,
+	}, func(ctx context.Context, it storage.Iterator) error {
+		var item storage.ListItem
+		for it.Next(ctx, &item) {
+			key := metabase.SegmentKey(item.Key)
+			if _, err := metabase.ParseSegmentKey(key); err != nil {
+				log.Warn("skipping invalid key", zap.ByteString("key", key), zap.Error(err))
+				skipped++
+				continue
+			}
+
+			pointer := &pb.Pointer{}
+			if err := pb.Unmarshal(item.Value, pointer); err != nil {
+				return Error.New("unexpected error unmarshalling pointer %s", err)
+			}
+
+			err := putMetabase(ctx, metabaseDB, key, pointer, true)
+			if err != nil {
+				return Error.New("unable to migrate %q: %w", key, err)
+			}
+
+			migrated++
+			if migrated%100000 == 0 {
+				log.Info("migrating", zap.Int64("migrated", migrated), zap.Int64("skipped", skipped))
+			}
+		}
+		return nil
+	})
+
+	log.Info("migration finished", zap.Int64("migrated", migrated), zap.Int64("skipped", skipped), zap.Error(err))
+	return migrated, err
+}
+
+// VerifyMetabase checks that every segment of the pointer database is stored
+// in the metabase with the same pieces and returns the number of segments
+// which aren't. The metabase is marked as verified when all are, which is
+// required before the metainfo loop iterates it.
+func VerifyMetabase(ctx context.Context, log *zap.Logger, pointerDB PointerDB, metabaseDB MetabaseDB, limit int) (inconsistent int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	startedAt := time.Now()
+
+	var verified int64
+	err = pointerDB.IterateWithoutLookupLimit(ctx, storage.IterateOptions{
+		Recurse: true,
+		Limit:   limit,
+	}, func(ctx context.Context, it storage.Iterator) error {
+		var item storage.ListItem
+		for it.Next(ctx, &item) {
+			key := metabase.SegmentKey(item.Key)
+			location, err := metabase.ParseSegmentKey(key)
+			if err != nil {
+				// invalid keys are neither migrated nor iterated by the loop.
+				continue
+			}
+
+			pointer := &pb.Pointer{}
+			if err := pb.Unmarshal(item.Value, pointer); err != nil {
+				return Error.New("unexpected error unmarshalling pointer %s", err)
+			}
+			data, _, err := segmentDataFromPointer(location, pointer)
+			if err != nil {
+				return Error.New("unable to convert %q: %w", key, err)
+			}
+
+			segments, err := metabaseDB.GetSegments(ctx, location)
+			if err != nil {
+				return Error.New("unable to verify %q: %w", key, err)
+			}
+			if !containsSegment(segments, data) {
+				log.Warn("segment is missing in metabase", zap.ByteString("key", key))
+				inconsistent++
+			}
+
+			verified++
+			if verified%100000 == 0 {
+				log.Info("verifying", zap.Int64("verified", verified), zap.Int64("inconsistent", inconsistent))
+			}
+		}
+		return nil
+	})
+	if err == nil && inconsistent == 0 {
+		err = metabaseDB.RecordVerified(ctx, startedAt)
+	}
+
+	log.Info("verification finished", zap.Int64("verified", verified), zap.Int64("inconsistent", inconsistent), zap.Error(err))
+	return inconsistent, err
+}
+
+// containsSegment returns whether one of the segments references the same
+// data as the segment of the pointer database.
+func containsSegment(segments []metabase.Segment, data metabase.SegmentData) bool {
+	for _, segment := range segments {
+		if segment.RootPieceID == data.RootPieceID &&
+			bytes.Equal(segment.InlineData, data.InlineData) &&
+			samePieces(segment.Pieces, data.Pieces) {
+			return true
+		}
+	}
+	return false
+}
+
+// samePieces returns whether both contain the same pieces in any order.
+func samePieces(a, b metabase.Pieces) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	pieces := make(map[metabase.Piece]int, len(a))
+	for _, piece := range a {
+		pieces[piece]++
+	}
+	for _, piece := range b {
+		if pieces[piece] == 0 {
+			return false
+		}
+		pieces[piece]--
+	}
+	return true
+}
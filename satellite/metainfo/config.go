@@ -6,6 +6,7 @@ package metainfo
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ import (
 
 	"storj.io/common/memory"
 	"storj.io/storj/private/dbutil"
+	"storj.io/storj/satellite/metainfo/metabase"
 	"storj.io/storj/satellite/metainfo/objectdeletion"
 	"storj.io/storj/satellite/metainfo/piecedeletion"
 	"storj.io/storj/storage"
@@ -115,6 +117,7 @@ type ProjectLimitConfig struct {
 // Config is a configuration struct that is everything you need to start a metainfo.
 type Config struct {
 	DatabaseURL          string                `help:"the database connection string to use" default:"postgres://"`
+	MetabaseDatabaseURL  string                `help:"the database connection string for the relational metabase, writes are duplicated into it when set" default:""`
 	MinRemoteSegmentSize memory.Size           `default:"1240" help:"minimum remote segment size"`
 	MaxInlineSegmentSize memory.Size           `default:"4KiB" help:"maximum inline segment size"`
 	MaxSegmentSize       memory.Size           `default:"64MiB" help:"maximum segment size"`
@@ -122,6 +125,7 @@ type Config struct {
 	MaxCommitInterval    time.Duration         `default:"48h" help:"maximum time allowed to pass between creating and committing a segment"`
 	Overlay              bool                  `default:"true" help:"toggle flag if overlay is enabled"`
 	RS                   RSConfig              `releaseDefault:"29/35/80/110-256B" devDefault:"4/6/8/10-256B" help:"redundancy scheme configuration in the format k/m/o/n-sharesize"`
+	RedundancyProfiles   RedundancyProfiles    `default:"" help:"comma separated redundancy profiles buckets can select in the format name:k/m/o/n-sharesize:price-multiplier"`
 	Loop                 LoopConfig            `help:"loop configuration"`
 	RateLimiter          RateLimiterConfig     `help:"rate limiter configuration"`
 	ProjectLimits        ProjectLimitConfig    `help:"project limit configuration"`
@@ -139,6 +143,46 @@ type PointerDB interface {
 	storage.KeyValueStore
 }
 
+// MetabaseDB stores objects and segments in relational tables.
+//
+// architecture: Database
+type MetabaseDB interface {
+	io.Closer
+	// MigrateToLatest migrates to latest schema version.
+	MigrateToLatest(ctx context.Context) error
+
+	// PutSegment stores a segment of a pending upload or of a committed object.
+	PutSegment(ctx context.Context, opts metabase.PutSegment) (metabase.Segment, error)
+	// CommitObject stores the last segment of the pending upload and commits it.
+	CommitObject(ctx context.Context, opts metabase.CommitObject) (metabase.Object, error)
+	// UpdateSegmentPieces replaces the pieces of a segment of the committed object.
+	UpdateSegmentPieces(ctx context.Context, opts metabase.UpdateSegmentPieces) error
+	// UpdateSegmentRemote replaces the remote data of a segment of the committed object.
+	UpdateSegmentRemote(ctx context.Context, opts metabase.UpdateSegmentRemote) error
+	// DeleteSegment deletes a segment of the committed object.
+	DeleteSegment(ctx context.Context, location metabase.SegmentLocation) error
+	// GetSegments returns the segments of the committed object and of pending uploads at the location.
+	GetSegments(ctx context.Context, location metabase.SegmentLocation) ([]metabase.Segment, error)
+	// IterateLoop iterates over all objects, including pending uploads, and their segments.
+	IterateLoop(ctx context.Context, batchSize int, fn func(ctx context.Context, object metabase.Object, segments []metabase.Segment) error) error
+
+	// RecordVerified records that a check, which started at startedAt, found all segments of the pointer database.
+	RecordVerified(ctx context.Context, startedAt time.Time) error
+	// RecordWriteFailed records that a write couldn't be duplicated into the metabase.
+	RecordWriteFailed(ctx context.Context, at time.Time) error
+	// Verified returns whether the metabase was verified and no write failed since.
+	Verified(ctx context.Context) (bool, error)
+}
+
+// OpenMetabase returns database for storing objects and segments in relational tables.
+func OpenMetabase(ctx context.Context, logger *zap.Logger, dbURLString string, app string) (db MetabaseDB, err error) {
+	mdb, err := metabase.Open(ctx, logger, dbURLString, app)
+	if err != nil {
+		return nil, err
+	}
+	return mdb, nil
+}
+
 // OpenStore returns database for storing pointer data.
 func OpenStore(ctx context.Context, logger *zap.Logger, dbURLString string, app string) (db PointerDB, err error) {
 	_, source, implementation, err := dbutil.SplitConnStr(dbURLString)
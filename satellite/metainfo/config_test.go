@@ -96,3 +96,36 @@ func TestRSConfigValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestRedundancyProfilesValidation(t *testing.T) {
+	var profiles metainfo.RedundancyProfiles
+	require.NoError(t, profiles.Set(""))
+	require.Empty(t, profiles)
+
+	require.NoError(t, profiles.Set("standard:29/35/80/110-256B:1, archive:29/50/100/160-256B:1.5"))
+	require.Len(t, profiles, 2)
+	require.Equal(t, "standard:29/35/80/110-256 B:1,archive:29/50/100/160-256 B:1.5", profiles.String())
+
+	archive, ok := profiles.Find("archive")
+	require.True(t, ok)
+	require.Equal(t, metainfo.RSConfig{
+		ErasureShareSize: 256 * memory.B, Min: 29, Repair: 50, Success: 100, Total: 160,
+	}, archive.RS)
+	require.Equal(t, 1.5, archive.PriceMultiplier)
+
+	require.NoError(t, profiles.Verify(""))
+	require.NoError(t, profiles.Verify("standard"))
+	require.True(t, metainfo.ErrUnknownRedundancyProfile.Has(profiles.Verify("unknown")))
+
+	for _, invalid := range []string{
+		"archive",
+		"archive:29/50/100/160-256B",
+		":29/50/100/160-256B:1.5",
+		"archive:29/50/20/160-256B:1.5",
+		"archive:29/50/100/160-256B:0",
+		"archive:29/50/100/160-256B:abc",
+		"archive:29/50/100/160-256B:1.5,archive:4/6/8/10-256B:1",
+	} {
+		require.Error(t, profiles.Set(invalid), invalid)
+	}
+}
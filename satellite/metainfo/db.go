@@ -44,4 +44,11 @@ type BucketsDB interface {
 	ListAllBuckets(ctx context.Context, listOpts ListAllBucketsOptions) (bucketList storj.BucketList, err error)
 	// CountBuckets returns the number of buckets a project currently has
 	CountBuckets(ctx context.Context, projectID uuid.UUID) (int, error)
+
+	// GetBucketRedundancyProfile returns the redundancy profile of a bucket, empty when using the default.
+	GetBucketRedundancyProfile(ctx context.Context, bucket metabase.BucketLocation) (profile string, err error)
+	// SetBucketRedundancyProfile sets the redundancy profile of a bucket, empty profile resets it to the default.
+	SetBucketRedundancyProfile(ctx context.Context, bucket metabase.BucketLocation, profile string) (err error)
+	// ListBucketRedundancyProfiles returns the redundancy profiles of the project buckets by bucket name.
+	ListBucketRedundancyProfiles(ctx context.Context, projectID uuid.UUID) (profiles map[string]string, err error)
 }
@@ -0,0 +1,265 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// segmentDataFromPointer converts a pointer to the segment data stored in the metabase.
+//
+// The encryption key of the last segment is stored in the stream metadata,
+// which is returned as well when the location is the last segment.
+func segmentDataFromPointer(location metabase.SegmentLocation, pointer *pb.Pointer) (_ metabase.SegmentData, streamMeta *pb.StreamMeta, err error) {
+	data := metabase.SegmentData{
+		EncryptedSize: pointer.SegmentSize,
+		CreatedAt:     pointer.CreationDate,
+	}
+
+	var segmentMeta *pb.SegmentMeta
+	if location.IsLast() {
+		streamMeta = &pb.StreamMeta{}
+		if err := pb.Unmarshal(pointer.Metadata, streamMeta); err != nil {
+			return metabase.SegmentData{}, nil, Error.Wrap(err)
+		}
+		segmentMeta = streamMeta.LastSegmentMeta
+	} else {
+		segmentMeta = &pb.SegmentMeta{}
+		if err := pb.Unmarshal(pointer.Metadata, segmentMeta); err != nil {
+			return metabase.SegmentData{}, nil, Error.Wrap(err)
+		}
+	}
+	if segmentMeta != nil {
+		data.EncryptedKey = segmentMeta.EncryptedKey
+		data.EncryptedKeyNonce = segmentMeta.KeyNonce
+	}
+
+	if redundancy := pointer.GetRemote().GetRedundancy(); redundancy != nil {
+		data.Redundancy = storj.RedundancyScheme{
+			Algorithm:      storj.ReedSolomon,
+			ShareSize:      redundancy.ErasureShareSize,
+			RequiredShares: int16(redundancy.MinReq),
+			RepairShares:   int16(redundancy.RepairThreshold),
+			OptimalShares:  int16(redundancy.SuccessThreshold),
+			TotalShares:    int16(redundancy.Total),
+		}
+	}
+
+	switch pointer.Type {
+	case pb.Pointer_INLINE:
+		data.InlineData = pointer.InlineSegment
+	case pb.Pointer_REMOTE:
+		data.RootPieceID = pointer.Remote.RootPieceId
+		data.Pieces = piecesFromPointer(pointer.Remote.RemotePieces)
+	default:
+		return metabase.SegmentData{}, nil, Error.New("unexpected pointer type %v", pointer.Type)
+	}
+
+	return data, streamMeta, nil
+}
+
+// piecesFromPointer converts remote pieces of a pointer to metabase pieces.
+func piecesFromPointer(remotePieces []*pb.RemotePiece) metabase.Pieces {
+	pieces := make(metabase.Pieces, len(remotePieces))
+	for i, piece := range remotePieces {
+		pieces[i] = metabase.Piece{
+			Number:      uint16(piece.PieceNum),
+			StorageNode: piece.NodeId,
+		}
+	}
+	return pieces
+}
+
+// pointerFromMetabase converts a segment stored in the metabase to the pointer
+// which would be stored in the pointer database for the same segment.
+func pointerFromMetabase(object metabase.Object, segment metabase.Segment, last bool) (*pb.Pointer, error) {
+	pointer := &pb.Pointer{
+		SegmentSize:  segment.EncryptedSize,
+		CreationDate: segment.CreatedAt,
+	}
+	if object.ExpiresAt != nil {
+		pointer.ExpirationDate = *object.ExpiresAt
+	}
+
+	if last {
+		pointer.Metadata = object.EncryptedMetadata
+	} else {
+		metadata, err := pb.Marshal(&pb.SegmentMeta{
+			EncryptedKey: segment.EncryptedKey,
+			KeyNonce:     segment.EncryptedKeyNonce,
+		})
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		pointer.Metadata = metadata
+	}
+
+	var redundancy *pb.RedundancyScheme
+	if !segment.Redundancy.IsZero() {
+		redundancy = &pb.RedundancyScheme{
+			Type:             pb.RedundancyScheme_RS,
+			MinReq:           int32(segment.Redundancy.RequiredShares),
+			RepairThreshold:  int32(segment.Redundancy.RepairShares),
+			SuccessThreshold: int32(segment.Redundancy.OptimalShares),
+			Total:            int32(segment.Redundancy.TotalShares),
+			ErasureShareSize: segment.Redundancy.ShareSize,
+		}
+	}
+
+	if segment.Inline() {
+		pointer.Type = pb.Pointer_INLINE
+		pointer.InlineSegment = segment.InlineData
+		if redundancy != nil {
+			pointer.Remote = &pb.RemoteSegment{Redundancy: redundancy}
+		}
+		return pointer, nil
+	}
+
+	pointer.Type = pb.Pointer_REMOTE
+	pointer.Remote = &pb.RemoteSegment{
+		RootPieceId:  segment.RootPieceID,
+		Redundancy:   redundancy,
+		RemotePieces: make([]*pb.RemotePiece, len(segment.Pieces)),
+	}
+	for i, piece := range segment.Pieces {
+		pointer.Remote.RemotePieces[i] = &pb.RemotePiece{
+			PieceNum: int32(piece.Number),
+			NodeId:   piece.StorageNode,
+		}
+	}
+	return pointer, nil
+}
+
+// putMetabase stores the pointer in the metabase.
+//
+// Segments are added to the pending upload at the location, unless committed
+// is set, and the last segment commits the object.
+func putMetabase(ctx context.Context, db MetabaseDB, key metabase.SegmentKey, pointer *pb.Pointer, committed bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	location, err := metabase.ParseSegmentKey(key)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	data, streamMeta, err := segmentDataFromPointer(location, pointer)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt *time.Time
+	if expirationDate := pointer.ExpirationDate; !expirationDate.IsZero() {
+		expiresAt = &expirationDate
+	}
+
+	if location.IsLast() {
+		_, err = db.CommitObject(ctx, metabase.CommitObject{
+			ObjectLocation:    location.Object(),
+			ExpiresAt:         expiresAt,
+			SegmentCount:      int32(streamMeta.NumberOfSegments),
+			EncryptedMetadata: pointer.Metadata,
+			LastSegment:       data,
+		})
+		return err
+	}
+
+	_, err = db.PutSegment(ctx, metabase.PutSegment{
+		ObjectLocation: location.Object(),
+		Position:       metabase.SegmentPosition{Index: uint32(location.Index)},
+		ExpiresAt:      expiresAt,
+		Committed:      committed,
+		SegmentData:    data,
+	})
+	return err
+}
+
+// updatePiecesMetabase replaces the pieces of the segment in the metabase.
+func (s *Service) updatePiecesMetabase(ctx context.Context, key metabase.SegmentKey, pieces []*pb.RemotePiece) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	location, err := metabase.ParseSegmentKey(key)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return s.metabaseDB.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+		Location:  location,
+		NewPieces: piecesFromPointer(pieces),
+	})
+}
+
+// updateRemoteMetabase replaces the remote data of the segment in the metabase.
+func (s *Service) updateRemoteMetabase(ctx context.Context, key metabase.SegmentKey, pointer *pb.Pointer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	location, err := metabase.ParseSegmentKey(key)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	data, _, err := segmentDataFromPointer(location, pointer)
+	if err != nil {
+		return err
+	}
+
+	return s.metabaseDB.UpdateSegmentRemote(ctx, metabase.UpdateSegmentRemote{
+		Location:    location,
+		RootPieceID: data.RootPieceID,
+		Redundancy:  data.Redundancy,
+		Pieces:      data.Pieces,
+	})
+}
+
+// deleteMetabase deletes the segment from the metabase, when dual writes are enabled.
+func (s *Service) deleteMetabase(ctx context.Context, key metabase.SegmentKey) {
+	if s.metabaseDB == nil {
+		return
+	}
+
+	location, err := metabase.ParseSegmentKey(key)
+	if err != nil {
+		s.dualWrite(ctx, key, Error.Wrap(err))
+		return
+	}
+	s.dualWrite(ctx, key, s.metabaseDB.DeleteSegment(ctx, location))
+}
+
+// dualWrite logs the error of a write to the metabase. The pointer database
+// stays the source of truth during the dual write period, so failures to
+// write to the metabase don't fail the request. They are recorded in the
+// metabase instead, so that it isn't iterated until it's verified again.
+func (s *Service) dualWrite(ctx context.Context, key metabase.SegmentKey, err error) {
+	if err != nil && !metabase.ErrObjectNotFound.Has(err) && !metabase.ErrSegmentNotFound.Has(err) {
+		mon.Meter("metabase_dual_write_failed").Mark(1)
+		s.logger.Warn("unable to write to metabase", zap.ByteString("key", key), zap.Error(err))
+
+		s.mu.Lock()
+		s.lastFailedWrite = time.Now()
+		s.mu.Unlock()
+	}
+	s.recordFailedWrite(ctx)
+}
+
+// recordFailedWrite records the last failed write in the metabase. When the
+// metabase isn't reachable, it's retried with the following writes.
+func (s *Service) recordFailedWrite(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastFailedWrite.IsZero() {
+		return
+	}
+	if err := s.metabaseDB.RecordWriteFailed(ctx, s.lastFailedWrite); err != nil {
+		s.logger.Error("unable to record failed write to metabase", zap.Error(err))
+		return
+	}
+	s.lastFailedWrite = time.Time{}
+}
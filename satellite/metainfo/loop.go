@@ -5,6 +5,7 @@ package metainfo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -161,25 +162,29 @@ type LoopConfig struct {
 	CoalesceDuration time.Duration `help:"how long to wait for new observers before starting iteration" releaseDefault:"5s" devDefault:"5s"`
 	RateLimit        float64       `help:"rate limit (default is 0 which is unlimited segments per second)" default:"0"`
 	ListLimit        int           `help:"how many items to query in a batch" default:"2500"`
+	UseMetabase      bool          `help:"iterate the relational metabase instead of the pointer database, once it passed verify-metabase" default:"false"`
 }
 
 // Loop is a metainfo loop service.
 //
 // architecture: Service
 type Loop struct {
-	config LoopConfig
-	db     PointerDB
-	join   chan []*observerContext
-	done   chan struct{}
+	config     LoopConfig
+	db         PointerDB
+	metabaseDB MetabaseDB
+	join       chan []*observerContext
+	done       chan struct{}
 }
 
-// NewLoop creates a new metainfo loop service.
-func NewLoop(config LoopConfig, db PointerDB) *Loop {
+// NewLoop creates a new metainfo loop service. metabaseDB is only used when
+// the loop is configured to iterate the metabase and may be nil otherwise.
+func NewLoop(config LoopConfig, db PointerDB, metabaseDB MetabaseDB) *Loop {
 	return &Loop{
-		db:     db,
-		config: config,
-		join:   make(chan []*observerContext),
-		done:   make(chan struct{}),
+		db:         db,
+		metabaseDB: metabaseDB,
+		config:     config,
+		join:       make(chan []*observerContext),
+		done:       make(chan struct{}),
 	}
 }
 
@@ -258,7 +263,12 @@ waitformore:
 			return ctx.Err()
 		}
 	}
-	return iterateDatabase(ctx, loop.db, observers, loop.config.ListLimit, rate.NewLimiter(rate.Limit(loop.config.RateLimit), 1))
+
+	rateLimiter := rate.NewLimiter(rate.Limit(loop.config.RateLimit), 1)
+	if loop.config.UseMetabase {
+		return iterateMetabase(ctx, loop.metabaseDB, observers, loop.config.ListLimit, rateLimiter)
+	}
+	return iterateDatabase(ctx, loop.db, observers, loop.config.ListLimit, rateLimiter)
 }
 
 // IterateDatabase iterates over PointerDB and notifies specified observers about results.
@@ -426,6 +436,85 @@ func iterateDatabase(ctx context.Context, db PointerDB, observers []*observerCon
 	return err
 }
 
+func iterateMetabase(ctx context.Context, db MetabaseDB, observers []*observerContext, limit int, rateLimiter *rate.Limiter) (err error) {
+	defer func() {
+		if err != nil {
+			for _, observer := range observers {
+				observer.HandleError(err)
+			}
+			return
+		}
+		finishObservers(observers)
+	}()
+
+	if db == nil {
+		return LoopError.New("metabase is not configured")
+	}
+
+	// segments missing in the metabase would be garbage collected.
+	verified, err := db.Verified(ctx)
+	if err != nil {
+		return LoopError.Wrap(err)
+	}
+	if !verified {
+		return LoopError.New("metabase wasn't verified against the pointer database since the last failed write, run verify-metabase")
+	}
+
+	errDone := errs.New("all observers finished")
+	err = db.IterateLoop(ctx, limit, func(ctx context.Context, object metabase.Object, segments []metabase.Segment) error {
+		for _, segment := range segments {
+			if err := rateLimiter.Wait(ctx); err != nil {
+				// We don't really execute concurrent batches so we should never
+				// exceed the burst size of 1 and this should never happen.
+				// We can also enter here if the context is cancelled.
+				return LoopError.Wrap(err)
+			}
+
+			// segments of pending uploads are never the last segment.
+			last := object.Status == metabase.Committed && int32(segment.Position.Index) == object.SegmentCount-1
+			pointer, err := pointerFromMetabase(object, segment, last)
+			if err != nil {
+				return LoopError.Wrap(err)
+			}
+
+			location := metabase.SegmentLocation{
+				ProjectID:  object.ProjectID,
+				BucketName: object.BucketName,
+				Index:      int64(segment.Position.Index),
+				ObjectKey:  object.ObjectKey,
+			}
+			if last {
+				location.Index = metabase.LastSegmentIndex
+			}
+
+			nextObservers := observers[:0]
+			for _, observer := range observers {
+				keepObserver := handlePointer(ctx, observer, location, pointer)
+				if keepObserver {
+					nextObservers = append(nextObservers, observer)
+				}
+			}
+
+			observers = nextObservers
+			if len(observers) == 0 {
+				return errDone
+			}
+
+			// if context has been canceled exit. Otherwise, continue
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errDone) {
+		return nil
+	}
+	return err
+}
+
 func finishObservers(observers []*observerContext) {
 	for _, observer := range observers {
 		observer.Finish()
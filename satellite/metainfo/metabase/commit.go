@@ -0,0 +1,331 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/dbutil/txutil"
+	"storj.io/storj/private/tagsql"
+)
+
+var (
+	// ErrInvalidRequest is used to indicate invalid requests.
+	ErrInvalidRequest = errs.Class("metabase: invalid request")
+	// ErrObjectNotFound is used to indicate that the object does not exist.
+	ErrObjectNotFound = errs.Class("metabase: object not found")
+	// ErrSegmentNotFound is used to indicate that the segment does not exist.
+	ErrSegmentNotFound = errs.Class("metabase: segment not found")
+)
+
+// Object is an object stored in the metabase.
+type Object struct {
+	ObjectLocation
+	Version  int64
+	StreamID uuid.UUID
+
+	Status    ObjectStatus
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+
+	SegmentCount       int32
+	EncryptedMetadata  []byte
+	TotalEncryptedSize int64
+}
+
+// SegmentData is the content of a segment.
+type SegmentData struct {
+	RootPieceID       storj.PieceID
+	EncryptedKeyNonce []byte
+	EncryptedKey      []byte
+	EncryptedSize     int64
+	Redundancy        storj.RedundancyScheme
+	InlineData        []byte
+	Pieces            Pieces
+	CreatedAt         time.Time
+}
+
+// Segment is a segment of an object stored in the metabase.
+type Segment struct {
+	StreamID uuid.UUID
+	Position SegmentPosition
+	SegmentData
+}
+
+// Inline returns whether the segment data is stored in the metabase instead of on storage nodes.
+func (segment *Segment) Inline() bool {
+	return segment.RootPieceID.IsZero()
+}
+
+// PutSegment contains arguments necessary for storing a segment.
+type PutSegment struct {
+	ObjectLocation
+	Position  SegmentPosition
+	ExpiresAt *time.Time
+
+	// Committed adds the segment to the committed object instead of the
+	// pending upload, when the segment belongs to it. It's used when
+	// migrating existing segments, see PutSegment.
+	Committed bool
+
+	SegmentData
+}
+
+// PutSegment stores a segment. The segment is added to the pending upload at
+// the location, which is created when missing.
+//
+// When opts.Committed is set, the segment is added to the committed object
+// instead, if it belongs to it: its position is before the last segment and
+// it wasn't created after the last segment. Segments of an upload which
+// replaces the committed object and hasn't finished yet are created later,
+// so they are still added to the pending upload.
+func (db *DB) PutSegment(ctx context.Context, opts PutSegment) (segment Segment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.ObjectLocation.verify(); err != nil {
+		return Segment{}, err
+	}
+
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		var object Object
+		var ok bool
+		if opts.Committed {
+			object, ok, err = committedObjectOf(ctx, tx, opts.ObjectLocation, opts.Position, opts.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		if !ok {
+			object, err = pendingObject(ctx, tx, opts.ObjectLocation, opts.ExpiresAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		segment, err = putSegment(ctx, tx, object.StreamID, opts.Position, opts.SegmentData)
+		return err
+	})
+	return segment, err
+}
+
+// CommitObject contains arguments necessary for committing an object.
+type CommitObject struct {
+	ObjectLocation
+	ExpiresAt *time.Time
+
+	// SegmentCount is the number of segments of the object including the
+	// last segment. When it's zero the number is derived from the stored segments.
+	SegmentCount      int32
+	EncryptedMetadata []byte
+
+	LastSegment SegmentData
+}
+
+// CommitObject stores the last segment of the pending upload at the location
+// and commits it, replacing the previously committed object.
+func (db *DB) CommitObject(ctx context.Context, opts CommitObject) (object Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.ObjectLocation.verify(); err != nil {
+		return Object{}, err
+	}
+	if opts.SegmentCount < 0 {
+		return Object{}, ErrInvalidRequest.New("SegmentCount is negative")
+	}
+
+	err = txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		object, err = pendingObject(ctx, tx, opts.ObjectLocation, opts.ExpiresAt)
+		if err != nil {
+			return err
+		}
+
+		segmentCount := opts.SegmentCount
+		if segmentCount == 0 {
+			err = tx.QueryRowContext(ctx, `
+				SELECT count(*) FROM segments WHERE stream_id = $1
+			`, object.StreamID).Scan(&segmentCount)
+			if err != nil {
+				return Error.New("unable to count segments: %w", err)
+			}
+			segmentCount++
+		}
+
+		_, err = putSegment(ctx, tx, object.StreamID, SegmentPosition{Index: uint32(segmentCount - 1)}, opts.LastSegment)
+		if err != nil {
+			return err
+		}
+
+		if _, err := deleteObjects(ctx, tx, opts.ObjectLocation, Committed); err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			UPDATE objects SET
+				status = $2,
+				segment_count = $3,
+				encrypted_metadata = $4,
+				expires_at = $5,
+				total_encrypted_size = (
+					SELECT coalesce(sum(encrypted_size), 0) FROM segments WHERE stream_id = $1
+				)
+			WHERE stream_id = $1
+			RETURNING created_at, total_encrypted_size
+		`, object.StreamID, Committed, segmentCount, opts.EncryptedMetadata, opts.ExpiresAt,
+		).Scan(&object.CreatedAt, &object.TotalEncryptedSize)
+		if err != nil {
+			return Error.New("unable to commit object: %w", err)
+		}
+
+		object.Status = Committed
+		object.SegmentCount = segmentCount
+		object.EncryptedMetadata = opts.EncryptedMetadata
+		object.ExpiresAt = opts.ExpiresAt
+		return nil
+	})
+	return object, err
+}
+
+// verify checks that the location is complete.
+func (obj ObjectLocation) verify() error {
+	switch {
+	case obj.ProjectID.IsZero():
+		return ErrInvalidRequest.New("ProjectID missing")
+	case obj.BucketName == "":
+		return ErrInvalidRequest.New("BucketName missing")
+	case len(obj.ObjectKey) == 0:
+		return ErrInvalidRequest.New("ObjectKey missing")
+	}
+	return nil
+}
+
+// committedObjectOf returns the committed object at the location, when the
+// segment at the position, which was created at createdAt, belongs to it.
+func committedObjectOf(ctx context.Context, tx tagsql.Tx, location ObjectLocation, position SegmentPosition, createdAt time.Time) (_ Object, ok bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	object, err := latestObject(ctx, tx, location, Committed)
+	if ErrObjectNotFound.Has(err) {
+		return Object{}, false, nil
+	}
+	if err != nil {
+		return Object{}, false, err
+	}
+	if int64(position.Index) >= int64(object.SegmentCount)-1 {
+		return Object{}, false, nil
+	}
+
+	var lastCreatedAt time.Time
+	err = tx.QueryRowContext(ctx, `
+		SELECT created_at FROM segments WHERE stream_id = $1 AND position = $2
+	`, object.StreamID, int64(SegmentPosition{Index: uint32(object.SegmentCount - 1)}.Encode())).Scan(&lastCreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Object{}, false, nil
+	}
+	if err != nil {
+		return Object{}, false, Error.New("unable to query last segment: %w", err)
+	}
+	if createdAt.After(lastCreatedAt) {
+		return Object{}, false, nil
+	}
+	return object, true, nil
+}
+
+// pendingObject returns the latest pending upload at the location, or creates a new one.
+func pendingObject(ctx context.Context, tx tagsql.Tx, location ObjectLocation, expiresAt *time.Time) (_ Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	object, err := latestObject(ctx, tx, location, Pending)
+	if err == nil || !ErrObjectNotFound.Has(err) {
+		return object, err
+	}
+
+	streamID, err := uuid.New()
+	if err != nil {
+		return Object{}, Error.Wrap(err)
+	}
+
+	// concurrent uploads may try to create the same version, in which case
+	// the version created by the other upload is used.
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO objects (project_id, bucket_name, object_key, version, stream_id, status, expires_at)
+		SELECT $1, $2, $3, coalesce(max(version), 0) + 1, $4, $5, $6
+			FROM objects
+			WHERE project_id = $1 AND bucket_name = $2 AND object_key = $3
+		ON CONFLICT DO NOTHING
+	`, location.ProjectID, []byte(location.BucketName), []byte(location.ObjectKey), streamID, Pending, expiresAt)
+	if err != nil {
+		return Object{}, Error.New("unable to insert object: %w", err)
+	}
+
+	return latestObject(ctx, tx, location, Pending)
+}
+
+// latestObject returns the latest object with the status at the location.
+func latestObject(ctx context.Context, tx tagsql.Tx, location ObjectLocation, status ObjectStatus) (_ Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	object := Object{ObjectLocation: location, Status: status}
+	err = tx.QueryRowContext(ctx, `
+		SELECT version, stream_id, created_at, expires_at, segment_count, encrypted_metadata, total_encrypted_size
+		FROM objects
+		WHERE project_id = $1 AND bucket_name = $2 AND object_key = $3 AND status = $4
+		ORDER BY version DESC
+		LIMIT 1
+	`, location.ProjectID, []byte(location.BucketName), []byte(location.ObjectKey), status).Scan(
+		&object.Version, &object.StreamID, &object.CreatedAt, &object.ExpiresAt,
+		&object.SegmentCount, &object.EncryptedMetadata, &object.TotalEncryptedSize,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Object{}, ErrObjectNotFound.New("%q", location.ObjectKey)
+	}
+	if err != nil {
+		return Object{}, Error.New("unable to query object: %w", err)
+	}
+	return object, nil
+}
+
+// putSegment inserts or replaces the segment at the position of the stream.
+func putSegment(ctx context.Context, tx tagsql.Tx, streamID uuid.UUID, position SegmentPosition, data SegmentData) (_ Segment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if data.CreatedAt.IsZero() {
+		data.CreatedAt = time.Now()
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO segments (
+			stream_id, position, root_piece_id,
+			encrypted_key_nonce, encrypted_key, encrypted_size,
+			redundancy, inline_data, remote_pieces, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (stream_id, position) DO UPDATE SET
+			root_piece_id = EXCLUDED.root_piece_id,
+			encrypted_key_nonce = EXCLUDED.encrypted_key_nonce,
+			encrypted_key = EXCLUDED.encrypted_key,
+			encrypted_size = EXCLUDED.encrypted_size,
+			redundancy = EXCLUDED.redundancy,
+			inline_data = EXCLUDED.inline_data,
+			remote_pieces = EXCLUDED.remote_pieces,
+			created_at = EXCLUDED.created_at
+	`, streamID, int64(position.Encode()), data.RootPieceID,
+		data.EncryptedKeyNonce, data.EncryptedKey, data.EncryptedSize,
+		redundancyScheme{&data.Redundancy}, data.InlineData, data.Pieces, data.CreatedAt,
+	)
+	if err != nil {
+		return Segment{}, Error.New("unable to insert segment: %w", err)
+	}
+
+	return Segment{
+		StreamID:    streamID,
+		Position:    position,
+		SegmentData: data,
+	}, nil
+}
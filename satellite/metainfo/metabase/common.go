@@ -195,3 +195,34 @@ type Piece struct {
 	Number      uint16
 	StorageNode storj.NodeID
 }
+
+// ObjectStatus defines the statuses that the object might be in.
+type ObjectStatus byte
+
+const (
+	// Pending means that the object is being uploaded or that the client failed during upload.
+	// The failed upload may be continued in the future.
+	Pending = ObjectStatus(1)
+	// Committed means that the object is finished and should be visible for general listing.
+	Committed = ObjectStatus(3)
+)
+
+// SegmentPosition is segment part and index combined.
+type SegmentPosition struct {
+	Part  uint32
+	Index uint32
+}
+
+// SegmentPositionFromEncoded decodes an uint64 into a SegmentPosition.
+func SegmentPositionFromEncoded(v uint64) SegmentPosition {
+	return SegmentPosition{
+		Part:  uint32(v >> 32),
+		Index: uint32(v),
+	}
+}
+
+// Encode encodes a segment position into an uint64, that can be stored in a database.
+func (pos SegmentPosition) Encode() uint64 { return uint64(pos.Part)<<32 | uint64(pos.Index) }
+
+// Less returns whether pos should be before b.
+func (pos SegmentPosition) Less(b SegmentPosition) bool { return pos.Encode() < b.Encode() }
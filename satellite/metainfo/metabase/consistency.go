@@ -0,0 +1,72 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Names of the events recorded in the consistency table.
+const (
+	consistencyVerified    = "verified"
+	consistencyWriteFailed = "write_failed"
+)
+
+// RecordVerified records that a check, which started at startedAt, found
+// every segment of the pointer database in the metabase.
+func (db *DB) RecordVerified(ctx context.Context, startedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return db.recordConsistency(ctx, consistencyVerified, startedAt)
+}
+
+// RecordWriteFailed records that a write to the pointer database couldn't be
+// duplicated into the metabase at the time.
+func (db *DB) RecordWriteFailed(ctx context.Context, at time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return db.recordConsistency(ctx, consistencyWriteFailed, at)
+}
+
+// recordConsistency records the latest time of the event.
+func (db *DB) recordConsistency(ctx context.Context, name string, at time.Time) (err error) {
+	_, err = db.db.ExecContext(ctx, `
+		INSERT INTO consistency (name, at) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET at = greatest(consistency.at, EXCLUDED.at)
+	`, name, at)
+	if err != nil {
+		return Error.New("unable to record %s: %w", name, err)
+	}
+	return nil
+}
+
+// Verified returns whether the metabase was verified against the pointer
+// database and no write failed to be duplicated since the check started.
+func (db *DB) Verified(ctx context.Context) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	verifiedAt, err := db.consistencyAt(ctx, consistencyVerified)
+	if err != nil || verifiedAt == nil {
+		return false, err
+	}
+	failedAt, err := db.consistencyAt(ctx, consistencyWriteFailed)
+	if err != nil {
+		return false, err
+	}
+	return failedAt == nil || failedAt.Before(*verifiedAt), nil
+}
+
+// consistencyAt returns the latest time of the event or nil, when it wasn't recorded.
+func (db *DB) consistencyAt(ctx context.Context, name string) (_ *time.Time, err error) {
+	var at time.Time
+	err = db.db.QueryRowContext(ctx, `SELECT at FROM consistency WHERE name = $1`, name).Scan(&at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, Error.New("unable to query %s: %w", name, err)
+	}
+	return &at, nil
+}
@@ -0,0 +1,154 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/dbutil"
+	"storj.io/storj/private/dbutil/pgutil"
+	"storj.io/storj/private/migrate"
+	"storj.io/storj/private/tagsql"
+)
+
+var (
+	mon = monkit.Package()
+)
+
+// DB implements a database for storing objects and segments.
+//
+// architecture: Database
+type DB struct {
+	log            *zap.Logger
+	db             tagsql.DB
+	source         string
+	implementation dbutil.Implementation
+}
+
+// Open opens a connection to the metabase.
+func Open(ctx context.Context, log *zap.Logger, connstr string, app string) (*DB, error) {
+	_, source, implementation, err := dbutil.SplitConnStr(connstr)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	switch implementation {
+	case dbutil.Postgres, dbutil.Cockroach:
+	default:
+		return nil, Error.New("unsupported db implementation: %s", connstr)
+	}
+
+	source, err = pgutil.CheckApplicationName(source, app)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	rawdb, err := tagsql.Open(ctx, "pgx", source)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	dbutil.Configure(ctx, rawdb, "metabase", mon)
+
+	log.Debug("Connected to:", zap.String("db source", source))
+	return &DB{
+		log:            log,
+		db:             rawdb,
+		source:         source,
+		implementation: implementation,
+	}, nil
+}
+
+// Close closes the connection to the database.
+func (db *DB) Close() error {
+	return Error.Wrap(db.db.Close())
+}
+
+// MigrateToLatest migrates the database to the latest version.
+func (db *DB) MigrateToLatest(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	switch db.implementation {
+	case dbutil.Postgres:
+		schema, err := pgutil.ParseSchemaFromConnstr(db.source)
+		if err != nil {
+			return Error.New("error parsing schema: %+v", err)
+		}
+		if schema != "" {
+			if err := pgutil.CreateSchema(ctx, db.db, schema); err != nil {
+				return Error.New("error creating schema: %+v", err)
+			}
+		}
+	case dbutil.Cockroach:
+		var dbName string
+		if err := db.db.QueryRow(ctx, `SELECT current_database();`).Scan(&dbName); err != nil {
+			return Error.New("error querying current database: %+v", err)
+		}
+		_, err := db.db.Exec(ctx, fmt.Sprintf(`CREATE DATABASE IF NOT EXISTS %s;`, pgutil.QuoteIdentifier(dbName)))
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	return Error.Wrap(db.PostgresMigration().Run(ctx, db.log.Named("migrate")))
+}
+
+// PostgresMigration returns steps needed for migrating the metabase.
+func (db *DB) PostgresMigration() *migrate.Migration {
+	return &migrate.Migration{
+		Table: "metabase_versions",
+		Steps: []*migrate.Step{
+			{
+				DB:          &db.db,
+				Description: "initial setup",
+				Version:     0,
+				Action: migrate.SQL{
+					`CREATE TABLE objects (
+						project_id           BYTEA NOT NULL,
+						bucket_name          BYTEA NOT NULL,
+						object_key           BYTEA NOT NULL,
+						version              INT8 NOT NULL,
+						stream_id            BYTEA NOT NULL,
+						status               INT2 NOT NULL,
+						created_at           TIMESTAMPTZ NOT NULL DEFAULT now(),
+						expires_at           TIMESTAMPTZ,
+						segment_count        INT4 NOT NULL DEFAULT 0,
+						encrypted_metadata   BYTEA,
+						total_encrypted_size INT8 NOT NULL DEFAULT 0,
+						PRIMARY KEY (project_id, bucket_name, object_key, version),
+						UNIQUE (stream_id)
+					)`,
+					`CREATE TABLE segments (
+						stream_id           BYTEA NOT NULL,
+						position            INT8 NOT NULL,
+						root_piece_id       BYTEA NOT NULL,
+						encrypted_key_nonce BYTEA NOT NULL,
+						encrypted_key       BYTEA NOT NULL,
+						encrypted_size      INT8 NOT NULL,
+						redundancy          INT8 NOT NULL DEFAULT 0,
+						inline_data         BYTEA,
+						remote_pieces       BYTEA,
+						created_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+						PRIMARY KEY (stream_id, position)
+					)`,
+				},
+			},
+			{
+				DB:          &db.db,
+				Description: "record consistency checks against the pointer database",
+				Version:     1,
+				Action: migrate.SQL{
+					`CREATE TABLE consistency (
+						name TEXT NOT NULL,
+						at   TIMESTAMPTZ NOT NULL,
+						PRIMARY KEY (name)
+					)`,
+				},
+			},
+		},
+	}
+}
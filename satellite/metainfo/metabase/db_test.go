@@ -0,0 +1,361 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/dbutil/pgtest"
+	"storj.io/storj/private/dbutil/tempdb"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+func runTest(t *testing.T, test func(ctx *testcontext.Context, t *testing.T, db *metabase.DB)) {
+	pgtest.Run(t, func(ctx *testcontext.Context, t *testing.T, connstr string) {
+		tempDB, err := tempdb.OpenUnique(ctx, connstr, "metabase")
+		require.NoError(t, err)
+		defer ctx.Check(tempDB.Close)
+
+		db, err := metabase.Open(ctx, zaptest.NewLogger(t), tempDB.ConnStr, "satellite-metabase-test")
+		require.NoError(t, err)
+		defer ctx.Check(db.Close)
+
+		require.NoError(t, db.MigrateToLatest(ctx))
+
+		test(ctx, t, db)
+	})
+}
+
+func remoteSegment(size int64) metabase.SegmentData {
+	return metabase.SegmentData{
+		RootPieceID:       testrand.PieceID(),
+		EncryptedKeyNonce: testrand.BytesInt(24),
+		EncryptedKey:      testrand.BytesInt(32),
+		EncryptedSize:     size,
+		Redundancy: storj.RedundancyScheme{
+			Algorithm:      storj.ReedSolomon,
+			ShareSize:      256,
+			RequiredShares: 1,
+			RepairShares:   2,
+			OptimalShares:  3,
+			TotalShares:    4,
+		},
+		Pieces: metabase.Pieces{
+			{Number: 0, StorageNode: testrand.NodeID()},
+			{Number: 1, StorageNode: testrand.NodeID()},
+		},
+	}
+}
+
+func TestCommitObject(t *testing.T) {
+	runTest(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		location := metabase.ObjectLocation{
+			ProjectID:  testrand.UUID(),
+			BucketName: "bucket",
+			ObjectKey:  "object",
+		}
+
+		_, err := db.PutSegment(ctx, metabase.PutSegment{})
+		require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+		first, err := db.PutSegment(ctx, metabase.PutSegment{
+			ObjectLocation: location,
+			Position:       metabase.SegmentPosition{Index: 0},
+			SegmentData:    remoteSegment(100),
+		})
+		require.NoError(t, err)
+
+		_, err = db.GetObject(ctx, location)
+		require.True(t, metabase.ErrObjectNotFound.Has(err), "pending object is not visible")
+
+		object, err := db.CommitObject(ctx, metabase.CommitObject{
+			ObjectLocation:    location,
+			SegmentCount:      2,
+			EncryptedMetadata: []byte{1, 2, 3},
+			LastSegment:       metabase.SegmentData{EncryptedSize: 10, InlineData: []byte{4}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, first.StreamID, object.StreamID)
+		require.Equal(t, metabase.Committed, object.Status)
+		require.EqualValues(t, 2, object.SegmentCount)
+		require.EqualValues(t, 110, object.TotalEncryptedSize)
+
+		segments, err := db.ListSegments(ctx, object.StreamID)
+		require.NoError(t, err)
+		require.Len(t, segments, 2)
+		require.Equal(t, first.Pieces, segments[0].Pieces)
+		require.Equal(t, first.Redundancy, segments[0].Redundancy)
+		require.False(t, segments[0].Inline())
+		require.True(t, segments[1].Inline())
+
+		// uploading the object again replaces it on commit
+		second, err := db.PutSegment(ctx, metabase.PutSegment{
+			ObjectLocation: location,
+			Position:       metabase.SegmentPosition{Index: 0},
+			SegmentData:    remoteSegment(200),
+		})
+		require.NoError(t, err)
+		require.NotEqual(t, object.StreamID, second.StreamID)
+
+		got, err := db.GetObject(ctx, location)
+		require.NoError(t, err)
+		require.Equal(t, object.StreamID, got.StreamID)
+
+		replaced, err := db.CommitObject(ctx, metabase.CommitObject{
+			ObjectLocation: location,
+			LastSegment:    remoteSegment(50),
+		})
+		require.NoError(t, err)
+		require.Equal(t, second.StreamID, replaced.StreamID)
+		require.EqualValues(t, 2, replaced.SegmentCount)
+		require.EqualValues(t, 250, replaced.TotalEncryptedSize)
+
+		segments, err = db.ListSegments(ctx, object.StreamID)
+		require.NoError(t, err)
+		require.Empty(t, segments)
+	})
+}
+
+func TestUpdateAndDelete(t *testing.T) {
+	runTest(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		location := metabase.ObjectLocation{
+			ProjectID:  testrand.UUID(),
+			BucketName: "bucket",
+			ObjectKey:  "object",
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := db.PutSegment(ctx, metabase.PutSegment{
+				ObjectLocation: location,
+				Position:       metabase.SegmentPosition{Index: uint32(i)},
+				SegmentData:    remoteSegment(100),
+			})
+			require.NoError(t, err)
+		}
+		object, err := db.CommitObject(ctx, metabase.CommitObject{
+			ObjectLocation: location,
+			SegmentCount:   3,
+			LastSegment:    remoteSegment(100),
+		})
+		require.NoError(t, err)
+
+		newPieces := metabase.Pieces{{Number: 5, StorageNode: testrand.NodeID()}}
+		err = db.UpdateSegmentPieces(ctx, metabase.UpdateSegmentPieces{
+			Location:  location.LastSegment(),
+			NewPieces: newPieces,
+		})
+		require.NoError(t, err)
+
+		segments, err := db.ListSegments(ctx, object.StreamID)
+		require.NoError(t, err)
+		require.Len(t, segments, 3)
+		require.Equal(t, newPieces, segments[2].Pieces)
+
+		second, err := location.Segment(1)
+		require.NoError(t, err)
+
+		reencoded := remoteSegment(100)
+		err = db.UpdateSegmentRemote(ctx, metabase.UpdateSegmentRemote{
+			Location:    second,
+			RootPieceID: reencoded.RootPieceID,
+			Redundancy:  storj.RedundancyScheme{Algorithm: storj.ReedSolomon, ShareSize: 512, RequiredShares: 2, RepairShares: 3, OptimalShares: 4, TotalShares: 5},
+			Pieces:      reencoded.Pieces,
+		})
+		require.NoError(t, err)
+
+		segments, err = db.ListSegments(ctx, object.StreamID)
+		require.NoError(t, err)
+		require.Equal(t, reencoded.RootPieceID, segments[1].RootPieceID)
+		require.Equal(t, reencoded.Pieces, segments[1].Pieces)
+		require.EqualValues(t, 2, segments[1].Redundancy.RequiredShares)
+
+		err = db.UpdateSegmentRemote(ctx, metabase.UpdateSegmentRemote{Location: second})
+		require.True(t, metabase.ErrInvalidRequest.Has(err))
+
+		err = db.DeleteSegment(ctx, location.FirstSegment())
+		require.NoError(t, err)
+		err = db.DeleteSegment(ctx, location.FirstSegment())
+		require.True(t, metabase.ErrSegmentNotFound.Has(err))
+
+		err = db.DeleteSegment(ctx, location.LastSegment())
+		require.NoError(t, err)
+
+		_, err = db.GetObject(ctx, location)
+		require.True(t, metabase.ErrObjectNotFound.Has(err))
+		segments, err = db.ListSegments(ctx, object.StreamID)
+		require.NoError(t, err)
+		require.Empty(t, segments)
+	})
+}
+
+func TestIterateLoop(t *testing.T) {
+	runTest(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		projectID := testrand.UUID()
+
+		const numObjects = 5
+		for i := 0; i < numObjects; i++ {
+			location := metabase.ObjectLocation{
+				ProjectID:  projectID,
+				BucketName: "bucket",
+				ObjectKey:  metabase.ObjectKey(testrand.Path()),
+			}
+			_, err := db.PutSegment(ctx, metabase.PutSegment{
+				ObjectLocation: location,
+				Position:       metabase.SegmentPosition{Index: 0},
+				SegmentData:    remoteSegment(100),
+			})
+			require.NoError(t, err)
+			_, err = db.CommitObject(ctx, metabase.CommitObject{
+				ObjectLocation: location,
+				SegmentCount:   2,
+				LastSegment:    remoteSegment(100),
+			})
+			require.NoError(t, err)
+		}
+
+		// pending uploads are iterated as well, their pieces are stored already
+		_, err := db.PutSegment(ctx, metabase.PutSegment{
+			ObjectLocation: metabase.ObjectLocation{ProjectID: projectID, BucketName: "bucket", ObjectKey: "pending"},
+			SegmentData:    remoteSegment(100),
+		})
+		require.NoError(t, err)
+
+		for _, batchSize := range []int{1, 2, numObjects, 100} {
+			committed, pending, segments := 0, 0, 0
+			err := db.IterateLoop(ctx, batchSize, func(ctx context.Context, object metabase.Object, objectSegments []metabase.Segment) error {
+				switch object.Status {
+				case metabase.Committed:
+					committed++
+				case metabase.Pending:
+					pending++
+				}
+				segments += len(objectSegments)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, numObjects, committed)
+			require.Equal(t, 1, pending)
+			require.Equal(t, 2*numObjects+1, segments)
+		}
+	})
+}
+
+func TestPutCommittedSegment(t *testing.T) {
+	runTest(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		location := metabase.ObjectLocation{
+			ProjectID:  testrand.UUID(),
+			BucketName: "bucket",
+			ObjectKey:  "object",
+		}
+
+		// segments without a committed object are added to a pending upload
+		orphan := remoteSegment(100)
+		_, err := db.PutSegment(ctx, metabase.PutSegment{
+			ObjectLocation: metabase.ObjectLocation{ProjectID: location.ProjectID, BucketName: "bucket", ObjectKey: "orphan"},
+			Committed:      true,
+			SegmentData:    orphan,
+		})
+		require.NoError(t, err)
+
+		// migrations add the last segment first
+		now := time.Now()
+		last := remoteSegment(100)
+		last.CreatedAt = now
+		object, err := db.CommitObject(ctx, metabase.CommitObject{
+			ObjectLocation: location,
+			SegmentCount:   2,
+			LastSegment:    last,
+		})
+		require.NoError(t, err)
+
+		first := remoteSegment(100)
+		first.CreatedAt = now.Add(-time.Minute)
+		segment, err := db.PutSegment(ctx, metabase.PutSegment{
+			ObjectLocation: location,
+			Position:       metabase.SegmentPosition{Index: 0},
+			Committed:      true,
+			SegmentData:    first,
+		})
+		require.NoError(t, err)
+		require.Equal(t, object.StreamID, segment.StreamID)
+
+		// segments of an upload, which replaces the object, are created after its last segment
+		reuploaded := remoteSegment(100)
+		reuploaded.CreatedAt = now.Add(time.Minute)
+		segment, err = db.PutSegment(ctx, metabase.PutSegment{
+			ObjectLocation: location,
+			Position:       metabase.SegmentPosition{Index: 0},
+			Committed:      true,
+			SegmentData:    reuploaded,
+		})
+		require.NoError(t, err)
+		require.NotEqual(t, object.StreamID, segment.StreamID)
+
+		// and so are segments after the last segment
+		segment, err = db.PutSegment(ctx, metabase.PutSegment{
+			ObjectLocation: location,
+			Position:       metabase.SegmentPosition{Index: 1},
+			Committed:      true,
+			SegmentData:    first,
+		})
+		require.NoError(t, err)
+		require.NotEqual(t, object.StreamID, segment.StreamID)
+
+		segments, err := db.ListSegments(ctx, object.StreamID)
+		require.NoError(t, err)
+		require.Len(t, segments, 2)
+		require.Equal(t, first.RootPieceID, segments[0].RootPieceID)
+		require.Equal(t, last.RootPieceID, segments[1].RootPieceID)
+
+		// the segments of both are found at the location
+		found, err := db.GetSegments(ctx, location.FirstSegment())
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+
+		found, err = db.GetSegments(ctx, location.LastSegment())
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.Equal(t, last.RootPieceID, found[0].RootPieceID)
+	})
+}
+
+func TestVerified(t *testing.T) {
+	runTest(t, func(ctx *testcontext.Context, t *testing.T, db *metabase.DB) {
+		verified, err := db.Verified(ctx)
+		require.NoError(t, err)
+		require.False(t, verified)
+
+		now := time.Now()
+		require.NoError(t, db.RecordVerified(ctx, now))
+		verified, err = db.Verified(ctx)
+		require.NoError(t, err)
+		require.True(t, verified)
+
+		// writes which failed while the check was running aren't covered by it
+		require.NoError(t, db.RecordWriteFailed(ctx, now.Add(time.Second)))
+		verified, err = db.Verified(ctx)
+		require.NoError(t, err)
+		require.False(t, verified)
+
+		require.NoError(t, db.RecordVerified(ctx, now.Add(time.Minute)))
+		verified, err = db.Verified(ctx)
+		require.NoError(t, err)
+		require.True(t, verified)
+
+		// older failures don't replace newer ones
+		require.NoError(t, db.RecordWriteFailed(ctx, now.Add(2*time.Minute)))
+		require.NoError(t, db.RecordWriteFailed(ctx, now))
+		verified, err = db.Verified(ctx)
+		require.NoError(t, err)
+		require.False(t, verified)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+
+	"storj.io/common/storj"
+)
+
+// pieceEncodedSize is the size of a single encoded piece: the piece number
+// followed by the storage node id.
+const pieceEncodedSize = 2 + len(storj.NodeID{})
+
+// Value implements sql/driver.Valuer interface.
+func (pieces Pieces) Value() (driver.Value, error) {
+	if len(pieces) == 0 {
+		return nil, nil
+	}
+
+	data := make([]byte, 0, len(pieces)*pieceEncodedSize)
+	for _, piece := range pieces {
+		var number [2]byte
+		binary.BigEndian.PutUint16(number[:], piece.Number)
+		data = append(data, number[:]...)
+		data = append(data, piece.StorageNode[:]...)
+	}
+	return data, nil
+}
+
+// Scan implements sql.Scanner interface.
+func (pieces *Pieces) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		*pieces = nil
+		return nil
+	case []byte:
+		if len(value)%pieceEncodedSize != 0 {
+			return Error.New("invalid length of encoded pieces %d", len(value))
+		}
+
+		decoded := make(Pieces, 0, len(value)/pieceEncodedSize)
+		for len(value) > 0 {
+			var piece Piece
+			piece.Number = binary.BigEndian.Uint16(value[:2])
+			copy(piece.StorageNode[:], value[2:pieceEncodedSize])
+			decoded = append(decoded, piece)
+			value = value[pieceEncodedSize:]
+		}
+		*pieces = decoded
+		return nil
+	default:
+		return Error.New("unable to scan %T into Pieces", value)
+	}
+}
+
+// redundancyScheme allows to store storj.RedundancyScheme as a single integer.
+//
+// The layout from the most significant byte is: algorithm, share size (3 bytes),
+// required shares, repair shares, optimal shares and total shares.
+type redundancyScheme struct {
+	*storj.RedundancyScheme
+}
+
+// Value implements sql/driver.Valuer interface.
+func (params redundancyScheme) Value() (driver.Value, error) {
+	rs := params.RedundancyScheme
+	switch {
+	case rs.ShareSize < 0 || rs.ShareSize >= 1<<24:
+		return nil, Error.New("invalid share size %v", rs.ShareSize)
+	case rs.RequiredShares < 0 || rs.RequiredShares > 255:
+		return nil, Error.New("invalid required shares %v", rs.RequiredShares)
+	case rs.RepairShares < 0 || rs.RepairShares > 255:
+		return nil, Error.New("invalid repair shares %v", rs.RepairShares)
+	case rs.OptimalShares < 0 || rs.OptimalShares > 255:
+		return nil, Error.New("invalid optimal shares %v", rs.OptimalShares)
+	case rs.TotalShares < 0 || rs.TotalShares > 255:
+		return nil, Error.New("invalid total shares %v", rs.TotalShares)
+	}
+
+	return int64(uint64(rs.Algorithm)<<56 |
+		uint64(rs.ShareSize)<<32 |
+		uint64(rs.RequiredShares)<<24 |
+		uint64(rs.RepairShares)<<16 |
+		uint64(rs.OptimalShares)<<8 |
+		uint64(rs.TotalShares)), nil
+}
+
+// Scan implements sql.Scanner interface.
+func (params redundancyScheme) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case int64:
+		v := uint64(value)
+		*params.RedundancyScheme = storj.RedundancyScheme{
+			Algorithm:      storj.RedundancyAlgorithm(byte(v >> 56)),
+			ShareSize:      int32(v >> 32 & 0xFFFFFF),
+			RequiredShares: int16(byte(v >> 24)),
+			RepairShares:   int16(byte(v >> 16)),
+			OptimalShares:  int16(byte(v >> 8)),
+			TotalShares:    int16(byte(v)),
+		}
+		return nil
+	default:
+		return Error.New("unable to scan %T into RedundancyScheme", value)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+)
+
+func TestPiecesEncoding(t *testing.T) {
+	pieces := Pieces{
+		{Number: 0, StorageNode: testrand.NodeID()},
+		{Number: 65535, StorageNode: testrand.NodeID()},
+	}
+
+	value, err := pieces.Value()
+	require.NoError(t, err)
+
+	var decoded Pieces
+	require.NoError(t, decoded.Scan(value))
+	require.Equal(t, pieces, decoded)
+
+	value, err = Pieces(nil).Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+	require.NoError(t, decoded.Scan(nil))
+	require.Nil(t, decoded)
+
+	require.Error(t, decoded.Scan([]byte{1, 2, 3}))
+}
+
+func TestRedundancySchemeEncoding(t *testing.T) {
+	scheme := storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      256 << 10,
+		RequiredShares: 29,
+		RepairShares:   35,
+		OptimalShares:  80,
+		TotalShares:    110,
+	}
+
+	value, err := redundancyScheme{&scheme}.Value()
+	require.NoError(t, err)
+
+	var decoded storj.RedundancyScheme
+	require.NoError(t, redundancyScheme{&decoded}.Scan(value))
+	require.Equal(t, scheme, decoded)
+
+	invalid := scheme
+	invalid.TotalShares = 256
+	_, err = redundancyScheme{&invalid}.Value()
+	require.Error(t, err)
+
+	invalid = scheme
+	invalid.ShareSize = 1 << 24
+	_, err = redundancyScheme{&invalid}.Value()
+	require.Error(t, err)
+}
+
+func TestSegmentPositionEncoding(t *testing.T) {
+	for _, pos := range []SegmentPosition{
+		{},
+		{Part: 0, Index: 1},
+		{Part: 1, Index: 0},
+		{Part: 1<<32 - 1, Index: 1<<32 - 1},
+	} {
+		require.Equal(t, pos, SegmentPositionFromEncoded(pos.Encode()))
+	}
+
+	require.True(t, SegmentPosition{Part: 0, Index: 5}.Less(SegmentPosition{Part: 1, Index: 0}))
+	require.False(t, SegmentPosition{Part: 1, Index: 0}.Less(SegmentPosition{Part: 0, Index: 5}))
+}
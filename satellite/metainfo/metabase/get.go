@@ -0,0 +1,137 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/private/dbutil/pgutil"
+	"storj.io/storj/private/tagsql"
+)
+
+// GetObject returns the committed object at the location.
+func (db *DB) GetObject(ctx context.Context, location ObjectLocation) (_ Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := location.verify(); err != nil {
+		return Object{}, err
+	}
+
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Object{}, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(tx.Rollback())) }()
+
+	return latestObject(ctx, tx, location, Committed)
+}
+
+// ListSegments returns the segments of the stream ordered by position.
+func (db *DB) ListSegments(ctx context.Context, streamID uuid.UUID) (_ []Segment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	segments, err := listSegments(ctx, db.db, [][]byte{streamID[:]})
+	return segments[streamID], err
+}
+
+// GetSegments returns the segments stored at the location. The last segment
+// is the last segment of the committed object, other indexes return the
+// segments at the position of the committed object and of pending uploads.
+func (db *DB) GetSegments(ctx context.Context, location SegmentLocation) (_ []Segment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := location.Object().verify(); err != nil {
+		return nil, err
+	}
+	if location.Index < LastSegmentIndex {
+		return nil, ErrInvalidRequest.New("invalid index %d", location.Index)
+	}
+
+	var rows tagsql.Rows
+	if location.IsLast() {
+		rows, err = db.db.QueryContext(ctx, `
+			SELECT
+				segments.stream_id, segments.position, segments.root_piece_id,
+				segments.encrypted_key_nonce, segments.encrypted_key, segments.encrypted_size,
+				segments.redundancy, segments.inline_data, segments.remote_pieces, segments.created_at
+			FROM objects
+			JOIN segments ON segments.stream_id = objects.stream_id AND segments.position = objects.segment_count - 1
+			WHERE objects.project_id = $1 AND objects.bucket_name = $2 AND objects.object_key = $3
+				AND objects.status = $4
+		`, location.ProjectID, []byte(location.BucketName), []byte(location.ObjectKey), Committed)
+	} else {
+		rows, err = db.db.QueryContext(ctx, `
+			SELECT
+				segments.stream_id, segments.position, segments.root_piece_id,
+				segments.encrypted_key_nonce, segments.encrypted_key, segments.encrypted_size,
+				segments.redundancy, segments.inline_data, segments.remote_pieces, segments.created_at
+			FROM objects
+			JOIN segments ON segments.stream_id = objects.stream_id
+			WHERE objects.project_id = $1 AND objects.bucket_name = $2 AND objects.object_key = $3
+				AND segments.position = $4
+		`, location.ProjectID, []byte(location.BucketName), []byte(location.ObjectKey),
+			int64(SegmentPosition{Index: uint32(location.Index)}.Encode()))
+	}
+	if err != nil {
+		return nil, Error.New("unable to query segments: %w", err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(rows.Close())) }()
+
+	var segments []Segment
+	for rows.Next() {
+		segment, err := scanSegment(rows)
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+	return segments, Error.Wrap(rows.Err())
+}
+
+// listSegments returns the segments of the streams grouped by stream id and ordered by position.
+func listSegments(ctx context.Context, db tagsql.DB, streamIDs [][]byte) (_ map[uuid.UUID][]Segment, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT
+			stream_id, position, root_piece_id,
+			encrypted_key_nonce, encrypted_key, encrypted_size,
+			redundancy, inline_data, remote_pieces, created_at
+		FROM segments
+		WHERE stream_id = ANY($1)
+		ORDER BY stream_id, position
+	`, pgutil.ByteaArray(streamIDs))
+	if err != nil {
+		return nil, Error.New("unable to query segments: %w", err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(rows.Close())) }()
+
+	segments := make(map[uuid.UUID][]Segment, len(streamIDs))
+	for rows.Next() {
+		segment, err := scanSegment(rows)
+		if err != nil {
+			return nil, err
+		}
+		segments[segment.StreamID] = append(segments[segment.StreamID], segment)
+	}
+	return segments, Error.Wrap(rows.Err())
+}
+
+// scanSegment scans a segment selected with all of its columns.
+func scanSegment(rows tagsql.Rows) (segment Segment, err error) {
+	var position int64
+	err = rows.Scan(
+		&segment.StreamID, &position, &segment.RootPieceID,
+		&segment.EncryptedKeyNonce, &segment.EncryptedKey, &segment.EncryptedSize,
+		redundancyScheme{&segment.Redundancy}, &segment.InlineData, &segment.Pieces, &segment.CreatedAt,
+	)
+	if err != nil {
+		return Segment{}, Error.New("unable to scan segment: %w", err)
+	}
+	segment.Position = SegmentPositionFromEncoded(uint64(position))
+	return segment, nil
+}
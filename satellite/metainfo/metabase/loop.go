@@ -0,0 +1,89 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+)
+
+// IterateLoop iterates over all objects in batches of batchSize and calls fn
+// with every object and its segments ordered by position. Pending uploads are
+// included, because their segments already have pieces on storage nodes.
+func (db *DB) IterateLoop(ctx context.Context, batchSize int, fn func(ctx context.Context, object Object, segments []Segment) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if batchSize <= 0 {
+		return ErrInvalidRequest.New("BatchSize is negative or zero")
+	}
+
+	var cursor Object
+	for {
+		objects, err := db.loopObjects(ctx, cursor, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(objects) == 0 {
+			return nil
+		}
+
+		streamIDs := make([][]byte, len(objects))
+		for i := range objects {
+			streamIDs[i] = objects[i].StreamID[:]
+		}
+		segments, err := listSegments(ctx, db.db, streamIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, object := range objects {
+			if err := fn(ctx, object, segments[object.StreamID]); err != nil {
+				return err
+			}
+		}
+
+		if len(objects) < batchSize {
+			return nil
+		}
+		cursor = objects[len(objects)-1]
+	}
+}
+
+// loopObjects returns the next batch of objects after the cursor.
+func (db *DB) loopObjects(ctx context.Context, cursor Object, batchSize int) (_ []Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT
+			project_id, bucket_name, object_key, version, stream_id, status,
+			created_at, expires_at, segment_count, encrypted_metadata, total_encrypted_size
+		FROM objects
+		WHERE (project_id, bucket_name, object_key, version) > ($1, $2, $3, $4)
+		ORDER BY project_id, bucket_name, object_key, version
+		LIMIT $5
+	`, cursor.ProjectID, []byte(cursor.BucketName), []byte(cursor.ObjectKey), cursor.Version,
+		batchSize)
+	if err != nil {
+		return nil, Error.New("unable to query objects: %w", err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(rows.Close())) }()
+
+	objects := make([]Object, 0, batchSize)
+	for rows.Next() {
+		var object Object
+		var bucketName, objectKey []byte
+		err := rows.Scan(
+			&object.ProjectID, &bucketName, &objectKey, &object.Version, &object.StreamID, &object.Status,
+			&object.CreatedAt, &object.ExpiresAt, &object.SegmentCount, &object.EncryptedMetadata, &object.TotalEncryptedSize,
+		)
+		if err != nil {
+			return nil, Error.New("unable to scan object: %w", err)
+		}
+		object.BucketName = string(bucketName)
+		object.ObjectKey = ObjectKey(objectKey)
+		objects = append(objects, object)
+	}
+	return objects, Error.Wrap(rows.Err())
+}
@@ -0,0 +1,232 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metabase
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/private/dbutil/pgutil"
+	"storj.io/storj/private/dbutil/txutil"
+	"storj.io/storj/private/tagsql"
+)
+
+// UpdateSegmentPieces contains arguments necessary for updating the pieces of a segment.
+type UpdateSegmentPieces struct {
+	// Location is the location of the segment of the committed object. The
+	// last segment is addressed with LastSegmentIndex.
+	Location SegmentLocation
+
+	NewPieces Pieces
+}
+
+// UpdateSegmentPieces replaces the pieces of a segment of the committed object.
+func (db *DB) UpdateSegmentPieces(ctx context.Context, opts UpdateSegmentPieces) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Location.Object().verify(); err != nil {
+		return err
+	}
+	if len(opts.NewPieces) == 0 {
+		return ErrInvalidRequest.New("NewPieces missing")
+	}
+
+	return txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		streamID, position, err := committedSegment(ctx, tx, opts.Location)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE segments SET remote_pieces = $3
+			WHERE stream_id = $1 AND position = $2
+		`, streamID, int64(position.Encode()), opts.NewPieces)
+		if err != nil {
+			return Error.New("unable to update segment pieces: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if affected == 0 {
+			return ErrSegmentNotFound.New("%q %d", opts.Location.ObjectKey, opts.Location.Index)
+		}
+		return nil
+	})
+}
+
+// UpdateSegmentRemote contains arguments necessary for replacing the remote
+// data of a segment, e.g. when the segment is re-encoded.
+type UpdateSegmentRemote struct {
+	// Location is the location of the segment of the committed object. The
+	// last segment is addressed with LastSegmentIndex.
+	Location SegmentLocation
+
+	RootPieceID storj.PieceID
+	Redundancy  storj.RedundancyScheme
+	Pieces      Pieces
+}
+
+// UpdateSegmentRemote replaces the root piece id, redundancy scheme and pieces
+// of a segment of the committed object.
+func (db *DB) UpdateSegmentRemote(ctx context.Context, opts UpdateSegmentRemote) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := opts.Location.Object().verify(); err != nil {
+		return err
+	}
+	switch {
+	case opts.RootPieceID.IsZero():
+		return ErrInvalidRequest.New("RootPieceID missing")
+	case opts.Redundancy.IsZero():
+		return ErrInvalidRequest.New("Redundancy missing")
+	case len(opts.Pieces) == 0:
+		return ErrInvalidRequest.New("Pieces missing")
+	}
+
+	return txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		streamID, position, err := committedSegment(ctx, tx, opts.Location)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			UPDATE segments SET
+				root_piece_id = $3,
+				redundancy = $4,
+				remote_pieces = $5
+			WHERE stream_id = $1 AND position = $2
+		`, streamID, int64(position.Encode()), opts.RootPieceID, redundancyScheme{&opts.Redundancy}, opts.Pieces)
+		if err != nil {
+			return Error.New("unable to update segment: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if affected == 0 {
+			return ErrSegmentNotFound.New("%q %d", opts.Location.ObjectKey, opts.Location.Index)
+		}
+		return nil
+	})
+}
+
+// DeleteSegment deletes a segment of the committed object. Deleting the last
+// segment deletes the whole object, the same way as removing the last segment
+// pointer makes the object invisible.
+func (db *DB) DeleteSegment(ctx context.Context, location SegmentLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if location.IsLast() {
+		return db.DeleteObject(ctx, location.Object())
+	}
+
+	if err := location.Object().verify(); err != nil {
+		return err
+	}
+
+	return txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		streamID, position, err := committedSegment(ctx, tx, location)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `
+			DELETE FROM segments WHERE stream_id = $1 AND position = $2
+		`, streamID, int64(position.Encode()))
+		if err != nil {
+			return Error.New("unable to delete segment: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if affected == 0 {
+			return ErrSegmentNotFound.New("%q %d", location.ObjectKey, location.Index)
+		}
+		return nil
+	})
+}
+
+// DeleteObject deletes the committed object and all of its segments.
+func (db *DB) DeleteObject(ctx context.Context, location ObjectLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := location.verify(); err != nil {
+		return err
+	}
+
+	return txutil.WithTx(ctx, db.db, nil, func(ctx context.Context, tx tagsql.Tx) error {
+		deleted, err := deleteObjects(ctx, tx, location, Committed)
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return ErrObjectNotFound.New("%q", location.ObjectKey)
+		}
+		return nil
+	})
+}
+
+// committedSegment returns the stream id and the position of the segment of the committed object.
+func committedSegment(ctx context.Context, tx tagsql.Tx, location SegmentLocation) (_ uuid.UUID, _ SegmentPosition, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	object, err := latestObject(ctx, tx, location.Object(), Committed)
+	if err != nil {
+		return uuid.UUID{}, SegmentPosition{}, err
+	}
+
+	if location.IsLast() {
+		return object.StreamID, SegmentPosition{Index: uint32(object.SegmentCount - 1)}, nil
+	}
+	if location.Index < 0 || location.Index >= int64(object.SegmentCount) {
+		return uuid.UUID{}, SegmentPosition{}, ErrSegmentNotFound.New("%q %d", location.ObjectKey, location.Index)
+	}
+	return object.StreamID, SegmentPosition{Index: uint32(location.Index)}, nil
+}
+
+// deleteObjects deletes all objects with the status at the location including their segments.
+func deleteObjects(ctx context.Context, tx tagsql.Tx, location ObjectLocation, status ObjectStatus) (deleted int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := tx.QueryContext(ctx, `
+		DELETE FROM objects
+		WHERE project_id = $1 AND bucket_name = $2 AND object_key = $3 AND status = $4
+		RETURNING stream_id
+	`, location.ProjectID, []byte(location.BucketName), []byte(location.ObjectKey), status)
+	if err != nil {
+		return 0, Error.New("unable to delete objects: %w", err)
+	}
+
+	var streamIDs [][]byte
+	for rows.Next() {
+		var streamID []byte
+		if err := rows.Scan(&streamID); err != nil {
+			return 0, Error.Wrap(errs.Combine(err, rows.Close()))
+		}
+		streamIDs = append(streamIDs, streamID)
+	}
+	if err := errs.Combine(rows.Err(), rows.Close()); err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	if len(streamIDs) == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM segments WHERE stream_id = ANY($1)
+	`, pgutil.ByteaArray(streamIDs))
+	if err != nil {
+		return 0, Error.New("unable to delete segments: %w", err)
+	}
+	return len(streamIDs), nil
+}
@@ -61,6 +61,11 @@ type APIKeys interface {
 	GetByHead(ctx context.Context, head []byte) (*console.APIKeyInfo, error)
 }
 
+// APIKeyUsage records the requests made with api keys.
+type APIKeyUsage interface {
+	Record(ctx context.Context, keyID uuid.UUID, usedAt time.Time)
+}
+
 // Endpoint metainfo endpoint.
 //
 // architecture: Endpoint
@@ -77,6 +82,7 @@ type Endpoint struct {
 	projectUsage         *accounting.Service
 	projects             console.Projects
 	apiKeys              APIKeys
+	apiKeyUsage          APIKeyUsage
 	satellite            signing.Signer
 	limiterCache         *lrucache.ExpiringLRU
 	encInlineSegmentSize int64 // max inline segment size + encryption overhead
@@ -89,7 +95,7 @@ type Endpoint struct {
 func NewEndpoint(log *zap.Logger, metainfo *Service, deletePieces *piecedeletion.Service,
 	orders *orders.Service, cache *overlay.Service, attributions attribution.DB,
 	partners *rewards.PartnersService, peerIdentities overlay.PeerIdentities,
-	apiKeys APIKeys, projectUsage *accounting.Service, projects console.Projects,
+	apiKeys APIKeys, apiKeyUsage APIKeyUsage, projectUsage *accounting.Service, projects console.Projects,
 	satellite signing.Signer, revocations revocation.DB, config Config) (*Endpoint, error) {
 	// TODO do something with too many params
 
@@ -105,15 +111,6 @@ func NewEndpoint(log *zap.Logger, metainfo *Service, deletePieces *piecedeletion
 		return nil, err
 	}
 
-	defaultRSScheme := &pb.RedundancyScheme{
-		Type:             pb.RedundancyScheme_RS,
-		MinReq:           int32(config.RS.Min),
-		RepairThreshold:  int32(config.RS.Repair),
-		SuccessThreshold: int32(config.RS.Success),
-		Total:            int32(config.RS.Total),
-		ErasureShareSize: config.RS.ErasureShareSize.Int32(),
-	}
-
 	return &Endpoint{
 		log:                 log,
 		metainfo:            metainfo,
@@ -125,6 +122,7 @@ func NewEndpoint(log *zap.Logger, metainfo *Service, deletePieces *piecedeletion
 		partners:            partners,
 		pointerVerification: pointerverification.NewService(peerIdentities),
 		apiKeys:             apiKeys,
+		apiKeyUsage:         apiKeyUsage,
 		projectUsage:        projectUsage,
 		projects:            projects,
 		satellite:           satellite,
@@ -134,7 +132,7 @@ func NewEndpoint(log *zap.Logger, metainfo *Service, deletePieces *piecedeletion
 		}),
 		encInlineSegmentSize: encInlineSegmentSize,
 		revocations:          revocations,
-		defaultRS:            defaultRSScheme,
+		defaultRS:            config.RS.Proto(),
 		config:               config,
 	}, nil
 }
@@ -260,7 +258,11 @@ func (endpoint *Endpoint) GetBucket(ctx context.Context, req *pb.BucketGetReques
 	}
 
 	// override RS to fit satellite settings
-	convBucket, err := convertBucketToProto(bucket, endpoint.defaultRS)
+	rs, err := endpoint.bucketRedundancy(ctx, metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.GetName())})
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
+	convBucket, err := convertBucketToProto(bucket, rs)
 	if err != nil {
 		return resp, err
 	}
@@ -335,7 +337,12 @@ func (endpoint *Endpoint) CreateBucket(ctx context.Context, req *pb.BucketCreate
 	}
 
 	// override RS to fit satellite settings
-	convBucket, err := convertBucketToProto(bucket, endpoint.defaultRS)
+	rs, err := endpoint.bucketRedundancy(ctx, metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: bucket.Name})
+	if err != nil {
+		endpoint.log.Error("error while getting bucket redundancy", zap.String("bucketName", bucket.Name), zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to create bucket")
+	}
+	convBucket, err := convertBucketToProto(bucket, rs)
 	if err != nil {
 		endpoint.log.Error("error while converting bucket to proto", zap.String("bucketName", bucket.Name), zap.Error(err))
 		return nil, rpcstatus.Error(rpcstatus.Internal, "unable to create bucket")
@@ -394,7 +401,11 @@ func (endpoint *Endpoint) DeleteBucket(ctx context.Context, req *pb.BucketDelete
 			return nil, err
 		}
 
-		convBucket, err = convertBucketToProto(bucket, endpoint.defaultRS)
+		rs, err := endpoint.bucketRedundancy(ctx, metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Name)})
+		if err != nil {
+			return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+		}
+		convBucket, err = convertBucketToProto(bucket, rs)
 		if err != nil {
 			return nil, err
 		}
@@ -676,7 +687,11 @@ func (endpoint *Endpoint) BeginObject(ctx context.Context, req *pb.ObjectBeginRe
 	}
 
 	// use only satellite values for Redundancy Scheme
-	pbRS := endpoint.defaultRS
+	pbRS, err := endpoint.bucketRedundancy(ctx, metabase.BucketLocation{ProjectID: keyInfo.ProjectID, BucketName: string(req.Bucket)})
+	if err != nil {
+		endpoint.log.Error("unable to get bucket redundancy profile", zap.Error(err))
+		return nil, rpcstatus.Error(rpcstatus.Internal, err.Error())
+	}
 
 	streamID, err := endpoint.packStreamID(ctx, &internalpb.StreamID{
 		Bucket:         req.Bucket,
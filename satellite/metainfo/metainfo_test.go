@@ -1697,3 +1697,47 @@ func TestGetObjectIPs(t *testing.T) {
 		}
 	})
 }
+
+func TestBucketRedundancyProfile(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 6, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				require.NoError(t, config.Metainfo.RedundancyProfiles.Set("archive:2/3/5/6-256B:1.5"))
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		projectID := planet.Uplinks[0].Projects[0].ID
+
+		require.NoError(t, planet.Uplinks[0].CreateBucket(ctx, satellite, "archive-bucket"))
+		bucket := metabase.BucketLocation{ProjectID: projectID, BucketName: "archive-bucket"}
+		require.NoError(t, satellite.DB.Buckets().SetBucketRedundancyProfile(ctx, bucket, "archive"))
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "archive-bucket", "object", testrand.Bytes(10*memory.KiB))
+		require.NoError(t, err)
+
+		location := metabase.SegmentLocation{
+			ProjectID:  projectID,
+			BucketName: "archive-bucket",
+			Index:      metabase.LastSegmentIndex,
+		}
+		items, _, err := satellite.Metainfo.Service.List(ctx, location.Encode(), "", false, 0, meta.All)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(items))
+
+		location.ObjectKey = metabase.ObjectKey(items[0].Path)
+		pointer, err := satellite.Metainfo.Service.Get(ctx, location.Encode())
+		require.NoError(t, err)
+
+		redundancy := pointer.GetRemote().GetRedundancy()
+		require.EqualValues(t, 2, redundancy.MinReq)
+		require.EqualValues(t, 3, redundancy.RepairThreshold)
+		require.EqualValues(t, 5, redundancy.SuccessThreshold)
+		require.EqualValues(t, 6, redundancy.Total)
+
+		multipliers, err := satMetainfo.NewBucketPriceMultipliers(satellite.DB.Buckets(), satellite.Config.Metainfo.RedundancyProfiles).StoragePriceMultipliers(ctx, projectID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]float64{"archive-bucket": 1.5}, multipliers)
+	})
+}
@@ -0,0 +1,211 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package metainfo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// ErrUnknownRedundancyProfile is used when a bucket refers to a redundancy
+// profile which isn't approved by the satellite.
+var ErrUnknownRedundancyProfile = errs.Class("unknown redundancy profile")
+
+// RedundancyProfile is a satellite approved redundancy scheme, which can be
+// selected for a bucket instead of the default one.
+type RedundancyProfile struct {
+	Name string
+	RS   RSConfig
+	// PriceMultiplier is applied to the storage used by buckets with the profile.
+	PriceMultiplier float64
+}
+
+// RedundancyProfiles is a list of redundancy profiles.
+//
+// Can be used as a flag.
+type RedundancyProfiles []RedundancyProfile
+
+// Type implements pflag.Value.
+func (RedundancyProfiles) Type() string { return "metainfo.RedundancyProfiles" }
+
+// String is required for pflag.Value.
+func (profiles *RedundancyProfiles) String() string {
+	var s strings.Builder
+	for i, profile := range *profiles {
+		if i > 0 {
+			s.WriteString(",")
+		}
+		s.WriteString(profile.Name)
+		s.WriteString(":")
+		s.WriteString(profile.RS.String())
+		s.WriteString(":")
+		s.WriteString(strconv.FormatFloat(profile.PriceMultiplier, 'f', -1, 64))
+	}
+	return s.String()
+}
+
+// Set sets the value from a comma separated list of profiles in the format
+// name:k/m/o/n-size:multiplier.
+func (profiles *RedundancyProfiles) Set(s string) error {
+	list := RedundancyProfiles{}
+	for _, info := range strings.Split(s, ",") {
+		info = strings.TrimSpace(info)
+		if info == "" {
+			continue
+		}
+
+		parts := strings.Split(info, ":")
+		if len(parts) != 3 {
+			return Error.New("Invalid redundancy profile (expect format name:k/m/o/n-ShareSize:multiplier, got %s)", info)
+		}
+
+		profile := RedundancyProfile{Name: parts[0]}
+		if profile.Name == "" {
+			return Error.New("Invalid redundancy profile (name missing): %s", info)
+		}
+		if _, ok := list.Find(profile.Name); ok {
+			return Error.New("Invalid redundancy profile (duplicate name): %s", profile.Name)
+		}
+		if err := profile.RS.Set(parts[1]); err != nil {
+			return err
+		}
+
+		multiplier, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return Error.New("Invalid redundancy profile price multiplier: '%s', %w", parts[2], err)
+		}
+		if multiplier <= 0 {
+			return Error.New("Invalid redundancy profile price multiplier (should be positive): %s", parts[2])
+		}
+		profile.PriceMultiplier = multiplier
+
+		list = append(list, profile)
+	}
+
+	*profiles = list
+	return nil
+}
+
+// Find returns the profile with the name.
+func (profiles RedundancyProfiles) Find(name string) (RedundancyProfile, bool) {
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return RedundancyProfile{}, false
+}
+
+// Verify checks whether the name refers to an approved profile.
+// An empty name refers to the default redundancy scheme.
+func (profiles RedundancyProfiles) Verify(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, ok := profiles.Find(name); !ok {
+		return ErrUnknownRedundancyProfile.New("%q", name)
+	}
+	return nil
+}
+
+// Proto returns the redundancy scheme sent to the uplinks.
+func (rs RSConfig) Proto() *pb.RedundancyScheme {
+	return &pb.RedundancyScheme{
+		Type:             pb.RedundancyScheme_RS,
+		MinReq:           int32(rs.Min),
+		RepairThreshold:  int32(rs.Repair),
+		SuccessThreshold: int32(rs.Success),
+		Total:            int32(rs.Total),
+		ErasureShareSize: rs.ErasureShareSize.Int32(),
+	}
+}
+
+// RedundancyScheme returns the redundancy scheme stored in segments.
+func (rs RSConfig) RedundancyScheme() storj.RedundancyScheme {
+	return storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      rs.ErasureShareSize.Int32(),
+		RequiredShares: int16(rs.Min),
+		RepairShares:   int16(rs.Repair),
+		OptimalShares:  int16(rs.Success),
+		TotalShares:    int16(rs.Total),
+	}
+}
+
+// BucketPriceMultipliers returns the storage price multipliers of buckets
+// based on their redundancy profiles.
+type BucketPriceMultipliers struct {
+	buckets  BucketsDB
+	profiles RedundancyProfiles
+}
+
+// NewBucketPriceMultipliers creates a new BucketPriceMultipliers.
+func NewBucketPriceMultipliers(buckets BucketsDB, profiles RedundancyProfiles) *BucketPriceMultipliers {
+	return &BucketPriceMultipliers{
+		buckets:  buckets,
+		profiles: profiles,
+	}
+}
+
+// StoragePriceMultipliers returns the price multipliers of the project buckets,
+// which use a redundancy profile. Buckets using the default redundancy scheme
+// are not included.
+func (multipliers *BucketPriceMultipliers) StoragePriceMultipliers(ctx context.Context, projectID uuid.UUID) (_ map[string]float64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(multipliers.profiles) == 0 {
+		return nil, nil
+	}
+
+	names, err := multipliers.buckets.ListBucketRedundancyProfiles(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]float64, len(names))
+	for bucketName, name := range names {
+		profile, ok := multipliers.profiles.Find(name)
+		if !ok {
+			continue
+		}
+		result[bucketName] = profile.PriceMultiplier
+	}
+	return result, nil
+}
+
+// bucketRedundancy returns the redundancy scheme used for uploads to the bucket.
+func (endpoint *Endpoint) bucketRedundancy(ctx context.Context, bucket metabase.BucketLocation) (_ *pb.RedundancyScheme, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(endpoint.config.RedundancyProfiles) == 0 {
+		return endpoint.defaultRS, nil
+	}
+
+	name, err := endpoint.metainfo.GetBucketRedundancyProfile(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return endpoint.defaultRS, nil
+	}
+
+	profile, ok := endpoint.config.RedundancyProfiles.Find(name)
+	if !ok {
+		endpoint.log.Warn("bucket uses unknown redundancy profile, using default",
+			zap.Stringer("Project ID", bucket.ProjectID),
+			zap.String("bucket", bucket.BucketName),
+			zap.String("profile", name))
+		return endpoint.defaultRS, nil
+	}
+	return profile.RS.Proto(), nil
+}
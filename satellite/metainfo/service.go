@@ -5,6 +5,7 @@ package metainfo
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/zeebo/errs"
@@ -28,14 +29,21 @@ var (
 //
 // architecture: Service
 type Service struct {
-	logger    *zap.Logger
-	db        PointerDB
-	bucketsDB BucketsDB
+	logger     *zap.Logger
+	db         PointerDB
+	metabaseDB MetabaseDB
+	bucketsDB  BucketsDB
+
+	// lastFailedWrite is the time of the last write, which couldn't be
+	// duplicated into the metabase and wasn't recorded there yet.
+	mu              sync.Mutex
+	lastFailedWrite time.Time
 }
 
-// NewService creates new metainfo service.
-func NewService(logger *zap.Logger, db PointerDB, bucketsDB BucketsDB) *Service {
-	return &Service{logger: logger, db: db, bucketsDB: bucketsDB}
+// NewService creates new metainfo service. When metabaseDB is not nil, all
+// writes to the pointer database are duplicated into it.
+func NewService(logger *zap.Logger, db PointerDB, metabaseDB MetabaseDB, bucketsDB BucketsDB) *Service {
+	return &Service{logger: logger, db: db, metabaseDB: metabaseDB, bucketsDB: bucketsDB}
 }
 
 // Put puts pointer to db under specific path.
@@ -56,7 +64,14 @@ func (s *Service) Put(ctx context.Context, key metabase.SegmentKey, pointer *pb.
 
 	// CompareAndSwap is used instead of Put to avoid overwriting existing pointers
 	err = s.db.CompareAndSwap(ctx, storage.Key(key), nil, pointerBytes)
-	return Error.Wrap(err)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if s.metabaseDB != nil {
+		s.dualWrite(ctx, key, putMetabase(ctx, s.metabaseDB, key, pointer, false))
+	}
+	return nil
 }
 
 // UnsynchronizedPut puts pointer to db under specific path without verifying for existing pointer under the same path.
@@ -76,7 +91,14 @@ func (s *Service) UnsynchronizedPut(ctx context.Context, key metabase.SegmentKey
 	}
 
 	err = s.db.Put(ctx, storage.Key(key), pointerBytes)
-	return Error.Wrap(err)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if s.metabaseDB != nil {
+		s.dualWrite(ctx, key, putMetabase(ctx, s.metabaseDB, key, pointer, false))
+	}
+	return nil
 }
 
 // UpdatePieces calls UpdatePiecesCheckDuplicates with checkDuplicates equal to false.
@@ -202,10 +224,47 @@ func (s *Service) UpdatePiecesCheckDuplicates(ctx context.Context, key metabase.
 			}
 			return nil, Error.Wrap(err)
 		}
+
+		if s.metabaseDB != nil {
+			s.dualWrite(ctx, key, s.updatePiecesMetabase(ctx, key, pieces))
+		}
 		return pointer, nil
 	}
 }
 
+// CompareAndSwap replaces the pointer, when the stored pointer still matches
+// oldPointerBytes. It's used to replace all remote pieces of a segment at once.
+func (s *Service) CompareAndSwap(ctx context.Context, key metabase.SegmentKey, oldPointerBytes []byte, pointer *pb.Pointer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := sanityCheckPointer(key, pointer); err != nil {
+		return Error.Wrap(err)
+	}
+
+	// clear hashes so we don't store them
+	for _, piece := range pointer.GetRemote().GetRemotePieces() {
+		piece.Hash = nil
+	}
+
+	newPointerBytes, err := pb.Marshal(pointer)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	err = s.db.CompareAndSwap(ctx, storage.Key(key), oldPointerBytes, newPointerBytes)
+	if storage.ErrKeyNotFound.Has(err) {
+		err = storj.ErrObjectNotFound.Wrap(err)
+	}
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	if s.metabaseDB != nil {
+		s.dualWrite(ctx, key, s.updateRemoteMetabase(ctx, key, pointer))
+	}
+	return nil
+}
+
 // Get gets decoded pointer from DB.
 func (s *Service) Get(ctx context.Context, key metabase.SegmentKey) (_ *pb.Pointer, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -355,7 +414,12 @@ func (s *Service) Delete(ctx context.Context, key metabase.SegmentKey, oldPointe
 	if storage.ErrKeyNotFound.Has(err) {
 		err = storj.ErrObjectNotFound.Wrap(err)
 	}
-	return Error.Wrap(err)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	s.deleteMetabase(ctx, key)
+	return nil
 }
 
 // UnsynchronizedGetDel deletes items from db without verifying whether the pointers have changed in the database,
@@ -385,6 +449,10 @@ func (s *Service) UnsynchronizedGetDel(ctx context.Context, keys []metabase.Segm
 		pointers = append(pointers, data)
 	}
 
+	for _, key := range pointerPaths {
+		s.deleteMetabase(ctx, key)
+	}
+
 	return pointerPaths, pointers, nil
 }
 
@@ -396,7 +464,12 @@ func (s *Service) UnsynchronizedDelete(ctx context.Context, key metabase.Segment
 	if storage.ErrKeyNotFound.Has(err) {
 		err = storj.ErrObjectNotFound.Wrap(err)
 	}
-	return Error.Wrap(err)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	s.deleteMetabase(ctx, key)
+	return nil
 }
 
 // CreateBucket creates a new bucket in the buckets db.
@@ -457,3 +530,9 @@ func (s *Service) CountBuckets(ctx context.Context, projectID uuid.UUID) (count
 	defer mon.Task()(&ctx)(&err)
 	return s.bucketsDB.CountBuckets(ctx, projectID)
 }
+
+// GetBucketRedundancyProfile returns the redundancy profile of a bucket, empty when using the default.
+func (s *Service) GetBucketRedundancyProfile(ctx context.Context, bucket metabase.BucketLocation) (_ string, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return s.bucketsDB.GetBucketRedundancyProfile(ctx, bucket)
+}
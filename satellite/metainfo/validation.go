@@ -57,6 +57,8 @@ func (endpoint *Endpoint) validateAuth(ctx context.Context, header *pb.RequestHe
 		return nil, rpcstatus.Error(rpcstatus.PermissionDenied, "Unauthorized API credentials")
 	}
 
+	endpoint.apiKeyUsage.Record(ctx, keyInfo.ID, action.Time)
+
 	return keyInfo, nil
 }
 
@@ -299,8 +299,16 @@ func (service *Service) CreateDeleteOrderLimits(ctx context.Context, bucket meta
 func (service *Service) CreateAuditOrderLimits(ctx context.Context, bucket metabase.BucketLocation, pointer *pb.Pointer, skip map[storj.NodeID]bool) (_ []*pb.AddressedOrderLimit, _ storj.PiecePrivateKey, cachedIPsAndPorts map[storj.NodeID]string, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	shareSize := pointer.GetRemote().GetRedundancy().GetErasureShareSize()
+	return service.CreateAuditRangeOrderLimits(ctx, bucket, pointer, skip, int64(shareSize))
+}
+
+// CreateAuditRangeOrderLimits creates the order limits for auditing ranges of
+// the pieces of pointer, which are up to size bytes long.
+func (service *Service) CreateAuditRangeOrderLimits(ctx context.Context, bucket metabase.BucketLocation, pointer *pb.Pointer, skip map[storj.NodeID]bool, size int64) (_ []*pb.AddressedOrderLimit, _ storj.PiecePrivateKey, cachedIPsAndPorts map[storj.NodeID]string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
 	redundancy := pointer.GetRemote().GetRedundancy()
-	shareSize := redundancy.GetErasureShareSize()
 	totalPieces := redundancy.GetTotal()
 
 	nodeIDs := make([]storj.NodeID, len(pointer.GetRemote().GetRemotePieces()))
@@ -314,7 +322,7 @@ func (service *Service) CreateAuditOrderLimits(ctx context.Context, bucket metab
 		return nil, storj.PiecePrivateKey{}, nil, Error.Wrap(err)
 	}
 
-	signer, err := NewSignerAudit(service, pointer.GetRemote().RootPieceId, time.Now(), int64(shareSize), bucket)
+	signer, err := NewSignerAudit(service, pointer.GetRemote().RootPieceId, time.Now(), size, bucket)
 	if err != nil {
 		return nil, storj.PiecePrivateKey{}, nil, Error.Wrap(err)
 	}
@@ -549,6 +557,45 @@ func (service *Service) CreatePutRepairOrderLimits(ctx context.Context, bucket m
 	return limits, signer.PrivateKey, nil
 }
 
+// CreatePutReencodeOrderLimits creates the order limits for uploading the pieces
+// of a segment re-encoded with a new redundancy scheme under a new root piece id.
+func (service *Service) CreatePutReencodeOrderLimits(ctx context.Context, bucket metabase.BucketLocation, newNodes []*overlay.SelectedNode, totalPieces int, pieceExpiration time.Time, pieceSize int64) (_ storj.PieceID, _ []*pb.AddressedOrderLimit, _ storj.PiecePrivateKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(newNodes) > totalPieces {
+		return storj.PieceID{}, nil, storj.PiecePrivateKey{}, Error.New("more nodes than total pieces: %d > %d", len(newNodes), totalPieces)
+	}
+
+	signer, err := NewSignerRepairPut(service, storj.NewPieceID(), pieceExpiration, time.Now(), pieceSize, bucket)
+	if err != nil {
+		return storj.PieceID{}, nil, storj.PiecePrivateKey{}, Error.Wrap(err)
+	}
+
+	limits := make([]*pb.AddressedOrderLimit, totalPieces)
+	for pieceNum, node := range newNodes {
+		address := node.Address.Address
+		if node.LastIPPort != "" {
+			address = node.LastIPPort
+		}
+		limit, err := signer.Sign(ctx, storj.NodeURL{ID: node.ID, Address: address}, int32(pieceNum))
+		if err != nil {
+			return storj.PieceID{}, nil, storj.PiecePrivateKey{}, Error.Wrap(err)
+		}
+		limit.StorageNodeAddress.Transport = node.Address.Transport
+		limits[pieceNum] = limit
+	}
+
+	err = service.saveSerial(ctx, signer.Serial, bucket, signer.OrderExpiration)
+	if err != nil {
+		return storj.PieceID{}, nil, storj.PiecePrivateKey{}, Error.Wrap(err)
+	}
+	if err := service.updateBandwidth(ctx, bucket, limits...); err != nil {
+		return storj.PieceID{}, nil, storj.PiecePrivateKey{}, Error.Wrap(err)
+	}
+
+	return signer.RootPieceID, limits, signer.PrivateKey, nil
+}
+
 // CreateGracefulExitPutOrderLimit creates an order limit for graceful exit put transfers.
 func (service *Service) CreateGracefulExitPutOrderLimit(ctx context.Context, bucket metabase.BucketLocation, nodeID storj.NodeID, pieceNum int32, rootPieceID storj.PieceID, shareSize int32) (limit *pb.AddressedOrderLimit, _ storj.PiecePrivateKey, err error) {
 	defer mon.Task()(&ctx)(&err)
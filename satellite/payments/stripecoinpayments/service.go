@@ -20,6 +20,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/common/memory"
+	"storj.io/common/uuid"
 	"storj.io/storj/satellite/accounting"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/payments"
@@ -51,6 +52,12 @@ type Config struct {
 	ListingLimit                 int           `help:"sets the maximum amount of items before we start paging on requests" default:"100" hidden:"true"`
 }
 
+// StoragePriceMultipliers returns the storage price multipliers of project buckets.
+type StoragePriceMultipliers interface {
+	// StoragePriceMultipliers returns the multipliers by bucket name, buckets without a multiplier are not included.
+	StoragePriceMultipliers(ctx context.Context, projectID uuid.UUID) (map[string]float64, error)
+}
+
 // Service is an implementation for payment service via Stripe and Coinpayments.
 //
 // architecture: Service
@@ -59,6 +66,7 @@ type Service struct {
 	db           DB
 	projectsDB   console.Projects
 	usageDB      accounting.ProjectAccounting
+	multipliers  StoragePriceMultipliers
 	stripeClient StripeClient
 	coinPayments *coinpayments.Client
 
@@ -87,7 +95,7 @@ type Service struct {
 }
 
 // NewService creates a Service instance.
-func NewService(log *zap.Logger, stripeClient StripeClient, config Config, db DB, projectsDB console.Projects, usageDB accounting.ProjectAccounting, storageTBPrice, egressTBPrice, objectPrice string, bonusRate, couponValue, couponDuration int64, couponProjectLimit memory.Size, minCoinPayment int64, paywallProportion float64) (*Service, error) {
+func NewService(log *zap.Logger, stripeClient StripeClient, config Config, db DB, projectsDB console.Projects, usageDB accounting.ProjectAccounting, multipliers StoragePriceMultipliers, storageTBPrice, egressTBPrice, objectPrice string, bonusRate, couponValue, couponDuration int64, couponProjectLimit memory.Size, minCoinPayment int64, paywallProportion float64) (*Service, error) {
 
 	coinPaymentsClient := coinpayments.NewClient(
 		coinpayments.Credentials{
@@ -119,6 +127,7 @@ func NewService(log *zap.Logger, stripeClient StripeClient, config Config, db DB
 		db:                       db,
 		projectsDB:               projectsDB,
 		usageDB:                  usageDB,
+		multipliers:              multipliers,
 		stripeClient:             stripeClient,
 		coinPayments:             coinPaymentsClient,
 		StorageMBMonthPriceCents: storageMBMonthPriceCents,
@@ -559,6 +568,11 @@ func (service *Service) createProjectRecords(ctx context.Context, customerID str
 			return 0, nil, err
 		}
 
+		usage.Storage, err = service.multipliedStorage(ctx, project.ID, usage.Storage, start, end)
+		if err != nil {
+			return 0, nil, err
+		}
+
 		// TODO: account for usage data.
 		records = append(records,
 			CreateProjectRecord{
@@ -588,6 +602,39 @@ func (service *Service) createProjectRecords(ctx context.Context, customerID str
 	return sumLeftToCharge, records, nil
 }
 
+// multipliedStorage applies the storage price multipliers of the project buckets to the storage usage.
+func (service *Service) multipliedStorage(ctx context.Context, projectID uuid.UUID, storage float64, start, end time.Time) (_ float64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.multipliers == nil {
+		return storage, nil
+	}
+
+	multipliers, err := service.multipliers.StoragePriceMultipliers(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+	if len(multipliers) == 0 {
+		return storage, nil
+	}
+
+	rollups, err := service.usageDB.GetBucketUsageRollups(ctx, projectID, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, rollup := range rollups {
+		multiplier, ok := multipliers[string(rollup.BucketName)]
+		if !ok {
+			continue
+		}
+		// rollups are in GB-hours, while the project storage is in byte-hours.
+		storedData := (rollup.RemoteStoredData + rollup.InlineStoredData) * memory.GB.Float64()
+		storage += (multiplier - 1) * storedData
+	}
+	return storage, nil
+}
+
 // InvoiceApplyProjectRecords iterates through unapplied invoice project records and creates invoice line items
 // for stripe customer.
 func (service *Service) InvoiceApplyProjectRecords(ctx context.Context, period time.Time) (err error) {
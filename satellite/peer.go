@@ -24,6 +24,7 @@ import (
 	"storj.io/storj/satellite/audit"
 	"storj.io/storj/satellite/compensation"
 	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/console/auditlogcleanup"
 	"storj.io/storj/satellite/console/consoleweb"
 	"storj.io/storj/satellite/contact"
 	"storj.io/storj/satellite/dbcleanup"
@@ -40,9 +41,11 @@ import (
 	"storj.io/storj/satellite/payments/paymentsconfig"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
 	"storj.io/storj/satellite/referrals"
+	"storj.io/storj/satellite/repair"
 	"storj.io/storj/satellite/repair/checker"
 	"storj.io/storj/satellite/repair/irreparable"
 	"storj.io/storj/satellite/repair/queue"
+	"storj.io/storj/satellite/repair/reencode"
 	"storj.io/storj/satellite/repair/repairer"
 	"storj.io/storj/satellite/revocation"
 	"storj.io/storj/satellite/rewards"
@@ -81,6 +84,10 @@ type DB interface {
 	ProjectAccounting() accounting.ProjectAccounting
 	// RepairQueue returns queue for segments that need repairing
 	RepairQueue() queue.RepairQueue
+	// ReencodeQueue returns queue for segments that need re-encoding
+	ReencodeQueue() reencode.Queue
+	// RepairPriorities returns database for repair priority classes of projects and buckets
+	RepairPriorities() repair.PriorityClasses
 	// Irreparable returns database for failed repairs
 	Irreparable() irreparable.DB
 	// Console returns database for satellite console
@@ -91,6 +98,8 @@ type DB interface {
 	Orders() orders.DB
 	// Containment returns database for containment
 	Containment() audit.Containment
+	// NodeAudits returns database for tracking when nodes were audited
+	NodeAudits() audit.NodeAuditsDB
 	// Buckets returns the database to interact with buckets
 	Buckets() metainfo.BucketsDB
 	// GracefulExit returns database for graceful exit
@@ -123,6 +132,7 @@ type Config struct {
 
 	Checker  checker.Config
 	Repairer repairer.Config
+	Reencode reencode.Config
 	Audit    audit.Config
 
 	GarbageCollection gc.Config
@@ -143,7 +153,10 @@ type Config struct {
 
 	Referrals referrals.Config
 
-	Console consoleweb.Config
+	Console     consoleweb.Config
+	APIKeyUsage console.APIKeyUsageConfig
+
+	AuditLogCleanup auditlogcleanup.Config
 
 	Marketing marketingweb.Config
 
@@ -38,23 +38,28 @@ type Checker struct {
 	logger          *zap.Logger
 	repairQueue     queue.RepairQueue
 	irrdb           irreparable.DB
+	priorities      repair.PriorityClasses
 	metainfo        *metainfo.Service
 	metaLoop        *metainfo.Loop
 	nodestate       *ReliabilityCache
 	statsCollector  *statsCollector
 	repairOverrides RepairOverridesMap
 	nodeFailureRate float64
+	// healthHistory contains the number of healthy pieces of the segments,
+	// which needed repair in the previous checker iteration.
+	healthHistory   map[string]int
 	Loop            *sync2.Cycle
 	IrreparableLoop *sync2.Cycle
 }
 
 // NewChecker creates a new instance of checker.
-func NewChecker(logger *zap.Logger, repairQueue queue.RepairQueue, irrdb irreparable.DB, metainfo *metainfo.Service, metaLoop *metainfo.Loop, overlay *overlay.Service, config Config) *Checker {
+func NewChecker(logger *zap.Logger, repairQueue queue.RepairQueue, irrdb irreparable.DB, priorities repair.PriorityClasses, metainfo *metainfo.Service, metaLoop *metainfo.Loop, overlay *overlay.Service, config Config) *Checker {
 	return &Checker{
 		logger: logger,
 
 		repairQueue:     repairQueue,
 		irrdb:           irrdb,
+		priorities:      priorities,
 		metainfo:        metainfo,
 		metaLoop:        metaLoop,
 		nodestate:       NewReliabilityCache(overlay, config.ReliabilityCacheStaleness),
@@ -123,6 +128,11 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 
 	startTime := time.Now()
 
+	classes, err := loadPriorityClasses(ctx, checker.priorities)
+	if err != nil {
+		return err
+	}
+
 	observer := &checkerObserver{
 		repairQueue:      checker.repairQueue,
 		irrdb:            checker.irrdb,
@@ -132,6 +142,9 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 		repairOverrides:  checker.repairOverrides,
 		nodeFailureRate:  checker.nodeFailureRate,
 		getNodesEstimate: checker.getNodesEstimate,
+		priorityClasses:  classes,
+		previousHealthy:  checker.healthHistory,
+		healthy:          make(map[string]int),
 		log:              checker.logger,
 	}
 	err = checker.metaLoop.Join(ctx, observer)
@@ -141,6 +154,7 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 		}
 		return err
 	}
+	checker.healthHistory = observer.healthy
 
 	// remove all segments which were not seen as unhealthy by this checker iteration
 	healthyDeleted, err := checker.repairQueue.Clean(ctx, startTime)
@@ -182,7 +196,7 @@ func containsObjectLocation(a []metabase.ObjectLocation, x metabase.ObjectLocati
 	return false
 }
 
-func (checker *Checker) updateIrreparableSegmentStatus(ctx context.Context, pointer *pb.Pointer, key metabase.SegmentKey) (err error) {
+func (checker *Checker) updateIrreparableSegmentStatus(ctx context.Context, pointer *pb.Pointer, key metabase.SegmentKey, classes priorityClasses) (err error) {
 	// TODO figure out how to reduce duplicate code between here and checkerObs.RemoteSegment
 	defer mon.Task()(&ctx)(&err)
 	remote := pointer.GetRemote()
@@ -223,11 +237,18 @@ func (checker *Checker) updateIrreparableSegmentStatus(ctx context.Context, poin
 	// keep it in the irreparabledb queue either.
 	if numHealthy >= redundancy.MinReq && numHealthy <= repairThreshold && numHealthy < redundancy.SuccessThreshold {
 		segmentHealth := repair.SegmentHealth(int(numHealthy), int(redundancy.MinReq), totalNumNodes, checker.nodeFailureRate)
+
+		class := repair.PriorityNormal
+		if location, err := metabase.ParseSegmentKey(key); err == nil {
+			class = classes.get(location.Bucket())
+		}
+		score := repair.RepairScore(int(numHealthy), 0, int(redundancy.MinReq), totalNumNodes, checker.nodeFailureRate, class)
+
 		_, err = checker.repairQueue.Insert(ctx, &internalpb.InjuredSegment{
 			Path:         key,
 			LostPieces:   missingPieces,
 			InsertedTime: time.Now().UTC(),
-		}, segmentHealth)
+		}, segmentHealth, score)
 		if err != nil {
 			return errs.Combine(Error.New("error adding injured segment to queue"), err)
 		}
@@ -276,7 +297,12 @@ type checkerObserver struct {
 	repairOverrides  RepairOverridesMap
 	nodeFailureRate  float64
 	getNodesEstimate func(ctx context.Context) (int, error)
-	log              *zap.Logger
+	priorityClasses  priorityClasses
+	// previousHealthy and healthy contain the number of healthy pieces of the
+	// segments needing repair in the previous and in this iteration.
+	previousHealthy map[string]int
+	healthy         map[string]int
+	log             *zap.Logger
 }
 
 func (obs *checkerObserver) getStatsByRS(redundancy storj.RedundancyScheme) *stats {
@@ -362,11 +388,24 @@ func (obs *checkerObserver) RemoteSegment(ctx context.Context, segment *metainfo
 		stats.injuredSegmentHealth.Observe(segmentHealth)
 		obs.monStats.remoteSegmentsNeedingRepair++
 		stats.iterationAggregates.remoteSegmentsNeedingRepair++
+
+		// track how fast the segment is losing pieces between checker iterations.
+		piecesLost := 0
+		if previous, ok := obs.previousHealthy[string(key)]; ok && previous > numHealthy {
+			piecesLost = previous - numHealthy
+		}
+		obs.healthy[string(key)] = numHealthy
+		mon.IntVal("checker_injured_segment_pieces_lost").Observe(int64(piecesLost))
+
+		class := obs.priorityClasses.get(segment.Location.Bucket())
+		score := repair.RepairScore(numHealthy, piecesLost, required, totalNumNodes, obs.nodeFailureRate, class)
+		mon.FloatVal("checker_injured_segment_repair_score").Observe(score)
+
 		alreadyInserted, err := obs.repairQueue.Insert(ctx, &internalpb.InjuredSegment{
 			Path:         key,
 			LostPieces:   missingPieces,
 			InsertedTime: time.Now().UTC(),
-		}, float64(numHealthy))
+		}, float64(numHealthy), score)
 		if err != nil {
 			obs.log.Error("error adding injured segment to queue", zap.Error(err))
 			return nil
@@ -471,6 +510,11 @@ func (checker *Checker) IrreparableProcess(ctx context.Context) (err error) {
 	const limit = 1000
 	lastSeenSegmentKey := metabase.SegmentKey{}
 
+	classes, err := loadPriorityClasses(ctx, checker.priorities)
+	if err != nil {
+		return err
+	}
+
 	for {
 		segments, err := checker.irrdb.GetLimited(ctx, limit, lastSeenSegmentKey)
 		if err != nil {
@@ -485,7 +529,7 @@ func (checker *Checker) IrreparableProcess(ctx context.Context) (err error) {
 		lastSeenSegmentKey = metabase.SegmentKey(segments[len(segments)-1].Path)
 
 		for _, segment := range segments {
-			err = checker.updateIrreparableSegmentStatus(ctx, segment.GetSegmentDetail(), metabase.SegmentKey(segment.GetPath()))
+			err = checker.updateIrreparableSegmentStatus(ctx, segment.GetSegmentDetail(), metabase.SegmentKey(segment.GetPath()), classes)
 			if err != nil {
 				checker.logger.Error("irrepair segment checker failed: ", zap.Error(err))
 			}
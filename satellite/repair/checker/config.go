@@ -11,6 +11,7 @@ import (
 
 	"storj.io/common/pb"
 	"storj.io/common/storj"
+	"storj.io/storj/satellite/metainfo"
 )
 
 // Config contains configurable values for checker.
@@ -149,6 +150,29 @@ func (ros *RepairOverrides) GetMap() RepairOverridesMap {
 	return newMap
 }
 
+// WithProfiles returns the overrides extended with the repair thresholds of the
+// redundancy profiles, so that segments uploaded with a profile are repaired at
+// the profile's threshold. Configured overrides take precedence.
+func (ros *RepairOverrides) WithProfiles(profiles metainfo.RedundancyProfiles) RepairOverrides {
+	result := RepairOverrides{List: append([]RepairOverride{}, ros.List...)}
+	overrides := ros.GetMap()
+	for _, profile := range profiles {
+		if profile.RS.Repair >= profile.RS.Success {
+			continue
+		}
+		if _, ok := overrides.overrideMap[getRepairOverrideKey(profile.RS.Min, profile.RS.Success, profile.RS.Total)]; ok {
+			continue
+		}
+		result.List = append(result.List, RepairOverride{
+			Min:      profile.RS.Min,
+			Success:  profile.RS.Success,
+			Total:    profile.RS.Total,
+			Override: int32(profile.RS.Repair),
+		})
+	}
+	return result
+}
+
 // RepairOverridesMap is derived from the RepairOverrides config, and is used for quickly retrieving
 // repair override values.
 type RepairOverridesMap struct {
@@ -10,6 +10,7 @@ import (
 
 	"storj.io/common/pb"
 	"storj.io/common/storj"
+	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/repair/checker"
 )
 
@@ -157,3 +158,22 @@ func TestRepairOverride(t *testing.T) {
 	require.EqualValues(t, 0, ro.GetOverrideValue(storjSchemes[3]))
 	require.EqualValues(t, 0, ro.GetOverrideValuePB(pbSchemes[3]))
 }
+
+func TestRepairOverridesWithProfiles(t *testing.T) {
+	overrides := checker.RepairOverrides{}
+	require.NoError(t, overrides.Set("29/80/110-52"))
+
+	var profiles metainfo.RedundancyProfiles
+	require.NoError(t, profiles.Set("standard:29/40/80/110-256B:1,archive:29/60/100/160-256B:1.5,fast:4/8/8/10-256B:1"))
+
+	ro := overrides.WithProfiles(profiles)
+	// configured overrides are not modified.
+	require.Len(t, overrides.List, 1)
+
+	m := ro.GetMap()
+	// configured override takes precedence over the profile.
+	require.EqualValues(t, 52, m.GetOverrideValue(storj.RedundancyScheme{RequiredShares: 29, OptimalShares: 80, TotalShares: 110}))
+	require.EqualValues(t, 60, m.GetOverrideValue(storj.RedundancyScheme{RequiredShares: 29, OptimalShares: 100, TotalShares: 160}))
+	// repair threshold equal to the success threshold is not a valid override.
+	require.EqualValues(t, 0, m.GetOverrideValue(storj.RedundancyScheme{RequiredShares: 4, OptimalShares: 8, TotalShares: 10}))
+}
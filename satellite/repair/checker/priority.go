@@ -0,0 +1,58 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker
+
+import (
+	"context"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/repair"
+)
+
+// priorityClasses maps projects and buckets to their repair priority class.
+type priorityClasses struct {
+	projects map[uuid.UUID]repair.PriorityClass
+	buckets  map[metabase.BucketLocation]repair.PriorityClass
+}
+
+// loadPriorityClasses loads the priority classes of all projects and buckets.
+func loadPriorityClasses(ctx context.Context, db repair.PriorityClasses) (_ priorityClasses, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	classes := priorityClasses{
+		projects: make(map[uuid.UUID]repair.PriorityClass),
+		buckets:  make(map[metabase.BucketLocation]repair.PriorityClass),
+	}
+	if db == nil {
+		return classes, nil
+	}
+
+	list, err := db.List(ctx)
+	if err != nil {
+		return classes, Error.Wrap(err)
+	}
+	for _, priority := range list {
+		if priority.BucketName == "" {
+			classes.projects[priority.ProjectID] = priority.Class
+			continue
+		}
+		classes.buckets[metabase.BucketLocation{
+			ProjectID:  priority.ProjectID,
+			BucketName: priority.BucketName,
+		}] = priority.Class
+	}
+	return classes, nil
+}
+
+// get returns the priority class of the bucket, falling back to the class of its project.
+func (classes priorityClasses) get(bucket metabase.BucketLocation) repair.PriorityClass {
+	if class, ok := classes.buckets[bucket]; ok {
+		return class
+	}
+	if class, ok := classes.projects[bucket.ProjectID]; ok {
+		return class
+	}
+	return repair.PriorityNormal
+}
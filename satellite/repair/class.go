@@ -0,0 +1,110 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repair
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+)
+
+// ErrPriorityClass is returned when a repair priority class is invalid.
+var ErrPriorityClass = errs.Class("repair priority class")
+
+// PriorityClass determines how urgently the segments of a project or bucket
+// are repaired compared to segments with the same health.
+type PriorityClass int
+
+const (
+	// PriorityLow is for data which can wait until everything else is repaired.
+	PriorityLow = PriorityClass(-1)
+	// PriorityNormal is the class of projects and buckets without a priority.
+	PriorityNormal = PriorityClass(0)
+	// PriorityHigh is for data which should be repaired before normal data.
+	PriorityHigh = PriorityClass(1)
+	// PriorityCritical is for data which should be repaired before anything else.
+	PriorityCritical = PriorityClass(2)
+)
+
+// ParsePriorityClass parses the name of a priority class.
+func ParsePriorityClass(name string) (PriorityClass, error) {
+	switch name {
+	case "low":
+		return PriorityLow, nil
+	case "normal":
+		return PriorityNormal, nil
+	case "high":
+		return PriorityHigh, nil
+	case "critical":
+		return PriorityCritical, nil
+	default:
+		return PriorityNormal, ErrPriorityClass.New("unknown class %q", name)
+	}
+}
+
+// String returns the name of the priority class.
+func (class PriorityClass) String() string {
+	switch class {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// Weight returns by how much the repair score of a segment is divided,
+// so that segments of higher classes are repaired first.
+func (class PriorityClass) Weight() float64 {
+	switch {
+	case class <= PriorityLow:
+		return 0.5
+	case class == PriorityNormal:
+		return 1
+	case class == PriorityHigh:
+		return 4
+	default:
+		return 16
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (class PriorityClass) MarshalText() ([]byte, error) {
+	return []byte(class.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (class *PriorityClass) UnmarshalText(text []byte) (err error) {
+	*class, err = ParsePriorityClass(string(text))
+	return err
+}
+
+// BucketPriority is the repair priority class of a bucket, or of a whole
+// project when BucketName is empty.
+type BucketPriority struct {
+	ProjectID  uuid.UUID     `json:"projectId"`
+	BucketName string        `json:"bucketName"`
+	Class      PriorityClass `json:"class"`
+}
+
+// PriorityClasses stores the repair priority classes of projects and buckets.
+//
+// architecture: Database
+type PriorityClasses interface {
+	// Set sets the priority class of a bucket, or of the whole project when bucketName is empty.
+	Set(ctx context.Context, projectID uuid.UUID, bucketName string, class PriorityClass) error
+	// Delete removes the priority class of a bucket, or of the whole project when bucketName is empty.
+	Delete(ctx context.Context, projectID uuid.UUID, bucketName string) error
+	// List returns the priority classes of all projects and buckets.
+	List(ctx context.Context) ([]BucketPriority, error)
+	// ListProject returns the priority classes of a project and its buckets.
+	ListProject(ctx context.Context, projectID uuid.UUID) ([]BucketPriority, error)
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package repair_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/repair"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestPriorityClassesDB(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		priorities := db.RepairPriorities()
+
+		projectA, projectB := testrand.UUID(), testrand.UUID()
+
+		list, err := priorities.List(ctx)
+		require.NoError(t, err)
+		require.Empty(t, list)
+
+		require.NoError(t, priorities.Set(ctx, projectA, "", repair.PriorityHigh))
+		require.NoError(t, priorities.Set(ctx, projectA, "bucket", repair.PriorityLow))
+		require.NoError(t, priorities.Set(ctx, projectB, "bucket", repair.PriorityHigh))
+
+		// setting the class again replaces it.
+		require.NoError(t, priorities.Set(ctx, projectB, "bucket", repair.PriorityCritical))
+
+		list, err = priorities.ListProject(ctx, projectA)
+		require.NoError(t, err)
+		require.Equal(t, []repair.BucketPriority{
+			{ProjectID: projectA, BucketName: "", Class: repair.PriorityHigh},
+			{ProjectID: projectA, BucketName: "bucket", Class: repair.PriorityLow},
+		}, list)
+
+		list, err = priorities.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, list, 3)
+		require.Contains(t, list, repair.BucketPriority{ProjectID: projectB, BucketName: "bucket", Class: repair.PriorityCritical})
+
+		require.NoError(t, priorities.Delete(ctx, projectA, ""))
+		list, err = priorities.ListProject(ctx, projectA)
+		require.NoError(t, err)
+		require.Equal(t, []repair.BucketPriority{
+			{ProjectID: projectA, BucketName: "bucket", Class: repair.PriorityLow},
+		}, list)
+	})
+}
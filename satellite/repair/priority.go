@@ -53,3 +53,21 @@ func SegmentHealth(numHealthy, minPieces, totalNodes int, failureRate float64) f
 }
 
 const minChurnPerRound = 1e-10
+
+// RepairScore combines the health of a segment with how fast the segment has
+// been losing pieces and the priority class of its bucket. Segments with a
+// lower score should be repaired first.
+//
+// piecesLost is the number of healthy pieces the segment lost since the
+// previous checker iteration. The segment is assumed to keep losing pieces at
+// the same rate, so its health is projected one iteration ahead.
+func RepairScore(numHealthy, piecesLost, minPieces, totalNodes int, failureRate float64, class PriorityClass) float64 {
+	projected := numHealthy
+	if piecesLost > 0 {
+		projected -= piecesLost
+		if projected < minPieces {
+			projected = minPieces
+		}
+	}
+	return SegmentHealth(projected, minPieces, totalNodes, failureRate) / class.Weight()
+}
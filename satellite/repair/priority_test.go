@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSegmentHealth(t *testing.T) {
@@ -52,3 +53,43 @@ func TestHighHealthAndLowFailureRate(t *testing.T) {
 		SegmentHealth(11, 10, 10000, failureRate),
 		SegmentHealth(39, 34, 10000, failureRate))
 }
+
+func TestRepairScore(t *testing.T) {
+	const failureRate = 0.01
+
+	// without losses and priority the score is the segment health.
+	assert.Equal(t,
+		SegmentHealth(12, 10, 10000, failureRate),
+		RepairScore(12, 0, 10, 10000, failureRate, PriorityNormal))
+
+	// segments which are losing pieces are repaired first.
+	assert.Less(t,
+		RepairScore(12, 1, 10, 10000, failureRate, PriorityNormal),
+		RepairScore(12, 0, 10, 10000, failureRate, PriorityNormal))
+	assert.Equal(t,
+		RepairScore(10, 0, 10, 10000, failureRate, PriorityNormal),
+		RepairScore(12, 5, 10, 10000, failureRate, PriorityNormal))
+
+	// segments of more important buckets are repaired first.
+	assert.Less(t,
+		RepairScore(12, 0, 10, 10000, failureRate, PriorityHigh),
+		RepairScore(12, 0, 10, 10000, failureRate, PriorityNormal))
+	assert.Less(t,
+		RepairScore(13, 0, 10, 10000, failureRate, PriorityCritical),
+		RepairScore(12, 0, 10, 10000, failureRate, PriorityHigh))
+	assert.Greater(t,
+		RepairScore(12, 0, 10, 10000, failureRate, PriorityLow),
+		RepairScore(12, 0, 10, 10000, failureRate, PriorityNormal))
+}
+
+func TestParsePriorityClass(t *testing.T) {
+	for _, class := range []PriorityClass{PriorityLow, PriorityNormal, PriorityHigh, PriorityCritical} {
+		parsed, err := ParsePriorityClass(class.String())
+		require.NoError(t, err)
+		assert.Equal(t, class, parsed)
+	}
+
+	_, err := ParsePriorityClass("urgent")
+	require.Error(t, err)
+	require.True(t, ErrPriorityClass.Has(err))
+}
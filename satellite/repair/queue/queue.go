@@ -15,9 +15,10 @@ import (
 //
 // architecture: Database
 type RepairQueue interface {
-	// Insert adds an injured segment.
-	Insert(ctx context.Context, s *internalpb.InjuredSegment, segmentHealth float64) (alreadyInserted bool, err error)
-	// Select gets an injured segment.
+	// Insert adds an injured segment, or updates its health and priority when it's already queued.
+	// Segments with a lower priority score are selected first.
+	Insert(ctx context.Context, s *internalpb.InjuredSegment, segmentHealth, priority float64) (alreadyInserted bool, err error)
+	// Select gets the injured segment with the lowest priority score.
 	Select(ctx context.Context) (*internalpb.InjuredSegment, error)
 	// Delete removes an injured segment.
 	Delete(ctx context.Context, s *internalpb.InjuredSegment) error
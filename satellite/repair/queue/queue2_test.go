@@ -32,7 +32,7 @@ func TestUntilEmpty(t *testing.T) {
 		for i := 0; i < 20; i++ {
 			path := "/path/" + strconv.Itoa(i)
 			injuredSeg := &internalpb.InjuredSegment{Path: []byte(path)}
-			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10)
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10, 10)
 			require.NoError(t, err)
 			require.False(t, alreadyInserted)
 			pathsMap[path] = 0
@@ -65,7 +65,7 @@ func TestOrder(t *testing.T) {
 
 		for _, path := range [][]byte{oldRepairPath, recentRepairPath, nullPath, olderRepairPath} {
 			injuredSeg := &internalpb.InjuredSegment{Path: path}
-			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10)
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10, 10)
 			require.NoError(t, err)
 			require.False(t, alreadyInserted)
 		}
@@ -160,7 +160,7 @@ func testorderHealthyPieces(t *testing.T, connStr string) {
 	for _, item := range injuredSegList {
 		// first, insert the injured segment
 		injuredSeg := &internalpb.InjuredSegment{Path: item.path}
-		alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, item.segmentHealth)
+		alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, item.segmentHealth, item.segmentHealth)
 		require.NoError(t, err)
 		require.False(t, alreadyInserted)
 
@@ -221,7 +221,7 @@ func TestOrderOverwrite(t *testing.T) {
 		}
 		for i, item := range injuredSegList {
 			injuredSeg := &internalpb.InjuredSegment{Path: item.path}
-			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, item.segmentHealth)
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, item.segmentHealth, item.segmentHealth)
 			require.NoError(t, err)
 			if i == 2 {
 				require.True(t, alreadyInserted)
@@ -246,6 +246,38 @@ func TestOrderOverwrite(t *testing.T) {
 	})
 }
 
+// TestOrderPriority ensures that segments are selected by their priority score rather than by their health.
+func TestOrderPriority(t *testing.T) {
+	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
+		repairQueue := db.RepairQueue()
+
+		// "path/b" is healthier than "path/a", but belongs to a more important bucket.
+		injuredSegList := []struct {
+			path          []byte
+			segmentHealth float64
+			priority      float64
+		}{
+			{[]byte("path/a"), 8, 8},
+			{[]byte("path/b"), 9, 9.0 / 4},
+		}
+		for _, item := range injuredSegList {
+			injuredSeg := &internalpb.InjuredSegment{Path: item.path}
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, item.segmentHealth, item.priority)
+			require.NoError(t, err)
+			require.False(t, alreadyInserted)
+		}
+
+		for _, nextPath := range []string{
+			"path/b",
+			"path/a",
+		} {
+			injuredSeg, err := repairQueue.Select(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, nextPath, string(injuredSeg.Path))
+		}
+	})
+}
+
 func TestCount(t *testing.T) {
 	satellitedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db satellite.DB) {
 		repairQueue := db.RepairQueue()
@@ -256,7 +288,7 @@ func TestCount(t *testing.T) {
 		for i := 0; i < numSegments; i++ {
 			path := "/path/" + strconv.Itoa(i)
 			injuredSeg := &internalpb.InjuredSegment{Path: []byte(path)}
-			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10)
+			alreadyInserted, err := repairQueue.Insert(ctx, injuredSeg, 10, 10)
 			require.NoError(t, err)
 			require.False(t, alreadyInserted)
 			pathsMap[path] = 0
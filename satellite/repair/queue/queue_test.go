@@ -28,7 +28,7 @@ func TestInsertSelect(t *testing.T) {
 			Path:       []byte("abc"),
 			LostPieces: []int32{int32(1), int32(3)},
 		}
-		alreadyInserted, err := q.Insert(ctx, seg, 10)
+		alreadyInserted, err := q.Insert(ctx, seg, 10, 10)
 		require.NoError(t, err)
 		require.False(t, alreadyInserted)
 		s, err := q.Select(ctx)
@@ -47,10 +47,10 @@ func TestInsertDuplicate(t *testing.T) {
 			Path:       []byte("abc"),
 			LostPieces: []int32{int32(1), int32(3)},
 		}
-		alreadyInserted, err := q.Insert(ctx, seg, 10)
+		alreadyInserted, err := q.Insert(ctx, seg, 10, 10)
 		require.NoError(t, err)
 		require.False(t, alreadyInserted)
-		alreadyInserted, err = q.Insert(ctx, seg, 10)
+		alreadyInserted, err = q.Insert(ctx, seg, 10, 10)
 		require.NoError(t, err)
 		require.True(t, alreadyInserted)
 	})
@@ -77,7 +77,7 @@ func TestSequential(t *testing.T) {
 				Path:       []byte(strconv.Itoa(i)),
 				LostPieces: []int32{int32(i)},
 			}
-			alreadyInserted, err := q.Insert(ctx, seg, 10)
+			alreadyInserted, err := q.Insert(ctx, seg, 10, 10)
 			require.NoError(t, err)
 			require.False(t, alreadyInserted)
 			addSegs = append(addSegs, seg)
@@ -119,7 +119,7 @@ func TestParallel(t *testing.T) {
 				_, err := q.Insert(ctx, &internalpb.InjuredSegment{
 					Path:       []byte(strconv.Itoa(i)),
 					LostPieces: []int32{int32(i)},
-				}, 10)
+				}, 10, 10)
 				return err
 			})
 		}
@@ -183,13 +183,13 @@ func TestClean(t *testing.T) {
 		timeBeforeInsert1 := time.Now()
 
 		segmentHealth := 1.3
-		_, err := q.Insert(ctx, seg1, segmentHealth)
+		_, err := q.Insert(ctx, seg1, segmentHealth, segmentHealth)
 		require.NoError(t, err)
 
-		_, err = q.Insert(ctx, seg2, segmentHealth)
+		_, err = q.Insert(ctx, seg2, segmentHealth, segmentHealth)
 		require.NoError(t, err)
 
-		_, err = q.Insert(ctx, seg3, segmentHealth)
+		_, err = q.Insert(ctx, seg3, segmentHealth, segmentHealth)
 		require.NoError(t, err)
 
 		count, err := q.Count(ctx)
@@ -208,11 +208,11 @@ func TestClean(t *testing.T) {
 
 		// seg1 "becomes healthy", so do not update it
 		// seg2 stays at the same health
-		_, err = q.Insert(ctx, seg2, segmentHealth)
+		_, err = q.Insert(ctx, seg2, segmentHealth, segmentHealth)
 		require.NoError(t, err)
 
 		// seg3 has a lower health
-		_, err = q.Insert(ctx, seg3, segmentHealth-0.1)
+		_, err = q.Insert(ctx, seg3, segmentHealth-0.1, segmentHealth-0.1)
 		require.NoError(t, err)
 
 		count, err = q.Count(ctx)
@@ -0,0 +1,142 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reencode
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// Chore periodically queues segments, whose redundancy scheme doesn't match
+// the target scheme of their bucket, for re-encoding.
+//
+// architecture: Chore
+type Chore struct {
+	log     *zap.Logger
+	queue   Queue
+	targets *Targets
+	Loop    *sync2.Cycle
+
+	metainfoLoop *metainfo.Loop
+	config       Config
+}
+
+// NewChore instantiates Chore.
+func NewChore(log *zap.Logger, queue Queue, targets *Targets, metaLoop *metainfo.Loop, config Config) *Chore {
+	return &Chore{
+		log:     log,
+		queue:   queue,
+		targets: targets,
+		Loop:    sync2.NewCycle(config.Interval),
+
+		metainfoLoop: metaLoop,
+		config:       config,
+	}
+}
+
+// Run starts the chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return chore.Loop.Run(ctx, func(ctx context.Context) (err error) {
+		defer mon.Task()(&ctx)(&err)
+
+		observer := newObserver(chore.log, chore.queue, chore.targets, chore.config.MinSegmentAge)
+		err = chore.metainfoLoop.Join(ctx, observer)
+		if err != nil {
+			chore.log.Error("error joining metainfoloop", zap.Error(err))
+			return nil
+		}
+
+		mon.IntVal("reencode_segments_queued").Observe(observer.queued)
+		mon.IntVal("reencode_segments_newly_queued").Observe(observer.newQueued)
+
+		chore.log.Debug("queued segments for re-encoding",
+			zap.Int64("queued", observer.queued),
+			zap.Int64("newly queued", observer.newQueued))
+		return nil
+	})
+}
+
+// Close closes chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
+
+var _ metainfo.Observer = (*observer)(nil)
+
+// observer queues remote segments, which need re-encoding.
+type observer struct {
+	log     *zap.Logger
+	queue   Queue
+	targets *Targets
+	minAge  time.Duration
+	now     time.Time
+
+	bucketTargets map[metabase.BucketLocation]storj.RedundancyScheme
+
+	queued    int64
+	newQueued int64
+}
+
+func newObserver(log *zap.Logger, queue Queue, targets *Targets, minAge time.Duration) *observer {
+	return &observer{
+		log:           log,
+		queue:         queue,
+		targets:       targets,
+		minAge:        minAge,
+		now:           time.Now(),
+		bucketTargets: make(map[metabase.BucketLocation]storj.RedundancyScheme),
+	}
+}
+
+// RemoteSegment queues the segment, when its redundancy scheme doesn't match the target scheme.
+func (obs *observer) RemoteSegment(ctx context.Context, segment *metainfo.Segment) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if segment.Expired(obs.now) {
+		return nil
+	}
+	if segment.CreationDate.After(obs.now.Add(-obs.minAge)) {
+		return nil
+	}
+
+	bucket := segment.Location.Bucket()
+	target, ok := obs.bucketTargets[bucket]
+	if !ok {
+		target, err = obs.targets.Get(ctx, bucket)
+		if err != nil {
+			return err
+		}
+		obs.bucketTargets[bucket] = target
+	}
+
+	if !NeedsReencode(segment.Redundancy, target) {
+		return nil
+	}
+
+	alreadyInserted, err := obs.queue.Insert(ctx, segment.Location.Encode())
+	if err != nil {
+		obs.log.Error("error adding segment to re-encode queue", zap.Error(err))
+		return nil
+	}
+	obs.queued++
+	if !alreadyInserted {
+		obs.newQueued++
+	}
+	return nil
+}
+
+// Object implements the metainfo.Observer interface.
+func (obs *observer) Object(context.Context, *metainfo.Object) error { return nil }
+
+// InlineSegment implements the metainfo.Observer interface.
+func (obs *observer) InlineSegment(context.Context, *metainfo.Segment) error { return nil }
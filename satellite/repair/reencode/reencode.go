@@ -0,0 +1,103 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package reencode implements migrating segments to a new redundancy scheme.
+//
+// The chore joins the metainfo loop and queues segments, whose redundancy
+// scheme doesn't match the target scheme of their bucket. The service
+// downloads the queued segments, re-encodes them with the target scheme,
+// uploads the new pieces, swaps the pointer and deletes the old pieces.
+package reencode
+
+import (
+	"context"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+var (
+	// Error is the default error class for re-encoding.
+	Error = errs.Class("reencode error")
+
+	mon = monkit.Package()
+)
+
+// Config contains configurable values for re-encoding.
+type Config struct {
+	Enabled       bool          `help:"whether segments are re-encoded when their redundancy scheme doesn't match the target scheme of their bucket" default:"false"`
+	Interval      time.Duration `help:"how frequently the metainfo loop is joined to find segments to re-encode" releaseDefault:"24h" devDefault:"1m"`
+	MinSegmentAge time.Duration `help:"minimum age of segments to re-encode, so that only cold data is re-encoded" releaseDefault:"720h" devDefault:"0h"`
+	QueueInterval time.Duration `help:"how frequently the re-encode queue is processed" releaseDefault:"5m" devDefault:"1m"`
+	MaxConcurrent int           `help:"maximum segments that can be re-encoded concurrently" releaseDefault:"2" devDefault:"1"`
+	Timeout       time.Duration `help:"time limit for uploading re-encoded pieces to storage nodes" default:"5m0s"`
+	TotalTimeout  time.Duration `help:"time limit for re-encoding a segment, from queue pop to pointer swap" default:"45m"`
+}
+
+// Queue implements queueing for segments that need re-encoding.
+// Implementation can be found at satellite/satellitedb/reencodequeue.go.
+//
+// architecture: Database
+type Queue interface {
+	// Insert adds a segment to the queue.
+	Insert(ctx context.Context, key metabase.SegmentKey) (alreadyInserted bool, err error)
+	// Select gets a segment, which wasn't attempted recently, and marks it as attempted.
+	// It returns storage.ErrEmptyQueue when there's no such segment.
+	Select(ctx context.Context) (metabase.SegmentKey, error)
+	// Delete removes a segment from the queue.
+	Delete(ctx context.Context, key metabase.SegmentKey) error
+	// Count counts the segments in the queue.
+	Count(ctx context.Context) (count int, err error)
+}
+
+// Targets resolves the redundancy scheme, which segments of a bucket should be stored with.
+type Targets struct {
+	buckets   metainfo.BucketsDB
+	defaultRS storj.RedundancyScheme
+	profiles  metainfo.RedundancyProfiles
+}
+
+// NewTargets creates a new Targets using the redundancy configuration of metainfo.
+func NewTargets(buckets metainfo.BucketsDB, config metainfo.Config) *Targets {
+	return &Targets{
+		buckets:   buckets,
+		defaultRS: config.RS.RedundancyScheme(),
+		profiles:  config.RedundancyProfiles,
+	}
+}
+
+// Get returns the target redundancy scheme of the bucket, which is the scheme
+// of the bucket's redundancy profile or the default scheme.
+func (targets *Targets) Get(ctx context.Context, bucket metabase.BucketLocation) (_ storj.RedundancyScheme, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(targets.profiles) == 0 {
+		return targets.defaultRS, nil
+	}
+
+	name, err := targets.buckets.GetBucketRedundancyProfile(ctx, bucket)
+	if err != nil {
+		return storj.RedundancyScheme{}, Error.Wrap(err)
+	}
+
+	profile, ok := targets.profiles.Find(name)
+	if !ok {
+		return targets.defaultRS, nil
+	}
+	return profile.RS.RedundancyScheme(), nil
+}
+
+// NeedsReencode returns whether a segment stored with the redundancy scheme has to
+// be re-encoded to match the target scheme. The repair threshold doesn't affect
+// the stored pieces, so it's not compared.
+func NeedsReencode(current, target storj.RedundancyScheme) bool {
+	return current.RequiredShares != target.RequiredShares ||
+		current.OptimalShares != target.OptimalShares ||
+		current.TotalShares != target.TotalShares ||
+		current.ShareSize != target.ShareSize
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reencode_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/repair/reencode"
+)
+
+func TestNeedsReencode(t *testing.T) {
+	scheme := storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      256,
+		RequiredShares: 2,
+		RepairShares:   4,
+		OptimalShares:  6,
+		TotalShares:    8,
+	}
+	require.False(t, reencode.NeedsReencode(scheme, scheme))
+
+	// the repair threshold is only used by the checker
+	repair := scheme
+	repair.RepairShares = 5
+	require.False(t, reencode.NeedsReencode(scheme, repair))
+
+	for _, modify := range []func(*storj.RedundancyScheme){
+		func(rs *storj.RedundancyScheme) { rs.ShareSize = 512 },
+		func(rs *storj.RedundancyScheme) { rs.RequiredShares = 3 },
+		func(rs *storj.RedundancyScheme) { rs.OptimalShares = 7 },
+		func(rs *storj.RedundancyScheme) { rs.TotalShares = 10 },
+	} {
+		target := scheme
+		modify(&target)
+		require.True(t, reencode.NeedsReencode(scheme, target))
+	}
+}
+
+func TestReencode(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 10, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				require.NoError(t, config.Metainfo.RedundancyProfiles.Set("archive:3/4/5/6-256B:1"))
+				config.Reencode.Enabled = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		uplinkPeer := planet.Uplinks[0]
+
+		satellite.Audit.Worker.Loop.Pause()
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+		satellite.Repair.ReencodeChore.Loop.Pause()
+		satellite.Repair.Reencoder.Loop.Pause()
+
+		testData := testrand.Bytes(8 * memory.KiB)
+		require.NoError(t, uplinkPeer.Upload(ctx, satellite, "testbucket", "test/path", testData))
+
+		key, pointer := getRemoteSegment(ctx, t, satellite)
+		require.EqualValues(t, 2, pointer.Remote.Redundancy.MinReq)
+
+		// segments with the target scheme are not queued
+		satellite.Repair.ReencodeChore.Loop.TriggerWait()
+		count, err := satellite.DB.ReencodeQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		bucket := metabase.BucketLocation{ProjectID: uplinkPeer.Projects[0].ID, BucketName: "testbucket"}
+		require.NoError(t, satellite.DB.Buckets().SetBucketRedundancyProfile(ctx, bucket, "archive"))
+
+		satellite.Repair.ReencodeChore.Loop.TriggerWait()
+		count, err = satellite.DB.ReencodeQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		satellite.Repair.Reencoder.Loop.TriggerWait()
+		satellite.Repair.Reencoder.WaitForPendingJobs()
+
+		count, err = satellite.DB.ReencodeQueue().Count(ctx)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		reencoded, err := satellite.Metainfo.Service.Get(ctx, key)
+		require.NoError(t, err)
+		require.NotEqual(t, pointer.Remote.RootPieceId, reencoded.Remote.RootPieceId)
+		require.EqualValues(t, 3, reencoded.Remote.Redundancy.MinReq)
+		require.EqualValues(t, 6, reencoded.Remote.Redundancy.Total)
+		require.GreaterOrEqual(t, len(reencoded.Remote.RemotePieces), 5)
+
+		downloaded, err := uplinkPeer.Download(ctx, satellite, "testbucket", "test/path")
+		require.NoError(t, err)
+		require.Equal(t, testData, downloaded)
+	})
+}
+
+func getRemoteSegment(ctx *testcontext.Context, t *testing.T, satellite *testplanet.Satellite) (metabase.SegmentKey, *pb.Pointer) {
+	t.Helper()
+
+	listResponse, _, err := satellite.Metainfo.Service.List(ctx, metabase.SegmentKey{}, "", true, 0, 0)
+	require.NoError(t, err)
+
+	for _, item := range listResponse {
+		key := metabase.SegmentKey(item.GetPath())
+		pointer, err := satellite.Metainfo.Service.Get(ctx, key)
+		require.NoError(t, err)
+		if pointer.GetType() == pb.Pointer_REMOTE {
+			return key, pointer
+		}
+	}
+
+	t.Fatal("satellite doesn't have any remote segment")
+	return nil, nil
+}
@@ -0,0 +1,257 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reencode
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/satellite/metainfo/piecedeletion"
+	"storj.io/storj/satellite/orders"
+	"storj.io/storj/satellite/overlay"
+	"storj.io/storj/satellite/repair/repairer"
+	"storj.io/storj/storage"
+	"storj.io/uplink/private/eestream"
+)
+
+// deletePiecesSuccessThreshold is the ratio of nodes, which have to respond
+// before deleting the replaced pieces is considered done. Pieces, which
+// couldn't be deleted, are collected by garbage collection.
+const deletePiecesSuccessThreshold = 0.75
+
+// SegmentReencoder re-encodes segments with their target redundancy scheme.
+type SegmentReencoder struct {
+	log          *zap.Logger
+	metainfo     *metainfo.Service
+	orders       *orders.Service
+	overlay      *overlay.Service
+	ec           *repairer.ECRepairer
+	deletePieces *piecedeletion.Service
+	targets      *Targets
+	timeout      time.Duration
+}
+
+// NewSegmentReencoder creates a new instance of SegmentReencoder.
+//
+// timeout is the time limit for uploading the re-encoded pieces.
+func NewSegmentReencoder(
+	log *zap.Logger, metainfo *metainfo.Service, orders *orders.Service,
+	overlay *overlay.Service, ec *repairer.ECRepairer, deletePieces *piecedeletion.Service,
+	targets *Targets, timeout time.Duration,
+) *SegmentReencoder {
+	return &SegmentReencoder{
+		log:          log,
+		metainfo:     metainfo,
+		orders:       orders,
+		overlay:      overlay,
+		ec:           ec,
+		deletePieces: deletePieces,
+		targets:      targets,
+		timeout:      timeout,
+	}
+}
+
+// Reencode downloads the segment, re-encodes it with the target redundancy
+// scheme of its bucket, uploads the new pieces, swaps the pointer and deletes
+// the old pieces.
+//
+// shouldDelete reports whether the segment should be removed from the queue,
+// it's used even in the case where err is not nil.
+func (reencoder *SegmentReencoder) Reencode(ctx context.Context, key metabase.SegmentKey) (shouldDelete bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pointerBytes, pointer, err := reencoder.metainfo.GetWithBytes(ctx, key)
+	if err != nil {
+		if storj.ErrObjectNotFound.Has(err) {
+			mon.Meter("reencode_segment_deleted").Mark(1)
+			return true, nil
+		}
+		return false, Error.Wrap(err)
+	}
+
+	if pointer.GetType() != pb.Pointer_REMOTE {
+		return true, Error.New("cannot re-encode inline segment")
+	}
+	if !pointer.ExpirationDate.IsZero() && pointer.ExpirationDate.Before(time.Now().UTC()) {
+		mon.Meter("reencode_expired").Mark(1)
+		return true, nil
+	}
+
+	location, err := metabase.ParseSegmentKey(key)
+	if err != nil {
+		return true, Error.New("could not parse segment key: %w", err)
+	}
+	bucket := location.Bucket()
+
+	target, err := reencoder.targets.Get(ctx, bucket)
+	if err != nil {
+		return false, err
+	}
+	if !NeedsReencode(redundancyFromProto(pointer.Remote.Redundancy), target) {
+		mon.Meter("reencode_unnecessary").Mark(1)
+		return true, nil
+	}
+
+	oldRedundancy, err := eestream.NewRedundancyStrategyFromProto(pointer.Remote.Redundancy)
+	if err != nil {
+		return true, Error.New("invalid redundancy strategy: %w", err)
+	}
+	newRedundancy, err := eestream.NewRedundancyStrategyFromStorj(target)
+	if err != nil {
+		return true, Error.New("invalid target redundancy strategy: %w", err)
+	}
+
+	mon.Meter("reencode_attempts").Mark(1)
+	mon.IntVal("reencode_segment_size").Observe(pointer.GetSegmentSize())
+
+	// Download the segment from the healthy pieces
+	pieces := pointer.Remote.RemotePieces
+	missingPieces, err := reencoder.overlay.GetMissingPieces(ctx, pieces)
+	if err != nil {
+		return false, Error.New("error identifying missing pieces: %w", err)
+	}
+	missing := make(map[int32]bool, len(missingPieces))
+	for _, pieceNum := range missingPieces {
+		missing[pieceNum] = true
+	}
+	var healthyPieces []*pb.RemotePiece
+	for _, piece := range pieces {
+		if !missing[piece.PieceNum] {
+			healthyPieces = append(healthyPieces, piece)
+		}
+	}
+	if len(healthyPieces) < oldRedundancy.RequiredCount() {
+		// the segment is left to the repairer, re-encoding is retried later.
+		return false, Error.New("not enough healthy pieces: %d < %d", len(healthyPieces), oldRedundancy.RequiredCount())
+	}
+
+	getLimits, getPrivateKey, err := reencoder.orders.CreateGetRepairOrderLimits(ctx, bucket, pointer, healthyPieces)
+	if err != nil {
+		return false, Error.New("could not create GET_REPAIR order limits: %w", err)
+	}
+
+	segmentReader, _, err := reencoder.ec.Get(ctx, getLimits, getPrivateKey, oldRedundancy, pointer.GetSegmentSize(), string(key))
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return false, ctxErr
+		}
+		return false, Error.New("segment could not be downloaded: %w", err)
+	}
+	defer func() { err = errs.Combine(err, segmentReader.Close()) }()
+
+	// Upload the pieces encoded with the target scheme
+	newNodes, err := reencoder.overlay.FindStorageNodesForUpload(ctx, overlay.FindStorageNodesRequest{
+		RequestedCount: newRedundancy.TotalCount(),
+	})
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	pieceSize := eestream.CalcPieceSize(pointer.GetSegmentSize(), newRedundancy)
+	rootPieceID, putLimits, putPrivateKey, err := reencoder.orders.CreatePutReencodeOrderLimits(ctx, bucket, newNodes, newRedundancy.TotalCount(), pointer.ExpirationDate, pieceSize)
+	if err != nil {
+		return false, Error.New("could not create PUT_REPAIR order limits: %w", err)
+	}
+
+	successfulNodes, _, err := reencoder.ec.Repair(ctx, putLimits, putPrivateKey, newRedundancy, segmentReader, reencoder.timeout, string(key), newRedundancy.OptimalThreshold())
+	if err != nil {
+		return false, Error.New("could not store re-encoded pieces: %w", err)
+	}
+
+	var newPieces []*pb.RemotePiece
+	for i, node := range successfulNodes {
+		if node == nil {
+			continue
+		}
+		newPieces = append(newPieces, &pb.RemotePiece{
+			PieceNum: int32(i),
+			NodeId:   node.Id,
+		})
+	}
+
+	if len(newPieces) < newRedundancy.OptimalThreshold() {
+		mon.Meter("reencode_failed").Mark(1)
+		reencoder.deleteRemotePieces(ctx, rootPieceID, newPieces)
+		return false, Error.New("stored %d pieces, which is less than the optimal threshold %d", len(newPieces), newRedundancy.OptimalThreshold())
+	}
+
+	// Swap the pointer, unless it has been modified in the meantime
+	newPointer := &pb.Pointer{}
+	if err := pb.Unmarshal(pointerBytes, newPointer); err != nil {
+		return false, Error.Wrap(err)
+	}
+	newPointer.Remote.RootPieceId = rootPieceID
+	newPointer.Remote.Redundancy = redundancyToProto(target)
+	newPointer.Remote.RemotePieces = newPieces
+	newPointer.LastRepaired = time.Now().UTC()
+
+	err = reencoder.metainfo.CompareAndSwap(ctx, key, pointerBytes, newPointer)
+	if err != nil {
+		reencoder.deleteRemotePieces(ctx, rootPieceID, newPieces)
+		if storage.ErrValueChanged.Has(err) || storj.ErrObjectNotFound.Has(err) {
+			// the segment was repaired or deleted while re-encoding, it's
+			// checked again when it's selected next time.
+			mon.Meter("reencode_segment_modified").Mark(1)
+			return false, nil
+		}
+		return false, Error.Wrap(err)
+	}
+
+	reencoder.deleteRemotePieces(ctx, pointer.Remote.RootPieceId, pieces)
+
+	mon.Meter("reencode_success").Mark(1)
+	return true, nil
+}
+
+// deleteRemotePieces queues the pieces for deletion on the storage nodes.
+func (reencoder *SegmentReencoder) deleteRemotePieces(ctx context.Context, rootPieceID storj.PieceID, pieces []*pb.RemotePiece) {
+	nodePieces := make(map[storj.NodeID][]storj.PieceID)
+	for _, piece := range pieces {
+		nodePieces[piece.NodeId] = append(nodePieces[piece.NodeId], rootPieceID.Derive(piece.NodeId, piece.PieceNum))
+	}
+
+	requests := make([]piecedeletion.Request, 0, len(nodePieces))
+	for nodeID, pieceIDs := range nodePieces {
+		requests = append(requests, piecedeletion.Request{
+			Node:   storj.NodeURL{ID: nodeID},
+			Pieces: pieceIDs,
+		})
+	}
+
+	if err := reencoder.deletePieces.Delete(ctx, requests, deletePiecesSuccessThreshold); err != nil {
+		reencoder.log.Warn("unable to delete pieces, they will be collected by garbage collection",
+			zap.Stringer("Root Piece ID", rootPieceID), zap.Error(err))
+	}
+}
+
+// redundancyFromProto converts the redundancy scheme of a pointer.
+func redundancyFromProto(scheme *pb.RedundancyScheme) storj.RedundancyScheme {
+	return storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      scheme.GetErasureShareSize(),
+		RequiredShares: int16(scheme.GetMinReq()),
+		RepairShares:   int16(scheme.GetRepairThreshold()),
+		OptimalShares:  int16(scheme.GetSuccessThreshold()),
+		TotalShares:    int16(scheme.GetTotal()),
+	}
+}
+
+// redundancyToProto converts the redundancy scheme for storing in a pointer.
+func redundancyToProto(scheme storj.RedundancyScheme) *pb.RedundancyScheme {
+	return &pb.RedundancyScheme{
+		Type:             pb.RedundancyScheme_RS,
+		MinReq:           int32(scheme.RequiredShares),
+		RepairThreshold:  int32(scheme.RepairShares),
+		SuccessThreshold: int32(scheme.OptimalShares),
+		Total:            int32(scheme.TotalShares),
+		ErasureShareSize: scheme.ShareSize,
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package reencode
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/sync/semaphore"
+
+	"storj.io/common/sync2"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/storage"
+)
+
+// Service re-encodes the segments from the re-encode queue.
+//
+// architecture: Worker
+type Service struct {
+	log        *zap.Logger
+	queue      Queue
+	config     Config
+	JobLimiter *semaphore.Weighted
+	Loop       *sync2.Cycle
+	reencoder  *SegmentReencoder
+}
+
+// NewService creates a new re-encode service.
+func NewService(log *zap.Logger, queue Queue, config Config, reencoder *SegmentReencoder) *Service {
+	return &Service{
+		log:        log,
+		queue:      queue,
+		config:     config,
+		JobLimiter: semaphore.NewWeighted(int64(config.MaxConcurrent)),
+		Loop:       sync2.NewCycle(config.QueueInterval),
+		reencoder:  reencoder,
+	}
+}
+
+// Close closes resources.
+func (service *Service) Close() error { return nil }
+
+// WaitForPendingJobs waits for all ongoing re-encode jobs to complete.
+func (service *Service) WaitForPendingJobs() {
+	// No error return is possible here; context.Background() can't be canceled
+	_ = service.JobLimiter.Acquire(context.Background(), int64(service.config.MaxConcurrent))
+	service.JobLimiter.Release(int64(service.config.MaxConcurrent))
+}
+
+// Run runs the re-encode service.
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	defer service.WaitForPendingJobs()
+
+	return service.Loop.Run(ctx, service.processWhileQueueHasItems)
+}
+
+// processWhileQueueHasItems keeps calling process() until the queue is empty or something
+// else goes wrong in fetching from the queue.
+func (service *Service) processWhileQueueHasItems(ctx context.Context) error {
+	for {
+		err := service.process(ctx)
+		if err != nil {
+			if storage.ErrEmptyQueue.Has(err) {
+				return nil
+			}
+			service.log.Error("process", zap.Error(Error.Wrap(err)))
+			return err
+		}
+	}
+}
+
+// process picks a segment from the re-encode queue and spawns a worker.
+func (service *Service) process(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := service.JobLimiter.Acquire(ctx, 1); err != nil {
+		return err
+	}
+
+	// the timeout includes the queue fetch, so that jobs are given up within
+	// a set interval after the attempted time in the queue, see repairer.Service.
+	ctx, cancel := context.WithTimeout(ctx, service.config.TotalTimeout)
+
+	key, err := service.queue.Select(ctx)
+	if err != nil {
+		service.JobLimiter.Release(1)
+		cancel()
+		return err
+	}
+
+	go func() {
+		defer service.JobLimiter.Release(1)
+		defer cancel()
+
+		if err := service.worker(ctx, key); err != nil {
+			service.log.Error("re-encode worker failed:", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+func (service *Service) worker(ctx context.Context, key metabase.SegmentKey) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	start := time.Now()
+
+	// note that shouldDelete is used even in the case where err is not null
+	shouldDelete, err := service.reencoder.Reencode(ctx, key)
+	if shouldDelete {
+		if delErr := service.queue.Delete(ctx, key); delErr != nil {
+			err = errs.Combine(err, Error.New("failed to remove segment from queue: %v", delErr))
+		}
+	}
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	mon.FloatVal("time_for_reencode").Observe(time.Since(start).Seconds())
+	return nil
+}
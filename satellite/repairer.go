@@ -25,10 +25,12 @@ import (
 	"storj.io/storj/private/lifecycle"
 	version_checker "storj.io/storj/private/version/checker"
 	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/metainfo/piecedeletion"
 	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/repair/irreparable"
 	"storj.io/storj/satellite/repair/queue"
+	"storj.io/storj/satellite/repair/reencode"
 	"storj.io/storj/satellite/repair/repairer"
 )
 
@@ -63,12 +65,17 @@ type Repairer struct {
 	}
 	SegmentRepairer *repairer.SegmentRepairer
 	Repairer        *repairer.Service
+
+	Reencode struct {
+		PieceDeletion *piecedeletion.Service
+		Service       *reencode.Service
+	}
 }
 
 // NewRepairer creates a new repairer peer.
 func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
-	pointerDB metainfo.PointerDB,
-	revocationDB extensions.RevocationDB, repairQueue queue.RepairQueue,
+	pointerDB metainfo.PointerDB, metabaseDB metainfo.MetabaseDB,
+	revocationDB extensions.RevocationDB, repairQueue queue.RepairQueue, reencodeQueue reencode.Queue,
 	bucketsDB metainfo.BucketsDB, overlayCache overlay.DB,
 	rollupsWriteCache *orders.RollupsWriteCache, irrDB irreparable.DB,
 	versionInfo version.Info, config *Config, atomicLogLevel *zap.AtomicLevel) (*Repairer, error) {
@@ -128,7 +135,7 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 	}
 
 	{ // setup metainfo
-		peer.Metainfo = metainfo.NewService(log.Named("metainfo"), pointerDB, bucketsDB)
+		peer.Metainfo = metainfo.NewService(log.Named("metainfo"), pointerDB, metabaseDB, bucketsDB)
 	}
 
 	{ // setup overlay
@@ -177,7 +184,7 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 			peer.Dialer,
 			config.Repairer.Timeout,
 			config.Repairer.MaxExcessRateOptimalThreshold,
-			config.Checker.RepairOverrides,
+			config.Checker.RepairOverrides.WithProfiles(config.Metainfo.RedundancyProfiles),
 			config.Repairer.DownloadTimeout,
 			config.Repairer.InMemoryRepair,
 			signing.SigneeFromPeerIdentity(peer.Identity.PeerIdentity()),
@@ -193,6 +200,50 @@ func NewRepairer(log *zap.Logger, full *identity.FullIdentity,
 			debug.Cycle("Repair Worker", peer.Repairer.Loop))
 	}
 
+	if config.Reencode.Enabled { // setup re-encode worker
+		var err error
+		peer.Reencode.PieceDeletion, err = piecedeletion.NewService(
+			log.Named("reencode:piecedeletion"),
+			peer.Dialer,
+			peer.Overlay,
+			config.Metainfo.PieceDeletion,
+		)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		peer.Services.Add(lifecycle.Item{
+			Name:  "reencode:piecedeletion",
+			Run:   peer.Reencode.PieceDeletion.Run,
+			Close: peer.Reencode.PieceDeletion.Close,
+		})
+
+		reencoder := reencode.NewSegmentReencoder(
+			log.Named("segment-reencode"),
+			peer.Metainfo,
+			peer.Orders.Service,
+			peer.Overlay,
+			repairer.NewECRepairer(
+				log.Named("reencode:ec"),
+				peer.Dialer,
+				signing.SigneeFromPeerIdentity(peer.Identity.PeerIdentity()),
+				config.Repairer.DownloadTimeout,
+				config.Repairer.InMemoryRepair,
+			),
+			peer.Reencode.PieceDeletion,
+			reencode.NewTargets(bucketsDB, config.Metainfo),
+			config.Reencode.Timeout,
+		)
+		peer.Reencode.Service = reencode.NewService(log.Named("reencode"), reencodeQueue, config.Reencode, reencoder)
+
+		peer.Services.Add(lifecycle.Item{
+			Name:  "reencode",
+			Run:   peer.Reencode.Service.Run,
+			Close: peer.Reencode.Service.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Re-encode Worker", peer.Reencode.Service.Loop))
+	}
+
 	return peer, nil
 }
 
@@ -5,12 +5,16 @@ package satellitedb
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 
 	"storj.io/common/uuid"
 	"storj.io/storj/pkg/cache"
+	"storj.io/storj/private/dbutil/pgutil"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/satellitedb/dbx"
 )
@@ -70,8 +74,10 @@ func (keys *apikeys) GetPagedByProjectID(ctx context.Context, projectID uuid.UUI
 	}
 
 	repoundQuery := keys.db.Rebind(`
-		SELECT ak.id, ak.project_id, ak.name, ak.partner_id, ak.created_at
+		SELECT ak.id, ak.project_id, ak.name, ak.partner_id, ak.caveat, ak.created_at,
+			u.last_used_at, COALESCE(u.request_count, 0)
 		FROM api_keys ak
+		LEFT JOIN api_key_usages u ON u.api_key_id = ak.id
 		WHERE ak.project_id = ?
 		AND lower(ak.name) LIKE ?
 		ORDER BY ` + sanitizedAPIKeyOrderColumnName(cursor.Order) + `
@@ -94,13 +100,19 @@ func (keys *apikeys) GetPagedByProjectID(ctx context.Context, projectID uuid.UUI
 	for rows.Next() {
 		ak := console.APIKeyInfo{}
 		var partnerID uuid.NullUUID
+		var caveat []byte
 
-		err = rows.Scan(&ak.ID, &ak.ProjectID, &ak.Name, &partnerID, &ak.CreatedAt)
+		err = rows.Scan(&ak.ID, &ak.ProjectID, &ak.Name, &partnerID, &caveat, &ak.CreatedAt,
+			&ak.LastUsedAt, &ak.RequestCount)
 		if err != nil {
 			return nil, err
 		}
 
 		ak.PartnerID = partnerID.UUID
+		ak.Restrictions, err = console.UnmarshalAPIKeyRestrictions(caveat)
+		if err != nil {
+			return nil, err
+		}
 		apiKeys = append(apiKeys, ak)
 	}
 
@@ -130,7 +142,21 @@ func (keys *apikeys) Get(ctx context.Context, id uuid.UUID) (_ *console.APIKeyIn
 		return nil, err
 	}
 
-	return fromDBXAPIKey(ctx, dbKey)
+	key, err := fromDBXAPIKey(ctx, dbKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = keys.db.QueryRowContext(ctx, `
+		SELECT last_used_at, request_count
+		FROM api_key_usages
+		WHERE api_key_id = $1
+	`, id[:]).Scan(&key.LastUsedAt, &key.RequestCount)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	return key, nil
 }
 
 // GetByHead implements satellite.APIKeys.
@@ -175,6 +201,13 @@ func (keys *apikeys) Create(ctx context.Context, head []byte, info console.APIKe
 	if !info.PartnerID.IsZero() {
 		optional.PartnerId = dbx.ApiKey_PartnerId(info.PartnerID[:])
 	}
+	if info.Restrictions != nil {
+		caveat, err := console.MarshalAPIKeyRestrictions(info.Restrictions)
+		if err != nil {
+			return nil, err
+		}
+		optional.Caveat = dbx.ApiKey_Caveat(caveat)
+	}
 
 	dbKey, err := keys.methods.Create_ApiKey(
 		ctx,
@@ -212,6 +245,115 @@ func (keys *apikeys) Delete(ctx context.Context, id uuid.UUID) (err error) {
 	return err
 }
 
+// UpdateUsage implements satellite.APIKeys.
+func (keys *apikeys) UpdateUsage(ctx context.Context, usage map[uuid.UUID]console.APIKeyUsage) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(usage) == 0 {
+		return nil
+	}
+
+	ids := make([][]byte, 0, len(usage))
+	lastUsed := make([]time.Time, 0, len(usage))
+	requests := make([]int64, 0, len(usage))
+	for id, keyUsage := range usage {
+		id := id
+		ids = append(ids, id[:])
+		lastUsed = append(lastUsed, keyUsage.LastUsedAt.UTC())
+		requests = append(requests, keyUsage.Requests)
+	}
+
+	// keys deleted since they were used are skipped.
+	_, err = keys.db.ExecContext(ctx, `
+		INSERT INTO api_key_usages (api_key_id, last_used_at, request_count)
+		SELECT u.api_key_id, u.last_used_at, u.request_count
+		FROM unnest($1::bytea[], $2::timestamptz[], $3::int8[]) AS u(api_key_id, last_used_at, request_count)
+		WHERE EXISTS (SELECT 1 FROM api_keys WHERE api_keys.id = u.api_key_id)
+		ON CONFLICT ( api_key_id ) DO UPDATE SET
+			last_used_at = GREATEST(api_key_usages.last_used_at, EXCLUDED.last_used_at),
+			request_count = api_key_usages.request_count + EXCLUDED.request_count
+	`, pgutil.ByteaArray(ids), pgutil.TimestampTZArray(lastUsed), pgutil.Int8Array(requests))
+	return Error.Wrap(err)
+}
+
+// apiKeyUsageTrackingStart is the accounting timestamp of the start of the api key usage tracking.
+const apiKeyUsageTrackingStart = "APIKeyUsageTrackingStart"
+
+// StartUsageTracking implements satellite.APIKeys.
+func (keys *apikeys) StartUsageTracking(ctx context.Context, now time.Time) (start time.Time, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = keys.db.ExecContext(ctx, `
+		INSERT INTO accounting_timestamps (name, value) VALUES ($1, $2)
+		ON CONFLICT ( name ) DO NOTHING
+	`, apiKeyUsageTrackingStart, now.UTC())
+	if err != nil {
+		return time.Time{}, Error.Wrap(err)
+	}
+
+	err = keys.db.QueryRowContext(ctx, `
+		SELECT value FROM accounting_timestamps WHERE name = $1
+	`, apiKeyUsageTrackingStart).Scan(&start)
+	return start, Error.Wrap(err)
+}
+
+// GetUnused implements satellite.APIKeys.
+func (keys *apikeys) GetUnused(ctx context.Context, before time.Time, limit int) (_ []console.APIKeyInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// keys without usage were only unused since before, if their usage was tracked since then.
+	var start time.Time
+	err = keys.db.QueryRowContext(ctx, `
+		SELECT value FROM accounting_timestamps WHERE name = $1
+	`, apiKeyUsageTrackingStart).Scan(&start)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, console.ErrUsageNotTracked.New("usage tracking hasn't started yet")
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if before.Before(start) {
+		return nil, console.ErrUsageNotTracked.New("usage is only tracked since %s", start.UTC().Format(time.RFC3339))
+	}
+
+	rows, err := keys.db.QueryContext(ctx, `
+		SELECT ak.id, ak.project_id, ak.name, ak.partner_id, ak.caveat, ak.created_at,
+			u.last_used_at, COALESCE(u.request_count, 0)
+		FROM api_keys ak
+		LEFT JOIN api_key_usages u ON u.api_key_id = ak.id
+		WHERE ak.created_at < $1
+			AND (u.last_used_at IS NULL OR u.last_used_at < $1)
+		ORDER BY ak.created_at
+		LIMIT $2
+	`, before.UTC(), limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var unused []console.APIKeyInfo
+	for rows.Next() {
+		ak := console.APIKeyInfo{}
+		var partnerID uuid.NullUUID
+		var caveat []byte
+
+		err = rows.Scan(&ak.ID, &ak.ProjectID, &ak.Name, &partnerID, &caveat, &ak.CreatedAt,
+			&ak.LastUsedAt, &ak.RequestCount)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		ak.PartnerID = partnerID.UUID
+		ak.Restrictions, err = console.UnmarshalAPIKeyRestrictions(caveat)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		unused = append(unused, ak)
+	}
+
+	return unused, Error.Wrap(rows.Err())
+}
+
 // fromDBXAPIKey converts dbx.ApiKey to satellite.APIKeyInfo.
 func fromDBXAPIKey(ctx context.Context, key *dbx.ApiKey) (_ *console.APIKeyInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -240,6 +382,11 @@ func fromDBXAPIKey(ctx context.Context, key *dbx.ApiKey) (_ *console.APIKeyInfo,
 		}
 	}
 
+	result.Restrictions, err = console.UnmarshalAPIKeyRestrictions(key.Caveat)
+	if err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -0,0 +1,159 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+)
+
+// ensures that auditLogs implements console.AuditLogs.
+var _ console.AuditLogs = (*auditLogs)(nil)
+
+// auditLogs is an implementation of console.AuditLogs.
+type auditLogs struct {
+	db *satelliteDB
+}
+
+// Insert implements console.AuditLogs.
+func (logs *auditLogs) Insert(ctx context.Context, entry console.AuditLogEntry) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if entry.ID.IsZero() {
+		entry.ID, err = uuid.New()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	_, err = logs.db.ExecContext(ctx, `
+		INSERT INTO audit_logs (
+			id, actor, operation, target_type, target_id, user_id, project_id,
+			source_ip, forwarded_for, outcome, error, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, entry.ID, entry.Actor, entry.Operation, string(entry.TargetType), nullString(entry.TargetID),
+		nullUUID(entry.UserID), nullUUID(entry.ProjectID),
+		entry.SourceIP, entry.ForwardedFor, string(entry.Outcome), nullString(entry.Error), entry.CreatedAt.UTC())
+	return Error.Wrap(err)
+}
+
+// List implements console.AuditLogs.
+func (logs *auditLogs) List(ctx context.Context, filter console.AuditLogFilter) (_ []console.AuditLogEntry, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if filter.UserID != nil {
+		addCondition("user_id = ?", *filter.UserID)
+	}
+	if filter.ProjectID != nil {
+		addCondition("project_id = ?", *filter.ProjectID)
+	}
+	if !filter.Since.IsZero() {
+		addCondition("created_at >= ?", filter.Since.UTC())
+	}
+	if !filter.Before.IsZero() {
+		addCondition("created_at < ?", filter.Before.UTC())
+	}
+
+	query := `
+		SELECT id, actor, operation, target_type, target_id, user_id, project_id,
+			source_ip, forwarded_for, outcome, error, created_at
+		FROM audit_logs
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += " LIMIT $" + strconv.Itoa(len(args))
+	}
+
+	rows, err := logs.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var entries []console.AuditLogEntry
+	for rows.Next() {
+		var entry console.AuditLogEntry
+		var targetType, outcome string
+		var targetID, entryErr sql.NullString
+		var userID, projectID uuid.NullUUID
+
+		err = rows.Scan(&entry.ID, &entry.Actor, &entry.Operation, &targetType, &targetID, &userID, &projectID,
+			&entry.SourceIP, &entry.ForwardedFor, &outcome, &entryErr, &entry.CreatedAt)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		entry.TargetType = console.AuditTarget(targetType)
+		entry.TargetID = targetID.String
+		if userID.Valid {
+			entry.UserID = &userID.UUID
+		}
+		if projectID.Valid {
+			entry.ProjectID = &projectID.UUID
+		}
+		entry.Outcome = console.AuditOutcome(outcome)
+		entry.Error = entryErr.String
+
+		entries = append(entries, entry)
+	}
+
+	return entries, Error.Wrap(rows.Err())
+}
+
+// DeleteBefore implements console.AuditLogs.
+func (logs *auditLogs) DeleteBefore(ctx context.Context, before time.Time, limit int) (deleted int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// postgres doesn't support DELETE with LIMIT, hence the subquery.
+	result, err := logs.db.ExecContext(ctx, `
+		DELETE FROM audit_logs
+		WHERE id IN (
+			SELECT id FROM audit_logs
+			WHERE created_at < $1
+			ORDER BY created_at
+			LIMIT $2
+		)
+	`, before.UTC(), limit)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	deleted, err = result.RowsAffected()
+	return deleted, Error.Wrap(err)
+}
+
+// nullString converts an empty string to NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullUUID converts a nil uuid to NULL.
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
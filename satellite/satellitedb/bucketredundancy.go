@@ -0,0 +1,78 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+// GetBucketRedundancyProfile returns the redundancy profile of a bucket, empty when using the default.
+func (db *bucketsDB) GetBucketRedundancyProfile(ctx context.Context, bucket metabase.BucketLocation) (profile string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = db.db.QueryRowContext(ctx, `
+		SELECT profile FROM bucket_redundancy_profiles
+		WHERE project_id = $1 AND bucket_name = $2
+	`, bucket.ProjectID, []byte(bucket.BucketName)).Scan(&profile)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", storj.ErrBucket.Wrap(err)
+	}
+	return profile, nil
+}
+
+// SetBucketRedundancyProfile sets the redundancy profile of a bucket, empty profile resets it to the default.
+func (db *bucketsDB) SetBucketRedundancyProfile(ctx context.Context, bucket metabase.BucketLocation, profile string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if profile == "" {
+		_, err = db.db.ExecContext(ctx, `
+			DELETE FROM bucket_redundancy_profiles WHERE project_id = $1 AND bucket_name = $2
+		`, bucket.ProjectID, []byte(bucket.BucketName))
+		return storj.ErrBucket.Wrap(err)
+	}
+
+	_, err = db.db.ExecContext(ctx, `
+		INSERT INTO bucket_redundancy_profiles ( project_id, bucket_name, profile, updated_at )
+		VALUES ( $1, $2, $3, now() )
+		ON CONFLICT ( project_id, bucket_name )
+		DO UPDATE SET profile = EXCLUDED.profile, updated_at = EXCLUDED.updated_at
+	`, bucket.ProjectID, []byte(bucket.BucketName), profile)
+	return storj.ErrBucket.Wrap(err)
+}
+
+// ListBucketRedundancyProfiles returns the redundancy profiles of the project buckets by bucket name.
+func (db *bucketsDB) ListBucketRedundancyProfiles(ctx context.Context, projectID uuid.UUID) (profiles map[string]string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := db.db.QueryContext(ctx, `
+		SELECT bucket_name, profile FROM bucket_redundancy_profiles
+		WHERE project_id = $1
+	`, projectID)
+	if err != nil {
+		return nil, storj.ErrBucket.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	profiles = make(map[string]string)
+	for rows.Next() {
+		var bucketName []byte
+		var profile string
+		if err := rows.Scan(&bucketName, &profile); err != nil {
+			return nil, storj.ErrBucket.Wrap(err)
+		}
+		profiles[string(bucketName)] = profile
+	}
+	return profiles, storj.ErrBucket.Wrap(rows.Err())
+}
@@ -126,7 +126,7 @@ func (db *bucketsDB) DeleteBucket(ctx context.Context, bucketName []byte, projec
 	if !deleted {
 		return storj.ErrBucketNotFound.New("%s", bucketName)
 	}
-	return nil
+	return db.SetBucketRedundancyProfile(ctx, metabase.BucketLocation{ProjectID: projectID, BucketName: string(bucketName)}, "")
 }
 
 // ListBuckets returns a list of buckets for a project.
@@ -73,6 +73,16 @@ func (db *ConsoleDB) UserCredits() console.UserCredits {
 	return &usercredits{db.db, db.tx}
 }
 
+// AuditLogs is a getter for AuditLogs repository.
+func (db *ConsoleDB) AuditLogs() console.AuditLogs {
+	return &auditLogs{db.db}
+}
+
+// SSOIdentities is a getter for SSOIdentities repository.
+func (db *ConsoleDB) SSOIdentities() console.SSOIdentities {
+	return &ssoIdentities{db.methods}
+}
+
 // WithTx is a method for executing and retrying transaction.
 func (db *ConsoleDB) WithTx(ctx context.Context, fn func(context.Context, console.DBTx) error) error {
 	if db.db == nil {
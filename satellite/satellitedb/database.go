@@ -27,8 +27,10 @@ import (
 	"storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/overlay"
 	"storj.io/storj/satellite/payments/stripecoinpayments"
+	"storj.io/storj/satellite/repair"
 	"storj.io/storj/satellite/repair/irreparable"
 	"storj.io/storj/satellite/repair/queue"
+	"storj.io/storj/satellite/repair/reencode"
 	"storj.io/storj/satellite/revocation"
 	"storj.io/storj/satellite/rewards"
 	"storj.io/storj/satellite/satellitedb/dbx"
@@ -197,6 +199,16 @@ func (dbc *satelliteDBCollection) RepairQueue() queue.RepairQueue {
 	return &repairQueue{db: dbc.getByName("repairqueue")}
 }
 
+// ReencodeQueue returns queue for segments that need re-encoding.
+func (dbc *satelliteDBCollection) ReencodeQueue() reencode.Queue {
+	return &reencodeQueue{db: dbc.getByName("reencodequeue")}
+}
+
+// RepairPriorities returns database for repair priority classes of projects and buckets.
+func (dbc *satelliteDBCollection) RepairPriorities() repair.PriorityClasses {
+	return &repairPriorities{db: dbc.getByName("repairpriorities")}
+}
+
 // StoragenodeAccounting returns database for tracking storagenode usage.
 func (dbc *satelliteDBCollection) StoragenodeAccounting() accounting.StoragenodeAccounting {
 	return &StoragenodeAccounting{db: dbc.getByName("storagenodeaccounting")}
@@ -258,6 +270,11 @@ func (dbc *satelliteDBCollection) Containment() audit.Containment {
 	return &containment{db: dbc.getByName("containment")}
 }
 
+// NodeAudits returns database for tracking when nodes were audited.
+func (dbc *satelliteDBCollection) NodeAudits() audit.NodeAuditsDB {
+	return &nodeAudits{db: dbc.getByName("nodeaudits")}
+}
+
 // GracefulExit returns database for graceful exit.
 func (dbc *satelliteDBCollection) GracefulExit() gracefulexit.DB {
 	return &gracefulexitDB{db: dbc.getByName("gracefulexit")}
@@ -341,6 +341,21 @@ CREATE TABLE audit_histories (
 	history bytea NOT NULL,
 	PRIMARY KEY ( node_id )
 );
+CREATE TABLE audit_logs (
+	id bytea NOT NULL,
+	actor text NOT NULL,
+	operation text NOT NULL,
+	target_type text NOT NULL,
+	target_id text,
+	user_id bytea,
+	project_id bytea,
+	source_ip text NOT NULL,
+	forwarded_for text NOT NULL,
+	outcome text NOT NULL,
+	error text,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE bucket_bandwidth_rollups (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -352,6 +367,13 @@ CREATE TABLE bucket_bandwidth_rollups (
 	settled bigint NOT NULL,
 	PRIMARY KEY ( bucket_name, project_id, interval_start, action )
 );
+CREATE TABLE bucket_redundancy_profiles (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	profile text NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name )
+);
 CREATE TABLE bucket_storage_tallies (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -429,6 +451,7 @@ CREATE TABLE injuredsegments (
 	attempted timestamp with time zone,
 	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	segment_health double precision NOT NULL DEFAULT 1,
+	priority double precision NOT NULL DEFAULT 1,
 	PRIMARY KEY ( path )
 );
 CREATE TABLE irreparabledbs (
@@ -492,6 +515,13 @@ CREATE TABLE node_api_versions (
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE node_audits (
+	node_id bytea NOT NULL,
+	first_audited timestamp with time zone NOT NULL,
+	last_audited timestamp with time zone NOT NULL,
+	audit_count bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE nodes_offline_times (
 	node_id bytea NOT NULL,
 	tracked_at timestamp with time zone NOT NULL,
@@ -558,6 +588,12 @@ CREATE TABLE project_bandwidth_rollups (
 	egress_allocated bigint NOT NULL,
 	PRIMARY KEY ( project_id, interval_month )
 );
+CREATE TABLE reencode_segments (
+	path bytea NOT NULL,
+	attempted timestamp with time zone,
+	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( path )
+);
 CREATE TABLE registration_tokens (
 	secret bytea NOT NULL,
 	owner_id bytea,
@@ -566,6 +602,13 @@ CREATE TABLE registration_tokens (
 	PRIMARY KEY ( secret ),
 	UNIQUE ( owner_id )
 );
+CREATE TABLE repair_priorities (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	class integer NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name )
+);
 CREATE TABLE reported_serials (
 	expires_at timestamp with time zone NOT NULL,
 	storage_node_id bytea NOT NULL,
@@ -706,6 +749,7 @@ CREATE TABLE api_keys (
 	name text NOT NULL,
 	secret bytea NOT NULL,
 	partner_id bytea,
+	caveat bytea,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id ),
 	UNIQUE ( head ),
@@ -737,6 +781,13 @@ CREATE TABLE project_members (
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( member_id, project_id )
 );
+CREATE TABLE sso_identities (
+	provider text NOT NULL,
+	subject text NOT NULL,
+	user_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( provider, subject )
+);
 CREATE TABLE stripecoinpayments_apply_balance_intents (
 	tx_id text NOT NULL REFERENCES coinpayments_transactions( id ) ON DELETE CASCADE,
 	state integer NOT NULL,
@@ -761,12 +812,22 @@ CREATE TABLE user_credits (
 	PRIMARY KEY ( id ),
 	UNIQUE ( id, offer_id )
 );
+CREATE TABLE api_key_usages (
+	api_key_id bytea NOT NULL REFERENCES api_keys( id ) ON DELETE CASCADE,
+	last_used_at timestamp with time zone NOT NULL,
+	request_count bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY ( api_key_id )
+);
 CREATE INDEX accounting_rollups_start_time_index ON accounting_rollups ( start_time );
+CREATE INDEX audit_logs_created_at_index ON audit_logs ( created_at );
+CREATE INDEX audit_logs_project_id_created_at_index ON audit_logs ( project_id, created_at );
+CREATE INDEX audit_logs_user_id_created_at_index ON audit_logs ( user_id, created_at );
 CREATE INDEX bucket_bandwidth_rollups_project_id_action_interval_index ON bucket_bandwidth_rollups ( project_id, action, interval_start );
 CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket_bandwidth_rollups ( action, interval_start, project_id );
 CREATE INDEX consumed_serials_expires_at_index ON consumed_serials ( expires_at );
 CREATE INDEX graceful_exit_transfer_queue_nid_dr_qa_fa_lfa_index ON graceful_exit_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at );
 CREATE INDEX injuredsegments_attempted_index ON injuredsegments ( attempted );
+CREATE INDEX injuredsegments_priority_index ON injuredsegments ( priority );
 CREATE INDEX injuredsegments_segment_health_index ON injuredsegments ( segment_health );
 CREATE INDEX injuredsegments_updated_at_index ON injuredsegments ( updated_at );
 CREATE INDEX node_last_ip ON nodes ( last_net );
@@ -897,6 +958,21 @@ CREATE TABLE audit_histories (
 	history bytea NOT NULL,
 	PRIMARY KEY ( node_id )
 );
+CREATE TABLE audit_logs (
+	id bytea NOT NULL,
+	actor text NOT NULL,
+	operation text NOT NULL,
+	target_type text NOT NULL,
+	target_id text,
+	user_id bytea,
+	project_id bytea,
+	source_ip text NOT NULL,
+	forwarded_for text NOT NULL,
+	outcome text NOT NULL,
+	error text,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id )
+);
 CREATE TABLE bucket_bandwidth_rollups (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -908,6 +984,13 @@ CREATE TABLE bucket_bandwidth_rollups (
 	settled bigint NOT NULL,
 	PRIMARY KEY ( bucket_name, project_id, interval_start, action )
 );
+CREATE TABLE bucket_redundancy_profiles (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	profile text NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name )
+);
 CREATE TABLE bucket_storage_tallies (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -985,6 +1068,7 @@ CREATE TABLE injuredsegments (
 	attempted timestamp with time zone,
 	updated_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
 	segment_health double precision NOT NULL DEFAULT 1,
+	priority double precision NOT NULL DEFAULT 1,
 	PRIMARY KEY ( path )
 );
 CREATE TABLE irreparabledbs (
@@ -1048,6 +1132,13 @@ CREATE TABLE node_api_versions (
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE node_audits (
+	node_id bytea NOT NULL,
+	first_audited timestamp with time zone NOT NULL,
+	last_audited timestamp with time zone NOT NULL,
+	audit_count bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE nodes_offline_times (
 	node_id bytea NOT NULL,
 	tracked_at timestamp with time zone NOT NULL,
@@ -1114,6 +1205,12 @@ CREATE TABLE project_bandwidth_rollups (
 	egress_allocated bigint NOT NULL,
 	PRIMARY KEY ( project_id, interval_month )
 );
+CREATE TABLE reencode_segments (
+	path bytea NOT NULL,
+	attempted timestamp with time zone,
+	inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+	PRIMARY KEY ( path )
+);
 CREATE TABLE registration_tokens (
 	secret bytea NOT NULL,
 	owner_id bytea,
@@ -1122,6 +1219,13 @@ CREATE TABLE registration_tokens (
 	PRIMARY KEY ( secret ),
 	UNIQUE ( owner_id )
 );
+CREATE TABLE repair_priorities (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	class integer NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name )
+);
 CREATE TABLE reported_serials (
 	expires_at timestamp with time zone NOT NULL,
 	storage_node_id bytea NOT NULL,
@@ -1262,6 +1366,7 @@ CREATE TABLE api_keys (
 	name text NOT NULL,
 	secret bytea NOT NULL,
 	partner_id bytea,
+	caveat bytea,
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id ),
 	UNIQUE ( head ),
@@ -1293,6 +1398,13 @@ CREATE TABLE project_members (
 	created_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( member_id, project_id )
 );
+CREATE TABLE sso_identities (
+	provider text NOT NULL,
+	subject text NOT NULL,
+	user_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( provider, subject )
+);
 CREATE TABLE stripecoinpayments_apply_balance_intents (
 	tx_id text NOT NULL REFERENCES coinpayments_transactions( id ) ON DELETE CASCADE,
 	state integer NOT NULL,
@@ -1317,12 +1429,22 @@ CREATE TABLE user_credits (
 	PRIMARY KEY ( id ),
 	UNIQUE ( id, offer_id )
 );
+CREATE TABLE api_key_usages (
+	api_key_id bytea NOT NULL REFERENCES api_keys( id ) ON DELETE CASCADE,
+	last_used_at timestamp with time zone NOT NULL,
+	request_count bigint NOT NULL DEFAULT 0,
+	PRIMARY KEY ( api_key_id )
+);
 CREATE INDEX accounting_rollups_start_time_index ON accounting_rollups ( start_time );
+CREATE INDEX audit_logs_created_at_index ON audit_logs ( created_at );
+CREATE INDEX audit_logs_project_id_created_at_index ON audit_logs ( project_id, created_at );
+CREATE INDEX audit_logs_user_id_created_at_index ON audit_logs ( user_id, created_at );
 CREATE INDEX bucket_bandwidth_rollups_project_id_action_interval_index ON bucket_bandwidth_rollups ( project_id, action, interval_start );
 CREATE INDEX bucket_bandwidth_rollups_action_interval_project_id_index ON bucket_bandwidth_rollups ( action, interval_start, project_id );
 CREATE INDEX consumed_serials_expires_at_index ON consumed_serials ( expires_at );
 CREATE INDEX graceful_exit_transfer_queue_nid_dr_qa_fa_lfa_index ON graceful_exit_transfer_queue ( node_id, durability_ratio, queued_at, finished_at, last_failed_at );
 CREATE INDEX injuredsegments_attempted_index ON injuredsegments ( attempted );
+CREATE INDEX injuredsegments_priority_index ON injuredsegments ( priority );
 CREATE INDEX injuredsegments_segment_health_index ON injuredsegments ( segment_health );
 CREATE INDEX injuredsegments_updated_at_index ON injuredsegments ( updated_at );
 CREATE INDEX node_last_ip ON nodes ( last_net );
@@ -1662,6 +1784,313 @@ func (f AuditHistory_History_Field) value() interface{} {
 
 func (AuditHistory_History_Field) _Column() string { return "history" }
 
+type AuditLog struct {
+	Id           []byte
+	Actor        string
+	Operation    string
+	TargetType   string
+	TargetId     *string
+	UserId       []byte
+	ProjectId    []byte
+	SourceIp     string
+	ForwardedFor string
+	Outcome      string
+	Error        *string
+	CreatedAt    time.Time
+}
+
+func (AuditLog) _Table() string { return "audit_logs" }
+
+type AuditLog_Create_Fields struct {
+	TargetId  AuditLog_TargetId_Field
+	UserId    AuditLog_UserId_Field
+	ProjectId AuditLog_ProjectId_Field
+	Error     AuditLog_Error_Field
+}
+
+type AuditLog_Update_Fields struct {
+}
+
+type AuditLog_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func AuditLog_Id(v []byte) AuditLog_Id_Field {
+	return AuditLog_Id_Field{_set: true, _value: v}
+}
+
+func (f AuditLog_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_Id_Field) _Column() string { return "id" }
+
+type AuditLog_Actor_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AuditLog_Actor(v string) AuditLog_Actor_Field {
+	return AuditLog_Actor_Field{_set: true, _value: v}
+}
+
+func (f AuditLog_Actor_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_Actor_Field) _Column() string { return "actor" }
+
+type AuditLog_Operation_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AuditLog_Operation(v string) AuditLog_Operation_Field {
+	return AuditLog_Operation_Field{_set: true, _value: v}
+}
+
+func (f AuditLog_Operation_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_Operation_Field) _Column() string { return "operation" }
+
+type AuditLog_TargetType_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AuditLog_TargetType(v string) AuditLog_TargetType_Field {
+	return AuditLog_TargetType_Field{_set: true, _value: v}
+}
+
+func (f AuditLog_TargetType_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_TargetType_Field) _Column() string { return "target_type" }
+
+type AuditLog_TargetId_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func AuditLog_TargetId(v string) AuditLog_TargetId_Field {
+	return AuditLog_TargetId_Field{_set: true, _value: &v}
+}
+
+func AuditLog_TargetId_Raw(v *string) AuditLog_TargetId_Field {
+	if v == nil {
+		return AuditLog_TargetId_Null()
+	}
+	return AuditLog_TargetId(*v)
+}
+
+func AuditLog_TargetId_Null() AuditLog_TargetId_Field {
+	return AuditLog_TargetId_Field{_set: true, _null: true}
+}
+
+func (f AuditLog_TargetId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f AuditLog_TargetId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_TargetId_Field) _Column() string { return "target_id" }
+
+type AuditLog_UserId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func AuditLog_UserId(v []byte) AuditLog_UserId_Field {
+	return AuditLog_UserId_Field{_set: true, _value: v}
+}
+
+func AuditLog_UserId_Raw(v []byte) AuditLog_UserId_Field {
+	if v == nil {
+		return AuditLog_UserId_Null()
+	}
+	return AuditLog_UserId(v)
+}
+
+func AuditLog_UserId_Null() AuditLog_UserId_Field {
+	return AuditLog_UserId_Field{_set: true, _null: true}
+}
+
+func (f AuditLog_UserId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f AuditLog_UserId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_UserId_Field) _Column() string { return "user_id" }
+
+type AuditLog_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func AuditLog_ProjectId(v []byte) AuditLog_ProjectId_Field {
+	return AuditLog_ProjectId_Field{_set: true, _value: v}
+}
+
+func AuditLog_ProjectId_Raw(v []byte) AuditLog_ProjectId_Field {
+	if v == nil {
+		return AuditLog_ProjectId_Null()
+	}
+	return AuditLog_ProjectId(v)
+}
+
+func AuditLog_ProjectId_Null() AuditLog_ProjectId_Field {
+	return AuditLog_ProjectId_Field{_set: true, _null: true}
+}
+
+func (f AuditLog_ProjectId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f AuditLog_ProjectId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_ProjectId_Field) _Column() string { return "project_id" }
+
+type AuditLog_SourceIp_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AuditLog_SourceIp(v string) AuditLog_SourceIp_Field {
+	return AuditLog_SourceIp_Field{_set: true, _value: v}
+}
+
+func (f AuditLog_SourceIp_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_SourceIp_Field) _Column() string { return "source_ip" }
+
+type AuditLog_ForwardedFor_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AuditLog_ForwardedFor(v string) AuditLog_ForwardedFor_Field {
+	return AuditLog_ForwardedFor_Field{_set: true, _value: v}
+}
+
+func (f AuditLog_ForwardedFor_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_ForwardedFor_Field) _Column() string { return "forwarded_for" }
+
+type AuditLog_Outcome_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func AuditLog_Outcome(v string) AuditLog_Outcome_Field {
+	return AuditLog_Outcome_Field{_set: true, _value: v}
+}
+
+func (f AuditLog_Outcome_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_Outcome_Field) _Column() string { return "outcome" }
+
+type AuditLog_Error_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func AuditLog_Error(v string) AuditLog_Error_Field {
+	return AuditLog_Error_Field{_set: true, _value: &v}
+}
+
+func AuditLog_Error_Raw(v *string) AuditLog_Error_Field {
+	if v == nil {
+		return AuditLog_Error_Null()
+	}
+	return AuditLog_Error(*v)
+}
+
+func AuditLog_Error_Null() AuditLog_Error_Field {
+	return AuditLog_Error_Field{_set: true, _null: true}
+}
+
+func (f AuditLog_Error_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f AuditLog_Error_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_Error_Field) _Column() string { return "error" }
+
+type AuditLog_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func AuditLog_CreatedAt(v time.Time) AuditLog_CreatedAt_Field {
+	return AuditLog_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f AuditLog_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (AuditLog_CreatedAt_Field) _Column() string { return "created_at" }
+
 type BucketBandwidthRollup struct {
 	BucketName      []byte
 	ProjectId       []byte
@@ -1833,6 +2262,95 @@ func (f BucketBandwidthRollup_Settled_Field) value() interface{} {
 
 func (BucketBandwidthRollup_Settled_Field) _Column() string { return "settled" }
 
+type BucketRedundancyProfile struct {
+	ProjectId  []byte
+	BucketName []byte
+	Profile    string
+	UpdatedAt  time.Time
+}
+
+func (BucketRedundancyProfile) _Table() string { return "bucket_redundancy_profiles" }
+
+type BucketRedundancyProfile_Update_Fields struct {
+	Profile BucketRedundancyProfile_Profile_Field
+}
+
+type BucketRedundancyProfile_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketRedundancyProfile_ProjectId(v []byte) BucketRedundancyProfile_ProjectId_Field {
+	return BucketRedundancyProfile_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f BucketRedundancyProfile_ProjectId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketRedundancyProfile_ProjectId_Field) _Column() string { return "project_id" }
+
+type BucketRedundancyProfile_BucketName_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketRedundancyProfile_BucketName(v []byte) BucketRedundancyProfile_BucketName_Field {
+	return BucketRedundancyProfile_BucketName_Field{_set: true, _value: v}
+}
+
+func (f BucketRedundancyProfile_BucketName_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketRedundancyProfile_BucketName_Field) _Column() string { return "bucket_name" }
+
+type BucketRedundancyProfile_Profile_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func BucketRedundancyProfile_Profile(v string) BucketRedundancyProfile_Profile_Field {
+	return BucketRedundancyProfile_Profile_Field{_set: true, _value: v}
+}
+
+func (f BucketRedundancyProfile_Profile_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketRedundancyProfile_Profile_Field) _Column() string { return "profile" }
+
+type BucketRedundancyProfile_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BucketRedundancyProfile_UpdatedAt(v time.Time) BucketRedundancyProfile_UpdatedAt_Field {
+	return BucketRedundancyProfile_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f BucketRedundancyProfile_UpdatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketRedundancyProfile_UpdatedAt_Field) _Column() string { return "updated_at" }
+
 type BucketStorageTally struct {
 	BucketName          []byte
 	ProjectId           []byte
@@ -3011,6 +3529,7 @@ type Injuredsegment struct {
 	Attempted     *time.Time
 	UpdatedAt     time.Time
 	SegmentHealth float64
+	Priority      float64
 }
 
 func (Injuredsegment) _Table() string { return "injuredsegments" }
@@ -3019,6 +3538,7 @@ type Injuredsegment_Create_Fields struct {
 	Attempted     Injuredsegment_Attempted_Field
 	UpdatedAt     Injuredsegment_UpdatedAt_Field
 	SegmentHealth Injuredsegment_SegmentHealth_Field
+	Priority      Injuredsegment_Priority_Field
 }
 
 type Injuredsegment_Update_Fields struct {
@@ -3134,6 +3654,25 @@ func (f Injuredsegment_SegmentHealth_Field) value() interface{} {
 
 func (Injuredsegment_SegmentHealth_Field) _Column() string { return "segment_health" }
 
+type Injuredsegment_Priority_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Injuredsegment_Priority(v float64) Injuredsegment_Priority_Field {
+	return Injuredsegment_Priority_Field{_set: true, _value: v}
+}
+
+func (f Injuredsegment_Priority_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Injuredsegment_Priority_Field) _Column() string { return "priority" }
+
 type Irreparabledb struct {
 	Segmentpath        []byte
 	Segmentdetail      []byte
@@ -4412,6 +4951,100 @@ func (f NodeApiVersion_UpdatedAt_Field) value() interface{} {
 
 func (NodeApiVersion_UpdatedAt_Field) _Column() string { return "updated_at" }
 
+type NodeAudit struct {
+	NodeId       []byte
+	FirstAudited time.Time
+	LastAudited  time.Time
+	AuditCount   int64
+}
+
+func (NodeAudit) _Table() string { return "node_audits" }
+
+type NodeAudit_Create_Fields struct {
+	AuditCount NodeAudit_AuditCount_Field
+}
+
+type NodeAudit_Update_Fields struct {
+	LastAudited NodeAudit_LastAudited_Field
+	AuditCount  NodeAudit_AuditCount_Field
+}
+
+type NodeAudit_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeAudit_NodeId(v []byte) NodeAudit_NodeId_Field {
+	return NodeAudit_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeAudit_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAudit_NodeId_Field) _Column() string { return "node_id" }
+
+type NodeAudit_FirstAudited_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeAudit_FirstAudited(v time.Time) NodeAudit_FirstAudited_Field {
+	return NodeAudit_FirstAudited_Field{_set: true, _value: v}
+}
+
+func (f NodeAudit_FirstAudited_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAudit_FirstAudited_Field) _Column() string { return "first_audited" }
+
+type NodeAudit_LastAudited_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeAudit_LastAudited(v time.Time) NodeAudit_LastAudited_Field {
+	return NodeAudit_LastAudited_Field{_set: true, _value: v}
+}
+
+func (f NodeAudit_LastAudited_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAudit_LastAudited_Field) _Column() string { return "last_audited" }
+
+type NodeAudit_AuditCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func NodeAudit_AuditCount(v int64) NodeAudit_AuditCount_Field {
+	return NodeAudit_AuditCount_Field{_set: true, _value: v}
+}
+
+func (f NodeAudit_AuditCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeAudit_AuditCount_Field) _Column() string { return "audit_count" }
+
 type NodesOfflineTime struct {
 	NodeId    []byte
 	TrackedAt time.Time
@@ -5415,103 +6048,190 @@ func Project_OwnerId(v []byte) Project_OwnerId_Field {
 	return Project_OwnerId_Field{_set: true, _value: v}
 }
 
-func (f Project_OwnerId_Field) value() interface{} {
+func (f Project_OwnerId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_OwnerId_Field) _Column() string { return "owner_id" }
+
+type Project_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Project_CreatedAt(v time.Time) Project_CreatedAt_Field {
+	return Project_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f Project_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_CreatedAt_Field) _Column() string { return "created_at" }
+
+type ProjectBandwidthRollup struct {
+	ProjectId       []byte
+	IntervalMonth   time.Time
+	EgressAllocated uint64
+}
+
+func (ProjectBandwidthRollup) _Table() string { return "project_bandwidth_rollups" }
+
+type ProjectBandwidthRollup_Update_Fields struct {
+	EgressAllocated ProjectBandwidthRollup_EgressAllocated_Field
+}
+
+type ProjectBandwidthRollup_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ProjectBandwidthRollup_ProjectId(v []byte) ProjectBandwidthRollup_ProjectId_Field {
+	return ProjectBandwidthRollup_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f ProjectBandwidthRollup_ProjectId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ProjectBandwidthRollup_ProjectId_Field) _Column() string { return "project_id" }
+
+type ProjectBandwidthRollup_IntervalMonth_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ProjectBandwidthRollup_IntervalMonth(v time.Time) ProjectBandwidthRollup_IntervalMonth_Field {
+	v = toDate(v)
+	return ProjectBandwidthRollup_IntervalMonth_Field{_set: true, _value: v}
+}
+
+func (f ProjectBandwidthRollup_IntervalMonth_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_OwnerId_Field) _Column() string { return "owner_id" }
+func (ProjectBandwidthRollup_IntervalMonth_Field) _Column() string { return "interval_month" }
 
-type Project_CreatedAt_Field struct {
+type ProjectBandwidthRollup_EgressAllocated_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value uint64
 }
 
-func Project_CreatedAt(v time.Time) Project_CreatedAt_Field {
-	return Project_CreatedAt_Field{_set: true, _value: v}
+func ProjectBandwidthRollup_EgressAllocated(v uint64) ProjectBandwidthRollup_EgressAllocated_Field {
+	return ProjectBandwidthRollup_EgressAllocated_Field{_set: true, _value: v}
 }
 
-func (f Project_CreatedAt_Field) value() interface{} {
+func (f ProjectBandwidthRollup_EgressAllocated_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_CreatedAt_Field) _Column() string { return "created_at" }
+func (ProjectBandwidthRollup_EgressAllocated_Field) _Column() string { return "egress_allocated" }
 
-type ProjectBandwidthRollup struct {
-	ProjectId       []byte
-	IntervalMonth   time.Time
-	EgressAllocated uint64
+type ReencodeSegment struct {
+	Path       []byte
+	Attempted  *time.Time
+	InsertedAt time.Time
 }
 
-func (ProjectBandwidthRollup) _Table() string { return "project_bandwidth_rollups" }
+func (ReencodeSegment) _Table() string { return "reencode_segments" }
 
-type ProjectBandwidthRollup_Update_Fields struct {
-	EgressAllocated ProjectBandwidthRollup_EgressAllocated_Field
+type ReencodeSegment_Create_Fields struct {
+	Attempted  ReencodeSegment_Attempted_Field
+	InsertedAt ReencodeSegment_InsertedAt_Field
 }
 
-type ProjectBandwidthRollup_ProjectId_Field struct {
+type ReencodeSegment_Update_Fields struct {
+	Attempted ReencodeSegment_Attempted_Field
+}
+
+type ReencodeSegment_Path_Field struct {
 	_set   bool
 	_null  bool
 	_value []byte
 }
 
-func ProjectBandwidthRollup_ProjectId(v []byte) ProjectBandwidthRollup_ProjectId_Field {
-	return ProjectBandwidthRollup_ProjectId_Field{_set: true, _value: v}
+func ReencodeSegment_Path(v []byte) ReencodeSegment_Path_Field {
+	return ReencodeSegment_Path_Field{_set: true, _value: v}
 }
 
-func (f ProjectBandwidthRollup_ProjectId_Field) value() interface{} {
+func (f ReencodeSegment_Path_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (ProjectBandwidthRollup_ProjectId_Field) _Column() string { return "project_id" }
+func (ReencodeSegment_Path_Field) _Column() string { return "path" }
 
-type ProjectBandwidthRollup_IntervalMonth_Field struct {
+type ReencodeSegment_Attempted_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value *time.Time
 }
 
-func ProjectBandwidthRollup_IntervalMonth(v time.Time) ProjectBandwidthRollup_IntervalMonth_Field {
-	v = toDate(v)
-	return ProjectBandwidthRollup_IntervalMonth_Field{_set: true, _value: v}
+func ReencodeSegment_Attempted(v time.Time) ReencodeSegment_Attempted_Field {
+	return ReencodeSegment_Attempted_Field{_set: true, _value: &v}
 }
 
-func (f ProjectBandwidthRollup_IntervalMonth_Field) value() interface{} {
+func ReencodeSegment_Attempted_Raw(v *time.Time) ReencodeSegment_Attempted_Field {
+	if v == nil {
+		return ReencodeSegment_Attempted_Null()
+	}
+	return ReencodeSegment_Attempted(*v)
+}
+
+func ReencodeSegment_Attempted_Null() ReencodeSegment_Attempted_Field {
+	return ReencodeSegment_Attempted_Field{_set: true, _null: true}
+}
+
+func (f ReencodeSegment_Attempted_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f ReencodeSegment_Attempted_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (ProjectBandwidthRollup_IntervalMonth_Field) _Column() string { return "interval_month" }
+func (ReencodeSegment_Attempted_Field) _Column() string { return "attempted" }
 
-type ProjectBandwidthRollup_EgressAllocated_Field struct {
+type ReencodeSegment_InsertedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value uint64
+	_value time.Time
 }
 
-func ProjectBandwidthRollup_EgressAllocated(v uint64) ProjectBandwidthRollup_EgressAllocated_Field {
-	return ProjectBandwidthRollup_EgressAllocated_Field{_set: true, _value: v}
+func ReencodeSegment_InsertedAt(v time.Time) ReencodeSegment_InsertedAt_Field {
+	return ReencodeSegment_InsertedAt_Field{_set: true, _value: v}
 }
 
-func (f ProjectBandwidthRollup_EgressAllocated_Field) value() interface{} {
+func (f ReencodeSegment_InsertedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (ProjectBandwidthRollup_EgressAllocated_Field) _Column() string { return "egress_allocated" }
+func (ReencodeSegment_InsertedAt_Field) _Column() string { return "inserted_at" }
 
 type RegistrationToken struct {
 	Secret       []byte
@@ -5619,6 +6339,95 @@ func (f RegistrationToken_CreatedAt_Field) value() interface{} {
 
 func (RegistrationToken_CreatedAt_Field) _Column() string { return "created_at" }
 
+type RepairPriority struct {
+	ProjectId  []byte
+	BucketName []byte
+	Class      int
+	UpdatedAt  time.Time
+}
+
+func (RepairPriority) _Table() string { return "repair_priorities" }
+
+type RepairPriority_Update_Fields struct {
+	Class RepairPriority_Class_Field
+}
+
+type RepairPriority_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func RepairPriority_ProjectId(v []byte) RepairPriority_ProjectId_Field {
+	return RepairPriority_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f RepairPriority_ProjectId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairPriority_ProjectId_Field) _Column() string { return "project_id" }
+
+type RepairPriority_BucketName_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func RepairPriority_BucketName(v []byte) RepairPriority_BucketName_Field {
+	return RepairPriority_BucketName_Field{_set: true, _value: v}
+}
+
+func (f RepairPriority_BucketName_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairPriority_BucketName_Field) _Column() string { return "bucket_name" }
+
+type RepairPriority_Class_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func RepairPriority_Class(v int) RepairPriority_Class_Field {
+	return RepairPriority_Class_Field{_set: true, _value: v}
+}
+
+func (f RepairPriority_Class_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairPriority_Class_Field) _Column() string { return "class" }
+
+type RepairPriority_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func RepairPriority_UpdatedAt(v time.Time) RepairPriority_UpdatedAt_Field {
+	return RepairPriority_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f RepairPriority_UpdatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RepairPriority_UpdatedAt_Field) _Column() string { return "updated_at" }
+
 type ReportedSerial struct {
 	ExpiresAt     time.Time
 	StorageNodeId []byte
@@ -7622,6 +8431,7 @@ type ApiKey struct {
 	Name      string
 	Secret    []byte
 	PartnerId []byte
+	Caveat    []byte
 	CreatedAt time.Time
 }
 
@@ -7629,6 +8439,7 @@ func (ApiKey) _Table() string { return "api_keys" }
 
 type ApiKey_Create_Fields struct {
 	PartnerId ApiKey_PartnerId_Field
+	Caveat    ApiKey_Caveat_Field
 }
 
 type ApiKey_Update_Fields struct {
@@ -7762,6 +8573,38 @@ func (f ApiKey_PartnerId_Field) value() interface{} {
 
 func (ApiKey_PartnerId_Field) _Column() string { return "partner_id" }
 
+type ApiKey_Caveat_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ApiKey_Caveat(v []byte) ApiKey_Caveat_Field {
+	return ApiKey_Caveat_Field{_set: true, _value: v}
+}
+
+func ApiKey_Caveat_Raw(v []byte) ApiKey_Caveat_Field {
+	if v == nil {
+		return ApiKey_Caveat_Null()
+	}
+	return ApiKey_Caveat(v)
+}
+
+func ApiKey_Caveat_Null() ApiKey_Caveat_Field {
+	return ApiKey_Caveat_Field{_set: true, _null: true}
+}
+
+func (f ApiKey_Caveat_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f ApiKey_Caveat_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ApiKey_Caveat_Field) _Column() string { return "caveat" }
+
 type ApiKey_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
@@ -8200,6 +9043,94 @@ func (f ProjectMember_CreatedAt_Field) value() interface{} {
 
 func (ProjectMember_CreatedAt_Field) _Column() string { return "created_at" }
 
+type SsoIdentity struct {
+	Provider  string
+	Subject   string
+	UserId    []byte
+	CreatedAt time.Time
+}
+
+func (SsoIdentity) _Table() string { return "sso_identities" }
+
+type SsoIdentity_Update_Fields struct {
+}
+
+type SsoIdentity_Provider_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func SsoIdentity_Provider(v string) SsoIdentity_Provider_Field {
+	return SsoIdentity_Provider_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_Provider_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SsoIdentity_Provider_Field) _Column() string { return "provider" }
+
+type SsoIdentity_Subject_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func SsoIdentity_Subject(v string) SsoIdentity_Subject_Field {
+	return SsoIdentity_Subject_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_Subject_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SsoIdentity_Subject_Field) _Column() string { return "subject" }
+
+type SsoIdentity_UserId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func SsoIdentity_UserId(v []byte) SsoIdentity_UserId_Field {
+	return SsoIdentity_UserId_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_UserId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SsoIdentity_UserId_Field) _Column() string { return "user_id" }
+
+type SsoIdentity_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func SsoIdentity_CreatedAt(v time.Time) SsoIdentity_CreatedAt_Field {
+	return SsoIdentity_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f SsoIdentity_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SsoIdentity_CreatedAt_Field) _Column() string { return "created_at" }
+
 type StripecoinpaymentsApplyBalanceIntent struct {
 	TxId      string
 	State     int
@@ -8441,90 +9372,164 @@ func UserCredit_Type(v string) UserCredit_Type_Field {
 	return UserCredit_Type_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_Type_Field) value() interface{} {
+func (f UserCredit_Type_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (UserCredit_Type_Field) _Column() string { return "type" }
+
+type UserCredit_CreditsEarnedInCents_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func UserCredit_CreditsEarnedInCents(v int) UserCredit_CreditsEarnedInCents_Field {
+	return UserCredit_CreditsEarnedInCents_Field{_set: true, _value: v}
+}
+
+func (f UserCredit_CreditsEarnedInCents_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (UserCredit_CreditsEarnedInCents_Field) _Column() string { return "credits_earned_in_cents" }
+
+type UserCredit_CreditsUsedInCents_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func UserCredit_CreditsUsedInCents(v int) UserCredit_CreditsUsedInCents_Field {
+	return UserCredit_CreditsUsedInCents_Field{_set: true, _value: v}
+}
+
+func (f UserCredit_CreditsUsedInCents_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (UserCredit_CreditsUsedInCents_Field) _Column() string { return "credits_used_in_cents" }
+
+type UserCredit_ExpiresAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func UserCredit_ExpiresAt(v time.Time) UserCredit_ExpiresAt_Field {
+	return UserCredit_ExpiresAt_Field{_set: true, _value: v}
+}
+
+func (f UserCredit_ExpiresAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_Type_Field) _Column() string { return "type" }
+func (UserCredit_ExpiresAt_Field) _Column() string { return "expires_at" }
 
-type UserCredit_CreditsEarnedInCents_Field struct {
+type UserCredit_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value time.Time
 }
 
-func UserCredit_CreditsEarnedInCents(v int) UserCredit_CreditsEarnedInCents_Field {
-	return UserCredit_CreditsEarnedInCents_Field{_set: true, _value: v}
+func UserCredit_CreatedAt(v time.Time) UserCredit_CreatedAt_Field {
+	return UserCredit_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_CreditsEarnedInCents_Field) value() interface{} {
+func (f UserCredit_CreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_CreditsEarnedInCents_Field) _Column() string { return "credits_earned_in_cents" }
+func (UserCredit_CreatedAt_Field) _Column() string { return "created_at" }
 
-type UserCredit_CreditsUsedInCents_Field struct {
+type ApiKeyUsage struct {
+	ApiKeyId     []byte
+	LastUsedAt   time.Time
+	RequestCount int64
+}
+
+func (ApiKeyUsage) _Table() string { return "api_key_usages" }
+
+type ApiKeyUsage_Create_Fields struct {
+	RequestCount ApiKeyUsage_RequestCount_Field
+}
+
+type ApiKeyUsage_Update_Fields struct {
+	LastUsedAt   ApiKeyUsage_LastUsedAt_Field
+	RequestCount ApiKeyUsage_RequestCount_Field
+}
+
+type ApiKeyUsage_ApiKeyId_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value []byte
 }
 
-func UserCredit_CreditsUsedInCents(v int) UserCredit_CreditsUsedInCents_Field {
-	return UserCredit_CreditsUsedInCents_Field{_set: true, _value: v}
+func ApiKeyUsage_ApiKeyId(v []byte) ApiKeyUsage_ApiKeyId_Field {
+	return ApiKeyUsage_ApiKeyId_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_CreditsUsedInCents_Field) value() interface{} {
+func (f ApiKeyUsage_ApiKeyId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_CreditsUsedInCents_Field) _Column() string { return "credits_used_in_cents" }
+func (ApiKeyUsage_ApiKeyId_Field) _Column() string { return "api_key_id" }
 
-type UserCredit_ExpiresAt_Field struct {
+type ApiKeyUsage_LastUsedAt_Field struct {
 	_set   bool
 	_null  bool
 	_value time.Time
 }
 
-func UserCredit_ExpiresAt(v time.Time) UserCredit_ExpiresAt_Field {
-	return UserCredit_ExpiresAt_Field{_set: true, _value: v}
+func ApiKeyUsage_LastUsedAt(v time.Time) ApiKeyUsage_LastUsedAt_Field {
+	return ApiKeyUsage_LastUsedAt_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_ExpiresAt_Field) value() interface{} {
+func (f ApiKeyUsage_LastUsedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_ExpiresAt_Field) _Column() string { return "expires_at" }
+func (ApiKeyUsage_LastUsedAt_Field) _Column() string { return "last_used_at" }
 
-type UserCredit_CreatedAt_Field struct {
+type ApiKeyUsage_RequestCount_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value int64
 }
 
-func UserCredit_CreatedAt(v time.Time) UserCredit_CreatedAt_Field {
-	return UserCredit_CreatedAt_Field{_set: true, _value: v}
+func ApiKeyUsage_RequestCount(v int64) ApiKeyUsage_RequestCount_Field {
+	return ApiKeyUsage_RequestCount_Field{_set: true, _value: v}
 }
 
-func (f UserCredit_CreatedAt_Field) value() interface{} {
+func (f ApiKeyUsage_RequestCount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (UserCredit_CreatedAt_Field) _Column() string { return "created_at" }
+func (ApiKeyUsage_RequestCount_Field) _Column() string { return "request_count" }
 
 func toUTC(t time.Time) time.Time {
 	return t.UTC()
@@ -9013,6 +10018,10 @@ type ProjectLimit_Row struct {
 	ProjectLimit int
 }
 
+type UserId_Row struct {
+	UserId []byte
+}
+
 type UsageLimit_Row struct {
 	UsageLimit *int64
 }
@@ -9545,18 +10554,19 @@ func (obj *pgxImpl) Create_ApiKey(ctx context.Context,
 	__name_val := api_key_name.value()
 	__secret_val := api_key_secret.value()
 	__partner_id_val := optional.PartnerId.value()
+	__caveat_val := optional.Caveat.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO api_keys ( id, project_id, head, name, secret, partner_id, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? ) RETURNING api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO api_keys ( id, project_id, head, name, secret, partner_id, caveat, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __project_id_val, __head_val, __name_val, __secret_val, __partner_id_val, __created_at_val)
+	__values = append(__values, __id_val, __project_id_val, __head_val, __name_val, __secret_val, __partner_id_val, __caveat_val, __created_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -11111,6 +12121,58 @@ func (obj *pgxImpl) Get_User_ProjectLimit_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxImpl) CreateNoReturn_SsoIdentity(ctx context.Context,
+	sso_identity_provider SsoIdentity_Provider_Field,
+	sso_identity_subject SsoIdentity_Subject_Field,
+	sso_identity_user_id SsoIdentity_UserId_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__provider_val := sso_identity_provider.value()
+	__subject_val := sso_identity_subject.value()
+	__user_id_val := sso_identity_user_id.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO sso_identities ( provider, subject, user_id, created_at ) VALUES ( ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __provider_val, __subject_val, __user_id_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
+func (obj *pgxImpl) Get_SsoIdentity_UserId_By_Provider_And_Subject(ctx context.Context,
+	sso_identity_provider SsoIdentity_Provider_Field,
+	sso_identity_subject SsoIdentity_Subject_Field) (
+	row *UserId_Row, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT sso_identities.user_id FROM sso_identities WHERE sso_identities.provider = ? AND sso_identities.subject = ?")
+
+	var __values []interface{}
+	__values = append(__values, sso_identity_provider.value(), sso_identity_subject.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	row = &UserId_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.UserId)
+	if err != nil {
+		return (*UserId_Row)(nil), obj.makeErr(err)
+	}
+	return row, nil
+
+}
+
 func (obj *pgxImpl) Get_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	project *Project, err error) {
@@ -11547,7 +12609,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Id(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at FROM api_keys WHERE api_keys.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at FROM api_keys WHERE api_keys.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_id.value())
@@ -11556,7 +12618,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -11569,7 +12631,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Head(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at FROM api_keys WHERE api_keys.head = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at FROM api_keys WHERE api_keys.head = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_head.value())
@@ -11578,7 +12640,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Head(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -11592,7 +12654,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at FROM api_keys WHERE api_keys.name = ? AND api_keys.project_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at FROM api_keys WHERE api_keys.name = ? AND api_keys.project_id = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_name.value(), api_key_project_id.value())
@@ -11601,7 +12663,7 @@ func (obj *pgxImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -11614,7 +12676,7 @@ func (obj *pgxImpl) All_ApiKey_By_ProjectId_OrderBy_Asc_Name(ctx context.Context
 	rows []*ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at FROM api_keys WHERE api_keys.project_id = ? ORDER BY api_keys.name")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at FROM api_keys WHERE api_keys.project_id = ? ORDER BY api_keys.name")
 
 	var __values []interface{}
 	__values = append(__values, api_key_project_id.value())
@@ -11632,7 +12694,7 @@ func (obj *pgxImpl) All_ApiKey_By_ProjectId_OrderBy_Asc_Name(ctx context.Context
 
 			for __rows.Next() {
 				api_key := &ApiKey{}
-				err = __rows.Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+				err = __rows.Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -15423,6 +16485,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM api_key_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM user_credits;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -15448,6 +16520,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM sso_identities;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -15618,6 +16700,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM repair_priorities;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -15628,6 +16720,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM reencode_segments;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -15698,6 +16800,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_audits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -15808,6 +16920,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_redundancy_profiles;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -15818,6 +16940,16 @@ func (obj *pgxImpl) deleteAll(ctx context.Context) (count int64, err error) {
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM audit_logs;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -16382,18 +17514,19 @@ func (obj *pgxcockroachImpl) Create_ApiKey(ctx context.Context,
 	__name_val := api_key_name.value()
 	__secret_val := api_key_secret.value()
 	__partner_id_val := optional.PartnerId.value()
+	__caveat_val := optional.Caveat.value()
 	__created_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO api_keys ( id, project_id, head, name, secret, partner_id, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ? ) RETURNING api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO api_keys ( id, project_id, head, name, secret, partner_id, caveat, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at")
 
 	var __values []interface{}
-	__values = append(__values, __id_val, __project_id_val, __head_val, __name_val, __secret_val, __partner_id_val, __created_at_val)
+	__values = append(__values, __id_val, __project_id_val, __head_val, __name_val, __secret_val, __partner_id_val, __caveat_val, __created_at_val)
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -17948,6 +19081,58 @@ func (obj *pgxcockroachImpl) Get_User_ProjectLimit_By_Id(ctx context.Context,
 
 }
 
+func (obj *pgxcockroachImpl) CreateNoReturn_SsoIdentity(ctx context.Context,
+	sso_identity_provider SsoIdentity_Provider_Field,
+	sso_identity_subject SsoIdentity_Subject_Field,
+	sso_identity_user_id SsoIdentity_UserId_Field) (
+	err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	__now := obj.db.Hooks.Now().UTC()
+	__provider_val := sso_identity_provider.value()
+	__subject_val := sso_identity_subject.value()
+	__user_id_val := sso_identity_user_id.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO sso_identities ( provider, subject, user_id, created_at ) VALUES ( ?, ?, ?, ? )")
+
+	var __values []interface{}
+	__values = append(__values, __provider_val, __subject_val, __user_id_val, __created_at_val)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	_, err = obj.driver.ExecContext(ctx, __stmt, __values...)
+	if err != nil {
+		return obj.makeErr(err)
+	}
+	return nil
+
+}
+
+func (obj *pgxcockroachImpl) Get_SsoIdentity_UserId_By_Provider_And_Subject(ctx context.Context,
+	sso_identity_provider SsoIdentity_Provider_Field,
+	sso_identity_subject SsoIdentity_Subject_Field) (
+	row *UserId_Row, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT sso_identities.user_id FROM sso_identities WHERE sso_identities.provider = ? AND sso_identities.subject = ?")
+
+	var __values []interface{}
+	__values = append(__values, sso_identity_provider.value(), sso_identity_subject.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	row = &UserId_Row{}
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&row.UserId)
+	if err != nil {
+		return (*UserId_Row)(nil), obj.makeErr(err)
+	}
+	return row, nil
+
+}
+
 func (obj *pgxcockroachImpl) Get_Project_By_Id(ctx context.Context,
 	project_id Project_Id_Field) (
 	project *Project, err error) {
@@ -18384,7 +19569,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Id(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at FROM api_keys WHERE api_keys.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at FROM api_keys WHERE api_keys.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_id.value())
@@ -18393,7 +19578,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -18406,7 +19591,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Head(ctx context.Context,
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at FROM api_keys WHERE api_keys.head = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at FROM api_keys WHERE api_keys.head = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_head.value())
@@ -18415,7 +19600,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Head(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -18429,7 +19614,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Contex
 	api_key *ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at FROM api_keys WHERE api_keys.name = ? AND api_keys.project_id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at FROM api_keys WHERE api_keys.name = ? AND api_keys.project_id = ?")
 
 	var __values []interface{}
 	__values = append(__values, api_key_name.value(), api_key_project_id.value())
@@ -18438,7 +19623,7 @@ func (obj *pgxcockroachImpl) Get_ApiKey_By_Name_And_ProjectId(ctx context.Contex
 	obj.logStmt(__stmt, __values...)
 
 	api_key = &ApiKey{}
-	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+	err = obj.queryRowContext(ctx, __stmt, __values...).Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 	if err != nil {
 		return (*ApiKey)(nil), obj.makeErr(err)
 	}
@@ -18451,7 +19636,7 @@ func (obj *pgxcockroachImpl) All_ApiKey_By_ProjectId_OrderBy_Asc_Name(ctx contex
 	rows []*ApiKey, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.created_at FROM api_keys WHERE api_keys.project_id = ? ORDER BY api_keys.name")
+	var __embed_stmt = __sqlbundle_Literal("SELECT api_keys.id, api_keys.project_id, api_keys.head, api_keys.name, api_keys.secret, api_keys.partner_id, api_keys.caveat, api_keys.created_at FROM api_keys WHERE api_keys.project_id = ? ORDER BY api_keys.name")
 
 	var __values []interface{}
 	__values = append(__values, api_key_project_id.value())
@@ -18469,7 +19654,7 @@ func (obj *pgxcockroachImpl) All_ApiKey_By_ProjectId_OrderBy_Asc_Name(ctx contex
 
 			for __rows.Next() {
 				api_key := &ApiKey{}
-				err = __rows.Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.CreatedAt)
+				err = __rows.Scan(&api_key.Id, &api_key.ProjectId, &api_key.Head, &api_key.Name, &api_key.Secret, &api_key.PartnerId, &api_key.Caveat, &api_key.CreatedAt)
 				if err != nil {
 					return nil, err
 				}
@@ -22260,6 +23445,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 	defer mon.Task()(&ctx)(&err)
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM api_key_usages;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM user_credits;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -22285,6 +23480,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM sso_identities;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -22455,6 +23660,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM repair_priorities;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -22465,6 +23680,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM reencode_segments;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -22535,6 +23760,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM node_audits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -22645,6 +23880,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM bucket_redundancy_profiles;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -22655,6 +23900,16 @@ func (obj *pgxcockroachImpl) deleteAll(ctx context.Context) (count int64, err er
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.ExecContext(ctx, "DELETE FROM audit_logs;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -23120,6 +24375,19 @@ func (rx *Rx) CreateNoReturn_SerialNumber(ctx context.Context,
 
 }
 
+func (rx *Rx) CreateNoReturn_SsoIdentity(ctx context.Context,
+	sso_identity_provider SsoIdentity_Provider_Field,
+	sso_identity_subject SsoIdentity_Subject_Field,
+	sso_identity_user_id SsoIdentity_UserId_Field) (
+	err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.CreateNoReturn_SsoIdentity(ctx, sso_identity_provider, sso_identity_subject, sso_identity_user_id)
+
+}
+
 func (rx *Rx) CreateNoReturn_StoragenodePayment(ctx context.Context,
 	storagenode_payment_node_id StoragenodePayment_NodeId_Field,
 	storagenode_payment_period StoragenodePayment_Period_Field,
@@ -24025,6 +25293,17 @@ func (rx *Rx) Get_SerialNumber_BucketId_By_SerialNumber(ctx context.Context,
 	return tx.Get_SerialNumber_BucketId_By_SerialNumber(ctx, serial_number_serial_number)
 }
 
+func (rx *Rx) Get_SsoIdentity_UserId_By_Provider_And_Subject(ctx context.Context,
+	sso_identity_provider SsoIdentity_Provider_Field,
+	sso_identity_subject SsoIdentity_Subject_Field) (
+	row *UserId_Row, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_SsoIdentity_UserId_By_Provider_And_Subject(ctx, sso_identity_provider, sso_identity_subject)
+}
+
 func (rx *Rx) Get_StripeCustomer_CustomerId_By_UserId(ctx context.Context,
 	stripe_customer_user_id StripeCustomer_UserId_Field) (
 	row *CustomerId_Row, err error) {
@@ -24755,6 +26034,12 @@ type Methods interface {
 		serial_number_expires_at SerialNumber_ExpiresAt_Field) (
 		err error)
 
+	CreateNoReturn_SsoIdentity(ctx context.Context,
+		sso_identity_provider SsoIdentity_Provider_Field,
+		sso_identity_subject SsoIdentity_Subject_Field,
+		sso_identity_user_id SsoIdentity_UserId_Field) (
+		err error)
+
 	CreateNoReturn_StoragenodePayment(ctx context.Context,
 		storagenode_payment_node_id StoragenodePayment_NodeId_Field,
 		storagenode_payment_period StoragenodePayment_Period_Field,
@@ -25180,6 +26465,11 @@ type Methods interface {
 		serial_number_serial_number SerialNumber_SerialNumber_Field) (
 		row *BucketId_Row, err error)
 
+	Get_SsoIdentity_UserId_By_Provider_And_Subject(ctx context.Context,
+		sso_identity_provider SsoIdentity_Provider_Field,
+		sso_identity_subject SsoIdentity_Subject_Field) (
+		row *UserId_Row, err error)
+
 	Get_StripeCustomer_CustomerId_By_UserId(ctx context.Context,
 		stripe_customer_user_id StripeCustomer_UserId_Field) (
 		row *CustomerId_Row, err error)
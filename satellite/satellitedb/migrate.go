@@ -1131,6 +1131,124 @@ func (db *satelliteDB) PostgresMigration() *migrate.Migration {
 					`ALTER TABLE injuredsegments DROP COLUMN num_healthy_pieces;`,
 				},
 			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add injuredsegments.priority and repair_priorities table",
+				Version:     135,
+				SeparateTx:  true,
+				Action: migrate.SQL{
+					`ALTER TABLE injuredsegments ADD COLUMN priority double precision NOT NULL DEFAULT 1;`,
+					`CREATE INDEX injuredsegments_priority_index ON injuredsegments ( priority );`,
+					`CREATE TABLE repair_priorities (
+						project_id bytea NOT NULL,
+						bucket_name bytea NOT NULL,
+						class integer NOT NULL,
+						updated_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( project_id, bucket_name )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add bucket_redundancy_profiles table",
+				Version:     136,
+				Action: migrate.SQL{
+					`CREATE TABLE bucket_redundancy_profiles (
+						project_id bytea NOT NULL,
+						bucket_name bytea NOT NULL,
+						profile text NOT NULL,
+						updated_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( project_id, bucket_name )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add reencode_segments table",
+				Version:     137,
+				Action: migrate.SQL{
+					`CREATE TABLE reencode_segments (
+						path bytea NOT NULL,
+						attempted timestamp with time zone,
+						inserted_at timestamp with time zone NOT NULL DEFAULT current_timestamp,
+						PRIMARY KEY ( path )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add node_audits table",
+				Version:     138,
+				Action: migrate.SQL{
+					`CREATE TABLE node_audits (
+						node_id bytea NOT NULL,
+						first_audited timestamp with time zone NOT NULL,
+						last_audited timestamp with time zone NOT NULL,
+						audit_count bigint NOT NULL DEFAULT 0,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add caveat column to api_keys",
+				Version:     139,
+				Action: migrate.SQL{
+					`ALTER TABLE api_keys ADD COLUMN caveat bytea;`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add api_key_usages table",
+				Version:     140,
+				Action: migrate.SQL{
+					`CREATE TABLE api_key_usages (
+						api_key_id bytea NOT NULL REFERENCES api_keys( id ) ON DELETE CASCADE,
+						last_used_at timestamp with time zone NOT NULL,
+						request_count bigint NOT NULL DEFAULT 0,
+						PRIMARY KEY ( api_key_id )
+					);`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add audit_logs table",
+				Version:     141,
+				Action: migrate.SQL{
+					`CREATE TABLE audit_logs (
+						id bytea NOT NULL,
+						actor text NOT NULL,
+						operation text NOT NULL,
+						target_type text NOT NULL,
+						target_id text,
+						user_id bytea,
+						project_id bytea,
+						source_ip text NOT NULL,
+						forwarded_for text NOT NULL,
+						outcome text NOT NULL,
+						error text,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( id )
+					);`,
+					`CREATE INDEX audit_logs_created_at_index ON audit_logs ( created_at );`,
+					`CREATE INDEX audit_logs_project_id_created_at_index ON audit_logs ( project_id, created_at );`,
+					`CREATE INDEX audit_logs_user_id_created_at_index ON audit_logs ( user_id, created_at );`,
+				},
+			},
+			{
+				DB:          &db.migrationDB,
+				Description: "add sso_identities table",
+				Version:     142,
+				Action: migrate.SQL{
+					`CREATE TABLE sso_identities (
+						provider text NOT NULL,
+						subject text NOT NULL,
+						user_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( provider, subject )
+					);`,
+				},
+			},
 		},
 	}
 }
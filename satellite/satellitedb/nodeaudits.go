@@ -0,0 +1,99 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/storj/private/dbutil/pgutil"
+	"storj.io/storj/satellite/audit"
+)
+
+// ensure that nodeAudits implements audit.NodeAuditsDB.
+var _ audit.NodeAuditsDB = (*nodeAudits)(nil)
+
+type nodeAudits struct {
+	db *satelliteDB
+}
+
+// RecordAudits records that the nodes were audited at the time.
+func (audits *nodeAudits) RecordAudits(ctx context.Context, nodeIDs storj.NodeIDList, auditedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// a node can't be updated twice by the same statement.
+	unique := make(storj.NodeIDList, 0, len(nodeIDs))
+	seen := make(map[storj.NodeID]struct{}, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		if _, ok := seen[nodeID]; ok {
+			continue
+		}
+		seen[nodeID] = struct{}{}
+		unique = append(unique, nodeID)
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+
+	_, err = audits.db.ExecContext(ctx, `
+		INSERT INTO node_audits ( node_id, first_audited, last_audited, audit_count )
+		SELECT unnest($1::bytea[]), $2::timestamptz, $2::timestamptz, 1
+		ON CONFLICT ( node_id )
+		DO UPDATE SET
+			last_audited = EXCLUDED.last_audited,
+			audit_count = node_audits.audit_count + 1
+	`, pgutil.NodeIDArray(unique), auditedAt.UTC())
+	return Error.Wrap(err)
+}
+
+// GetAll returns the audit information of all nodes, which are neither
+// disqualified nor have exited.
+func (audits *nodeAudits) GetAll(ctx context.Context) (_ []audit.NodeAudits, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return audits.query(ctx, `
+		SELECT nodes.id, node_audits.first_audited, node_audits.last_audited, coalesce(node_audits.audit_count, 0),
+			nodes.vetted_at IS NOT NULL,
+			nodes.unknown_audit_suspended IS NOT NULL OR nodes.offline_suspended IS NOT NULL
+		FROM nodes
+		LEFT JOIN node_audits ON node_audits.node_id = nodes.id
+		WHERE nodes.disqualified IS NULL
+			AND nodes.exit_finished_at IS NULL
+	`)
+}
+
+// Get returns the audit information of the nodes.
+func (audits *nodeAudits) Get(ctx context.Context, nodeIDs storj.NodeIDList) (_ []audit.NodeAudits, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return audits.query(ctx, `
+		SELECT nodes.id, node_audits.first_audited, node_audits.last_audited, coalesce(node_audits.audit_count, 0),
+			nodes.vetted_at IS NOT NULL,
+			nodes.unknown_audit_suspended IS NOT NULL OR nodes.offline_suspended IS NOT NULL
+		FROM nodes
+		LEFT JOIN node_audits ON node_audits.node_id = nodes.id
+		WHERE nodes.id = any($1::bytea[])
+	`, pgutil.NodeIDArray(nodeIDs))
+}
+
+func (audits *nodeAudits) query(ctx context.Context, query string, args ...interface{}) (list []audit.NodeAudits, err error) {
+	rows, err := audits.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var info audit.NodeAudits
+		err := rows.Scan(&info.NodeID, &info.FirstAudited, &info.LastAudited, &info.AuditCount, &info.Vetted, &info.Suspended)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		list = append(list, info)
+	}
+	return list, Error.Wrap(rows.Err())
+}
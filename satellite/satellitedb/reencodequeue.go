@@ -0,0 +1,103 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/private/dbutil"
+	"storj.io/storj/satellite/metainfo/metabase"
+	"storj.io/storj/storage"
+)
+
+type reencodeQueue struct {
+	db *satelliteDB
+}
+
+func (r *reencodeQueue) Insert(ctx context.Context, key metabase.SegmentKey) (alreadyInserted bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// see repairQueue.Insert for the reasoning behind the separate queries.
+	var query string
+	switch r.db.implementation {
+	case dbutil.Postgres:
+		query = `
+			INSERT INTO reencode_segments (path) VALUES ($1)
+			ON CONFLICT (path) DO UPDATE SET path = EXCLUDED.path
+			RETURNING (xmax != 0) AS alreadyInserted
+		`
+	case dbutil.Cockroach:
+		query = `
+			INSERT INTO reencode_segments (path) VALUES ($1)
+			ON CONFLICT (path) DO NOTHING
+			RETURNING false
+		`
+	default:
+		return false, errs.New("invalid dbType: %v", r.db.implementation)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, []byte(key))
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	if !rows.Next() {
+		// cockroach query does not return anything if the segment is already in the queue
+		alreadyInserted = true
+	} else {
+		err = rows.Scan(&alreadyInserted)
+		if err != nil {
+			return false, Error.Wrap(err)
+		}
+	}
+	return alreadyInserted, Error.Wrap(rows.Err())
+}
+
+func (r *reencodeQueue) Select(ctx context.Context) (key metabase.SegmentKey, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var path []byte
+	switch r.db.implementation {
+	case dbutil.Cockroach:
+		err = r.db.QueryRowContext(ctx, `
+				UPDATE reencode_segments SET attempted = now()
+				WHERE attempted IS NULL OR attempted < now() - interval '6 hours'
+				ORDER BY inserted_at ASC
+				LIMIT 1
+				RETURNING path`).Scan(&path)
+	case dbutil.Postgres:
+		err = r.db.QueryRowContext(ctx, `
+				UPDATE reencode_segments SET attempted = now() WHERE path = (
+					SELECT path FROM reencode_segments
+					WHERE attempted IS NULL OR attempted < now() - interval '6 hours'
+					ORDER BY attempted NULLS FIRST, inserted_at ASC FOR UPDATE SKIP LOCKED LIMIT 1
+				) RETURNING path`).Scan(&path)
+	default:
+		return nil, errs.New("invalid dbType: %v", r.db.implementation)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrEmptyQueue.New("")
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return metabase.SegmentKey(path), nil
+}
+
+func (r *reencodeQueue) Delete(ctx context.Context, key metabase.SegmentKey) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	_, err = r.db.ExecContext(ctx, `DELETE FROM reencode_segments WHERE path = $1`, []byte(key))
+	return Error.Wrap(err)
+}
+
+func (r *reencodeQueue) Count(ctx context.Context) (count int, err error) {
+	defer mon.Task()(&ctx)(&err)
+	err = r.db.QueryRowContext(ctx, `SELECT count(*) FROM reencode_segments`).Scan(&count)
+	return count, Error.Wrap(err)
+}
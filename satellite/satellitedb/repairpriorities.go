@@ -0,0 +1,85 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/repair"
+)
+
+// ensure that repairPriorities implements repair.PriorityClasses.
+var _ repair.PriorityClasses = (*repairPriorities)(nil)
+
+type repairPriorities struct {
+	db *satelliteDB
+}
+
+// Set sets the priority class of a bucket, or of the whole project when bucketName is empty.
+func (priorities *repairPriorities) Set(ctx context.Context, projectID uuid.UUID, bucketName string, class repair.PriorityClass) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = priorities.db.ExecContext(ctx, `
+		INSERT INTO repair_priorities ( project_id, bucket_name, class, updated_at )
+		VALUES ( $1, $2, $3, now() )
+		ON CONFLICT ( project_id, bucket_name )
+		DO UPDATE SET class = EXCLUDED.class, updated_at = EXCLUDED.updated_at
+	`, projectID, []byte(bucketName), int(class))
+	return Error.Wrap(err)
+}
+
+// Delete removes the priority class of a bucket, or of the whole project when bucketName is empty.
+func (priorities *repairPriorities) Delete(ctx context.Context, projectID uuid.UUID, bucketName string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = priorities.db.ExecContext(ctx, `
+		DELETE FROM repair_priorities WHERE project_id = $1 AND bucket_name = $2
+	`, projectID, []byte(bucketName))
+	return Error.Wrap(err)
+}
+
+// List returns the priority classes of all projects and buckets.
+func (priorities *repairPriorities) List(ctx context.Context) (_ []repair.BucketPriority, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return priorities.query(ctx, `
+		SELECT project_id, bucket_name, class FROM repair_priorities
+		ORDER BY project_id, bucket_name
+	`)
+}
+
+// ListProject returns the priority classes of a project and its buckets.
+func (priorities *repairPriorities) ListProject(ctx context.Context, projectID uuid.UUID) (_ []repair.BucketPriority, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return priorities.query(ctx, `
+		SELECT project_id, bucket_name, class FROM repair_priorities
+		WHERE project_id = $1
+		ORDER BY bucket_name
+	`, projectID)
+}
+
+func (priorities *repairPriorities) query(ctx context.Context, query string, args ...interface{}) (list []repair.BucketPriority, err error) {
+	rows, err := priorities.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		var priority repair.BucketPriority
+		var bucketName []byte
+		var class int
+		if err := rows.Scan(&priority.ProjectID, &bucketName, &class); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		priority.BucketName = string(bucketName)
+		priority.Class = repair.PriorityClass(class)
+		list = append(list, priority)
+	}
+	return list, Error.Wrap(rows.Err())
+}
@@ -24,9 +24,9 @@ type repairQueue struct {
 	db *satelliteDB
 }
 
-func (r *repairQueue) Insert(ctx context.Context, seg *internalpb.InjuredSegment, segmentHealth float64) (alreadyInserted bool, err error) {
+func (r *repairQueue) Insert(ctx context.Context, seg *internalpb.InjuredSegment, segmentHealth, priority float64) (alreadyInserted bool, err error) {
 	defer mon.Task()(&ctx)(&err)
-	// insert if not exists, or update healthy count if does exist
+	// insert if not exists, or update segment health and priority if does exist
 	var query string
 
 	// we want to insert the segment if it is not in the queue, but update the segment health if it already is in the queue
@@ -37,29 +37,29 @@ func (r *repairQueue) Insert(ctx context.Context, seg *internalpb.InjuredSegment
 		query = `
 			INSERT INTO injuredsegments
 			(
-				path, data, segment_health
+				path, data, segment_health, priority
 			)
 			VALUES (
-				$1, $2, $3
+				$1, $2, $3, $4
 			)
 			ON CONFLICT (path)
 			DO UPDATE
-			SET segment_health=$3, updated_at=current_timestamp
+			SET segment_health=$3, priority=$4, updated_at=current_timestamp
 			RETURNING (xmax != 0) AS alreadyInserted
 		`
 	case dbutil.Cockroach:
 		query = `
 			WITH updater AS (
-				UPDATE injuredsegments SET segment_health = $3, updated_at = current_timestamp WHERE path = $1
+				UPDATE injuredsegments SET segment_health = $3, priority = $4, updated_at = current_timestamp WHERE path = $1
 				RETURNING *
 			)
-			INSERT INTO injuredsegments (path, data, segment_health)
-			SELECT $1, $2, $3
+			INSERT INTO injuredsegments (path, data, segment_health, priority)
+			SELECT $1, $2, $3, $4
 			WHERE NOT EXISTS (SELECT * FROM updater)
 			RETURNING false
 		`
 	}
-	rows, err := r.db.QueryContext(ctx, query, seg.Path, seg, segmentHealth)
+	rows, err := r.db.QueryContext(ctx, query, seg.Path, seg, segmentHealth, priority)
 	if err != nil {
 		return false, err
 	}
@@ -85,6 +85,7 @@ func (r *repairQueue) Select(ctx context.Context) (seg *internalpb.InjuredSegmen
 		err = r.db.QueryRowContext(ctx, `
 				UPDATE injuredsegments SET attempted = now()
 				WHERE attempted IS NULL OR attempted < now() - interval '6 hours'
+				ORDER BY priority ASC
 				LIMIT 1
 				RETURNING data`).Scan(&seg)
 	case dbutil.Postgres:
@@ -92,7 +93,7 @@ func (r *repairQueue) Select(ctx context.Context) (seg *internalpb.InjuredSegmen
 				UPDATE injuredsegments SET attempted = now() WHERE path = (
 					SELECT path FROM injuredsegments
 					WHERE attempted IS NULL OR attempted < now() - interval '6 hours'
-					ORDER BY segment_health ASC, attempted NULLS FIRST FOR UPDATE SKIP LOCKED LIMIT 1
+					ORDER BY priority ASC, attempted NULLS FIRST FOR UPDATE SKIP LOCKED LIMIT 1
 				) RETURNING data`).Scan(&seg)
 	default:
 		return seg, errs.New("invalid dbType: %v", r.db.implementation)
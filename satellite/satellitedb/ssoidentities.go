@@ -0,0 +1,44 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+
+	"storj.io/common/uuid"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/dbx"
+)
+
+// ensures that ssoIdentities implements console.SSOIdentities.
+var _ console.SSOIdentities = (*ssoIdentities)(nil)
+
+// ssoIdentities is an implementation of console.SSOIdentities.
+type ssoIdentities struct {
+	db dbx.Methods
+}
+
+// GetUserID implements console.SSOIdentities.
+func (identities *ssoIdentities) GetUserID(ctx context.Context, provider, subject string) (_ uuid.UUID, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	row, err := identities.db.Get_SsoIdentity_UserId_By_Provider_And_Subject(ctx,
+		dbx.SsoIdentity_Provider(provider),
+		dbx.SsoIdentity_Subject(subject))
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	return uuid.FromBytes(row.UserId)
+}
+
+// Insert implements console.SSOIdentities.
+func (identities *ssoIdentities) Insert(ctx context.Context, provider, subject string, userID uuid.UUID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return identities.db.CreateNoReturn_SsoIdentity(ctx,
+		dbx.SsoIdentity_Provider(provider),
+		dbx.SsoIdentity_Subject(subject),
+		dbx.SsoIdentity_UserId(userID[:]))
+}
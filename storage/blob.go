@@ -99,6 +99,8 @@ type Blobs interface {
 	FreeSpace() (int64, error)
 	// CheckWritability tests writability of the storage directory by creating and deleting a file.
 	CheckWritability() error
+	// ProbeWrite tests that data reaches the disk of the storage directory by writing, syncing and deleting a file.
+	ProbeWrite() error
 	// SpaceUsedForTrash returns the total space used by the trash.
 	SpaceUsedForTrash(ctx context.Context) (int64, error)
 	// SpaceUsedForBlobs adds up how much is used in all namespaces.
@@ -6,6 +6,7 @@ package filestore
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/base32"
 	"errors"
 	"io"
@@ -217,6 +218,48 @@ func (dir *Dir) blobToGarbagePath(ref storage.BlobRef) string {
 // Commit commits the temporary file to permanent storage.
 func (dir *Dir) Commit(ctx context.Context, file *os.File, ref storage.BlobRef, formatVersion storage.FormatVersion) (err error) {
 	defer mon.Task()(&ctx)(&err)
+	if err := closeTemporaryFile(file); err != nil {
+		return err
+	}
+
+	path, err := dir.blobToBasePath(ref)
+	if err != nil {
+		removeErr := os.Remove(file.Name())
+		return errs.Combine(err, removeErr)
+	}
+	path = blobPathForFormatVersion(path, formatVersion)
+
+	return commitToPath(file, path)
+}
+
+// CommitToTrash commits the temporary file directly into the trash directory. The
+// modification time of the committed file is set to trashedAt, so that EmptyTrash
+// treats the blob as if it was trashed at that time.
+func (dir *Dir) CommitToTrash(ctx context.Context, file *os.File, ref storage.BlobRef, formatVersion storage.FormatVersion, trashedAt time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if err := closeTemporaryFile(file); err != nil {
+		return err
+	}
+
+	chtimesErr := os.Chtimes(file.Name(), trashedAt, trashedAt)
+	if chtimesErr != nil {
+		removeErr := os.Remove(file.Name())
+		return errs.Combine(chtimesErr, removeErr)
+	}
+
+	path, err := dir.refToDirPath(ref, dir.trashdir())
+	if err != nil {
+		removeErr := os.Remove(file.Name())
+		return errs.Combine(err, removeErr)
+	}
+	path = blobPathForFormatVersion(path, formatVersion)
+
+	return commitToPath(file, path)
+}
+
+// closeTemporaryFile truncates the temporary file at the current position, syncs and
+// closes it. The file is removed when any of these steps fail.
+func closeTemporaryFile(file *os.File) error {
 	position, seekErr := file.Seek(0, io.SeekCurrent)
 	truncErr := file.Truncate(position)
 	syncErr := file.Sync()
@@ -227,14 +270,11 @@ func (dir *Dir) Commit(ctx context.Context, file *os.File, ref storage.BlobRef,
 		removeErr := os.Remove(file.Name())
 		return errs.Combine(seekErr, truncErr, syncErr, chmodErr, closeErr, removeErr)
 	}
+	return nil
+}
 
-	path, err := dir.blobToBasePath(ref)
-	if err != nil {
-		removeErr := os.Remove(file.Name())
-		return errs.Combine(err, removeErr)
-	}
-	path = blobPathForFormatVersion(path, formatVersion)
-
+// commitToPath moves an already synced and closed temporary file to path.
+func commitToPath(file *os.File, path string) error {
 	mkdirErr := os.MkdirAll(filepath.Dir(path), dirPermission)
 	if os.IsExist(mkdirErr) {
 		mkdirErr = nil
@@ -646,6 +686,13 @@ func (dir *Dir) ListNamespaces(ctx context.Context) (ids [][]byte, err error) {
 	return dir.listNamespacesInPath(ctx, dir.blobsdir())
 }
 
+// ListTrashNamespaces finds all namespace IDs which have a directory in the trash. They are
+// not guaranteed to contain any blobs.
+func (dir *Dir) ListTrashNamespaces(ctx context.Context) (ids [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return dir.listNamespacesInPath(ctx, dir.trashdir())
+}
+
 func (dir *Dir) listNamespacesInPath(ctx context.Context, path string) (ids [][]byte, err error) {
 	defer mon.Task()(&ctx)(&err)
 	openDir, err := os.Open(path)
@@ -685,6 +732,15 @@ func (dir *Dir) WalkNamespace(ctx context.Context, namespace []byte, walkFunc fu
 	return dir.walkNamespaceInPath(ctx, namespace, dir.blobsdir(), walkFunc)
 }
 
+// WalkTrashNamespace executes walkFunc for each blob in the trash for the given namespace. The
+// modification time reported by Stat on each blob is the time it was moved to the trash. If
+// walkFunc returns a non-nil error, WalkTrashNamespace will stop iterating and return the error
+// immediately.
+func (dir *Dir) WalkTrashNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return dir.walkNamespaceInPath(ctx, namespace, dir.trashdir(), walkFunc)
+}
+
 func (dir *Dir) walkNamespaceInPath(ctx context.Context, namespace []byte, path string, walkFunc func(storage.BlobInfo) error) (err error) {
 	defer mon.Task()(&ctx)(&err)
 	namespaceDir := pathEncoding.EncodeToString(namespace)
@@ -836,13 +892,44 @@ type DiskInfo struct {
 
 // Info returns information about the current state of the dir.
 func (dir *Dir) Info() (DiskInfo, error) {
-	path, err := filepath.Abs(dir.path)
+	return DiskInfoFromPath(dir.path)
+}
+
+// DiskInfoFromPath returns information about the disk containing path.
+func DiskInfoFromPath(path string) (DiskInfo, error) {
+	path, err := filepath.Abs(path)
 	if err != nil {
 		return DiskInfo{}, err
 	}
 	return diskInfoFromPath(path)
 }
 
+// writeProbeSize is the amount of data written when probing writes.
+const writeProbeSize = 64 * 1024
+
+// ProbeWrite tests that data can be written to and synced in path by writing
+// a temporary file, which is removed afterwards.
+func ProbeWrite(path string) (err error) {
+	f, err := ioutil.TempFile(path, "write-probe")
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, os.Remove(f.Name())) }()
+
+	// random data, so it can't be compressed away by the file system
+	data := make([]byte, writeProbeSize)
+	if _, err := rand.Read(data); err != nil {
+		return errs.Combine(err, f.Close())
+	}
+	if _, err := f.Write(data); err != nil {
+		return errs.Combine(err, f.Close())
+	}
+	if err := f.Sync(); err != nil {
+		return errs.Combine(err, f.Close())
+	}
+	return f.Close()
+}
+
 type blobInfo struct {
 	ref           storage.BlobRef
 	path          string
@@ -267,6 +267,11 @@ func (store *blobStore) CheckWritability() error {
 	return os.Remove(f.Name())
 }
 
+// ProbeWrite tests that data reaches the disk of the storage directory by writing, syncing and deleting a file.
+func (store *blobStore) ProbeWrite() error {
+	return ProbeWrite(store.dir.Path())
+}
+
 // ListNamespaces finds all known namespace IDs in use in local storage. They are not
 // guaranteed to contain any blobs.
 func (store *blobStore) ListNamespaces(ctx context.Context) (ids [][]byte, err error) {
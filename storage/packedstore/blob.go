@@ -0,0 +1,179 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packedstore
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+// blobReader implements reading blobs from a log file.
+type blobReader struct {
+	*io.SectionReader
+	file          *os.File
+	formatVersion storage.FormatVersion
+}
+
+func newBlobReader(file *os.File, offset, size int64, formatVersion storage.FormatVersion) *blobReader {
+	return &blobReader{
+		SectionReader: io.NewSectionReader(file, offset, size),
+		file:          file,
+		formatVersion: formatVersion,
+	}
+}
+
+// Size returns how large is the blob.
+func (blob *blobReader) Size() (int64, error) {
+	return blob.SectionReader.Size(), nil
+}
+
+// StorageFormatVersion gets the storage format version being used by the blob.
+func (blob *blobReader) StorageFormatVersion() storage.FormatVersion {
+	return blob.formatVersion
+}
+
+// Close closes the underlying log file handle.
+func (blob *blobReader) Close() error {
+	return blob.file.Close()
+}
+
+// blobWriter implements writing blobs. The content is written to a temporary file,
+// which is appended to the active log on commit.
+type blobWriter struct {
+	ref           storage.BlobRef
+	store         *blobStore
+	closed        bool
+	formatVersion storage.FormatVersion
+	buffer        *bufio.Writer
+	fh            *os.File
+}
+
+func newBlobWriter(ref storage.BlobRef, store *blobStore, formatVersion storage.FormatVersion, file *os.File, bufferSize int) *blobWriter {
+	return &blobWriter{
+		ref:           ref,
+		store:         store,
+		closed:        false,
+		formatVersion: formatVersion,
+		buffer:        bufio.NewWriterSize(file, bufferSize),
+		fh:            file,
+	}
+}
+
+// Write adds data to the blob.
+func (blob *blobWriter) Write(p []byte) (int, error) {
+	return blob.buffer.Write(p)
+}
+
+// Cancel discards the blob.
+func (blob *blobWriter) Cancel(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return nil
+	}
+	blob.closed = true
+
+	err = blob.fh.Close()
+	removeErr := os.Remove(blob.fh.Name())
+	return Error.Wrap(errs.Combine(err, removeErr))
+}
+
+// Commit appends the blob to the active log. The content of the blob ends at the
+// current position.
+func (blob *blobWriter) Commit(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if blob.closed {
+		return Error.New("already closed")
+	}
+	blob.closed = true
+
+	defer func() {
+		err = errs.Combine(err, blob.fh.Close(), os.Remove(blob.fh.Name()))
+	}()
+
+	size, err := blob.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	return Error.Wrap(blob.store.commit(ctx, blob.ref, blob.formatVersion, blob.fh, size))
+}
+
+// Seek flushes any buffer and seeks the underlying file.
+func (blob *blobWriter) Seek(offset int64, whence int) (int64, error) {
+	if err := blob.buffer.Flush(); err != nil {
+		return 0, err
+	}
+
+	return blob.fh.Seek(offset, whence)
+}
+
+// Size returns how much has been written so far.
+func (blob *blobWriter) Size() (int64, error) {
+	return blob.Seek(0, io.SeekCurrent)
+}
+
+// StorageFormatVersion indicates what storage format version the blob is using.
+func (blob *blobWriter) StorageFormatVersion() storage.FormatVersion {
+	return blob.formatVersion
+}
+
+// blobInfo allows inspecting a blob during iteration.
+type blobInfo struct {
+	ref           storage.BlobRef
+	path          string
+	size          int64
+	modTime       time.Time
+	formatVersion storage.FormatVersion
+}
+
+func newBlobInfo(ref storage.BlobRef, path string, size int64, modTime time.Time, formatVer storage.FormatVersion) storage.BlobInfo {
+	return &blobInfo{
+		ref:           ref,
+		path:          path,
+		size:          size,
+		modTime:       modTime,
+		formatVersion: formatVer,
+	}
+}
+
+func (info *blobInfo) BlobRef() storage.BlobRef {
+	return info.ref
+}
+
+func (info *blobInfo) StorageFormatVersion() storage.FormatVersion {
+	return info.formatVersion
+}
+
+// Stat returns the metadata of the blob. The size is the size of the blob and the
+// modification time is the time the blob was committed.
+func (info *blobInfo) Stat(ctx context.Context) (os.FileInfo, error) {
+	return &fileInfo{info: info}, nil
+}
+
+// FullPath returns the path of the log file containing the blob.
+func (info *blobInfo) FullPath(ctx context.Context) (string, error) {
+	return info.path, nil
+}
+
+// fileInfo implements os.FileInfo for a blob inside a log file.
+type fileInfo struct {
+	info *blobInfo
+}
+
+func (fi *fileInfo) Name() string       { return filepath.Base(fi.info.path) }
+func (fi *fileInfo) Size() int64        { return fi.info.size }
+func (fi *fileInfo) Mode() os.FileMode  { return 0600 }
+func (fi *fileInfo) ModTime() time.Time { return fi.info.modTime }
+func (fi *fileInfo) IsDir() bool        { return false }
+func (fi *fileInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,224 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packedstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+const (
+	indexMagic   = 0x504b4958 // "PKIX"
+	indexVersion = 1
+
+	indexFileName = "index"
+)
+
+// The index file is a snapshot of the in-memory index. It contains every blob and every
+// log at the time it was written and the position of the end of the active log at that
+// time. Opening the store loads the snapshot and only replays the records appended after
+// that position, instead of scanning every log.
+//
+//   magic          uint32
+//   version        uint8
+//   log            uint64  active log when the snapshot was taken
+//   offset         int64   size of the active log when the snapshot was taken
+//   logCount       uint32
+//     seq          uint64
+//     size         int64
+//   namespaceCount uint32
+//     namespaceLen uint16
+//     namespace    []byte
+//     entryCount   uint32
+//       keyLen     uint16
+//       key        []byte
+//       formatVer  uint8
+//       log        uint64
+//       offset     int64
+//       size       int64
+//       created    int64   unix nanoseconds
+//       trashedAt  int64   unix nanoseconds, 0 when the blob is not in the trash
+//   crc            uint32  checksum of everything before it
+
+// indexWriter writes the fields of the index file and keeps track of the checksum.
+type indexWriter struct {
+	w   *bufio.Writer
+	crc hash.Hash32
+	buf [8]byte
+}
+
+func (iw *indexWriter) write(p []byte) {
+	_, _ = iw.w.Write(p)
+	_, _ = iw.crc.Write(p)
+}
+
+func (iw *indexWriter) uint8(v uint8) {
+	iw.write([]byte{v})
+}
+
+func (iw *indexWriter) uint16(v uint16) {
+	binary.BigEndian.PutUint16(iw.buf[:], v)
+	iw.write(iw.buf[:2])
+}
+
+func (iw *indexWriter) uint32(v uint32) {
+	binary.BigEndian.PutUint32(iw.buf[:], v)
+	iw.write(iw.buf[:4])
+}
+
+func (iw *indexWriter) uint64(v uint64) {
+	binary.BigEndian.PutUint64(iw.buf[:], v)
+	iw.write(iw.buf[:8])
+}
+
+func (iw *indexWriter) bytes(v []byte) {
+	iw.uint16(uint16(len(v)))
+	iw.write(v)
+}
+
+// writeIndex writes the snapshot of logs and namespaces, taken at pos, to w.
+func writeIndex(w io.Writer, pos recordPos, logs map[uint64]*logFile, namespaces map[string]map[blobKey]*entry) error {
+	iw := &indexWriter{w: bufio.NewWriterSize(w, 256*1024), crc: crc32.NewIEEE()}
+
+	iw.uint32(indexMagic)
+	iw.uint8(indexVersion)
+	iw.uint64(pos.log)
+	iw.uint64(uint64(pos.offset))
+
+	iw.uint32(uint32(len(logs)))
+	for seq, log := range logs {
+		iw.uint64(seq)
+		iw.uint64(uint64(log.size))
+	}
+
+	iw.uint32(uint32(len(namespaces)))
+	for namespace, blobs := range namespaces {
+		iw.bytes([]byte(namespace))
+		iw.uint32(uint32(len(blobs)))
+		for key, e := range blobs {
+			iw.bytes([]byte(key.key))
+			iw.uint8(uint8(key.formatVer))
+			iw.uint64(e.pos.log)
+			iw.uint64(uint64(e.pos.offset))
+			iw.uint64(uint64(e.size))
+			iw.uint64(uint64(e.created.UnixNano()))
+			var trashedAt int64
+			if e.trashed {
+				trashedAt = e.trashedAt.UnixNano()
+			}
+			iw.uint64(uint64(trashedAt))
+		}
+	}
+
+	var crc [4]byte
+	binary.BigEndian.PutUint32(crc[:], iw.crc.Sum32())
+	_, _ = iw.w.Write(crc[:])
+	return iw.w.Flush()
+}
+
+// indexReader reads the fields of the index file and keeps track of the checksum.
+type indexReader struct {
+	r   *bufio.Reader
+	crc hash.Hash32
+	buf [8]byte
+	err error
+}
+
+func (ir *indexReader) read(p []byte) {
+	if ir.err != nil {
+		return
+	}
+	if _, err := io.ReadFull(ir.r, p); err != nil {
+		ir.err = err
+		return
+	}
+	_, _ = ir.crc.Write(p)
+}
+
+func (ir *indexReader) uint8() uint8 {
+	ir.read(ir.buf[:1])
+	return ir.buf[0]
+}
+
+func (ir *indexReader) uint16() uint16 {
+	ir.read(ir.buf[:2])
+	return binary.BigEndian.Uint16(ir.buf[:])
+}
+
+func (ir *indexReader) uint32() uint32 {
+	ir.read(ir.buf[:4])
+	return binary.BigEndian.Uint32(ir.buf[:])
+}
+
+func (ir *indexReader) uint64() uint64 {
+	ir.read(ir.buf[:8])
+	return binary.BigEndian.Uint64(ir.buf[:])
+}
+
+func (ir *indexReader) bytes() []byte {
+	v := make([]byte, ir.uint16())
+	ir.read(v)
+	return v
+}
+
+// readIndex reads the snapshot from the index file at path.
+func readIndex(path string) (pos recordPos, logs map[uint64]*logFile, namespaces map[string]map[blobKey]*entry, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return pos, nil, nil, err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	ir := &indexReader{r: bufio.NewReaderSize(file, 256*1024), crc: crc32.NewIEEE()}
+
+	if ir.uint32() != indexMagic || ir.uint8() != indexVersion {
+		return pos, nil, nil, errs.Combine(Error.New("invalid index header"), ir.err)
+	}
+	pos.log = ir.uint64()
+	pos.offset = int64(ir.uint64())
+
+	logs = make(map[uint64]*logFile)
+	for i, n := 0, ir.uint32(); i < int(n) && ir.err == nil; i++ {
+		seq := ir.uint64()
+		logs[seq] = &logFile{seq: seq, size: int64(ir.uint64())}
+	}
+
+	namespaces = make(map[string]map[blobKey]*entry)
+	for i, n := 0, ir.uint32(); i < int(n) && ir.err == nil; i++ {
+		namespace := ir.bytes()
+		blobs := make(map[blobKey]*entry)
+		for k, m := 0, ir.uint32(); k < int(m) && ir.err == nil; k++ {
+			rec := record{namespace: namespace, key: ir.bytes(), formatVer: storage.FormatVersion(ir.uint8())}
+			blobPos := recordPos{log: ir.uint64(), offset: int64(ir.uint64())}
+			rec.dataLen = int64(ir.uint64())
+			rec.time = time.Unix(0, int64(ir.uint64()))
+
+			e := newEntry(blobPos, rec)
+			if trashedAt := int64(ir.uint64()); trashedAt != 0 {
+				e.trashed, e.trashedAt = true, time.Unix(0, trashedAt)
+			}
+			blobs[blobKey{key: string(rec.key), formatVer: rec.formatVer}] = e
+		}
+		namespaces[string(namespace)] = blobs
+	}
+
+	expected := ir.crc.Sum32()
+	var crc [4]byte
+	if _, err := io.ReadFull(ir.r, crc[:]); err != nil || ir.err != nil {
+		return pos, nil, nil, errs.Combine(Error.New("truncated index"), ir.err, err)
+	}
+	if binary.BigEndian.Uint32(crc[:]) != expected {
+		return pos, nil, nil, Error.New("invalid index checksum")
+	}
+	return pos, logs, namespaces, nil
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packedstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/storage"
+)
+
+// recordKind describes what a log record does.
+type recordKind byte
+
+const (
+	// recordBlob stores the content of a blob.
+	recordBlob recordKind = 1
+	// recordDelete removes the target blob record.
+	recordDelete recordKind = 2
+	// recordTrash moves the target blob record to the trash.
+	recordTrash recordKind = 3
+	// recordRestore restores the target blob record from the trash.
+	recordRestore recordKind = 4
+)
+
+const (
+	recordMagic = 0x504b // "PK"
+
+	// recordHeaderSize is the size of the fixed part of a record, which is followed by
+	// the namespace, the key and, for blob records, the blob data.
+	//
+	//   magic        uint16
+	//   kind         uint8
+	//   formatVer    uint8
+	//   namespaceLen uint16
+	//   keyLen       uint16
+	//   dataLen      int64
+	//   time         int64   unix nanoseconds; creation or trash time
+	//   targetLog    uint64  log of the blob record targeted by delete, trash and restore
+	//   targetOffset int64   offset of the blob record targeted by delete, trash and restore
+	//   crc          uint32  checksum of the header fields, the namespace and the key
+	recordHeaderSize = 44
+
+	logFileSuffix = ".log"
+)
+
+// recordPos identifies a record by the log it is in and its offset in the log.
+type recordPos struct {
+	log    uint64
+	offset int64
+}
+
+// record is a single entry in a log file.
+type record struct {
+	kind      recordKind
+	formatVer storage.FormatVersion
+	namespace []byte
+	key       []byte
+	dataLen   int64
+	time      time.Time
+	target    recordPos
+}
+
+// size returns the number of bytes the record takes in the log.
+func (rec *record) size() int64 {
+	return recordHeaderSize + int64(len(rec.namespace)) + int64(len(rec.key)) + rec.dataLen
+}
+
+// dataOffset returns the offset of the blob data relative to the start of the record.
+func (rec *record) dataOffset() int64 {
+	return recordHeaderSize + int64(len(rec.namespace)) + int64(len(rec.key))
+}
+
+// marshalHeader returns the header, namespace and key of the record.
+func (rec *record) marshalHeader() []byte {
+	buf := make([]byte, rec.dataOffset())
+	binary.BigEndian.PutUint16(buf[0:], recordMagic)
+	buf[2] = byte(rec.kind)
+	buf[3] = byte(rec.formatVer)
+	binary.BigEndian.PutUint16(buf[4:], uint16(len(rec.namespace)))
+	binary.BigEndian.PutUint16(buf[6:], uint16(len(rec.key)))
+	binary.BigEndian.PutUint64(buf[8:], uint64(rec.dataLen))
+	binary.BigEndian.PutUint64(buf[16:], uint64(rec.time.UnixNano()))
+	binary.BigEndian.PutUint64(buf[24:], rec.target.log)
+	binary.BigEndian.PutUint64(buf[32:], uint64(rec.target.offset))
+	copy(buf[recordHeaderSize:], rec.namespace)
+	copy(buf[recordHeaderSize+len(rec.namespace):], rec.key)
+
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write(buf[:40])
+	_, _ = crc.Write(buf[recordHeaderSize:])
+	binary.BigEndian.PutUint32(buf[40:], crc.Sum32())
+	return buf
+}
+
+// readRecord reads the header, namespace and key of a record from r. The blob data,
+// if any, is not read.
+func readRecord(r io.Reader) (rec record, err error) {
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return rec, err
+	}
+	if binary.BigEndian.Uint16(header[0:]) != recordMagic {
+		return rec, Error.New("invalid record magic")
+	}
+
+	rec.kind = recordKind(header[2])
+	rec.formatVer = storage.FormatVersion(header[3])
+	rec.namespace = make([]byte, binary.BigEndian.Uint16(header[4:]))
+	rec.key = make([]byte, binary.BigEndian.Uint16(header[6:]))
+	rec.dataLen = int64(binary.BigEndian.Uint64(header[8:]))
+	rec.time = time.Unix(0, int64(binary.BigEndian.Uint64(header[16:])))
+	rec.target.log = binary.BigEndian.Uint64(header[24:])
+	rec.target.offset = int64(binary.BigEndian.Uint64(header[32:]))
+
+	if _, err := io.ReadFull(r, rec.namespace); err != nil {
+		return rec, err
+	}
+	if _, err := io.ReadFull(r, rec.key); err != nil {
+		return rec, err
+	}
+
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write(header[:40])
+	_, _ = crc.Write(rec.namespace)
+	_, _ = crc.Write(rec.key)
+	if crc.Sum32() != binary.BigEndian.Uint32(header[40:]) {
+		return rec, Error.New("invalid record checksum")
+	}
+	if rec.kind < recordBlob || rec.kind > recordRestore || rec.dataLen < 0 || (rec.kind != recordBlob && rec.dataLen != 0) {
+		return rec, Error.New("invalid record")
+	}
+	return rec, nil
+}
+
+// scanLog calls fn for every record in the log file at path starting at offset start,
+// in order. It returns the offset after the last valid record, which is smaller than the
+// file size when the log ends with an incomplete or corrupted record.
+func scanLog(path string, start int64, fn func(offset int64, rec record) error) (end int64, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	end = start
+	reader := bufio.NewReader(file)
+	for end < stat.Size() {
+		rec, err := readRecord(reader)
+		if err != nil || end+rec.size() > stat.Size() {
+			return end, nil
+		}
+		if err := fn(end, rec); err != nil {
+			return end, err
+		}
+		if _, err := reader.Discard(int(rec.dataLen)); err != nil {
+			return end, nil
+		}
+		end += rec.size()
+	}
+	return end, nil
+}
+
+// logFile keeps track of a single log file.
+type logFile struct {
+	seq  uint64
+	path string
+	// size is the number of bytes used by records in the log.
+	size int64
+	// live is the number of bytes used by blob records which are still referenced.
+	live int64
+}
+
+// deadRatio returns the fraction of the log which can be reclaimed by compaction.
+func (log *logFile) deadRatio() float64 {
+	if log.size == 0 {
+		return 0
+	}
+	return float64(log.size-log.live) / float64(log.size)
+}
+
+// logFileName returns the file name of the log with the given sequence number.
+func logFileName(seq uint64) string {
+	return fmt.Sprintf("%016x%s", seq, logFileSuffix)
+}
+
+// listLogs returns the sequence numbers of the log files in dir, in order.
+func listLogs(dir string) (seqs []uint64, err error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+logFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		name := strings.TrimSuffix(filepath.Base(match), logFileSuffix)
+		seq, err := strconv.ParseUint(name, 16, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, k int) bool { return seqs[i] < seqs[k] })
+	return seqs, nil
+}
+
+// syncPath flushes the file at path to disk. Syncing through a separate handle doesn't
+// need any of the locks guarding the handle used for writing.
+func syncPath(path string) (err error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	return errs.Combine(file.Sync(), file.Close())
+}
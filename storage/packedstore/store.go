@@ -0,0 +1,1084 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packedstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+var (
+	// Error is the default packedstore error class.
+	Error = errs.Class("packedstore error")
+
+	mon = monkit.Package()
+
+	_ storage.Blobs = (*blobStore)(nil)
+)
+
+const (
+	dirPermission = 0700
+
+	verificationFileName = "storage-dir-verification"
+)
+
+// Config is configuration for the packed blob store.
+type Config struct {
+	WriteBufferSize     memory.Size `help:"in-memory buffer for uploads" default:"128KiB"`
+	MaxLogSize          memory.Size `help:"size at which a new log file is started" default:"1GiB"`
+	CompactionThreshold float64     `help:"fraction of reclaimable space in a log file at which it is compacted" default:"0.25"`
+}
+
+// DefaultConfig is the default value for Config.
+var DefaultConfig = Config{
+	WriteBufferSize:     128 * memory.KiB,
+	MaxLogSize:          memory.GiB,
+	CompactionThreshold: 0.25,
+}
+
+// blobKey identifies a blob within a namespace.
+type blobKey struct {
+	key       string
+	formatVer storage.FormatVersion
+}
+
+// entry is the index entry of a blob.
+type entry struct {
+	pos        recordPos
+	recordSize int64
+	dataOffset int64
+	size       int64
+	created    time.Time
+	trashed    bool
+	trashedAt  time.Time
+}
+
+// newEntry creates the index entry for the blob record rec stored at pos.
+func newEntry(pos recordPos, rec record) *entry {
+	return &entry{
+		pos:        pos,
+		recordSize: rec.size(),
+		dataOffset: pos.offset + rec.dataOffset(),
+		size:       rec.dataLen,
+		created:    rec.time,
+	}
+}
+
+// blobStore implements a blob store, which packs blobs into large append-only log
+// files instead of storing every blob in a separate file.
+//
+// Every change (a new blob, a deletion, moving a blob to the trash or restoring it) is
+// appended as a record to the active log file. The index of all blobs is kept in memory.
+// A snapshot of it is saved to the index file whenever a new log is started, after
+// compaction and when the store is closed; opening the store loads the snapshot and
+// replays the records appended after it. Space used by deleted blobs is reclaimed by
+// compaction, which copies the blobs that are still in use from a log file to the active
+// log file and removes the old log file.
+//
+// Changes are serialized by appendMu, which is held while blob data is copied to the
+// active log and synced. The index itself is guarded by mu, which is only held to look
+// up or apply a change, so reads don't wait for uploads or compaction.
+type blobStore struct {
+	log    *zap.Logger
+	path   string
+	config Config
+
+	// appendMu is held while appending records and applying them to the index. The
+	// index only changes while appendMu is held, so holding it is enough for reading.
+	appendMu  sync.Mutex
+	active    *os.File
+	activeLog *logFile
+	rotated   bool
+
+	// indexMu serializes saving the index.
+	indexMu sync.Mutex
+	// compactMu serializes compactions.
+	compactMu sync.Mutex
+
+	// mu must be held together with appendMu to change logs and namespaces, and on
+	// its own to read them.
+	mu         sync.Mutex
+	logs       map[uint64]*logFile
+	namespaces map[string]map[blobKey]*entry
+}
+
+// NewAt creates a new packed blob store in the specified directory.
+func NewAt(log *zap.Logger, path string, config Config) (storage.Blobs, error) {
+	store := newBlobStore(log, path, config)
+	err := errs.Combine(
+		os.MkdirAll(store.logsdir(), dirPermission),
+		os.MkdirAll(store.tempdir(), dirPermission),
+	)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return store, Error.Wrap(store.load())
+}
+
+// OpenAt opens an existing packed blob store in the specified directory.
+func OpenAt(log *zap.Logger, path string, config Config) (storage.Blobs, error) {
+	store := newBlobStore(log, path, config)
+	for _, dir := range []string{store.logsdir(), store.tempdir()} {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+	return store, Error.Wrap(store.load())
+}
+
+func newBlobStore(log *zap.Logger, path string, config Config) *blobStore {
+	return &blobStore{
+		log:        log,
+		path:       path,
+		config:     config,
+		logs:       make(map[uint64]*logFile),
+		namespaces: make(map[string]map[blobKey]*entry),
+	}
+}
+
+// logsdir is the sub-directory containing the log files.
+func (store *blobStore) logsdir() string { return filepath.Join(store.path, "packed") }
+
+// tempdir is used for blobs which are being written.
+func (store *blobStore) tempdir() string { return filepath.Join(store.path, "temp") }
+
+// indexPath is the path of the index file.
+func (store *blobStore) indexPath() string { return filepath.Join(store.logsdir(), indexFileName) }
+
+// load loads the index and opens the active log. When the index file is missing or
+// doesn't match the logs, the index is rebuilt from all the log files.
+func (store *blobStore) load() (err error) {
+	// blobs which were being written when the store was closed are lost anyway.
+	partials, err := filepath.Glob(filepath.Join(store.tempdir(), "*.partial"))
+	if err != nil {
+		return err
+	}
+	for _, partial := range partials {
+		_ = os.Remove(partial)
+	}
+
+	seqs, err := listLogs(store.logsdir())
+	if err != nil {
+		return err
+	}
+
+	loaded := false
+	if pos, logs, namespaces, err := readIndex(store.indexPath()); err == nil {
+		store.logs, store.namespaces = logs, namespaces
+		if err := store.replay(seqs, pos); err != nil {
+			store.log.Warn("index doesn't match the logs, rebuilding it", zap.Error(err))
+		} else {
+			loaded = true
+		}
+	} else if !os.IsNotExist(err) {
+		store.log.Warn("unable to read index, rebuilding it", zap.Error(err))
+	}
+
+	if !loaded {
+		store.logs = make(map[uint64]*logFile)
+		store.namespaces = make(map[string]map[blobKey]*entry)
+		if err := store.replay(seqs, recordPos{}); err != nil {
+			return err
+		}
+	}
+
+	if len(seqs) == 0 {
+		return store.rotate()
+	}
+	return store.openActive(store.logs[seqs[len(seqs)-1]])
+}
+
+// replay applies the records of the logs in seqs, which were appended after from, to the
+// index. The index must contain the state at from; an empty index and a zero from replay
+// every log.
+func (store *blobStore) replay(seqs []uint64, from recordPos) error {
+	onDisk := make(map[uint64]bool, len(seqs))
+	for i, seq := range seqs {
+		onDisk[seq] = true
+
+		log, ok := store.logs[seq]
+		if !ok {
+			if seq < from.log {
+				return Error.New("log %d is missing from the index", seq)
+			}
+			log = &logFile{seq: seq}
+			store.logs[seq] = log
+		}
+		log.path = filepath.Join(store.logsdir(), logFileName(seq))
+
+		var start int64
+		switch {
+		case seq < from.log:
+			continue
+		case seq == from.log:
+			start = from.offset
+		}
+
+		end, err := scanLog(log.path, start, func(offset int64, rec record) error {
+			store.apply(recordPos{log: seq, offset: offset}, rec)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		log.size = end
+
+		stat, err := os.Stat(log.path)
+		if err != nil {
+			return err
+		}
+		if stat.Size() < start {
+			return Error.New("log %d is shorter than the index", seq)
+		}
+		if stat.Size() != end {
+			if i == len(seqs)-1 {
+				// the last record was not completely written before a crash.
+				store.log.Warn("truncating incomplete record at the end of log",
+					zap.String("Path", log.path), zap.Int64("Offset", end))
+				if err := os.Truncate(log.path, end); err != nil {
+					return err
+				}
+			} else {
+				store.log.Error("ignoring corrupted records in log",
+					zap.String("Path", log.path), zap.Int64("Offset", end))
+			}
+		}
+	}
+
+	// logs which were compacted after the index was saved are gone, all their blobs
+	// must have been copied by the replayed records.
+	for seq, log := range store.logs {
+		if onDisk[seq] {
+			log.live = 0
+			continue
+		}
+		delete(store.logs, seq)
+	}
+	for _, blobs := range store.namespaces {
+		for _, e := range blobs {
+			log, ok := store.logs[e.pos.log]
+			if !ok {
+				return Error.New("blob in missing log %d", e.pos.log)
+			}
+			log.live += e.recordSize
+		}
+	}
+	return nil
+}
+
+// saveIndex writes a snapshot of the index to the index file. The snapshot is taken while
+// holding appendMu, so that it is consistent, but reads can continue meanwhile. The logs
+// are synced up to the position of the snapshot before the index file is replaced.
+func (store *blobStore) saveIndex() (err error) {
+	store.indexMu.Lock()
+	defer store.indexMu.Unlock()
+
+	file, err := ioutil.TempFile(store.logsdir(), indexFileName+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	closed := false
+	defer func() {
+		if err != nil {
+			if !closed {
+				err = errs.Combine(err, file.Close())
+			}
+			err = errs.Combine(err, os.Remove(file.Name()))
+		}
+	}()
+
+	var activePath string
+	err = func() error {
+		store.appendMu.Lock()
+		defer store.appendMu.Unlock()
+
+		if store.activeLog == nil {
+			return Error.New("store is closed")
+		}
+		activePath = store.activeLog.path
+		pos := recordPos{log: store.activeLog.seq, offset: store.activeLog.size}
+		return writeIndex(file, pos, store.logs, store.namespaces)
+	}()
+	if err != nil {
+		return err
+	}
+
+	// older logs were synced when they were rotated.
+	if err := syncPath(activePath); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	closed = true
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), store.indexPath())
+}
+
+// change runs fn with appendMu held. When fn started a new log, the index is saved
+// afterwards, so that opening the store only has to replay the newest log.
+func (store *blobStore) change(fn func() error) error {
+	store.appendMu.Lock()
+	err := fn()
+	rotated := store.rotated
+	store.rotated = false
+	store.appendMu.Unlock()
+
+	if rotated {
+		if err := store.saveIndex(); err != nil {
+			store.log.Error("saving index failed", zap.Error(err))
+		}
+	}
+	return err
+}
+
+// openActive makes log the log where new records are appended.
+func (store *blobStore) openActive(log *logFile) error {
+	file, err := os.OpenFile(log.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(log.size, io.SeekStart); err != nil {
+		return errs.Combine(err, file.Close())
+	}
+	store.active, store.activeLog = file, log
+	return nil
+}
+
+// rotate closes the active log and starts a new one.
+//
+// store.appendMu must be held.
+func (store *blobStore) rotate() error {
+	var seq uint64 = 1
+	if store.activeLog != nil {
+		seq = store.activeLog.seq + 1
+		if err := errs.Combine(store.active.Sync(), store.active.Close()); err != nil {
+			return err
+		}
+		store.active, store.activeLog = nil, nil
+	}
+
+	log := &logFile{seq: seq, path: filepath.Join(store.logsdir(), logFileName(seq))}
+	if err := store.openActive(log); err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	store.logs[seq] = log
+	store.mu.Unlock()
+
+	store.rotated = true
+	return nil
+}
+
+// appendRecord appends rec followed by dataLen bytes from data to the active log. Blob
+// records are synced to disk before returning when sync is set; losing any other record
+// in a crash only means that a delete, trash or restore operation has to be repeated.
+//
+// store.appendMu must be held, store.mu must not be held.
+func (store *blobStore) appendRecord(rec record, data io.Reader, sync bool) (pos recordPos, err error) {
+	if store.active == nil {
+		return pos, Error.New("store is closed")
+	}
+	if store.activeLog.size >= store.config.MaxLogSize.Int64() {
+		if err := store.rotate(); err != nil {
+			return pos, err
+		}
+	}
+	pos = recordPos{log: store.activeLog.seq, offset: store.activeLog.size}
+
+	defer func() {
+		if err != nil {
+			// drop the partially written record.
+			_, seekErr := store.active.Seek(pos.offset, io.SeekStart)
+			err = errs.Combine(err, store.active.Truncate(pos.offset), seekErr)
+		}
+	}()
+
+	if _, err := store.active.Write(rec.marshalHeader()); err != nil {
+		return pos, err
+	}
+	if rec.kind == recordBlob {
+		if _, err := io.CopyN(store.active, data, rec.dataLen); err != nil {
+			return pos, err
+		}
+		if sync {
+			if err := store.active.Sync(); err != nil {
+				return pos, err
+			}
+		}
+	}
+
+	store.activeLog.size += rec.size()
+	return pos, nil
+}
+
+// publish applies a record appended at pos to the index.
+//
+// store.appendMu must be held.
+func (store *blobStore) publish(pos recordPos, rec record) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.apply(pos, rec)
+}
+
+// apply updates the index with a record stored at pos.
+//
+// store.appendMu and store.mu must be held.
+func (store *blobStore) apply(pos recordPos, rec record) {
+	key := blobKey{key: string(rec.key), formatVer: rec.formatVer}
+
+	if rec.kind == recordBlob {
+		blobs, ok := store.namespaces[string(rec.namespace)]
+		if !ok {
+			blobs = make(map[blobKey]*entry)
+			store.namespaces[string(rec.namespace)] = blobs
+		}
+		if previous, ok := blobs[key]; ok {
+			store.logs[previous.pos.log].live -= previous.recordSize
+		}
+		blobs[key] = newEntry(pos, rec)
+		store.logs[pos.log].live += rec.size()
+		return
+	}
+
+	e := store.namespaces[string(rec.namespace)][key]
+	if e == nil || e.pos != rec.target {
+		// the record refers to a blob which has been deleted or rewritten since.
+		return
+	}
+
+	switch rec.kind {
+	case recordDelete:
+		store.removeEntry(rec.namespace, key)
+	case recordTrash:
+		e.trashed = true
+		e.trashedAt = rec.time
+	case recordRestore:
+		e.trashed = false
+		e.trashedAt = time.Time{}
+	}
+}
+
+// removeEntry removes a blob from the index.
+//
+// store.appendMu and store.mu must be held.
+func (store *blobStore) removeEntry(namespace []byte, key blobKey) {
+	blobs := store.namespaces[string(namespace)]
+	if e, ok := blobs[key]; ok {
+		store.logs[e.pos.log].live -= e.recordSize
+		delete(blobs, key)
+	}
+	if len(blobs) == 0 {
+		delete(store.namespaces, string(namespace))
+	}
+}
+
+// update appends a delete, trash or restore record for the blob and applies it to the index.
+//
+// store.appendMu must be held.
+func (store *blobStore) update(kind recordKind, namespace []byte, key blobKey, e *entry, now time.Time) error {
+	rec := record{
+		kind:      kind,
+		formatVer: key.formatVer,
+		namespace: namespace,
+		key:       []byte(key.key),
+		time:      now,
+		target:    e.pos,
+	}
+	pos, err := store.appendRecord(rec, nil, false)
+	if err != nil {
+		return err
+	}
+	store.publish(pos, rec)
+	return nil
+}
+
+// lookup finds the newest stored format version of ref, which is not in the trash.
+//
+// store.mu or store.appendMu must be held.
+func (store *blobStore) lookup(ref storage.BlobRef) (*entry, storage.FormatVersion, bool) {
+	for formatVer := filestore.MaxFormatVersionSupported; formatVer >= filestore.MinFormatVersionSupported; formatVer-- {
+		if e, ok := store.lookupWithStorageFormat(ref, formatVer); ok {
+			return e, formatVer, true
+		}
+	}
+	return nil, 0, false
+}
+
+// lookupWithStorageFormat finds the blob with the given format version, which is not in
+// the trash.
+//
+// store.mu or store.appendMu must be held.
+func (store *blobStore) lookupWithStorageFormat(ref storage.BlobRef, formatVer storage.FormatVersion) (*entry, bool) {
+	e, ok := store.namespaces[string(ref.Namespace)][blobKey{key: string(ref.Key), formatVer: formatVer}]
+	if !ok || e.trashed {
+		return nil, false
+	}
+	return e, true
+}
+
+// logPath returns the path of the log file containing e.
+//
+// store.mu or store.appendMu must be held.
+func (store *blobStore) logPath(e *entry) string {
+	return store.logs[e.pos.log].path
+}
+
+// Close saves the index and closes the store.
+func (store *blobStore) Close() error {
+	indexErr := store.saveIndex()
+
+	store.appendMu.Lock()
+	defer store.appendMu.Unlock()
+
+	if store.active == nil {
+		return nil
+	}
+	err := errs.Combine(indexErr, store.active.Sync(), store.active.Close())
+	store.active, store.activeLog = nil, nil
+	return Error.Wrap(err)
+}
+
+// Create creates a new blob that can be written.
+// Optionally takes a size argument for performance improvements, -1 is unknown size.
+func (store *blobStore) Create(ctx context.Context, ref storage.BlobRef, size int64) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.create(ctx, ref, filestore.MaxFormatVersionSupported)
+}
+
+// TestCreateV0 creates a new V0 blob that can be written. This is ONLY appropriate in test situations.
+func (store *blobStore) TestCreateV0(ctx context.Context, ref storage.BlobRef) (_ storage.BlobWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.create(ctx, ref, filestore.FormatV0)
+}
+
+func (store *blobStore) create(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobWriter, err error) {
+	if !ref.IsValid() {
+		return nil, storage.ErrInvalidBlobRef.New("")
+	}
+	file, err := ioutil.TempFile(store.tempdir(), "blob-*.partial")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return newBlobWriter(ref, store, formatVer, file, store.config.WriteBufferSize.Int()), nil
+}
+
+// commit appends the first size bytes of file as the content of the blob.
+func (store *blobStore) commit(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion, file *os.File, size int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return store.change(func() error {
+		if store.active == nil {
+			return Error.New("store is closed")
+		}
+
+		rec := record{
+			kind:      recordBlob,
+			formatVer: formatVer,
+			namespace: ref.Namespace,
+			key:       ref.Key,
+			dataLen:   size,
+			time:      time.Now(),
+		}
+		pos, err := store.appendRecord(rec, file, true)
+		if err != nil {
+			return err
+		}
+		store.publish(pos, rec)
+		return nil
+	})
+}
+
+// Open loads blob with the specified hash.
+func (store *blobStore) Open(ctx context.Context, ref storage.BlobRef) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	e, formatVer, ok := store.lookup(ref)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return store.openEntry(e, formatVer)
+}
+
+// OpenWithStorageFormat loads the already-located blob, avoiding the potential need to check multiple
+// storage formats to find the blob.
+func (store *blobStore) OpenWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobReader, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	e, ok := store.lookupWithStorageFormat(ref, formatVer)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return store.openEntry(e, formatVer)
+}
+
+// openEntry opens a reader for the blob. The log file is opened while holding
+// store.mu, so that compaction can't remove it before it's opened.
+//
+// store.mu must be held.
+func (store *blobStore) openEntry(e *entry, formatVer storage.FormatVersion) (storage.BlobReader, error) {
+	file, err := os.Open(store.logPath(e))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return newBlobReader(file, e.dataOffset, e.size, formatVer), nil
+}
+
+// Stat looks up metadata of the blob.
+func (store *blobStore) Stat(ctx context.Context, ref storage.BlobRef) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	e, formatVer, ok := store.lookup(ref)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return newBlobInfo(ref, store.logPath(e), e.size, e.created, formatVer), nil
+}
+
+// StatWithStorageFormat looks up metadata of the blob with the given storage format version.
+func (store *blobStore) StatWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (_ storage.BlobInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	e, ok := store.lookupWithStorageFormat(ref, formatVer)
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return newBlobInfo(ref, store.logPath(e), e.size, e.created, formatVer), nil
+}
+
+// Delete deletes blobs with the specified ref.
+//
+// It doesn't return an error if the blob isn't found.
+func (store *blobStore) Delete(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var group errs.Group
+	for formatVer := filestore.MinFormatVersionSupported; formatVer <= filestore.MaxFormatVersionSupported; formatVer++ {
+		group.Add(store.DeleteWithStorageFormat(ctx, ref, formatVer))
+	}
+	return group.Err()
+}
+
+// DeleteWithStorageFormat deletes the blob with the specified ref and storage format version.
+func (store *blobStore) DeleteWithStorageFormat(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Error.Wrap(store.change(func() error {
+		e, ok := store.lookupWithStorageFormat(ref, formatVer)
+		if !ok {
+			return nil
+		}
+		key := blobKey{key: string(ref.Key), formatVer: formatVer}
+		return store.update(recordDelete, ref.Namespace, key, e, time.Now())
+	}))
+}
+
+// DeleteNamespace deletes all blobs of the namespace, which are not in the trash.
+func (store *blobStore) DeleteNamespace(ctx context.Context, namespace []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Error.Wrap(store.change(func() error {
+		now := time.Now()
+		for key, e := range store.namespaces[string(namespace)] {
+			if e.trashed {
+				continue
+			}
+			if err := store.update(recordDelete, namespace, key, e, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// Trash moves the blob with every storage format version to the trash.
+func (store *blobStore) Trash(ctx context.Context, ref storage.BlobRef) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return Error.Wrap(store.change(func() error {
+		now := time.Now()
+		for formatVer := filestore.MinFormatVersionSupported; formatVer <= filestore.MaxFormatVersionSupported; formatVer++ {
+			e, ok := store.lookupWithStorageFormat(ref, formatVer)
+			if !ok {
+				continue
+			}
+			key := blobKey{key: string(ref.Key), formatVer: formatVer}
+			if err := store.update(recordTrash, ref.Namespace, key, e, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// RestoreTrash moves every blob of the namespace in the trash back.
+func (store *blobStore) RestoreTrash(ctx context.Context, namespace []byte) (keysRestored [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = store.change(func() error {
+		now := time.Now()
+		for key, e := range store.namespaces[string(namespace)] {
+			if !e.trashed {
+				continue
+			}
+			if err := store.update(recordRestore, namespace, key, e, now); err != nil {
+				return err
+			}
+			keysRestored = append(keysRestored, []byte(key.key))
+		}
+		return nil
+	})
+	return keysRestored, Error.Wrap(err)
+}
+
+// EmptyTrash removes all blobs in the trash of the namespace, which were trashed before
+// trashedBefore. Afterwards the log files with enough reclaimable space are compacted.
+func (store *blobStore) EmptyTrash(ctx context.Context, namespace []byte, trashedBefore time.Time) (bytesEmptied int64, keys [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = store.change(func() error {
+		now := time.Now()
+		for key, e := range store.namespaces[string(namespace)] {
+			if !e.trashed || !e.trashedAt.Before(trashedBefore) {
+				continue
+			}
+			size := e.size
+			if err := store.update(recordDelete, namespace, key, e, now); err != nil {
+				return err
+			}
+			bytesEmptied += size
+			keys = append(keys, []byte(key.key))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, Error.Wrap(err)
+	}
+
+	if err := store.Compact(ctx); err != nil {
+		store.log.Error("compaction failed", zap.Error(err))
+	}
+	return bytesEmptied, keys, nil
+}
+
+// GarbageCollect compacts the log files with enough reclaimable space.
+func (store *blobStore) GarbageCollect(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return store.Compact(ctx)
+}
+
+// Compact rewrites every log file, which has at least the configured fraction of
+// reclaimable space, by copying the blobs still in use to the active log. The index
+// is saved afterwards, so that it doesn't refer to the removed logs.
+func (store *blobStore) Compact(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.compactMu.Lock()
+	defer store.compactMu.Unlock()
+
+	store.appendMu.Lock()
+	var candidates []*logFile
+	for _, log := range store.logs {
+		if log == store.activeLog || log.size == log.live {
+			continue
+		}
+		if log.live == 0 || log.deadRatio() >= store.config.CompactionThreshold {
+			candidates = append(candidates, log)
+		}
+	}
+	store.appendMu.Unlock()
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.Slice(candidates, func(i, k int) bool { return candidates[i].seq < candidates[k].seq })
+
+	for _, log := range candidates {
+		if err := store.compactLog(ctx, log); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	return Error.Wrap(store.saveIndex())
+}
+
+// compactLog copies everything that is still needed from log to the active log
+// and removes log afterwards. The copied blobs are synced once at the end instead
+// of after every blob.
+//
+// store.compactMu must be held.
+func (store *blobStore) compactLog(ctx context.Context, log *logFile) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	source, err := os.Open(log.path)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	_, err = scanLog(log.path, 0, func(offset int64, rec record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		return store.change(func() error {
+			if store.active == nil {
+				return Error.New("store is closed")
+			}
+
+			key := blobKey{key: string(rec.key), formatVer: rec.formatVer}
+
+			switch rec.kind {
+			case recordBlob:
+				e := store.namespaces[string(rec.namespace)][key]
+				if e == nil || e.pos != (recordPos{log: log.seq, offset: offset}) {
+					return nil
+				}
+
+				rec.time = e.created
+				data := io.NewSectionReader(source, e.dataOffset, e.size)
+				pos, err := store.appendRecord(rec, data, false)
+				if err != nil {
+					return err
+				}
+				trashed, trashedAt := e.trashed, e.trashedAt
+				store.publish(pos, rec)
+				if trashed {
+					return store.update(recordTrash, rec.namespace, key, store.namespaces[string(rec.namespace)][key], trashedAt)
+				}
+				return nil
+
+			case recordDelete:
+				// deletions of blobs in other logs must be kept, otherwise the blobs
+				// would come back when the index is rebuilt.
+				if _, ok := store.logs[rec.target.log]; !ok || rec.target.log == log.seq {
+					return nil
+				}
+				_, err := store.appendRecord(rec, nil, false)
+				return err
+
+			case recordTrash, recordRestore:
+				// re-emit the current trash state of blobs in other logs, since older
+				// records for them might still exist.
+				e := store.namespaces[string(rec.namespace)][key]
+				if e == nil || e.pos != rec.target || rec.target.log == log.seq {
+					return nil
+				}
+				if e.trashed {
+					return store.update(recordTrash, rec.namespace, key, e, e.trashedAt)
+				}
+				return store.update(recordRestore, rec.namespace, key, e, time.Now())
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// the copies went to the active log, or to logs which were synced when they
+	// were rotated.
+	store.appendMu.Lock()
+	if store.activeLog == nil {
+		store.appendMu.Unlock()
+		return Error.New("store is closed")
+	}
+	activePath := store.activeLog.path
+	store.appendMu.Unlock()
+
+	if err := syncPath(activePath); err != nil {
+		return err
+	}
+
+	return store.change(func() error {
+		if err := os.Remove(log.path); err != nil {
+			// keep the log around, so that deletions of its blobs are kept by
+			// further compactions. Removing it is retried on the next compaction.
+			return err
+		}
+		store.mu.Lock()
+		delete(store.logs, log.seq)
+		store.mu.Unlock()
+		return nil
+	})
+}
+
+// SpaceUsedForBlobs adds up the space used in all namespaces for blob storage.
+func (store *blobStore) SpaceUsedForBlobs(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var total int64
+	for _, blobs := range store.namespaces {
+		for _, e := range blobs {
+			if !e.trashed {
+				total += e.size
+			}
+		}
+	}
+	return total, nil
+}
+
+// SpaceUsedForBlobsInNamespace adds up how much is used in the given namespace for blob storage.
+func (store *blobStore) SpaceUsedForBlobsInNamespace(ctx context.Context, namespace []byte) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var total int64
+	for _, e := range store.namespaces[string(namespace)] {
+		if !e.trashed {
+			total += e.size
+		}
+	}
+	return total, nil
+}
+
+// SpaceUsedForTrash returns the total space used by the trash.
+func (store *blobStore) SpaceUsedForTrash(ctx context.Context) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var total int64
+	for _, blobs := range store.namespaces {
+		for _, e := range blobs {
+			if e.trashed {
+				total += e.size
+			}
+		}
+	}
+	return total, nil
+}
+
+// FreeSpace returns how much space left in underlying directory.
+func (store *blobStore) FreeSpace() (int64, error) {
+	info, err := filestore.DiskInfoFromPath(store.path)
+	if err != nil {
+		return 0, err
+	}
+	return info.AvailableSpace, nil
+}
+
+// CheckWritability tests writability of the storage directory by creating and deleting a file.
+func (store *blobStore) CheckWritability() error {
+	f, err := ioutil.TempFile(store.path, "write-test")
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(f.Name())
+}
+
+// ProbeWrite tests that data reaches the disk of the storage directory by writing, syncing and deleting a file.
+func (store *blobStore) ProbeWrite() error {
+	return filestore.ProbeWrite(store.path)
+}
+
+// ListNamespaces finds all namespaces with blobs in the store.
+func (store *blobStore) ListNamespaces(ctx context.Context) (ids [][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for namespace := range store.namespaces {
+		ids = append(ids, []byte(namespace))
+	}
+	return ids, nil
+}
+
+// WalkNamespace executes walkFunc for each blob in the given namespace, which is not in
+// the trash. If walkFunc returns a non-nil error, WalkNamespace will stop iterating and
+// return the error immediately. The ctx parameter is intended specifically to allow
+// canceling iteration early.
+func (store *blobStore) WalkNamespace(ctx context.Context, namespace []byte, walkFunc func(storage.BlobInfo) error) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// collect the infos first, so that walkFunc can use the store.
+	var infos []storage.BlobInfo
+	store.mu.Lock()
+	for key, e := range store.namespaces[string(namespace)] {
+		if e.trashed {
+			continue
+		}
+		ref := storage.BlobRef{Namespace: namespace, Key: []byte(key.key)}
+		infos = append(infos, newBlobInfo(ref, store.logPath(e), e.size, e.created, key.formatVer))
+	}
+	store.mu.Unlock()
+
+	for _, info := range infos {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := walkFunc(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateVerificationFile creates a file to be used for storage directory verification.
+func (store *blobStore) CreateVerificationFile(id storj.NodeID) error {
+	return ioutil.WriteFile(filepath.Join(store.path, verificationFileName), id.Bytes(), 0644)
+}
+
+// VerifyStorageDir verifies that the storage directory is correct by checking for the existence and validity
+// of the verification file.
+func (store *blobStore) VerifyStorageDir(id storj.NodeID) error {
+	content, err := ioutil.ReadFile(filepath.Join(store.path, verificationFileName))
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(content, id.Bytes()) {
+		verifyID, err := storj.NodeIDFromBytes(content)
+		if err != nil {
+			return errs.New("content of file is not a valid node ID: %x", content)
+		}
+		return errs.New("node ID in file (%s) does not match running node's ID (%s)", verifyID, id.String())
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package packedstore_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/memory"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/blobstest"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packedstore"
+)
+
+const (
+	namespaceSize = 32
+	keySize       = 32
+)
+
+func TestStore(t *testing.T) {
+	blobstest.RunTests(t, func(ctx *testcontext.Context, t *testing.T, dir string) storage.Blobs {
+		store, err := packedstore.NewAt(zaptest.NewLogger(t), dir, packedstore.Config{
+			WriteBufferSize: memory.KiB,
+			MaxLogSize:      memory.MiB,
+		})
+		require.NoError(t, err)
+		return store
+	})
+}
+
+func TestCompactDeleted(t *testing.T) {
+	const blobSize = 8 << 10
+
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store, err := packedstore.NewAt(zaptest.NewLogger(t), ctx.Dir("store"), packedstore.Config{
+		WriteBufferSize: memory.KiB,
+		MaxLogSize:      1, // start a new log after every record
+	})
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	ref := storage.BlobRef{Namespace: testrand.Bytes(namespaceSize), Key: testrand.Bytes(keySize)}
+	blobstest.WriteBlob(ctx, t, store, ref, testrand.Bytes(blobSize), filestore.FormatV1)
+	require.NoError(t, store.Delete(ctx, ref))
+
+	// compact the log containing the deleted blob
+	require.NoError(t, store.(interface {
+		Compact(ctx context.Context) error
+	}).Compact(ctx))
+
+	// the blob content should be gone from disk
+	err = filepath.Walk(ctx.Dir("store"), func(path string, info os.FileInfo, _ error) error {
+		if info.IsDir() || info.Size() < blobSize {
+			return nil
+		}
+		return errs.New("found file %q", path)
+	})
+	require.NoError(t, err)
+}
+
+func TestReopen(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	config := packedstore.Config{
+		WriteBufferSize: memory.KiB,
+		MaxLogSize:      4 * memory.KiB,
+	}
+
+	store, err := packedstore.NewAt(log, ctx.Dir("store"), config)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(namespaceSize)
+	data := map[string][]byte{}
+	var refs []storage.BlobRef
+	for i := 0; i < 10; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+		refs = append(refs, ref)
+		data[string(ref.Key)] = testrand.BytesInt(1500)
+		blobstest.WriteBlob(ctx, t, store, ref, data[string(ref.Key)], filestore.FormatV1)
+	}
+
+	deleted, trashed, restored := refs[0], refs[1], refs[2]
+	require.NoError(t, store.Delete(ctx, deleted))
+	require.NoError(t, store.Trash(ctx, trashed))
+	require.NoError(t, store.Trash(ctx, restored))
+	_, err = store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.NoError(t, store.Trash(ctx, trashed))
+
+	checkStore := func(store storage.Blobs) {
+		_, err := store.Open(ctx, deleted)
+		require.True(t, os.IsNotExist(err))
+		_, err = store.Open(ctx, trashed)
+		require.True(t, os.IsNotExist(err))
+
+		for _, ref := range refs[2:] {
+			blobstest.RequireBlob(ctx, t, store, data[string(ref.Key)], ref, filestore.FormatV1)
+		}
+
+		trash, err := store.SpaceUsedForTrash(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1500, trash)
+
+		used, err := store.SpaceUsedForBlobs(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 8*1500, used)
+	}
+
+	checkStore(store)
+	require.NoError(t, store.Close())
+
+	// the index is rebuilt from the logs
+	store, err = packedstore.OpenAt(log, ctx.Dir("store"), config)
+	require.NoError(t, err)
+	checkStore(store)
+
+	// compaction keeps the state of blobs moved between logs
+	require.NoError(t, store.(interface {
+		Compact(ctx context.Context) error
+	}).Compact(ctx))
+	checkStore(store)
+	require.NoError(t, store.Close())
+
+	store, err = packedstore.OpenAt(log, ctx.Dir("store"), config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	checkStore(store)
+
+	restoredKeys, err := store.RestoreTrash(ctx, namespace)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{trashed.Key}, restoredKeys)
+	blobstest.RequireBlob(ctx, t, store, data[string(trashed.Key)], trashed, filestore.FormatV1)
+}
+
+func TestCompaction(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	config := packedstore.Config{
+		WriteBufferSize:     memory.KiB,
+		MaxLogSize:          8 * memory.KiB,
+		CompactionThreshold: 0.5,
+	}
+
+	store, err := packedstore.NewAt(log, ctx.Dir("store"), config)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(namespaceSize)
+	var refs []storage.BlobRef
+	data := map[string][]byte{}
+	for i := 0; i < 32; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+		refs = append(refs, ref)
+		data[string(ref.Key)] = testrand.BytesInt(1024)
+		blobstest.WriteBlob(ctx, t, store, ref, data[string(ref.Key)], filestore.FormatV1)
+	}
+
+	logSize := func() (total int64) {
+		err := filepath.Walk(ctx.Dir("store", "packed"), func(path string, info os.FileInfo, err error) error {
+			require.NoError(t, err)
+			if filepath.Ext(path) == ".log" {
+				total += info.Size()
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		return total
+	}
+	before := logSize()
+
+	// trash every other blob and empty the trash, which compacts the logs
+	for i := 0; i < len(refs); i += 2 {
+		require.NoError(t, store.Trash(ctx, refs[i]))
+	}
+	emptied, keys, err := store.EmptyTrash(ctx, namespace, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.EqualValues(t, 16*1024, emptied)
+	require.Len(t, keys, 16)
+
+	require.Less(t, logSize(), before)
+
+	check := func(store storage.Blobs) {
+		for i, ref := range refs {
+			if i%2 == 0 {
+				_, err := store.Open(ctx, ref)
+				require.True(t, os.IsNotExist(err))
+				continue
+			}
+			blobstest.RequireBlob(ctx, t, store, data[string(ref.Key)], ref, filestore.FormatV1)
+		}
+	}
+	check(store)
+	require.NoError(t, store.Close())
+
+	// deleted blobs don't come back after rebuilding the index
+	store, err = packedstore.OpenAt(log, ctx.Dir("store"), config)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+	check(store)
+}
+
+func TestTruncatedLog(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	store, err := packedstore.NewAt(log, ctx.Dir("store"), packedstore.DefaultConfig)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(namespaceSize)
+	first := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	second := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+	data := testrand.BytesInt(1024)
+	blobstest.WriteBlob(ctx, t, store, first, data, filestore.FormatV1)
+	blobstest.WriteBlob(ctx, t, store, second, data, filestore.FormatV1)
+	require.NoError(t, store.Close())
+
+	// simulate a crash while writing the second blob
+	logs, err := filepath.Glob(filepath.Join(ctx.Dir("store", "packed"), "*.log"))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	stat, err := os.Stat(logs[0])
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(logs[0], stat.Size()-100))
+
+	store, err = packedstore.OpenAt(log, ctx.Dir("store"), packedstore.DefaultConfig)
+	require.NoError(t, err)
+	defer ctx.Check(store.Close)
+
+	blobstest.RequireBlob(ctx, t, store, data, first, filestore.FormatV1)
+	_, err = store.Open(ctx, second)
+	require.True(t, os.IsNotExist(err))
+
+	// new blobs are appended after the last complete record
+	blobstest.WriteBlob(ctx, t, store, second, data, filestore.FormatV1)
+	blobstest.RequireBlob(ctx, t, store, data, second, filestore.FormatV1)
+}
+
+func TestIndex(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+	config := packedstore.Config{
+		WriteBufferSize: memory.KiB,
+		MaxLogSize:      4 * memory.KiB,
+	}
+
+	store, err := packedstore.NewAt(log, ctx.Dir("store"), config)
+	require.NoError(t, err)
+
+	namespace := testrand.Bytes(namespaceSize)
+	data := map[string][]byte{}
+	var refs []storage.BlobRef
+	for i := 0; i < 10; i++ {
+		ref := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+		refs = append(refs, ref)
+		data[string(ref.Key)] = testrand.BytesInt(1500)
+		blobstest.WriteBlob(ctx, t, store, ref, data[string(ref.Key)], filestore.FormatV1)
+	}
+	trashed := refs[0]
+	require.NoError(t, store.Trash(ctx, trashed))
+	require.NoError(t, store.Close())
+
+	indexPath := filepath.Join(ctx.Dir("store", "packed"), "index")
+
+	checkStore := func(store storage.Blobs, refs []storage.BlobRef) {
+		_, err := store.Open(ctx, trashed)
+		require.True(t, os.IsNotExist(err))
+		for _, ref := range refs {
+			blobstest.RequireBlob(ctx, t, store, data[string(ref.Key)], ref, filestore.FormatV1)
+		}
+		used, err := store.SpaceUsedForBlobs(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, len(refs)*1500, used)
+		trash, err := store.SpaceUsedForTrash(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1500, trash)
+	}
+
+	{ // a corrupted index is rebuilt from the logs
+		require.NoError(t, ioutil.WriteFile(indexPath, []byte("garbage"), 0600))
+
+		store, err = packedstore.OpenAt(log, ctx.Dir("store"), config)
+		require.NoError(t, err)
+		checkStore(store, refs[1:])
+		require.NoError(t, store.Close())
+	}
+
+	{ // the logs covered by the index are not read when opening the store
+		logs, err := filepath.Glob(filepath.Join(ctx.Dir("store", "packed"), "*.log"))
+		require.NoError(t, err)
+		require.True(t, len(logs) > 1)
+
+		file, err := os.OpenFile(logs[0], os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = file.WriteAt([]byte{0, 0}, 0)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		store, err = packedstore.OpenAt(log, ctx.Dir("store"), config)
+		require.NoError(t, err)
+		defer ctx.Check(store.Close)
+		checkStore(store, refs[1:])
+	}
+
+	{ // changes after the index was saved are replayed from the logs
+		added := storage.BlobRef{Namespace: namespace, Key: testrand.Bytes(keySize)}
+		data[string(added.Key)] = testrand.BytesInt(1500)
+		blobstest.WriteBlob(ctx, t, store, added, data[string(added.Key)], filestore.FormatV1)
+		require.NoError(t, store.Delete(ctx, refs[1]))
+
+		// open the directory again, as if the store crashed without saving the index.
+		reopened, err := packedstore.OpenAt(log, ctx.Dir("store"), config)
+		require.NoError(t, err)
+		defer ctx.Check(reopened.Close)
+
+		_, err = reopened.Open(ctx, refs[1])
+		require.True(t, os.IsNotExist(err))
+		checkStore(reopened, append(refs[2:], added))
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+	"storj.io/storj/storagenode/orders"
+)
+
+// ErrOrdersAPI - console orders api error type.
+var ErrOrdersAPI = errs.Class("orders console web error")
+
+// Orders is an api controller that exposes the unsent orders of the node.
+type Orders struct {
+	log     *zap.Logger
+	service *orders.Service
+}
+
+// NewOrders is a constructor for orders controller.
+func NewOrders(log *zap.Logger, service *orders.Service) *Orders {
+	return &Orders{
+		log:     log,
+		service: service,
+	}
+}
+
+// UnsentWindow describes the unsent orders file of a satellite and order creation hour.
+type UnsentWindow struct {
+	SatelliteID   storj.NodeID `json:"satelliteId"`
+	CreatedAtHour time.Time    `json:"createdAtHour"`
+	Version       string       `json:"version"`
+	Size          int64        `json:"size"`
+	Ready         bool         `json:"ready"`
+}
+
+// UnsentOrder describes a single unsent order.
+type UnsentOrder struct {
+	SerialNumber  storj.SerialNumber `json:"serialNumber"`
+	PieceID       storj.PieceID      `json:"pieceId"`
+	Action        string             `json:"action"`
+	Limit         int64              `json:"limit"`
+	Amount        int64              `json:"amount"`
+	OrderCreation time.Time          `json:"orderCreation"`
+}
+
+// ActionTotal contains the number of orders and the total amount for a piece action.
+type ActionTotal struct {
+	Action string `json:"action"`
+	Count  int64  `json:"count"`
+	Amount int64  `json:"amount"`
+}
+
+// WindowSummary contains the orders of an unsent window and their totals.
+type WindowSummary struct {
+	Window    UnsentWindow  `json:"window"`
+	Orders    []UnsentOrder `json:"orders"`
+	Corrupted int           `json:"corrupted"`
+	Totals    []ActionTotal `json:"totals"`
+}
+
+// SettleResult contains the outcome of settling an unsent window.
+type SettleResult struct {
+	Status string `json:"status"`
+	Count  int    `json:"count"`
+}
+
+// RepairResult contains the outcome of repairing an unsent window.
+type RepairResult struct {
+	Salvaged  int  `json:"salvaged"`
+	Corrupted int  `json:"corrupted"`
+	Repaired  bool `json:"repaired"`
+}
+
+// ListUnsent returns the unsent orders windows of all satellites.
+func (controller *Orders) ListUnsent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	windows, err := controller.service.ListUnsentWindows(ctx, time.Now())
+	if err != nil {
+		if len(windows) == 0 {
+			controller.serveJSONError(w, http.StatusInternalServerError, ErrOrdersAPI.Wrap(err))
+			return
+		}
+		controller.log.Warn("unable to read some unsent orders files", zap.Error(err))
+	}
+
+	response := make([]UnsentWindow, 0, len(windows))
+	for _, window := range windows {
+		response = append(response, unsentWindow(window))
+	}
+
+	controller.serveJSON(w, response)
+}
+
+// Inspect returns the orders of an unsent window and their totals.
+func (controller *Orders) Inspect(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	satelliteID, createdAt, err := parseWindow(r)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrOrdersAPI.Wrap(err))
+		return
+	}
+
+	summary, err := controller.service.InspectUnsentWindow(ctx, satelliteID, createdAt, time.Now())
+	if err != nil {
+		controller.serveWindowError(w, err)
+		return
+	}
+
+	response := WindowSummary{
+		Window:    unsentWindow(summary.Window),
+		Orders:    make([]UnsentOrder, 0, len(summary.Orders)),
+		Corrupted: summary.Corrupted,
+		Totals:    make([]ActionTotal, 0, len(summary.Totals)),
+	}
+	for _, info := range summary.Orders {
+		response.Orders = append(response.Orders, UnsentOrder{
+			SerialNumber:  info.Limit.SerialNumber,
+			PieceID:       info.Limit.PieceId,
+			Action:        info.Limit.Action.String(),
+			Limit:         info.Limit.Limit,
+			Amount:        info.Order.Amount,
+			OrderCreation: info.Limit.OrderCreation,
+		})
+	}
+	for _, total := range summary.Totals {
+		response.Totals = append(response.Totals, ActionTotal{
+			Action: total.Action.String(),
+			Count:  total.Count,
+			Amount: total.Amount,
+		})
+	}
+
+	controller.serveJSON(w, response)
+}
+
+// Settle sends the orders of an unsent window to the satellite immediately.
+func (controller *Orders) Settle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	satelliteID, createdAt, err := parseWindow(r)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrOrdersAPI.Wrap(err))
+		return
+	}
+
+	status, count, err := controller.service.SettleUnsentWindow(ctx, satelliteID, createdAt, time.Now())
+	if err != nil {
+		controller.serveWindowError(w, err)
+		return
+	}
+
+	controller.serveJSON(w, SettleResult{
+		Status: status.String(),
+		Count:  count,
+	})
+}
+
+// Repair salvages the valid orders of a corrupted unsent window.
+func (controller *Orders) Repair(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var err error
+	defer mon.Task()(&ctx)(&err)
+
+	w.Header().Set(contentType, applicationJSON)
+
+	satelliteID, createdAt, err := parseWindow(r)
+	if err != nil {
+		controller.serveJSONError(w, http.StatusBadRequest, ErrOrdersAPI.Wrap(err))
+		return
+	}
+
+	result, err := controller.service.RepairUnsentWindow(ctx, satelliteID, createdAt, time.Now())
+	if err != nil {
+		controller.serveWindowError(w, err)
+		return
+	}
+
+	controller.serveJSON(w, RepairResult{
+		Salvaged:  result.Salvaged,
+		Corrupted: result.Corrupted,
+		Repaired:  result.Repaired,
+	})
+}
+
+// parseWindow parses the satellite ID and the order creation time, in RFC3339
+// format, which identify an unsent window.
+func parseWindow(r *http.Request) (satelliteID storj.NodeID, createdAt time.Time, err error) {
+	params := mux.Vars(r)
+
+	satelliteID, err = storj.NodeIDFromString(params["satelliteID"])
+	if err != nil {
+		return storj.NodeID{}, time.Time{}, err
+	}
+
+	createdAt, err = time.Parse(time.RFC3339, params["createdAt"])
+	if err != nil {
+		return storj.NodeID{}, time.Time{}, err
+	}
+
+	return satelliteID, createdAt, nil
+}
+
+func unsentWindow(window orders.UnsentWindow) UnsentWindow {
+	return UnsentWindow{
+		SatelliteID:   window.SatelliteID,
+		CreatedAtHour: window.CreatedAtHour.UTC(),
+		Version:       string(window.Version),
+		Size:          window.Size,
+		Ready:         window.Ready,
+	}
+}
+
+// serveWindowError writes an error of an unsent window operation.
+func (controller *Orders) serveWindowError(w http.ResponseWriter, err error) {
+	if orders.ErrWindowNotFound.Has(err) {
+		controller.serveJSONError(w, http.StatusNotFound, ErrOrdersAPI.Wrap(err))
+		return
+	}
+	controller.serveJSONError(w, http.StatusInternalServerError, ErrOrdersAPI.Wrap(err))
+}
+
+// serveJSON writes a JSON response.
+func (controller *Orders) serveJSON(w http.ResponseWriter, response interface{}) {
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		controller.log.Error("failed to encode json response", zap.Error(ErrOrdersAPI.Wrap(err)))
+	}
+}
+
+// serveJSONError writes JSON error to response output stream.
+func (controller *Orders) serveJSONError(w http.ResponseWriter, status int, err error) {
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+
+	response.Error = err.Error()
+
+	err = json.NewEncoder(w).Encode(response)
+	if err != nil {
+		controller.log.Error("failed to write json error response", zap.Error(ErrOrdersAPI.Wrap(err)))
+		return
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"storj.io/storj/storagenode/console"
 	"storj.io/storj/storagenode/console/consoleapi"
 	"storj.io/storj/storagenode/notifications"
+	"storj.io/storj/storagenode/orders"
 	"storj.io/storj/storagenode/payout"
 )
 
@@ -44,19 +45,21 @@ type Server struct {
 	service       *console.Service
 	notifications *notifications.Service
 	payout        *payout.Service
+	orders        *orders.Service
 	listener      net.Listener
 
 	server http.Server
 }
 
 // NewServer creates new instance of storagenode console web server.
-func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifications.Service, service *console.Service, payout *payout.Service, listener net.Listener) *Server {
+func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifications.Service, service *console.Service, payout *payout.Service, orders *orders.Service, listener net.Listener) *Server {
 	server := Server{
 		log:           logger,
 		service:       service,
 		listener:      listener,
 		notifications: notifications,
 		payout:        payout,
+		orders:        orders,
 	}
 
 	router := mux.NewRouter()
@@ -86,6 +89,14 @@ func NewServer(logger *zap.Logger, assets http.FileSystem, notifications *notifi
 	payoutRouter.HandleFunc("/periods", payoutController.HeldAmountPeriods).Methods(http.MethodGet)
 	payoutRouter.HandleFunc("/payout-history/{period}", payoutController.PayoutHistory).Methods(http.MethodGet)
 
+	ordersController := consoleapi.NewOrders(server.log, server.orders)
+	ordersRouter := router.PathPrefix("/api/orders").Subrouter()
+	ordersRouter.StrictSlash(true)
+	ordersRouter.HandleFunc("/unsent", ordersController.ListUnsent).Methods(http.MethodGet)
+	ordersRouter.HandleFunc("/unsent/{satelliteID}/{createdAt}", ordersController.Inspect).Methods(http.MethodGet)
+	ordersRouter.HandleFunc("/unsent/{satelliteID}/{createdAt}/settle", ordersController.Settle).Methods(http.MethodPost)
+	ordersRouter.HandleFunc("/unsent/{satelliteID}/{createdAt}/repair", ordersController.Repair).Methods(http.MethodPost)
+
 	if assets != nil {
 		fs := http.FileServer(assets)
 		router.PathPrefix("/static/").Handler(server.cacheMiddleware(http.StripPrefix("/static", fs)))
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 
+	"storj.io/common/memory"
 	"storj.io/common/pb"
 	"storj.io/common/testcontext"
 	"storj.io/storj/private/testplanet"
@@ -102,6 +103,31 @@ func TestServicePingSatellites(t *testing.T) {
 	})
 }
 
+func TestServicePingSatellites_SatelliteCapacity(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 2, StorageNodeCount: 1, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		node := planet.StorageNodes[0]
+		node.Contact.Chore.Pause(ctx)
+
+		capacity := pb.NodeCapacity{FreeDisk: 2 * memory.GB.Int64()}
+		limited := pb.NodeCapacity{FreeDisk: memory.GB.Int64()}
+		node.Contact.Service.UpdateSelf(&capacity)
+		node.Contact.Service.UpdateSatellite(planet.Satellites[0].ID(), &limited)
+
+		err := node.Contact.Service.PingSatellites(ctx, 10*time.Second)
+		require.NoError(t, err)
+
+		info, err := planet.Satellites[0].Overlay.Service.Get(ctx, node.ID())
+		require.NoError(t, err)
+		require.Equal(t, limited, info.Capacity)
+
+		info, err = planet.Satellites[1].Overlay.Service.Get(ctx, node.ID())
+		require.NoError(t, err)
+		require.Equal(t, capacity, info.Capacity)
+	})
+}
+
 func TestLocalAndUpdateSelf(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
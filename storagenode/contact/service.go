@@ -55,6 +55,8 @@ type Service struct {
 
 	mu   sync.Mutex
 	self NodeInfo
+	// satelliteCapacity overrides the capacity reported to satellites with a quota.
+	satelliteCapacity map[storj.NodeID]pb.NodeCapacity
 
 	trust *trust.Pool
 
@@ -68,6 +70,8 @@ func NewService(log *zap.Logger, dialer rpc.Dialer, self NodeInfo, trust *trust.
 		dialer: dialer,
 		trust:  trust,
 		self:   self,
+
+		satelliteCapacity: make(map[storj.NodeID]pb.NodeCapacity),
 	}
 }
 
@@ -127,7 +131,7 @@ func (service *Service) pingSatelliteOnce(ctx context.Context, id storj.NodeID)
 	}
 	defer func() { err = errs.Combine(err, conn.Close()) }()
 
-	self := service.Local()
+	self := service.LocalFor(id)
 	resp, err := pb.NewDRPCNodeClient(conn).CheckIn(ctx, &pb.CheckInRequest{
 		Address:  self.Address,
 		Version:  &self.Version,
@@ -150,6 +154,17 @@ func (service *Service) Local() NodeInfo {
 	return service.self
 }
 
+// LocalFor returns the storagenode info as reported to the satellite.
+func (service *Service) LocalFor(satelliteID storj.NodeID) NodeInfo {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	self := service.self
+	if capacity, ok := service.satelliteCapacity[satelliteID]; ok {
+		self.Capacity = capacity
+	}
+	return self
+}
+
 // UpdateSelf updates the local node with the capacity.
 func (service *Service) UpdateSelf(capacity *pb.NodeCapacity) {
 	service.mu.Lock()
@@ -159,3 +174,13 @@ func (service *Service) UpdateSelf(capacity *pb.NodeCapacity) {
 	}
 	service.initialized.Release()
 }
+
+// UpdateSatellite updates the capacity reported to a single satellite, which
+// is limited by its quota.
+func (service *Service) UpdateSatellite(satelliteID storj.NodeID, capacity *pb.NodeCapacity) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if capacity != nil {
+		service.satelliteCapacity[satelliteID] = *capacity
+	}
+}
@@ -0,0 +1,812 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: nodeorders.proto
+
+package internalpb
+
+import (
+	context "context"
+	fmt "fmt"
+	math "math"
+	time "time"
+
+	proto "github.com/gogo/protobuf/proto"
+
+	drpc "storj.io/drpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// UnsentWindow identifies the unsent orders file of a satellite and order creation hour.
+type UnsentWindow struct {
+	SatelliteId   NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	CreatedAtHour time.Time `protobuf:"bytes,2,opt,name=created_at_hour,json=createdAtHour,proto3,stdtime" json:"created_at_hour"`
+	Version       string    `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	FileSize      int64     `protobuf:"varint,4,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	// ready is true when no more orders can be added to the window.
+	Ready                bool     `protobuf:"varint,5,opt,name=ready,proto3" json:"ready,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnsentWindow) Reset()         { *m = UnsentWindow{} }
+func (m *UnsentWindow) String() string { return proto.CompactTextString(m) }
+func (*UnsentWindow) ProtoMessage()    {}
+func (*UnsentWindow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{0}
+}
+func (m *UnsentWindow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsentWindow.Unmarshal(m, b)
+}
+func (m *UnsentWindow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsentWindow.Marshal(b, m, deterministic)
+}
+func (m *UnsentWindow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsentWindow.Merge(m, src)
+}
+func (m *UnsentWindow) XXX_Size() int {
+	return xxx_messageInfo_UnsentWindow.Size(m)
+}
+func (m *UnsentWindow) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsentWindow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsentWindow proto.InternalMessageInfo
+
+func (m *UnsentWindow) GetCreatedAtHour() time.Time {
+	if m != nil {
+		return m.CreatedAtHour
+	}
+	return time.Time{}
+}
+
+func (m *UnsentWindow) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *UnsentWindow) GetFileSize() int64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+func (m *UnsentWindow) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+type ListUnsentWindowsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListUnsentWindowsRequest) Reset()         { *m = ListUnsentWindowsRequest{} }
+func (m *ListUnsentWindowsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListUnsentWindowsRequest) ProtoMessage()    {}
+func (*ListUnsentWindowsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{1}
+}
+func (m *ListUnsentWindowsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnsentWindowsRequest.Unmarshal(m, b)
+}
+func (m *ListUnsentWindowsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnsentWindowsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListUnsentWindowsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnsentWindowsRequest.Merge(m, src)
+}
+func (m *ListUnsentWindowsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListUnsentWindowsRequest.Size(m)
+}
+func (m *ListUnsentWindowsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnsentWindowsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnsentWindowsRequest proto.InternalMessageInfo
+
+type ListUnsentWindowsResponse struct {
+	Windows              []*UnsentWindow `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListUnsentWindowsResponse) Reset()         { *m = ListUnsentWindowsResponse{} }
+func (m *ListUnsentWindowsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListUnsentWindowsResponse) ProtoMessage()    {}
+func (*ListUnsentWindowsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{2}
+}
+func (m *ListUnsentWindowsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListUnsentWindowsResponse.Unmarshal(m, b)
+}
+func (m *ListUnsentWindowsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListUnsentWindowsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListUnsentWindowsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListUnsentWindowsResponse.Merge(m, src)
+}
+func (m *ListUnsentWindowsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListUnsentWindowsResponse.Size(m)
+}
+func (m *ListUnsentWindowsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListUnsentWindowsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListUnsentWindowsResponse proto.InternalMessageInfo
+
+func (m *ListUnsentWindowsResponse) GetWindows() []*UnsentWindow {
+	if m != nil {
+		return m.Windows
+	}
+	return nil
+}
+
+type InspectUnsentWindowRequest struct {
+	SatelliteId          NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	CreatedAt            time.Time `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3,stdtime" json:"created_at"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *InspectUnsentWindowRequest) Reset()         { *m = InspectUnsentWindowRequest{} }
+func (m *InspectUnsentWindowRequest) String() string { return proto.CompactTextString(m) }
+func (*InspectUnsentWindowRequest) ProtoMessage()    {}
+func (*InspectUnsentWindowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{3}
+}
+func (m *InspectUnsentWindowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectUnsentWindowRequest.Unmarshal(m, b)
+}
+func (m *InspectUnsentWindowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectUnsentWindowRequest.Marshal(b, m, deterministic)
+}
+func (m *InspectUnsentWindowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectUnsentWindowRequest.Merge(m, src)
+}
+func (m *InspectUnsentWindowRequest) XXX_Size() int {
+	return xxx_messageInfo_InspectUnsentWindowRequest.Size(m)
+}
+func (m *InspectUnsentWindowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectUnsentWindowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectUnsentWindowRequest proto.InternalMessageInfo
+
+func (m *InspectUnsentWindowRequest) GetCreatedAt() time.Time {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return time.Time{}
+}
+
+type InspectUnsentWindowResponse struct {
+	Window               *UnsentWindow  `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	Orders               []*UnsentOrder `protobuf:"bytes,2,rep,name=orders,proto3" json:"orders,omitempty"`
+	Corrupted            int64          `protobuf:"varint,3,opt,name=corrupted,proto3" json:"corrupted,omitempty"`
+	Totals               []*ActionTotal `protobuf:"bytes,4,rep,name=totals,proto3" json:"totals,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *InspectUnsentWindowResponse) Reset()         { *m = InspectUnsentWindowResponse{} }
+func (m *InspectUnsentWindowResponse) String() string { return proto.CompactTextString(m) }
+func (*InspectUnsentWindowResponse) ProtoMessage()    {}
+func (*InspectUnsentWindowResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{4}
+}
+func (m *InspectUnsentWindowResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InspectUnsentWindowResponse.Unmarshal(m, b)
+}
+func (m *InspectUnsentWindowResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InspectUnsentWindowResponse.Marshal(b, m, deterministic)
+}
+func (m *InspectUnsentWindowResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InspectUnsentWindowResponse.Merge(m, src)
+}
+func (m *InspectUnsentWindowResponse) XXX_Size() int {
+	return xxx_messageInfo_InspectUnsentWindowResponse.Size(m)
+}
+func (m *InspectUnsentWindowResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InspectUnsentWindowResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InspectUnsentWindowResponse proto.InternalMessageInfo
+
+func (m *InspectUnsentWindowResponse) GetWindow() *UnsentWindow {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+func (m *InspectUnsentWindowResponse) GetOrders() []*UnsentOrder {
+	if m != nil {
+		return m.Orders
+	}
+	return nil
+}
+
+func (m *InspectUnsentWindowResponse) GetCorrupted() int64 {
+	if m != nil {
+		return m.Corrupted
+	}
+	return 0
+}
+
+func (m *InspectUnsentWindowResponse) GetTotals() []*ActionTotal {
+	if m != nil {
+		return m.Totals
+	}
+	return nil
+}
+
+type UnsentOrder struct {
+	SerialNumber         SerialNumber `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3,customtype=SerialNumber" json:"serial_number"`
+	PieceId              PieceID      `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3,customtype=PieceID" json:"piece_id"`
+	Action               string       `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Limit                int64        `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Amount               int64        `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	OrderCreation        time.Time    `protobuf:"bytes,6,opt,name=order_creation,json=orderCreation,proto3,stdtime" json:"order_creation"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *UnsentOrder) Reset()         { *m = UnsentOrder{} }
+func (m *UnsentOrder) String() string { return proto.CompactTextString(m) }
+func (*UnsentOrder) ProtoMessage()    {}
+func (*UnsentOrder) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{5}
+}
+func (m *UnsentOrder) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnsentOrder.Unmarshal(m, b)
+}
+func (m *UnsentOrder) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnsentOrder.Marshal(b, m, deterministic)
+}
+func (m *UnsentOrder) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnsentOrder.Merge(m, src)
+}
+func (m *UnsentOrder) XXX_Size() int {
+	return xxx_messageInfo_UnsentOrder.Size(m)
+}
+func (m *UnsentOrder) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnsentOrder.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnsentOrder proto.InternalMessageInfo
+
+func (m *UnsentOrder) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *UnsentOrder) GetLimit() int64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *UnsentOrder) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *UnsentOrder) GetOrderCreation() time.Time {
+	if m != nil {
+		return m.OrderCreation
+	}
+	return time.Time{}
+}
+
+type ActionTotal struct {
+	Action               string   `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Amount               int64    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActionTotal) Reset()         { *m = ActionTotal{} }
+func (m *ActionTotal) String() string { return proto.CompactTextString(m) }
+func (*ActionTotal) ProtoMessage()    {}
+func (*ActionTotal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{6}
+}
+func (m *ActionTotal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActionTotal.Unmarshal(m, b)
+}
+func (m *ActionTotal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActionTotal.Marshal(b, m, deterministic)
+}
+func (m *ActionTotal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionTotal.Merge(m, src)
+}
+func (m *ActionTotal) XXX_Size() int {
+	return xxx_messageInfo_ActionTotal.Size(m)
+}
+func (m *ActionTotal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionTotal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionTotal proto.InternalMessageInfo
+
+func (m *ActionTotal) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *ActionTotal) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ActionTotal) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type SettleUnsentWindowRequest struct {
+	SatelliteId          NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	CreatedAt            time.Time `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3,stdtime" json:"created_at"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SettleUnsentWindowRequest) Reset()         { *m = SettleUnsentWindowRequest{} }
+func (m *SettleUnsentWindowRequest) String() string { return proto.CompactTextString(m) }
+func (*SettleUnsentWindowRequest) ProtoMessage()    {}
+func (*SettleUnsentWindowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{7}
+}
+func (m *SettleUnsentWindowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SettleUnsentWindowRequest.Unmarshal(m, b)
+}
+func (m *SettleUnsentWindowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SettleUnsentWindowRequest.Marshal(b, m, deterministic)
+}
+func (m *SettleUnsentWindowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SettleUnsentWindowRequest.Merge(m, src)
+}
+func (m *SettleUnsentWindowRequest) XXX_Size() int {
+	return xxx_messageInfo_SettleUnsentWindowRequest.Size(m)
+}
+func (m *SettleUnsentWindowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SettleUnsentWindowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SettleUnsentWindowRequest proto.InternalMessageInfo
+
+func (m *SettleUnsentWindowRequest) GetCreatedAt() time.Time {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return time.Time{}
+}
+
+type SettleUnsentWindowResponse struct {
+	Status               string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SettleUnsentWindowResponse) Reset()         { *m = SettleUnsentWindowResponse{} }
+func (m *SettleUnsentWindowResponse) String() string { return proto.CompactTextString(m) }
+func (*SettleUnsentWindowResponse) ProtoMessage()    {}
+func (*SettleUnsentWindowResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{8}
+}
+func (m *SettleUnsentWindowResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SettleUnsentWindowResponse.Unmarshal(m, b)
+}
+func (m *SettleUnsentWindowResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SettleUnsentWindowResponse.Marshal(b, m, deterministic)
+}
+func (m *SettleUnsentWindowResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SettleUnsentWindowResponse.Merge(m, src)
+}
+func (m *SettleUnsentWindowResponse) XXX_Size() int {
+	return xxx_messageInfo_SettleUnsentWindowResponse.Size(m)
+}
+func (m *SettleUnsentWindowResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SettleUnsentWindowResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SettleUnsentWindowResponse proto.InternalMessageInfo
+
+func (m *SettleUnsentWindowResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SettleUnsentWindowResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type RepairUnsentWindowRequest struct {
+	SatelliteId          NodeID    `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	CreatedAt            time.Time `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3,stdtime" json:"created_at"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RepairUnsentWindowRequest) Reset()         { *m = RepairUnsentWindowRequest{} }
+func (m *RepairUnsentWindowRequest) String() string { return proto.CompactTextString(m) }
+func (*RepairUnsentWindowRequest) ProtoMessage()    {}
+func (*RepairUnsentWindowRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{9}
+}
+func (m *RepairUnsentWindowRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RepairUnsentWindowRequest.Unmarshal(m, b)
+}
+func (m *RepairUnsentWindowRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RepairUnsentWindowRequest.Marshal(b, m, deterministic)
+}
+func (m *RepairUnsentWindowRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepairUnsentWindowRequest.Merge(m, src)
+}
+func (m *RepairUnsentWindowRequest) XXX_Size() int {
+	return xxx_messageInfo_RepairUnsentWindowRequest.Size(m)
+}
+func (m *RepairUnsentWindowRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepairUnsentWindowRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RepairUnsentWindowRequest proto.InternalMessageInfo
+
+func (m *RepairUnsentWindowRequest) GetCreatedAt() time.Time {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return time.Time{}
+}
+
+type RepairUnsentWindowResponse struct {
+	Salvaged             int64    `protobuf:"varint,1,opt,name=salvaged,proto3" json:"salvaged,omitempty"`
+	Corrupted            int64    `protobuf:"varint,2,opt,name=corrupted,proto3" json:"corrupted,omitempty"`
+	Repaired             bool     `protobuf:"varint,3,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RepairUnsentWindowResponse) Reset()         { *m = RepairUnsentWindowResponse{} }
+func (m *RepairUnsentWindowResponse) String() string { return proto.CompactTextString(m) }
+func (*RepairUnsentWindowResponse) ProtoMessage()    {}
+func (*RepairUnsentWindowResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9f1b7c4a9430673f, []int{10}
+}
+func (m *RepairUnsentWindowResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RepairUnsentWindowResponse.Unmarshal(m, b)
+}
+func (m *RepairUnsentWindowResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RepairUnsentWindowResponse.Marshal(b, m, deterministic)
+}
+func (m *RepairUnsentWindowResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RepairUnsentWindowResponse.Merge(m, src)
+}
+func (m *RepairUnsentWindowResponse) XXX_Size() int {
+	return xxx_messageInfo_RepairUnsentWindowResponse.Size(m)
+}
+func (m *RepairUnsentWindowResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RepairUnsentWindowResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RepairUnsentWindowResponse proto.InternalMessageInfo
+
+func (m *RepairUnsentWindowResponse) GetSalvaged() int64 {
+	if m != nil {
+		return m.Salvaged
+	}
+	return 0
+}
+
+func (m *RepairUnsentWindowResponse) GetCorrupted() int64 {
+	if m != nil {
+		return m.Corrupted
+	}
+	return 0
+}
+
+func (m *RepairUnsentWindowResponse) GetRepaired() bool {
+	if m != nil {
+		return m.Repaired
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*UnsentWindow)(nil), "storagenode.orders.UnsentWindow")
+	proto.RegisterType((*ListUnsentWindowsRequest)(nil), "storagenode.orders.ListUnsentWindowsRequest")
+	proto.RegisterType((*ListUnsentWindowsResponse)(nil), "storagenode.orders.ListUnsentWindowsResponse")
+	proto.RegisterType((*InspectUnsentWindowRequest)(nil), "storagenode.orders.InspectUnsentWindowRequest")
+	proto.RegisterType((*InspectUnsentWindowResponse)(nil), "storagenode.orders.InspectUnsentWindowResponse")
+	proto.RegisterType((*UnsentOrder)(nil), "storagenode.orders.UnsentOrder")
+	proto.RegisterType((*ActionTotal)(nil), "storagenode.orders.ActionTotal")
+	proto.RegisterType((*SettleUnsentWindowRequest)(nil), "storagenode.orders.SettleUnsentWindowRequest")
+	proto.RegisterType((*SettleUnsentWindowResponse)(nil), "storagenode.orders.SettleUnsentWindowResponse")
+	proto.RegisterType((*RepairUnsentWindowRequest)(nil), "storagenode.orders.RepairUnsentWindowRequest")
+	proto.RegisterType((*RepairUnsentWindowResponse)(nil), "storagenode.orders.RepairUnsentWindowResponse")
+}
+
+func init() { proto.RegisterFile("nodeorders.proto", fileDescriptor_9f1b7c4a9430673f) }
+
+var fileDescriptor_9f1b7c4a9430673f = []byte{
+	// 726 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x71, 0x9b, 0x9f, 0x49, 0xda, 0xc2, 0x52, 0x55, 0xae, 0x8b, 0x14, 0xcb, 0x12, 0x22,
+	0x42, 0xe0, 0x88, 0x72, 0xe0, 0xe7, 0xd6, 0x9f, 0x03, 0x81, 0xaa, 0x45, 0x9b, 0xa2, 0x4a, 0x5c,
+	0x22, 0x27, 0xde, 0x86, 0x45, 0x8e, 0xd7, 0xec, 0xae, 0x5b, 0xd1, 0x17, 0xe0, 0x8a, 0x04, 0x0f,
+	0xc5, 0x33, 0x20, 0x54, 0xb8, 0xf1, 0x1a, 0xc8, 0xeb, 0x4d, 0xea, 0x36, 0x8e, 0x94, 0x72, 0xea,
+	0xcd, 0x9f, 0xf7, 0x9b, 0x99, 0xef, 0x9b, 0x9d, 0x59, 0xb8, 0x1d, 0xb1, 0x80, 0x30, 0x1e, 0x10,
+	0x2e, 0xbc, 0x98, 0x33, 0xc9, 0x10, 0x12, 0x92, 0x71, 0x7f, 0x48, 0xd2, 0x03, 0x2f, 0x3b, 0xb1,
+	0x61, 0xc8, 0x86, 0x2c, 0x3b, 0xb7, 0x9b, 0x43, 0xc6, 0x86, 0x21, 0x69, 0x2b, 0xd4, 0x4f, 0x8e,
+	0xdb, 0x92, 0x8e, 0x88, 0x90, 0xfe, 0x28, 0xce, 0x08, 0xee, 0x2f, 0x03, 0x1a, 0xef, 0x22, 0x41,
+	0x22, 0x79, 0x44, 0xa3, 0x80, 0x9d, 0xa2, 0x27, 0xd0, 0x10, 0xbe, 0x24, 0x61, 0x48, 0x25, 0xe9,
+	0xd1, 0xc0, 0x32, 0x1c, 0xa3, 0xd5, 0xd8, 0x5e, 0xfe, 0x71, 0xde, 0xbc, 0xf5, 0xf3, 0xbc, 0x59,
+	0xde, 0x67, 0x01, 0xe9, 0xec, 0xe2, 0xfa, 0x84, 0xd3, 0x09, 0xd0, 0x1e, 0xac, 0x0c, 0x38, 0xf1,
+	0x25, 0x09, 0x7a, 0xbe, 0xec, 0x7d, 0x60, 0x09, 0xb7, 0x4a, 0x8e, 0xd1, 0xaa, 0x6f, 0xda, 0x5e,
+	0x56, 0xde, 0x1b, 0x97, 0xf7, 0x0e, 0xc7, 0xe5, 0xb7, 0xab, 0x69, 0xc6, 0xaf, 0xbf, 0x9b, 0x06,
+	0x5e, 0xd2, 0xc1, 0x5b, 0xf2, 0x15, 0x4b, 0x38, 0xb2, 0xa0, 0x72, 0x42, 0xb8, 0xa0, 0x2c, 0xb2,
+	0x4c, 0xc7, 0x68, 0xd5, 0xf0, 0x18, 0xa2, 0x0d, 0xa8, 0x1d, 0xd3, 0x90, 0xf4, 0x04, 0x3d, 0x23,
+	0xd6, 0x82, 0x63, 0xb4, 0x4c, 0x5c, 0x4d, 0x7f, 0x74, 0xe9, 0x19, 0x41, 0xab, 0xb0, 0xc8, 0x89,
+	0x1f, 0x7c, 0xb6, 0x16, 0x1d, 0xa3, 0x55, 0xc5, 0x19, 0x70, 0x6d, 0xb0, 0xf6, 0xa8, 0x90, 0x79,
+	0x87, 0x02, 0x93, 0x4f, 0x09, 0x11, 0xd2, 0x3d, 0x82, 0xf5, 0x82, 0x33, 0x11, 0xb3, 0x48, 0x10,
+	0xf4, 0x12, 0x2a, 0xa7, 0xd9, 0x2f, 0xcb, 0x70, 0xcc, 0x56, 0x7d, 0xd3, 0xf1, 0xa6, 0x5b, 0xed,
+	0xe5, 0x63, 0xf1, 0x38, 0xc0, 0xfd, 0x6e, 0x80, 0xdd, 0x89, 0x44, 0x4c, 0x06, 0x97, 0x92, 0xeb,
+	0xba, 0xff, 0xd3, 0xe1, 0x1d, 0x80, 0x8b, 0x0e, 0x5f, 0xab, 0xb9, 0xb5, 0x49, 0x73, 0xdd, 0xbf,
+	0x06, 0x6c, 0x14, 0xca, 0xd2, 0x96, 0x9f, 0x43, 0x39, 0x73, 0xa0, 0x14, 0xcd, 0xe3, 0x58, 0xf3,
+	0xd1, 0x33, 0x28, 0x67, 0xc7, 0x56, 0x49, 0xf5, 0xaa, 0x39, 0x3b, 0xf2, 0x20, 0x05, 0x58, 0xd3,
+	0xd1, 0x3d, 0xa8, 0x0d, 0x18, 0xe7, 0x49, 0x2c, 0x49, 0xa0, 0x6e, 0xdb, 0xc4, 0x17, 0x3f, 0xd2,
+	0xb4, 0x92, 0x49, 0x3f, 0x14, 0xd6, 0xc2, 0xec, 0xb4, 0x5b, 0x03, 0x49, 0x59, 0x74, 0x98, 0xf2,
+	0xb0, 0xa6, 0xbb, 0x5f, 0x4a, 0x50, 0xcf, 0x95, 0x43, 0x2f, 0x60, 0x49, 0x10, 0x4e, 0xfd, 0xb0,
+	0x17, 0x25, 0xa3, 0x3e, 0xe1, 0xba, 0xe5, 0xab, 0xba, 0xe5, 0x8d, 0xae, 0x3a, 0xdc, 0x57, 0x67,
+	0xb8, 0x21, 0x72, 0x08, 0x3d, 0x84, 0x6a, 0x4c, 0xc9, 0x40, 0x5d, 0x54, 0x49, 0x45, 0xad, 0xe8,
+	0xa8, 0xca, 0xdb, 0xf4, 0x7f, 0x67, 0x17, 0x57, 0x14, 0xa1, 0x13, 0xa0, 0x35, 0x28, 0xfb, 0x4a,
+	0x8d, 0x1e, 0x5c, 0x8d, 0xd2, 0xd1, 0x0c, 0xe9, 0x88, 0x4a, 0x3d, 0xb3, 0x19, 0x50, 0xec, 0x11,
+	0x4b, 0x22, 0xa9, 0x26, 0xd6, 0xc4, 0x1a, 0xa1, 0x37, 0xb0, 0xac, 0xac, 0xf5, 0xd4, 0xcd, 0xa5,
+	0xd9, 0xca, 0xd7, 0x59, 0x26, 0x15, 0xbb, 0xa3, 0x43, 0xdd, 0x2e, 0xd4, 0x73, 0x0d, 0xca, 0x29,
+	0x34, 0xae, 0x2a, 0x1c, 0x28, 0x29, 0xa5, 0x4c, 0xa1, 0x02, 0x39, 0x85, 0x66, 0x5e, 0xa1, 0xfb,
+	0xcd, 0x80, 0xf5, 0x2e, 0x91, 0x32, 0x24, 0x37, 0x69, 0xbc, 0x5f, 0x83, 0x5d, 0x24, 0x4a, 0x0f,
+	0xf7, 0x1a, 0x94, 0x85, 0xf4, 0x65, 0x22, 0xc6, 0xce, 0x33, 0x54, 0xec, 0x5c, 0x39, 0xc4, 0x24,
+	0xf6, 0x29, 0xbf, 0x49, 0x0e, 0x39, 0xd8, 0x45, 0xa2, 0xb4, 0x43, 0x1b, 0xaa, 0xc2, 0x0f, 0x4f,
+	0xfc, 0x21, 0xc9, 0x14, 0x99, 0x78, 0x82, 0x2f, 0xef, 0x59, 0xe9, 0xea, 0x9e, 0xd9, 0x50, 0xe5,
+	0x2a, 0xaf, 0x5e, 0xc2, 0x2a, 0x9e, 0xe0, 0xcd, 0x3f, 0x26, 0x40, 0x6a, 0xe8, 0x20, 0x5b, 0xd8,
+	0x18, 0xee, 0x4c, 0xbd, 0x99, 0xe8, 0x51, 0xd1, 0x5e, 0xce, 0x7a, 0x76, 0xed, 0xc7, 0x73, 0xb2,
+	0xb5, 0xad, 0x13, 0xb8, 0x5b, 0xf0, 0x68, 0x21, 0xaf, 0x28, 0xcb, 0xec, 0x47, 0xd7, 0x6e, 0xcf,
+	0xcd, 0xd7, 0x75, 0x05, 0xa0, 0xe9, 0x71, 0x42, 0x85, 0xe2, 0x67, 0xee, 0x82, 0xed, 0xcd, 0x4b,
+	0xbf, 0x28, 0x3a, 0x7d, 0xc3, 0xc5, 0x45, 0x67, 0x8e, 0xa7, 0xed, 0xcd, 0x4b, 0xcf, 0x8a, 0x6e,
+	0x3f, 0x78, 0x7f, 0x3f, 0x0d, 0xf8, 0xe8, 0x51, 0xd6, 0x56, 0x1f, 0xed, 0x5c, 0x7c, 0x9b, 0x46,
+	0x92, 0xf0, 0xc8, 0x0f, 0xe3, 0x7e, 0xbf, 0xac, 0x06, 0xf5, 0xe9, 0xbf, 0x01, 0x00, 0x45, 0xb3,
+	0x29, 0x3a, 0x87, 0x08, 0x00, 0x00,
+}
+
+// --- DRPC BEGIN ---
+
+type DRPCNodeOrdersClient interface {
+	DRPCConn() drpc.Conn
+
+	// ListUnsentWindows returns the unsent orders windows of all satellites.
+	ListUnsentWindows(ctx context.Context, in *ListUnsentWindowsRequest) (*ListUnsentWindowsResponse, error)
+	// InspectUnsentWindow returns the orders of an unsent window and their totals.
+	InspectUnsentWindow(ctx context.Context, in *InspectUnsentWindowRequest) (*InspectUnsentWindowResponse, error)
+	// SettleUnsentWindow sends the orders of an unsent window to the satellite immediately.
+	SettleUnsentWindow(ctx context.Context, in *SettleUnsentWindowRequest) (*SettleUnsentWindowResponse, error)
+	// RepairUnsentWindow salvages the valid orders of a corrupted unsent window.
+	RepairUnsentWindow(ctx context.Context, in *RepairUnsentWindowRequest) (*RepairUnsentWindowResponse, error)
+}
+
+type drpcNodeOrdersClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCNodeOrdersClient(cc drpc.Conn) DRPCNodeOrdersClient {
+	return &drpcNodeOrdersClient{cc}
+}
+
+func (c *drpcNodeOrdersClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcNodeOrdersClient) ListUnsentWindows(ctx context.Context, in *ListUnsentWindowsRequest) (*ListUnsentWindowsResponse, error) {
+	out := new(ListUnsentWindowsResponse)
+	err := c.cc.Invoke(ctx, "/storagenode.orders.NodeOrders/ListUnsentWindows", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcNodeOrdersClient) InspectUnsentWindow(ctx context.Context, in *InspectUnsentWindowRequest) (*InspectUnsentWindowResponse, error) {
+	out := new(InspectUnsentWindowResponse)
+	err := c.cc.Invoke(ctx, "/storagenode.orders.NodeOrders/InspectUnsentWindow", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcNodeOrdersClient) SettleUnsentWindow(ctx context.Context, in *SettleUnsentWindowRequest) (*SettleUnsentWindowResponse, error) {
+	out := new(SettleUnsentWindowResponse)
+	err := c.cc.Invoke(ctx, "/storagenode.orders.NodeOrders/SettleUnsentWindow", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcNodeOrdersClient) RepairUnsentWindow(ctx context.Context, in *RepairUnsentWindowRequest) (*RepairUnsentWindowResponse, error) {
+	out := new(RepairUnsentWindowResponse)
+	err := c.cc.Invoke(ctx, "/storagenode.orders.NodeOrders/RepairUnsentWindow", in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCNodeOrdersServer interface {
+	// ListUnsentWindows returns the unsent orders windows of all satellites.
+	ListUnsentWindows(context.Context, *ListUnsentWindowsRequest) (*ListUnsentWindowsResponse, error)
+	// InspectUnsentWindow returns the orders of an unsent window and their totals.
+	InspectUnsentWindow(context.Context, *InspectUnsentWindowRequest) (*InspectUnsentWindowResponse, error)
+	// SettleUnsentWindow sends the orders of an unsent window to the satellite immediately.
+	SettleUnsentWindow(context.Context, *SettleUnsentWindowRequest) (*SettleUnsentWindowResponse, error)
+	// RepairUnsentWindow salvages the valid orders of a corrupted unsent window.
+	RepairUnsentWindow(context.Context, *RepairUnsentWindowRequest) (*RepairUnsentWindowResponse, error)
+}
+
+type DRPCNodeOrdersDescription struct{}
+
+func (DRPCNodeOrdersDescription) NumMethods() int { return 4 }
+
+func (DRPCNodeOrdersDescription) Method(n int) (string, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/storagenode.orders.NodeOrders/ListUnsentWindows",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNodeOrdersServer).
+					ListUnsentWindows(
+						ctx,
+						in1.(*ListUnsentWindowsRequest),
+					)
+			}, DRPCNodeOrdersServer.ListUnsentWindows, true
+	case 1:
+		return "/storagenode.orders.NodeOrders/InspectUnsentWindow",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNodeOrdersServer).
+					InspectUnsentWindow(
+						ctx,
+						in1.(*InspectUnsentWindowRequest),
+					)
+			}, DRPCNodeOrdersServer.InspectUnsentWindow, true
+	case 2:
+		return "/storagenode.orders.NodeOrders/SettleUnsentWindow",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNodeOrdersServer).
+					SettleUnsentWindow(
+						ctx,
+						in1.(*SettleUnsentWindowRequest),
+					)
+			}, DRPCNodeOrdersServer.SettleUnsentWindow, true
+	case 3:
+		return "/storagenode.orders.NodeOrders/RepairUnsentWindow",
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNodeOrdersServer).
+					RepairUnsentWindow(
+						ctx,
+						in1.(*RepairUnsentWindowRequest),
+					)
+			}, DRPCNodeOrdersServer.RepairUnsentWindow, true
+	default:
+		return "", nil, nil, false
+	}
+}
+
+func DRPCRegisterNodeOrders(mux drpc.Mux, impl DRPCNodeOrdersServer) error {
+	return mux.Register(impl, DRPCNodeOrdersDescription{})
+}
+
+type DRPCNodeOrders_ListUnsentWindowsStream interface {
+	drpc.Stream
+	SendAndClose(*ListUnsentWindowsResponse) error
+}
+
+type drpcNodeOrdersListUnsentWindowsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNodeOrdersListUnsentWindowsStream) SendAndClose(m *ListUnsentWindowsResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCNodeOrders_InspectUnsentWindowStream interface {
+	drpc.Stream
+	SendAndClose(*InspectUnsentWindowResponse) error
+}
+
+type drpcNodeOrdersInspectUnsentWindowStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNodeOrdersInspectUnsentWindowStream) SendAndClose(m *InspectUnsentWindowResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCNodeOrders_SettleUnsentWindowStream interface {
+	drpc.Stream
+	SendAndClose(*SettleUnsentWindowResponse) error
+}
+
+type drpcNodeOrdersSettleUnsentWindowStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNodeOrdersSettleUnsentWindowStream) SendAndClose(m *SettleUnsentWindowResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCNodeOrders_RepairUnsentWindowStream interface {
+	drpc.Stream
+	SendAndClose(*RepairUnsentWindowResponse) error
+}
+
+type drpcNodeOrdersRepairUnsentWindowStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNodeOrdersRepairUnsentWindowStream) SendAndClose(m *RepairUnsentWindowResponse) error {
+	if err := x.MsgSend(m); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+// --- DRPC END ---
@@ -10,3 +10,6 @@ type PieceID = storj.PieceID
 
 // NodeID is an alias to storj.NodeID for use in generated protobuf code.
 type NodeID = storj.NodeID
+
+// SerialNumber is an alias to storj.SerialNumber for use in generated protobuf code.
+type SerialNumber = storj.SerialNumber
@@ -5,6 +5,9 @@ package monitor
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/spacemonkeygo/monkit/v3"
@@ -14,9 +17,11 @@ import (
 
 	"storj.io/common/memory"
 	"storj.io/common/pb"
+	"storj.io/common/storj"
 	"storj.io/common/sync2"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/contact"
+	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/pieces"
 )
 
@@ -35,6 +40,17 @@ type Config struct {
 	MinimumDiskSpace          memory.Size   `help:"how much disk space a node at minimum has to advertise" default:"500GB"`
 	MinimumBandwidth          memory.Size   `help:"how much bandwidth a node at minimum has to advertise (deprecated)" default:"0TB"`
 	NotifyLowDiskCooldown     time.Duration `help:"minimum length of time between capacity reports" default:"10m" hidden:"true"`
+
+	DiskHealth DiskHealthConfig
+}
+
+// DiskHealthConfig defines when the storage disk is considered degraded.
+type DiskHealthConfig struct {
+	Interval       time.Duration `help:"how frequently to evaluate the health of the storage disk" default:"1m"`
+	MinimumSamples int           `help:"minimum number of recent disk reads or writes needed to evaluate the disk health" default:"100"`
+	ReadLatency    time.Duration `help:"99th percentile of disk read latency above which the disk is considered degraded" default:"10s"`
+	WriteLatency   time.Duration `help:"99th percentile of disk write latency above which the disk is considered degraded" default:"30s"`
+	ErrorRate      float64       `help:"rate of failed disk reads or writes above which the disk is considered degraded" default:"0.05"`
 }
 
 // Service which monitors disk usage.
@@ -44,27 +60,34 @@ type Service struct {
 	log                   *zap.Logger
 	store                 *pieces.Store
 	contact               *contact.Service
+	notifications         *notifications.Service
 	usageDB               bandwidth.DB
 	allocatedDiskSpace    int64
+	satelliteQuotas       SatelliteQuotas
 	cooldown              *sync2.Cooldown
+	degraded              int32
 	Loop                  *sync2.Cycle
 	VerifyDirReadableLoop *sync2.Cycle
 	VerifyDirWritableLoop *sync2.Cycle
+	DiskHealthLoop        *sync2.Cycle
 	Config                Config
 }
 
 // NewService creates a new storage node monitoring service.
-func NewService(log *zap.Logger, store *pieces.Store, contact *contact.Service, usageDB bandwidth.DB, allocatedDiskSpace int64, interval time.Duration, reportCapacity func(context.Context), config Config) *Service {
+func NewService(log *zap.Logger, store *pieces.Store, contact *contact.Service, notifications *notifications.Service, usageDB bandwidth.DB, allocatedDiskSpace int64, satelliteQuotas SatelliteQuotas, interval time.Duration, reportCapacity func(context.Context), config Config) *Service {
 	return &Service{
 		log:                   log,
 		store:                 store,
 		contact:               contact,
+		notifications:         notifications,
 		usageDB:               usageDB,
 		allocatedDiskSpace:    allocatedDiskSpace,
+		satelliteQuotas:       satelliteQuotas,
 		cooldown:              sync2.NewCooldown(config.NotifyLowDiskCooldown),
 		Loop:                  sync2.NewCycle(interval),
 		VerifyDirReadableLoop: sync2.NewCycle(config.VerifyDirReadableInterval),
 		VerifyDirWritableLoop: sync2.NewCycle(config.VerifyDirWritableInterval),
+		DiskHealthLoop:        sync2.NewCycle(config.DiskHealth.Interval),
 		Config:                config,
 	}
 }
@@ -132,6 +155,12 @@ func (service *Service) Run(ctx context.Context) (err error) {
 			return nil
 		})
 	})
+	group.Go(func() error {
+		return service.DiskHealthLoop.Run(ctx, func(ctx context.Context) error {
+			service.CheckDiskHealth(ctx)
+			return nil
+		})
+	})
 	group.Go(func() error {
 		return service.Loop.Run(ctx, func(ctx context.Context) error {
 			err := service.updateNodeInformation(ctx)
@@ -166,10 +195,126 @@ func (service *Service) NotifyLowDisk() {
 // Close stops the monitor service.
 func (service *Service) Close() (err error) {
 	service.Loop.Close()
+	service.DiskHealthLoop.Close()
 	service.cooldown.Close()
 	return nil
 }
 
+// Degraded returns whether the storage disk is considered degraded, in which case
+// no new uploads should be accepted.
+func (service *Service) Degraded() bool {
+	return atomic.LoadInt32(&service.degraded) != 0
+}
+
+// CheckDiskHealth evaluates the latency and error rate of the recent disk operations.
+// When the disk becomes degraded, the node reports no free space to the satellites
+// and the operator is notified. Downloads and audits are still served.
+func (service *Service) CheckDiskHealth(ctx context.Context) {
+	defer mon.Task()(&ctx)(nil)
+
+	health := service.store.DiskHealth()
+	stats := health.Stats()
+	config := service.Config.DiskHealth
+
+	// The statistics are reset when the disk becomes degraded, so it stays degraded
+	// until enough new operations, mostly downloads and audits, show it's healthy.
+	if service.Degraded() && stats.Reads.Count+stats.Writes.Count < config.MinimumSamples {
+		return
+	}
+
+	var reasons []string
+	check := func(kind string, ops pieces.DiskOpStats, maxLatency time.Duration) {
+		if ops.Count < config.MinimumSamples {
+			return
+		}
+		if maxLatency > 0 && ops.P99 > maxLatency {
+			reasons = append(reasons, fmt.Sprintf("%s latency p99 %v exceeds %v", kind, ops.P99, maxLatency))
+		}
+		if config.ErrorRate > 0 && ops.ErrorRate > config.ErrorRate {
+			reasons = append(reasons, fmt.Sprintf("%s error rate %.2f%% exceeds %.2f%%", kind, 100*ops.ErrorRate, 100*config.ErrorRate))
+		}
+	}
+	check("read", stats.Reads, config.ReadLatency)
+	check("write", stats.Writes, config.WriteLatency)
+
+	mon.DurationVal("disk_read_latency_p99").Observe(stats.Reads.P99)
+	mon.DurationVal("disk_write_latency_p99").Observe(stats.Writes.P99)
+	mon.FloatVal("disk_read_error_rate").Observe(stats.Reads.ErrorRate)
+	mon.FloatVal("disk_write_error_rate").Observe(stats.Writes.ErrorRate)
+
+	// uploads are refused while the disk is degraded, so the recent operations
+	// don't show whether writing recovered as well.
+	if len(reasons) == 0 && service.Degraded() {
+		if reason := service.probeWrite(ctx, config.WriteLatency); reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+
+	degraded := len(reasons) > 0
+	wasDegraded := atomic.SwapInt32(&service.degraded, boolToInt32(degraded)) != 0
+	mon.IntVal("disk_degraded").Observe(int64(boolToInt32(degraded)))
+
+	switch {
+	case degraded && !wasDegraded:
+		service.log.Error("Storage disk is degraded, not accepting uploads.", zap.Strings("Reasons", reasons))
+		service.notifyDegraded(ctx, reasons)
+		health.Reset()
+	case !degraded && wasDegraded:
+		service.log.Info("Storage disk recovered, accepting uploads again.")
+	default:
+		return
+	}
+
+	// report the changed capacity to the satellites right away.
+	if err := service.updateNodeInformation(ctx); err != nil {
+		service.log.Error("error during updating node information: ", zap.Error(err))
+	}
+	service.NotifyLowDisk()
+}
+
+// probeWrite writes to the storage disk and returns why writing isn't healthy,
+// or an empty string when it is.
+func (service *Service) probeWrite(ctx context.Context, maxLatency time.Duration) string {
+	defer mon.Task()(&ctx)(nil)
+
+	start := time.Now()
+	err := service.store.ProbeWrite()
+	duration := time.Since(start)
+	mon.DurationVal("disk_write_probe_latency").Observe(duration)
+
+	switch {
+	case err != nil:
+		return fmt.Sprintf("write probe failed: %v", err)
+	case maxLatency > 0 && duration > maxLatency:
+		return fmt.Sprintf("write probe latency %v exceeds %v", duration, maxLatency)
+	default:
+		return ""
+	}
+}
+
+// notifyDegraded raises a notification for the operator about the degraded disk.
+func (service *Service) notifyDegraded(ctx context.Context, reasons []string) {
+	if service.notifications == nil {
+		return
+	}
+	_, err := service.notifications.Receive(ctx, notifications.NewNotification{
+		SenderID: service.contact.Local().ID,
+		Type:     notifications.TypeDiskDegraded,
+		Title:    "Your storage disk is degraded",
+		Message:  "Your node stopped accepting uploads because the storage disk is slow or failing: " + strings.Join(reasons, "; ") + ". Please check the disk.",
+	})
+	if err != nil {
+		service.log.Error("failed to create disk degraded notification", zap.Error(err))
+	}
+}
+
+func boolToInt32(v bool) int32 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
 func (service *Service) updateNodeInformation(ctx context.Context) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -177,10 +322,23 @@ func (service *Service) updateNodeInformation(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if service.Degraded() {
+		freeSpace = 0
+	}
 	service.contact.UpdateSelf(&pb.NodeCapacity{
 		FreeDisk: freeSpace,
 	})
 
+	for _, quota := range service.satelliteQuotas {
+		satelliteFreeSpace, err := service.availableSpaceForQuota(ctx, quota, freeSpace)
+		if err != nil {
+			return err
+		}
+		service.contact.UpdateSatellite(quota.SatelliteID, &pb.NodeCapacity{
+			FreeDisk: satelliteFreeSpace,
+		})
+	}
+
 	return nil
 }
 
@@ -217,3 +375,40 @@ func (service *Service) AvailableSpace(ctx context.Context) (_ int64, err error)
 
 	return freeSpaceForStorj, nil
 }
+
+// AvailableSpaceForSatellite returns available disk space for uploads from the
+// satellite, taking the satellite's quota into account.
+func (service *Service) AvailableSpaceForSatellite(ctx context.Context, satelliteID storj.NodeID) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	freeSpace, err := service.AvailableSpace(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	quota, ok := service.satelliteQuotas.Find(satelliteID)
+	if !ok {
+		return freeSpace, nil
+	}
+	return service.availableSpaceForQuota(ctx, quota, freeSpace)
+}
+
+// availableSpaceForQuota returns the space left in the satellite's quota,
+// capped by the space available to the whole node.
+func (service *Service) availableSpaceForQuota(ctx context.Context, quota SatelliteQuota, freeSpace int64) (_ int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	usedSpace, _, err := service.store.SpaceUsedBySatellite(ctx, quota.SatelliteID)
+	if err != nil {
+		return 0, Error.Wrap(err)
+	}
+
+	freeSpaceForSatellite := quota.Limit(service.allocatedDiskSpace) - usedSpace
+	if freeSpaceForSatellite < 0 {
+		freeSpaceForSatellite = 0
+	}
+	if freeSpace < freeSpaceForSatellite {
+		freeSpaceForSatellite = freeSpace
+	}
+	return freeSpaceForSatellite, nil
+}
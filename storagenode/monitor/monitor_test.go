@@ -5,15 +5,21 @@ package monitor_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 
 	"storj.io/common/memory"
 	"storj.io/common/testcontext"
 	"storj.io/common/testrand"
+	"storj.io/storj/private/testblobs"
 	"storj.io/storj/private/testplanet"
+	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/internalpb"
+	"storj.io/storj/storagenode/notifications"
 )
 
 func TestMonitor(t *testing.T) {
@@ -43,3 +49,65 @@ func TestMonitor(t *testing.T) {
 		assert.NotZero(t, nodeAssertions, "No storage node were verifed")
 	})
 }
+
+func TestMonitorDiskHealth(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			StorageNodeDB: func(index int, db storagenode.DB, log *zap.Logger) (storagenode.DB, error) {
+				return testblobs.NewBadDB(log.Named("baddb"), db), nil
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		node := planet.StorageNodes[0]
+		monitor := node.Storage2.Monitor
+		monitor.DiskHealthLoop.Pause()
+		monitor.Loop.Pause()
+		// the storage directory checks fail the monitor while blobs fail
+		monitor.VerifyDirReadableLoop.Pause()
+		monitor.VerifyDirWritableLoop.Pause()
+
+		expectedData := testrand.Bytes(10 * memory.KiB)
+		require.NoError(t, planet.Uplinks[0].Upload(ctx, planet.Satellites[0], "testbucket", "test/path", expectedData))
+
+		// slow writes degrade the disk
+		health := node.Storage2.Store.DiskHealth()
+		for i := 0; i < monitor.Config.DiskHealth.MinimumSamples; i++ {
+			health.ObserveWrite(time.Minute, nil)
+		}
+		monitor.DiskHealthLoop.TriggerWait()
+		require.True(t, monitor.Degraded())
+		require.Zero(t, node.Contact.Service.Local().Capacity.FreeDisk)
+
+		page, err := node.DB.Notifications().List(ctx, notifications.Cursor{Limit: 10, Page: 1})
+		require.NoError(t, err)
+		require.Len(t, page.Notifications, 1)
+		require.Equal(t, notifications.TypeDiskDegraded, page.Notifications[0].Type)
+
+		// downloads are still served, uploads are refused
+		data, err := planet.Uplinks[0].Download(ctx, planet.Satellites[0], "testbucket", "test/path")
+		require.NoError(t, err)
+		require.Equal(t, expectedData, data)
+
+		err = planet.Uplinks[0].Upload(ctx, planet.Satellites[0], "testbucket", "test/other", testrand.Bytes(10*memory.KiB))
+		require.Error(t, err)
+
+		// healthy reads don't recover the disk, while writing still fails
+		for i := 0; i < monitor.Config.DiskHealth.MinimumSamples; i++ {
+			health.ObserveRead(time.Millisecond, nil)
+		}
+		badDB := node.DB.(*testblobs.BadDB)
+		badDB.SetError(errs.New("write failed"))
+		monitor.DiskHealthLoop.TriggerWait()
+		require.True(t, monitor.Degraded())
+
+		// healthy reads and writes recover the disk
+		badDB.SetError(nil)
+		for i := 0; i < monitor.Config.DiskHealth.MinimumSamples; i++ {
+			health.ObserveRead(time.Millisecond, nil)
+		}
+		monitor.DiskHealthLoop.TriggerWait()
+		require.False(t, monitor.Degraded())
+		require.NotZero(t, node.Contact.Service.Local().Capacity.FreeDisk)
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monitor
+
+import (
+	"strconv"
+	"strings"
+
+	"storj.io/common/memory"
+	"storj.io/common/storj"
+)
+
+// SatelliteQuota limits the disk space a single satellite may use, either as
+// an absolute size or as a percentage of the allocated disk space.
+type SatelliteQuota struct {
+	SatelliteID storj.NodeID
+	Size        memory.Size
+	Percent     float64
+}
+
+// Limit returns the disk space the satellite may use out of allocatedDiskSpace.
+func (quota SatelliteQuota) Limit(allocatedDiskSpace int64) int64 {
+	if quota.Percent > 0 {
+		return int64(float64(allocatedDiskSpace) * quota.Percent / 100)
+	}
+	return quota.Size.Int64()
+}
+
+// String returns the string representation of the quota.
+func (quota SatelliteQuota) String() string {
+	if quota.Percent > 0 {
+		return quota.SatelliteID.String() + ":" + strconv.FormatFloat(quota.Percent, 'f', -1, 64) + "%"
+	}
+	return quota.SatelliteID.String() + ":" + quota.Size.String()
+}
+
+// ParseSatelliteQuota parses a quota of the form <satellite id>:<size> or
+// <satellite id>:<percent>%.
+func ParseSatelliteQuota(value string) (SatelliteQuota, error) {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) != 2 {
+		return SatelliteQuota{}, Error.New("invalid satellite quota %q: expected <satellite id>:<size or percent>", value)
+	}
+
+	id, err := storj.NodeIDFromString(parts[0])
+	if err != nil {
+		return SatelliteQuota{}, Error.New("invalid satellite quota %q: %v", value, err)
+	}
+	quota := SatelliteQuota{SatelliteID: id}
+
+	if percent := strings.TrimSuffix(parts[1], "%"); percent != parts[1] {
+		quota.Percent, err = strconv.ParseFloat(percent, 64)
+		if err != nil {
+			return SatelliteQuota{}, Error.New("invalid satellite quota %q: %v", value, err)
+		}
+		if quota.Percent <= 0 || quota.Percent > 100 {
+			return SatelliteQuota{}, Error.New("invalid satellite quota %q: percent must be in (0, 100]", value)
+		}
+		return quota, nil
+	}
+
+	if err := quota.Size.Set(parts[1]); err != nil {
+		return SatelliteQuota{}, Error.New("invalid satellite quota %q: %v", value, err)
+	}
+	if quota.Size <= 0 {
+		return SatelliteQuota{}, Error.New("invalid satellite quota %q: size must be positive", value)
+	}
+	return quota, nil
+}
+
+// SatelliteQuotas is a list of per-satellite quotas that implements pflag.Value.
+type SatelliteQuotas []SatelliteQuota
+
+// String returns the string representation of the config.
+func (quotas SatelliteQuotas) String() string {
+	s := make([]string, 0, len(quotas))
+	for _, quota := range quotas {
+		s = append(s, quota.String())
+	}
+	return strings.Join(s, ",")
+}
+
+// Set implements pflag.Value by parsing a comma separated list of quotas.
+func (quotas *SatelliteQuotas) Set(value string) error {
+	var entries []string
+	if value != "" {
+		entries = strings.Split(value, ",")
+	}
+
+	var toSet SatelliteQuotas
+	seen := make(map[storj.NodeID]bool)
+	for _, entry := range entries {
+		quota, err := ParseSatelliteQuota(strings.TrimSpace(entry))
+		if err != nil {
+			return err
+		}
+		if seen[quota.SatelliteID] {
+			return Error.New("duplicate satellite quota for %s", quota.SatelliteID)
+		}
+		seen[quota.SatelliteID] = true
+		toSet = append(toSet, quota)
+	}
+
+	*quotas = toSet
+	return nil
+}
+
+// Type returns the type of the pflag.Value.
+func (quotas SatelliteQuotas) Type() string {
+	return "satellite-quotas"
+}
+
+// Find returns the quota of the satellite, if any.
+func (quotas SatelliteQuotas) Find(satelliteID storj.NodeID) (SatelliteQuota, bool) {
+	for _, quota := range quotas {
+		if quota.SatelliteID == satelliteID {
+			return quota, true
+		}
+	}
+	return SatelliteQuota{}, false
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monitor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/monitor"
+)
+
+func TestSatelliteQuotas(t *testing.T) {
+	a, b := testrand.NodeID(), testrand.NodeID()
+
+	var quotas monitor.SatelliteQuotas
+	require.NoError(t, quotas.Set(""))
+	require.Empty(t, quotas)
+
+	require.NoError(t, quotas.Set(a.String()+":500GB, "+b.String()+":12.5%"))
+	require.Len(t, quotas, 2)
+
+	quota, ok := quotas.Find(a)
+	require.True(t, ok)
+	require.Equal(t, 500*memory.GB.Int64(), quota.Limit(2*memory.TB.Int64()))
+
+	quota, ok = quotas.Find(b)
+	require.True(t, ok)
+	require.Equal(t, 250*memory.GB.Int64(), quota.Limit(2*memory.TB.Int64()))
+
+	_, ok = quotas.Find(testrand.NodeID())
+	require.False(t, ok)
+
+	// the string representation can be parsed again.
+	var parsed monitor.SatelliteQuotas
+	require.NoError(t, parsed.Set(quotas.String()))
+	require.Equal(t, quotas, parsed)
+
+	for _, invalid := range []string{
+		"500GB",
+		"invalid:500GB",
+		a.String() + ":",
+		a.String() + ":0GB",
+		a.String() + ":0%",
+		a.String() + ":150%",
+		a.String() + ":x%",
+		a.String() + ":1GB," + a.String() + ":2GB",
+	} {
+		require.Error(t, quotas.Set(invalid), invalid)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package multinode implements the storage node endpoints used by the multinode dashboard.
+package multinode
+
+import (
+	"context"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for multinode endpoints.
+	Error = errs.Class("multinode endpoint")
+)
+
+// authenticate checks that the request carries an api key issued by the storage node.
+func authenticate(ctx context.Context, apiKeys *apikeys.Service) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	apiKey, ok := multinodepb.APIKeyFromContext(ctx)
+	if !ok {
+		return rpcstatus.Error(rpcstatus.Unauthenticated, "api key is missing")
+	}
+
+	var secret apikeys.Secret
+	if len(apiKey) != len(secret) {
+		return rpcstatus.Error(rpcstatus.Unauthenticated, "invalid api key")
+	}
+	copy(secret[:], apiKey)
+
+	if err := apiKeys.Check(ctx, secret); err != nil {
+		if apikeys.ErrNoSecret.Has(err) {
+			return rpcstatus.Error(rpcstatus.Unauthenticated, "invalid api key")
+		}
+		return rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	return nil
+}
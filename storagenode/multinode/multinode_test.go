@@ -0,0 +1,131 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/private/version"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/multinode"
+	"storj.io/storj/storagenode/reputation"
+	"storj.io/storj/storagenode/storagenodedb/storagenodedbtest"
+)
+
+func TestEndpointsAuthentication(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		log := zaptest.NewLogger(t)
+		service := apikeys.NewService(db.Secret())
+
+		apiKey := issueAPIKey(ctx, t, db)
+
+		startedAt := time.Now().UTC()
+		semVer, err := version.NewSemVer("v1.2.3")
+		require.NoError(t, err)
+		versionInfo := version.Info{Version: semVer}
+		status := multinode.NewStatusEndpoint(log, service, startedAt, versionInfo)
+
+		// missing api key
+		_, err = status.Get(ctx, &multinodepb.GetRequest{})
+		require.Error(t, err)
+		require.Equal(t, rpcstatus.Unauthenticated, rpcstatus.Code(err))
+
+		// unknown api key
+		unknown, err := apikeys.NewSecret()
+		require.NoError(t, err)
+		_, err = status.Get(multinodepb.WithAPIKey(ctx, unknown[:]), &multinodepb.GetRequest{})
+		require.Error(t, err)
+		require.Equal(t, rpcstatus.Unauthenticated, rpcstatus.Code(err))
+
+		// malformed api key
+		_, err = status.Get(multinodepb.WithAPIKey(ctx, []byte("short")), &multinodepb.GetRequest{})
+		require.Error(t, err)
+		require.Equal(t, rpcstatus.Unauthenticated, rpcstatus.Code(err))
+
+		authCtx := multinodepb.WithAPIKey(ctx, apiKey.Secret[:])
+
+		resp, err := status.Get(authCtx, &multinodepb.GetRequest{})
+		require.NoError(t, err)
+		require.Equal(t, startedAt, resp.StartedAt)
+		require.Equal(t, "v1.2.3", resp.Version)
+
+		// revoked api key
+		require.NoError(t, service.Remove(ctx, apiKey.Secret))
+		_, err = status.Get(authCtx, &multinodepb.GetRequest{})
+		require.Error(t, err)
+		require.Equal(t, rpcstatus.Unauthenticated, rpcstatus.Code(err))
+	})
+}
+
+func TestReputationEndpoint(t *testing.T) {
+	storagenodedbtest.Run(t, func(ctx *testcontext.Context, t *testing.T, db storagenode.DB) {
+		service := apikeys.NewService(db.Secret())
+
+		apiKey := issueAPIKey(ctx, t, db)
+		authCtx := multinodepb.WithAPIKey(ctx, apiKey.Secret[:])
+
+		timestamp := time.Now().UTC().Truncate(time.Second)
+		stats := reputation.Stats{
+			SatelliteID: testrand.NodeID(),
+			Audit: reputation.Metric{
+				TotalCount:   10,
+				SuccessCount: 9,
+				Alpha:        9.5,
+				Beta:         0.5,
+				Score:        0.95,
+				UnknownAlpha: 1,
+				UnknownBeta:  0,
+				UnknownScore: 1,
+			},
+			OnlineScore:    0.9,
+			DisqualifiedAt: &timestamp,
+			UpdatedAt:      timestamp,
+			JoinedAt:       timestamp,
+		}
+		require.NoError(t, db.Reputation().Store(ctx, stats))
+
+		endpoint := multinode.NewReputationEndpoint(zaptest.NewLogger(t), service, db.Reputation())
+
+		resp, err := endpoint.GetBySatelliteID(authCtx, &multinodepb.GetBySatelliteIDRequest{SatelliteId: stats.SatelliteID})
+		require.NoError(t, err)
+		require.Equal(t, stats.SatelliteID, resp.SatelliteId)
+		require.Equal(t, stats.Audit.TotalCount, resp.AuditCheck.TotalCount)
+		require.Equal(t, stats.Audit.SuccessCount, resp.AuditCheck.SuccessCount)
+		require.Equal(t, stats.Audit.Score, resp.AuditCheck.ReputationScore)
+		require.Equal(t, stats.Audit.UnknownScore, resp.AuditCheck.UnknownReputationScore)
+		require.Equal(t, stats.OnlineScore, resp.OnlineScore)
+		require.NotNil(t, resp.Disqualified)
+		require.True(t, timestamp.Equal(*resp.Disqualified))
+		require.Nil(t, resp.Suspended)
+		require.True(t, timestamp.Equal(resp.JoinedAt))
+
+		all, err := endpoint.All(authCtx, &multinodepb.AllRequest{})
+		require.NoError(t, err)
+		require.Len(t, all.Reputation, 1)
+		require.Equal(t, resp.AuditCheck, all.Reputation[0].AuditCheck)
+	})
+}
+
+// issueAPIKey stores a new api key in the storage node database.
+func issueAPIKey(ctx *testcontext.Context, t *testing.T, db storagenode.DB) apikeys.APIKey {
+	secret, err := apikeys.NewSecret()
+	require.NoError(t, err)
+
+	apiKey := apikeys.APIKey{
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	}
+	require.NoError(t, db.Secret().Store(ctx, apiKey))
+
+	return apiKey
+}
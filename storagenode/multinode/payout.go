@@ -0,0 +1,207 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/payout"
+	"storj.io/storj/storagenode/payout/estimatedpayout"
+)
+
+var _ multinodepb.DRPCPayoutsServer = (*PayoutEndpoint)(nil)
+
+// PayoutEndpoint implements multinode payouts endpoint.
+//
+// architecture: Endpoint
+type PayoutEndpoint struct {
+	log        *zap.Logger
+	apiKeys    *apikeys.Service
+	payout     *payout.Service
+	estimation *estimatedpayout.Service
+}
+
+// NewPayoutEndpoint creates new multinode payouts endpoint.
+func NewPayoutEndpoint(log *zap.Logger, apiKeys *apikeys.Service, payout *payout.Service, estimation *estimatedpayout.Service) *PayoutEndpoint {
+	return &PayoutEndpoint{
+		log:        log,
+		apiKeys:    apiKeys,
+		payout:     payout,
+		estimation: estimation,
+	}
+}
+
+// PayStubs returns the paystubs of the specified satellite, or of all
+// satellites when the satellite id is not set, within the period range.
+func (endpoint *PayoutEndpoint) PayStubs(ctx context.Context, req *multinodepb.PayStubsRequest) (_ *multinodepb.PayStubsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	var payStubs []payout.PayStub
+	if req.SatelliteId.IsZero() {
+		payStubs, err = endpoint.payout.AllPayStubsPeriod(ctx, req.PeriodStart, req.PeriodEnd)
+	} else {
+		payStubs, err = endpoint.payout.SatellitePayStubPeriod(ctx, req.SatelliteId, req.PeriodStart, req.PeriodEnd)
+	}
+	if err != nil {
+		if payout.ErrBadPeriod.Has(err) {
+			return nil, rpcstatus.Wrap(rpcstatus.InvalidArgument, err)
+		}
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	resp := &multinodepb.PayStubsResponse{
+		PayStubs: make([]*multinodepb.PayStub, 0, len(payStubs)),
+	}
+	for _, payStub := range payStubs {
+		resp.PayStubs = append(resp.PayStubs, &multinodepb.PayStub{
+			SatelliteId:    payStub.SatelliteID,
+			Period:         payStub.Period,
+			Created:        payStub.Created,
+			Codes:          payStub.Codes,
+			UsageAtRest:    payStub.UsageAtRest,
+			UsageGet:       payStub.UsageGet,
+			UsagePut:       payStub.UsagePut,
+			UsageGetRepair: payStub.UsageGetRepair,
+			UsagePutRepair: payStub.UsagePutRepair,
+			UsageGetAudit:  payStub.UsageGetAudit,
+			CompAtRest:     payStub.CompAtRest,
+			CompGet:        payStub.CompGet,
+			CompPut:        payStub.CompPut,
+			CompGetRepair:  payStub.CompGetRepair,
+			CompPutRepair:  payStub.CompPutRepair,
+			CompGetAudit:   payStub.CompGetAudit,
+			SurgePercent:   payStub.SurgePercent,
+			Held:           payStub.Held,
+			Owed:           payStub.Owed,
+			Disposed:       payStub.Disposed,
+			Paid:           payStub.Paid,
+		})
+	}
+
+	return resp, nil
+}
+
+// HeldHistory returns the amounts held by all satellites since the node joined them.
+func (endpoint *PayoutEndpoint) HeldHistory(ctx context.Context, req *multinodepb.HeldHistoryRequest) (_ *multinodepb.HeldHistoryResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	history, err := endpoint.payout.AllHeldbackHistory(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	resp := &multinodepb.HeldHistoryResponse{
+		HeldHistory: make([]*multinodepb.HeldHistory, 0, len(history)),
+	}
+	for _, held := range history {
+		resp.HeldHistory = append(resp.HeldHistory, &multinodepb.HeldHistory{
+			SatelliteId:         held.SatelliteID,
+			SatelliteName:       held.SatelliteName,
+			HoldForFirstPeriod:  held.HoldForFirstPeriod,
+			HoldForSecondPeriod: held.HoldForSecondPeriod,
+			HoldForThirdPeriod:  held.HoldForThirdPeriod,
+			TotalHeld:           held.TotalHeld,
+			TotalDisposed:       held.TotalDisposed,
+			JoinedAt:            held.JoinedAt,
+		})
+	}
+
+	return resp, nil
+}
+
+// EstimatedPayout returns the estimated payout of the specified satellite, or
+// of all satellites when the satellite id is not set.
+func (endpoint *PayoutEndpoint) EstimatedPayout(ctx context.Context, req *multinodepb.EstimatedPayoutRequest) (_ *multinodepb.EstimatedPayoutResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	var estimated estimatedpayout.EstimatedPayout
+	if req.SatelliteId.IsZero() {
+		estimated, err = endpoint.estimation.GetAllSatellitesEstimatedPayout(ctx)
+	} else {
+		estimated, err = endpoint.estimation.GetSatelliteEstimatedPayout(ctx, req.SatelliteId)
+	}
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	return &multinodepb.EstimatedPayoutResponse{
+		CurrentMonth:  estimatedMonthlyPayoutToPB(estimated.CurrentMonth),
+		PreviousMonth: estimatedMonthlyPayoutToPB(estimated.PreviousMonth),
+	}, nil
+}
+
+// PaymentHistory returns the payments of all satellites for the period.
+func (endpoint *PayoutEndpoint) PaymentHistory(ctx context.Context, req *multinodepb.PaymentHistoryRequest) (_ *multinodepb.PaymentHistoryResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	payments, err := endpoint.payout.AllSatellitesPayoutPeriod(ctx, req.Period)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	resp := &multinodepb.PaymentHistoryResponse{
+		Payments: make([]*multinodepb.SatellitePayment, 0, len(payments)),
+	}
+	for _, payment := range payments {
+		satelliteID, err := storj.NodeIDFromString(payment.SatelliteID)
+		if err != nil {
+			return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+		}
+
+		resp.Payments = append(resp.Payments, &multinodepb.SatellitePayment{
+			SatelliteId:    satelliteID,
+			SatelliteUrl:   payment.SatelliteURL,
+			Age:            payment.Age,
+			Earned:         payment.Earned,
+			Surge:          payment.Surge,
+			SurgePercent:   payment.SurgePercent,
+			Held:           payment.Held,
+			HeldPercent:    payment.HeldPercent,
+			AfterHeld:      payment.AfterHeld,
+			Disposed:       payment.Disposed,
+			Paid:           payment.Paid,
+			Receipt:        payment.Receipt,
+			IsExitComplete: payment.IsExitComplete,
+		})
+	}
+
+	return resp, nil
+}
+
+// estimatedMonthlyPayoutToPB converts the estimated payout of a month to its protobuf representation.
+func estimatedMonthlyPayoutToPB(payout estimatedpayout.PayoutMonthly) *multinodepb.EstimatedMonthlyPayout {
+	return &multinodepb.EstimatedMonthlyPayout{
+		EgressBandwidth:         payout.EgressBandwidth,
+		EgressBandwidthPayout:   payout.EgressBandwidthPayout,
+		EgressRepairAudit:       payout.EgressRepairAudit,
+		EgressRepairAuditPayout: payout.EgressRepairAuditPayout,
+		DiskSpace:               payout.DiskSpace,
+		DiskSpacePayout:         payout.DiskSpacePayout,
+		HeldRate:                payout.HeldRate,
+		Payout:                  payout.Payout,
+		Held:                    payout.Held,
+	}
+}
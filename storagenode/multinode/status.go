@@ -0,0 +1,51 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/private/version"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+)
+
+var _ multinodepb.DRPCStatusServer = (*StatusEndpoint)(nil)
+
+// StatusEndpoint implements multinode status endpoint.
+//
+// architecture: Endpoint
+type StatusEndpoint struct {
+	log         *zap.Logger
+	apiKeys     *apikeys.Service
+	startedAt   time.Time
+	versionInfo version.Info
+}
+
+// NewStatusEndpoint creates new multinode status endpoint.
+func NewStatusEndpoint(log *zap.Logger, apiKeys *apikeys.Service, startedAt time.Time, versionInfo version.Info) *StatusEndpoint {
+	return &StatusEndpoint{
+		log:         log,
+		apiKeys:     apiKeys,
+		startedAt:   startedAt,
+		versionInfo: versionInfo,
+	}
+}
+
+// Get returns the time the storage node was started at and its version.
+func (endpoint *StatusEndpoint) Get(ctx context.Context, req *multinodepb.GetRequest) (_ *multinodepb.GetResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, endpoint.apiKeys); err != nil {
+		return nil, err
+	}
+
+	return &multinodepb.GetResponse{
+		StartedAt: endpoint.startedAt,
+		Version:   endpoint.versionInfo.Version.String(),
+	}, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package multinode
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/memory"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/storagenode/apikeys"
+	"storj.io/storj/storagenode/pieces"
+	"storj.io/storj/storagenode/storageusage"
+)
+
+var _ multinodepb.DRPCNodeDiskSpaceServer = (*StorageEndpoint)(nil)
+
+// StorageEndpoint implements multinode disk space endpoint.
+//
+// architecture: Endpoint
+type StorageEndpoint struct {
+	log                *zap.Logger
+	apiKeys            *apikeys.Service
+	nodeID             storj.NodeID
+	pieceStore         *pieces.Store
+	usage              storageusage.DB
+	allocatedDiskSpace memory.Size
+}
+
+// NewStorageEndpoint creates new multinode disk space endpoint.
+func NewStorageEndpoint(log *zap.Logger, apiKeys *apikeys.Service, nodeID storj.NodeID, pieceStore *pieces.Store, usage storageusage.DB, allocatedDiskSpace memory.Size) *StorageEndpoint {
+	return &StorageEndpoint{
+		log:                log,
+		apiKeys:            apiKeys,
+		nodeID:             nodeID,
+		pieceStore:         pieceStore,
+		usage:              usage,
+		allocatedDiskSpace: allocatedDiskSpace,
+	}
+}
+
+// GetDiskSpace returns the disk space used by pieces and trash, and the allocated disk space.
+func (storage *StorageEndpoint) GetDiskSpace(ctx context.Context, req *multinodepb.GetDiskSpaceRequest) (_ *multinodepb.GetDiskSpaceResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, storage.apiKeys); err != nil {
+		return nil, err
+	}
+
+	used, _, err := storage.pieceStore.SpaceUsedForPieces(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	trash, err := storage.pieceStore.SpaceUsedForTrash(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	diskSpace := &multinodepb.DiskSpace{
+		Used:      used,
+		Available: storage.allocatedDiskSpace.Int64(),
+		Trash:     trash,
+	}
+
+	if overused := storage.allocatedDiskSpace.Int64() - used - trash; overused < 0 {
+		diskSpace.Overused = -overused
+	}
+
+	return &multinodepb.GetDiskSpaceResponse{
+		DiskSpace: diskSpace,
+	}, nil
+}
+
+// DailyStorageUsage returns daily storage usage for the specified satellite, or
+// for all satellites when the satellite id is not set, within the time range.
+func (storage *StorageEndpoint) DailyStorageUsage(ctx context.Context, req *multinodepb.DailyStorageUsageRequest) (_ *multinodepb.DailyStorageUsageResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, storage.apiKeys); err != nil {
+		return nil, err
+	}
+
+	var stamps []storageusage.Stamp
+	if req.SatelliteId.IsZero() {
+		stamps, err = storage.usage.GetDailyTotal(ctx, req.From, req.To)
+	} else {
+		stamps, err = storage.usage.GetDaily(ctx, req.SatelliteId, req.From, req.To)
+	}
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	usage := make([]*multinodepb.DailyStorageUsageResponse_StorageUsage, 0, len(stamps))
+	for _, stamp := range stamps {
+		usage = append(usage, &multinodepb.DailyStorageUsageResponse_StorageUsage{
+			AtRestTotal: stamp.AtRestTotal,
+			Timestamp:   stamp.IntervalStart,
+		})
+	}
+
+	return &multinodepb.DailyStorageUsageResponse{
+		NodeId:            storage.nodeID.Bytes(),
+		DailyStorageUsage: usage,
+	}, nil
+}
+
+// SatelliteSummary returns the storage usage of the specified satellite, or of
+// all satellites when the satellite id is not set, within the time range.
+func (storage *StorageEndpoint) SatelliteSummary(ctx context.Context, req *multinodepb.SatelliteSummaryRequest) (_ *multinodepb.SatelliteSummaryResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err = authenticate(ctx, storage.apiKeys); err != nil {
+		return nil, err
+	}
+
+	var summary float64
+	if req.SatelliteId.IsZero() {
+		summary, err = storage.usage.Summary(ctx, req.From, req.To)
+	} else {
+		summary, err = storage.usage.SatelliteSummary(ctx, req.SatelliteId, req.From, req.To)
+	}
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	return &multinodepb.SatelliteSummaryResponse{
+		StorageUsage: summary,
+	}, nil
+}
@@ -37,6 +37,8 @@ const (
 	TypeDisqualification Type = 3
 	// TypeSuspension is a notification type which describes node's suspension status.
 	TypeSuspension Type = 4
+	// TypeDiskDegraded is a notification type which describes a slow or failing storage disk.
+	TypeDiskDegraded Type = 5
 )
 
 // NewNotification holds notification entity info which is being received from satellite or local client.
@@ -0,0 +1,122 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package orders
+
+import (
+	"context"
+	"time"
+
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/storj/storagenode/internalpb"
+)
+
+// Endpoint implements the private orders endpoint used by the storagenode
+// orders command.
+//
+// architecture: Endpoint
+type Endpoint struct {
+	service *Service
+}
+
+// NewEndpoint creates a new private orders endpoint.
+func NewEndpoint(service *Service) *Endpoint {
+	return &Endpoint{service: service}
+}
+
+// ListUnsentWindows returns the unsent orders windows of all satellites.
+func (endpoint *Endpoint) ListUnsentWindows(ctx context.Context, req *internalpb.ListUnsentWindowsRequest) (_ *internalpb.ListUnsentWindowsResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	windows, err := endpoint.service.ListUnsentWindows(ctx, time.Now())
+	if err != nil && len(windows) == 0 {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	response := &internalpb.ListUnsentWindowsResponse{}
+	for _, window := range windows {
+		response.Windows = append(response.Windows, unsentWindowToProto(window))
+	}
+	return response, nil
+}
+
+// InspectUnsentWindow returns the orders of an unsent window and their totals.
+func (endpoint *Endpoint) InspectUnsentWindow(ctx context.Context, req *internalpb.InspectUnsentWindowRequest) (_ *internalpb.InspectUnsentWindowResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	summary, err := endpoint.service.InspectUnsentWindow(ctx, req.SatelliteId, req.CreatedAt, time.Now())
+	if err != nil {
+		return nil, windowError(err)
+	}
+
+	response := &internalpb.InspectUnsentWindowResponse{
+		Window:    unsentWindowToProto(summary.Window),
+		Corrupted: int64(summary.Corrupted),
+	}
+	for _, info := range summary.Orders {
+		response.Orders = append(response.Orders, &internalpb.UnsentOrder{
+			SerialNumber:  info.Limit.SerialNumber,
+			PieceId:       info.Limit.PieceId,
+			Action:        info.Limit.Action.String(),
+			Limit:         info.Limit.Limit,
+			Amount:        info.Order.Amount,
+			OrderCreation: info.Limit.OrderCreation,
+		})
+	}
+	for _, total := range summary.Totals {
+		response.Totals = append(response.Totals, &internalpb.ActionTotal{
+			Action: total.Action.String(),
+			Count:  total.Count,
+			Amount: total.Amount,
+		})
+	}
+	return response, nil
+}
+
+// SettleUnsentWindow sends the orders of an unsent window to the satellite immediately.
+func (endpoint *Endpoint) SettleUnsentWindow(ctx context.Context, req *internalpb.SettleUnsentWindowRequest) (_ *internalpb.SettleUnsentWindowResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	status, count, err := endpoint.service.SettleUnsentWindow(ctx, req.SatelliteId, req.CreatedAt, time.Now())
+	if err != nil {
+		return nil, windowError(err)
+	}
+
+	return &internalpb.SettleUnsentWindowResponse{
+		Status: status.String(),
+		Count:  int64(count),
+	}, nil
+}
+
+// RepairUnsentWindow salvages the valid orders of a corrupted unsent window.
+func (endpoint *Endpoint) RepairUnsentWindow(ctx context.Context, req *internalpb.RepairUnsentWindowRequest) (_ *internalpb.RepairUnsentWindowResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := endpoint.service.RepairUnsentWindow(ctx, req.SatelliteId, req.CreatedAt, time.Now())
+	if err != nil {
+		return nil, windowError(err)
+	}
+
+	return &internalpb.RepairUnsentWindowResponse{
+		Salvaged:  int64(result.Salvaged),
+		Corrupted: int64(result.Corrupted),
+		Repaired:  result.Repaired,
+	}, nil
+}
+
+func unsentWindowToProto(window UnsentWindow) *internalpb.UnsentWindow {
+	return &internalpb.UnsentWindow{
+		SatelliteId:   window.SatelliteID,
+		CreatedAtHour: window.CreatedAtHour.UTC(),
+		Version:       string(window.Version),
+		FileSize:      window.Size,
+		Ready:         window.Ready,
+	}
+}
+
+func windowError(err error) error {
+	if ErrWindowNotFound.Has(err) {
+		return rpcstatus.Wrap(rpcstatus.NotFound, err)
+	}
+	return rpcstatus.Wrap(rpcstatus.Internal, err)
+}
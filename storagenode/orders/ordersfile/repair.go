@@ -0,0 +1,87 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ordersfile
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+)
+
+// ReadAll reads all valid entries of the orders file at path.
+// Corrupted entries are skipped and counted. When the file is truncated, the
+// entries before the truncated one are returned.
+func ReadAll(path string, version Version) (infos []*Info, corrupted int, err error) {
+	of, err := OpenReadable(path, version)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = errs.Combine(err, of.Close()) }()
+
+	for {
+		info, err := of.ReadOne()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if ErrEntryCorrupt.Has(err) {
+				corrupted++
+				// V0 entries have no header to resynchronize on, so everything after
+				// a corrupted entry is unreadable, as is anything after a truncated entry.
+				if version == V0 || errors.Is(err, io.ErrUnexpectedEOF) {
+					break
+				}
+				continue
+			}
+			return infos, corrupted, err
+		}
+		infos = append(infos, info)
+	}
+
+	return infos, corrupted, nil
+}
+
+// Rewrite replaces the orders file at path with a file of the same version
+// containing only the entries. The new file is written to tempDir, which must
+// be on the same file system, and renamed, so the old file stays intact when
+// writing fails.
+func Rewrite(path, tempDir string, version Version, satelliteID storj.NodeID, createdAtHour time.Time, infos []*Info) (err error) {
+	tempPath := filepath.Join(tempDir, filepath.Base(path)+".tmp")
+	_ = os.Remove(tempPath)
+
+	var of Writable
+	if version == V0 {
+		of, err = OpenWritableV0(tempPath)
+	} else {
+		of, err = OpenWritableV1(tempPath, satelliteID, createdAtHour)
+	}
+	if err != nil {
+		return errs.Combine(err, removeTemp(tempPath))
+	}
+
+	for _, info := range infos {
+		if err := of.Append(info); err != nil {
+			return errs.Combine(err, of.Close(), removeTemp(tempPath))
+		}
+	}
+	if err := of.Close(); err != nil {
+		return errs.Combine(Error.Wrap(err), removeTemp(tempPath))
+	}
+
+	return Error.Wrap(os.Rename(tempPath, path))
+}
+
+func removeTemp(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return Error.Wrap(err)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"storj.io/common/rpc"
 	"storj.io/common/storj"
 	"storj.io/common/sync2"
+	"storj.io/storj/private/date"
 	"storj.io/storj/storagenode/orders/ordersfile"
 	"storj.io/storj/storagenode/trust"
 )
@@ -103,6 +104,16 @@ type Service struct {
 
 	Sender  *sync2.Cycle
 	Cleanup *sync2.Cycle
+
+	// settling contains the unsent windows, which are being settled.
+	settlingMu sync.Mutex
+	settling   map[settlingWindow]struct{}
+}
+
+// settlingWindow identifies the unsent window of a satellite by its creation hour.
+type settlingWindow struct {
+	satelliteID   storj.NodeID
+	createdAtHour int64
 }
 
 // NewService creates an order service.
@@ -117,6 +128,8 @@ func NewService(log *zap.Logger, dialer rpc.Dialer, ordersStore *FileStore, orde
 
 		Sender:  sync2.NewCycle(config.SenderInterval),
 		Cleanup: sync2.NewCycle(config.CleanupInterval),
+
+		settling: make(map[settlingWindow]struct{}),
 	}
 }
 
@@ -408,6 +421,23 @@ func (service *Service) sendOrdersFromFileStore(ctx context.Context, now time.Ti
 
 			group.Go(func() error {
 				log := service.log.Named(satelliteID.String())
+
+				release, ok := service.claimWindow(satelliteID, unsentInfo.CreatedAtHour)
+				if !ok {
+					// the window is settled by SettleUnsentWindow, try the satellite again later.
+					errorSatellitesMu.Lock()
+					errorSatellites[satelliteID] = struct{}{}
+					errorSatellitesMu.Unlock()
+					log.Debug("window is already being settled", zap.Time("created at", unsentInfo.CreatedAtHour))
+					return nil
+				}
+				defer release()
+
+				// the window could have been settled after it was listed.
+				if !service.ordersStore.unsentWindowExists(satelliteID, unsentInfo.CreatedAtHour, now) {
+					return nil
+				}
+
 				status, err := service.settleWindow(ctx, log, satelliteID, unsentInfo.InfoList)
 				if err != nil {
 					// satellite returned an error, but settlement was not explicitly rejected; we want to retry later
@@ -486,6 +516,83 @@ func (service *Service) settleWindow(ctx context.Context, log *zap.Logger, satel
 	return res.Status, nil
 }
 
+// ListUnsentWindows returns all unsent orders windows.
+func (service *Service) ListUnsentWindows(ctx context.Context, now time.Time) (_ []UnsentWindow, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.ordersStore.ListUnsentWindows(now)
+}
+
+// InspectUnsentWindow returns the orders of the unsent window of the satellite containing createdAt.
+func (service *Service) InspectUnsentWindow(ctx context.Context, satelliteID storj.NodeID, createdAt, now time.Time) (_ *WindowSummary, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.ordersStore.InspectUnsentWindow(satelliteID, createdAt, now)
+}
+
+// RepairUnsentWindow salvages the valid orders of a corrupted or truncated
+// unsent window of the satellite containing createdAt.
+func (service *Service) RepairUnsentWindow(ctx context.Context, satelliteID storj.NodeID, createdAt, now time.Time) (_ RepairResult, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return service.ordersStore.RepairUnsentWindow(satelliteID, createdAt, now)
+}
+
+// SettleUnsentWindow attempts to settle the unsent window of the satellite
+// containing createdAt immediately and archives it, when the satellite
+// responds with a settlement status.
+func (service *Service) SettleUnsentWindow(ctx context.Context, satelliteID storj.NodeID, createdAt, now time.Time) (status pb.SettlementWithWindowResponse_Status, count int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	release, ok := service.claimWindow(satelliteID, createdAt)
+	if !ok {
+		return 0, 0, OrderError.New("window %s is already being settled", createdAt.UTC().Truncate(time.Hour).Format(time.RFC3339))
+	}
+	defer release()
+
+	unsentInfo, err := service.ordersStore.ReadUnsentWindow(satelliteID, createdAt, now)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, service.config.SenderTimeout)
+	defer cancel()
+
+	log := service.log.Named(satelliteID.String())
+	status, err = service.settleWindow(ctx, log, satelliteID, unsentInfo.InfoList)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = service.ordersStore.Archive(satelliteID, unsentInfo, time.Now().UTC(), status)
+	if err != nil {
+		return status, len(unsentInfo.InfoList), err
+	}
+
+	return status, len(unsentInfo.InfoList), nil
+}
+
+// claimWindow claims the unsent window of the satellite containing createdAt,
+// so that it's only settled once. It returns false, when the window is already
+// being settled, otherwise the claim has to be released after settling.
+func (service *Service) claimWindow(satelliteID storj.NodeID, createdAt time.Time) (release func(), ok bool) {
+	window := settlingWindow{
+		satelliteID:   satelliteID,
+		createdAtHour: date.TruncateToHourInNano(createdAt),
+	}
+
+	service.settlingMu.Lock()
+	defer service.settlingMu.Unlock()
+
+	if _, ok := service.settling[window]; ok {
+		return nil, false
+	}
+	service.settling[window] = struct{}{}
+
+	return func() {
+		service.settlingMu.Lock()
+		defer service.settlingMu.Unlock()
+		delete(service.settling, window)
+	}, true
+}
+
 // sleep for random interval in [0;maxSleep).
 // Returns an error if context was cancelled.
 func (service *Service) sleep(ctx context.Context) error {
@@ -0,0 +1,253 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package orders
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/storj/private/date"
+	"storj.io/storj/storagenode/orders/ordersfile"
+)
+
+// ErrWindowNotFound is returned when there is no unsent orders file for a window.
+var ErrWindowNotFound = errs.Class("orders window not found")
+
+// UnsentWindow describes the unsent orders file of a satellite and order creation hour.
+type UnsentWindow struct {
+	SatelliteID   storj.NodeID
+	CreatedAtHour time.Time
+	Version       ordersfile.Version
+	Size          int64
+	// Ready is true when no more orders can be added to the window, so it
+	// can be settled.
+	Ready bool
+}
+
+// ActionTotal contains the number of orders and the total amount for a piece action.
+type ActionTotal struct {
+	Action pb.PieceAction
+	Count  int64
+	Amount int64
+}
+
+// WindowSummary contains the orders of an unsent window and their totals.
+type WindowSummary struct {
+	Window    UnsentWindow
+	Orders    []*ordersfile.Info
+	Corrupted int
+	Totals    []ActionTotal
+}
+
+// RepairResult contains the outcome of repairing an unsent orders file.
+type RepairResult struct {
+	Salvaged  int
+	Corrupted int
+	// Repaired is false when the file didn't contain any corrupted entries,
+	// in which case it's left unchanged.
+	Repaired bool
+}
+
+// ListUnsentWindows returns all unsent orders files, ordered by satellite and creation hour.
+func (store *FileStore) ListUnsentWindows(now time.Time) (_ []UnsentWindow, err error) {
+	store.unsentMu.Lock()
+	defer store.unsentMu.Unlock()
+
+	var errList errs.Group
+	var windows []UnsentWindow
+	err = filepath.Walk(store.unsentDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			errList.Add(OrderError.Wrap(err))
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		fileInfo, err := ordersfile.GetUnsentInfo(info)
+		if err != nil {
+			errList.Add(OrderError.Wrap(err))
+			return nil
+		}
+
+		windows = append(windows, UnsentWindow{
+			SatelliteID:   fileInfo.SatelliteID,
+			CreatedAtHour: fileInfo.CreatedAtHour,
+			Version:       fileInfo.Version,
+			Size:          info.Size(),
+			Ready:         store.windowReady(fileInfo.SatelliteID, fileInfo.CreatedAtHour, now),
+		})
+		return nil
+	})
+	errList.Add(err)
+
+	sort.Slice(windows, func(i, k int) bool {
+		if windows[i].SatelliteID != windows[k].SatelliteID {
+			return windows[i].SatelliteID.Less(windows[k].SatelliteID)
+		}
+		return windows[i].CreatedAtHour.Before(windows[k].CreatedAtHour)
+	})
+
+	return windows, errList.Err()
+}
+
+// InspectUnsentWindow reads the unsent orders of the window and calculates their totals.
+func (store *FileStore) InspectUnsentWindow(satelliteID storj.NodeID, createdAt, now time.Time) (_ *WindowSummary, err error) {
+	store.unsentMu.Lock()
+	defer store.unsentMu.Unlock()
+
+	window, path, err := store.findUnsentWindowLocked(satelliteID, createdAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	infos, corrupted, err := ordersfile.ReadAll(path, window.Version)
+	if err != nil {
+		return nil, OrderError.Wrap(err)
+	}
+
+	totals := make(map[pb.PieceAction]*ActionTotal)
+	for _, info := range infos {
+		total, ok := totals[info.Limit.Action]
+		if !ok {
+			total = &ActionTotal{Action: info.Limit.Action}
+			totals[info.Limit.Action] = total
+		}
+		total.Count++
+		total.Amount += info.Order.Amount
+	}
+
+	summary := &WindowSummary{
+		Window:    window,
+		Orders:    infos,
+		Corrupted: corrupted,
+	}
+	for _, total := range totals {
+		summary.Totals = append(summary.Totals, *total)
+	}
+	sort.Slice(summary.Totals, func(i, k int) bool {
+		return summary.Totals[i].Action < summary.Totals[k].Action
+	})
+
+	return summary, nil
+}
+
+// RepairUnsentWindow rewrites the unsent orders file of the window, keeping
+// only the entries, which can be read, when the file is corrupted or truncated.
+func (store *FileStore) RepairUnsentWindow(satelliteID storj.NodeID, createdAt, now time.Time) (_ RepairResult, err error) {
+	// holding unsentMu blocks orders from being appended to the file during the repair.
+	store.unsentMu.Lock()
+	defer store.unsentMu.Unlock()
+
+	window, path, err := store.findUnsentWindowLocked(satelliteID, createdAt, now)
+	if err != nil {
+		return RepairResult{}, err
+	}
+
+	infos, corrupted, err := ordersfile.ReadAll(path, window.Version)
+	if err != nil {
+		return RepairResult{}, OrderError.Wrap(err)
+	}
+
+	result := RepairResult{
+		Salvaged:  len(infos),
+		Corrupted: corrupted,
+	}
+	if corrupted == 0 {
+		return result, nil
+	}
+
+	err = ordersfile.Rewrite(path, store.ordersDir, window.Version, window.SatelliteID, window.CreatedAtHour, infos)
+	if err != nil {
+		return RepairResult{}, OrderError.Wrap(err)
+	}
+
+	mon.Meter("orders_unsent_file_repaired").Mark(1)
+	store.log.Info("repaired unsent orders file", zap.Stringer("Satellite ID", satelliteID), zap.Time("Created At", window.CreatedAtHour),
+		zap.Int("Salvaged", len(infos)), zap.Int("Corrupted", corrupted))
+
+	result.Repaired = true
+	return result, nil
+}
+
+// ReadUnsentWindow returns the orders of the window for settlement. It fails
+// when orders can still be added to the window.
+func (store *FileStore) ReadUnsentWindow(satelliteID storj.NodeID, createdAt, now time.Time) (_ UnsentInfo, err error) {
+	store.unsentMu.Lock()
+	defer store.unsentMu.Unlock()
+
+	window, path, err := store.findUnsentWindowLocked(satelliteID, createdAt, now)
+	if err != nil {
+		return UnsentInfo{}, err
+	}
+	if !window.Ready {
+		return UnsentInfo{}, OrderError.New("orders can still be added to the window %s", window.CreatedAtHour.UTC().Format(time.RFC3339))
+	}
+
+	infos, corrupted, err := ordersfile.ReadAll(path, window.Version)
+	if err != nil {
+		return UnsentInfo{}, OrderError.Wrap(err)
+	}
+	if corrupted > 0 {
+		store.log.Warn("Corrupted orders skipped in orders file", zap.Stringer("Satellite ID", satelliteID), zap.Int("Corrupted", corrupted))
+		mon.Meter("orders_unsent_file_corrupted").Mark(corrupted)
+	}
+
+	return UnsentInfo{
+		CreatedAtHour: window.CreatedAtHour,
+		Version:       window.Version,
+		InfoList:      infos,
+	}, nil
+}
+
+// unsentWindowExists returns whether the unsent orders file of the window
+// containing createdAt exists, i.e. the window hasn't been archived.
+func (store *FileStore) unsentWindowExists(satelliteID storj.NodeID, createdAt, now time.Time) bool {
+	store.unsentMu.Lock()
+	defer store.unsentMu.Unlock()
+
+	_, _, err := store.findUnsentWindowLocked(satelliteID, createdAt, now)
+	return err == nil
+}
+
+// findUnsentWindowLocked finds the unsent orders file of the window containing createdAt.
+func (store *FileStore) findUnsentWindowLocked(satelliteID storj.NodeID, createdAt, now time.Time) (UnsentWindow, string, error) {
+	createdAtHour := time.Unix(0, date.TruncateToHourInNano(createdAt))
+
+	for _, version := range []ordersfile.Version{ordersfile.V0, ordersfile.V1} {
+		path := filepath.Join(store.unsentDir, ordersfile.UnsentFileName(satelliteID, createdAtHour, version))
+		info, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return UnsentWindow{}, "", OrderError.Wrap(err)
+		}
+
+		return UnsentWindow{
+			SatelliteID:   satelliteID,
+			CreatedAtHour: createdAtHour,
+			Version:       version,
+			Size:          info.Size(),
+			Ready:         store.windowReady(satelliteID, createdAtHour, now),
+		}, path, nil
+	}
+
+	return UnsentWindow{}, "", ErrWindowNotFound.New("satellite %s, window %s", satelliteID, createdAtHour.UTC().Format(time.RFC3339))
+}
+
+// windowReady returns whether orders can't be added to the window anymore.
+func (store *FileStore) windowReady(satelliteID storj.NodeID, createdAtHour, now time.Time) bool {
+	// the newest order of the window is created an hour after the window starts.
+	if now.Sub(createdAtHour.Add(time.Hour)) <= store.orderLimitGracePeriod {
+		return false
+	}
+	return !store.hasActiveEnqueue(satelliteID, createdAtHour)
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package orders_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storagenode/orders"
+	"storj.io/storj/storagenode/orders/ordersfile"
+)
+
+func TestOrdersStore_InspectUnsentWindow(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+	dirName := ctx.Dir("test-orders")
+	now := time.Now()
+	tomorrow := now.Add(24 * time.Hour)
+
+	// make order limit grace period 1 hour
+	ordersStore, err := orders.NewFileStore(zaptest.NewLogger(t), dirName, time.Hour)
+	require.NoError(t, err)
+
+	// empty store means no windows can be listed
+	windows, err := ordersStore.ListUnsentWindows(tomorrow)
+	require.NoError(t, err)
+	require.Len(t, windows, 0)
+
+	originalInfos, err := storeNewOrders(ordersStore, 2, 3, []time.Time{now})
+	require.NoError(t, err)
+
+	// the windows can't be settled yet
+	windows, err = ordersStore.ListUnsentWindows(now)
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+	for _, window := range windows {
+		require.False(t, window.Ready)
+		require.Equal(t, ordersfile.V1, window.Version)
+		require.NotZero(t, window.Size)
+	}
+
+	windows, err = ordersStore.ListUnsentWindows(tomorrow)
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+
+	for _, window := range windows {
+		require.True(t, window.Ready)
+
+		// any time within the window identifies it
+		summary, err := ordersStore.InspectUnsentWindow(window.SatelliteID, window.CreatedAtHour.Add(time.Minute), tomorrow)
+		require.NoError(t, err)
+		require.Equal(t, window, summary.Window)
+		require.Zero(t, summary.Corrupted)
+		require.Len(t, summary.Orders, 3)
+
+		expectedTotals := make(map[pb.PieceAction]orders.ActionTotal)
+		for _, info := range summary.Orders {
+			verifyInfosEqual(t, info, originalInfos[info.Limit.SerialNumber])
+
+			total := expectedTotals[info.Limit.Action]
+			total.Action = info.Limit.Action
+			total.Count++
+			total.Amount += info.Order.Amount
+			expectedTotals[info.Limit.Action] = total
+		}
+		require.Len(t, summary.Totals, len(expectedTotals))
+		for _, total := range summary.Totals {
+			require.Equal(t, expectedTotals[total.Action], total)
+		}
+	}
+
+	_, err = ordersStore.InspectUnsentWindow(testrand.NodeID(), now, tomorrow)
+	require.True(t, orders.ErrWindowNotFound.Has(err))
+}
+
+func TestOrdersStore_RepairUnsentWindow(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+	dirName := ctx.Dir("test-orders")
+	now := time.Now()
+	satellite := testrand.NodeID()
+	tomorrow := now.Add(24 * time.Hour)
+
+	// make order limit grace period 1 hour
+	ordersStore, err := orders.NewFileStore(zaptest.NewLogger(t), dirName, time.Hour)
+	require.NoError(t, err)
+
+	sn1 := testrand.SerialNumber()
+	sn2 := testrand.SerialNumber()
+	sn3 := testrand.SerialNumber()
+	info := &ordersfile.Info{
+		Limit: &pb.OrderLimit{
+			SerialNumber:  sn1,
+			SatelliteId:   satellite,
+			Action:        pb.PieceAction_GET,
+			OrderCreation: now,
+		},
+		Order: &pb.Order{
+			SerialNumber: sn1,
+			Amount:       1,
+		},
+	}
+	// store sn1 and sn2 in the same window
+	require.NoError(t, ordersStore.Enqueue(info))
+	info.Limit.SerialNumber = sn2
+	info.Order.SerialNumber = sn2
+	require.NoError(t, ordersStore.Enqueue(info))
+
+	// repairing a valid file leaves it unchanged
+	result, err := ordersStore.RepairUnsentWindow(satellite, now, tomorrow)
+	require.NoError(t, err)
+	require.Equal(t, orders.RepairResult{Salvaged: 2}, result)
+
+	// corrupt unsent orders file by removing the last byte
+	err = filepath.Walk(filepath.Join(dirName, "unsent"), func(path string, info os.FileInfo, err error) error {
+		require.NoError(t, err)
+		if info.IsDir() {
+			return nil
+		}
+		return os.Truncate(path, info.Size()-1)
+	})
+	require.NoError(t, err)
+
+	summary, err := ordersStore.InspectUnsentWindow(satellite, now, tomorrow)
+	require.NoError(t, err)
+	require.Len(t, summary.Orders, 1)
+	require.Equal(t, 1, summary.Corrupted)
+
+	// only the second order is corrupted, so the first one is salvaged
+	result, err = ordersStore.RepairUnsentWindow(satellite, now, tomorrow)
+	require.NoError(t, err)
+	require.Equal(t, orders.RepairResult{Salvaged: 1, Corrupted: 1, Repaired: true}, result)
+
+	// orders appended after the repair don't follow a corrupted entry anymore
+	info.Limit.SerialNumber = sn3
+	info.Order.SerialNumber = sn3
+	require.NoError(t, ordersStore.Enqueue(info))
+
+	summary, err = ordersStore.InspectUnsentWindow(satellite, now, tomorrow)
+	require.NoError(t, err)
+	require.Zero(t, summary.Corrupted)
+	require.Len(t, summary.Orders, 2)
+	require.EqualValues(t, sn1, summary.Orders[0].Order.SerialNumber)
+	require.EqualValues(t, sn3, summary.Orders[1].Order.SerialNumber)
+
+	// the temporary file is not left behind
+	entries, err := os.ReadDir(dirName)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.True(t, entry.IsDir(), entry.Name())
+	}
+
+	unsent, err := ordersStore.ReadUnsentWindow(satellite, now, tomorrow)
+	require.NoError(t, err)
+	require.Len(t, unsent.InfoList, 2)
+
+	// the window isn't ready for settlement before the grace period passes
+	_, err = ordersStore.ReadUnsentWindow(satellite, now, now)
+	require.Error(t, err)
+}
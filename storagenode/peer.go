@@ -19,18 +19,21 @@ import (
 
 	"storj.io/common/identity"
 	"storj.io/common/pb"
-	"storj.io/common/peertls/extensions"
 	"storj.io/common/peertls/tlsopts"
 	"storj.io/common/rpc"
 	"storj.io/common/signing"
 	"storj.io/common/storj"
 	"storj.io/private/debug"
 	"storj.io/private/version"
+	"storj.io/storj/multinodepb"
+	"storj.io/storj/pkg/piecechallenge/piecechallengepb"
+	"storj.io/storj/pkg/revocation"
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/private/lifecycle"
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packedstore"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/collector"
@@ -42,6 +45,7 @@ import (
 	"storj.io/storj/storagenode/inspector"
 	"storj.io/storj/storagenode/internalpb"
 	"storj.io/storj/storagenode/monitor"
+	"storj.io/storj/storagenode/multinode"
 	"storj.io/storj/storagenode/nodestats"
 	"storj.io/storj/storagenode/notifications"
 	"storj.io/storj/storagenode/orders"
@@ -55,6 +59,7 @@ import (
 	"storj.io/storj/storagenode/pricing"
 	"storj.io/storj/storagenode/reputation"
 	"storj.io/storj/storagenode/retain"
+	"storj.io/storj/storagenode/revocations"
 	"storj.io/storj/storagenode/satellites"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/storagenode/storageusage"
@@ -109,7 +114,8 @@ type Config struct {
 	Storage2  piecestore.Config
 	Collector collector.Config
 
-	Filestore filestore.Config
+	Filestore   filestore.Config
+	Packedstore packedstore.Config
 
 	Pieces pieces.Config
 
@@ -124,6 +130,8 @@ type Config struct {
 	Bandwidth bandwidth.Config
 
 	GracefulExit gracefulexit.Config
+
+	Revocations revocations.Config
 }
 
 // DatabaseConfig returns the storagenodedb.Config that should be used with this Config.
@@ -138,6 +146,9 @@ func (config *Config) DatabaseConfig() storagenodedb.Config {
 		Info2:     filepath.Join(dbdir, "info.db"),
 		Pieces:    config.Storage.Path,
 		Filestore: config.Filestore,
+
+		PieceBackend: config.Storage2.PieceBackend,
+		Packedstore:  config.Packedstore,
 	}
 }
 
@@ -229,21 +240,24 @@ type Peer struct {
 
 	Storage2 struct {
 		// TODO: lift things outside of it to organize better
-		Trust         *trust.Pool
-		Store         *pieces.Store
-		TrashChore    *pieces.TrashChore
-		BlobsCache    *pieces.BlobsUsageCache
-		CacheService  *pieces.CacheService
-		RetainService *retain.Service
-		PieceDeleter  *pieces.Deleter
-		Endpoint      *piecestore.Endpoint
-		Inspector     *inspector.Endpoint
-		Monitor       *monitor.Service
-		Orders        *orders.Service
+		Trust          *trust.Pool
+		Store          *pieces.Store
+		TrashChore     *pieces.TrashChore
+		BlobsCache     *pieces.BlobsUsageCache
+		CacheService   *pieces.CacheService
+		RetainService  *retain.Service
+		PieceDeleter   *pieces.Deleter
+		Endpoint       *piecestore.Endpoint
+		Inspector      *inspector.Endpoint
+		Monitor        *monitor.Service
+		Orders         *orders.Service
+		OrdersEndpoint *orders.Endpoint
 	}
 
 	Collector *collector.Service
 
+	Revocations *revocations.Chore
+
 	NodeStats struct {
 		Service *nodestats.Service
 		Cache   *nodestats.Cache
@@ -279,10 +293,17 @@ type Peer struct {
 	Bandwidth *bandwidth.Service
 
 	Reputation *reputation.Service
+
+	Multinode struct {
+		Storage    *multinode.StorageEndpoint
+		Reputation *multinode.ReputationEndpoint
+		Status     *multinode.StatusEndpoint
+		Payout     *multinode.PayoutEndpoint
+	}
 }
 
 // New creates a new Storage Node.
-func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB extensions.RevocationDB, config Config, versionInfo version.Info, atomicLogLevel *zap.AtomicLevel) (*Peer, error) {
+func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB *revocation.DB, config Config, versionInfo version.Info, atomicLogLevel *zap.AtomicLevel) (*Peer, error) {
 	peer := &Peer{
 		Log:      log,
 		Identity: full,
@@ -318,25 +339,6 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 
 	var err error
 
-	{ // version setup
-		if !versionInfo.IsZero() {
-			peer.Log.Debug("Version info",
-				zap.Stringer("Version", versionInfo.Version.Version),
-				zap.String("Commit Hash", versionInfo.CommitHash),
-				zap.Stringer("Build Timestamp", versionInfo.Timestamp),
-				zap.Bool("Release Build", versionInfo.Release),
-			)
-		}
-
-		peer.Version.Service = checker.NewService(log.Named("version"), config.Version, versionInfo, "Storagenode")
-		versionCheckInterval := 12 * time.Hour
-		peer.Version.Chore = version2.NewChore(peer.Log.Named("version:chore"), peer.Version.Service, peer.Notifications.Service, peer.Identity.ID, versionCheckInterval)
-		peer.Services.Add(lifecycle.Item{
-			Name: "version",
-			Run:  peer.Version.Chore.Run,
-		})
-	}
-
 	{ // setup listener and server
 		sc := config.Server
 
@@ -344,10 +346,13 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+		if sc.Config.Extensions.Revocation {
+			tlsOptions.VerificationFuncs.Add(revocation.VerifyNotRevoked(revocationDB))
+		}
 
 		peer.Dialer = rpc.NewDefaultDialer(tlsOptions)
 
-		peer.Server, err = server.New(log.Named("server"), tlsOptions, sc.Address, sc.PrivateAddress)
+		peer.Server, err = server.New(log.Named("server"), tlsOptions, sc)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
@@ -376,6 +381,25 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		})
 	}
 
+	{ // version setup
+		if !versionInfo.IsZero() {
+			peer.Log.Debug("Version info",
+				zap.Stringer("Version", versionInfo.Version.Version),
+				zap.String("Commit Hash", versionInfo.CommitHash),
+				zap.Stringer("Build Timestamp", versionInfo.Timestamp),
+				zap.Bool("Release Build", versionInfo.Release),
+			)
+		}
+
+		peer.Version.Service = checker.NewService(log.Named("version"), config.Version, versionInfo, "Storagenode")
+		versionCheckInterval := 12 * time.Hour
+		peer.Version.Chore = version2.NewChore(peer.Log.Named("version:chore"), peer.Version.Service, peer.Notifications.Service, peer.Storage2.Trust, peer.Identity.ID, versionCheckInterval)
+		peer.Services.Add(lifecycle.Item{
+			Name: "version",
+			Run:  peer.Version.Chore.Run,
+		})
+	}
+
 	{
 		peer.Preflight.LocalTime = preflight.NewLocalTime(peer.Log.Named("preflight:localtime"), config.Preflight, peer.Storage2.Trust, peer.Dialer)
 	}
@@ -465,8 +489,10 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			log.Named("piecestore:monitor"),
 			peer.Storage2.Store,
 			peer.Contact.Service,
+			peer.Notifications.Service,
 			peer.DB.Bandwidth(),
 			config.Storage.AllocatedDiskSpace.Int64(),
+			config.Storage.SatelliteQuotas,
 			// TODO: use config.Storage.Monitor.Interval, but for some reason is not set
 			config.Storage.KBucketRefreshInterval,
 			peer.Contact.Chore.Trigger,
@@ -479,6 +505,8 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		})
 		peer.Debug.Server.Panel.Add(
 			debug.Cycle("Piecestore Monitor", peer.Storage2.Monitor.Loop))
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Piecestore Monitor Disk Health", peer.Storage2.Monitor.DiskHealthLoop))
 
 		peer.Storage2.RetainService = retain.NewService(
 			peer.Log.Named("retain"),
@@ -523,6 +551,9 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		if err := pb.DRPCRegisterPiecestore(peer.Server.DRPC(), peer.Storage2.Endpoint); err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+		if err := piecechallengepb.DRPCRegisterPieceChallenge(peer.Server.DRPC(), peer.Storage2.Endpoint); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
 
 		// TODO workaround for custom timeout for order sending request (read/write)
 		sc := config.Server
@@ -531,6 +562,9 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+		if sc.Config.Extensions.Revocation {
+			tlsOptions.VerificationFuncs.Add(revocation.VerifyNotRevoked(revocationDB))
+		}
 
 		dialer := rpc.NewDefaultDialer(tlsOptions)
 		dialer.DialTimeout = config.Storage2.Orders.SenderDialTimeout
@@ -552,6 +586,11 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			debug.Cycle("Orders Sender", peer.Storage2.Orders.Sender))
 		peer.Debug.Server.Panel.Add(
 			debug.Cycle("Orders Cleanup", peer.Storage2.Orders.Cleanup))
+
+		peer.Storage2.OrdersEndpoint = orders.NewEndpoint(peer.Storage2.Orders)
+		if err := internalpb.DRPCRegisterNodeOrders(peer.Server.PrivateDRPC(), peer.Storage2.OrdersEndpoint); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
 	}
 
 	{ // setup payout service.
@@ -666,6 +705,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 			peer.Notifications.Service,
 			peer.Console.Service,
 			peer.Payout.Service,
+			peer.Storage2.Orders,
 			peer.Console.Listener,
 		)
 		peer.Services.Add(lifecycle.Item{
@@ -691,6 +731,49 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 		}
 	}
 
+	{ // setup multinode endpoints
+		apiKeys := apikeys.NewService(peer.DB.Secret())
+
+		peer.Multinode.Storage = multinode.NewStorageEndpoint(
+			peer.Log.Named("multinode:storage-endpoint"),
+			apiKeys,
+			peer.Identity.ID,
+			peer.Storage2.Store,
+			peer.DB.StorageUsage(),
+			config.Storage.AllocatedDiskSpace,
+		)
+		peer.Multinode.Reputation = multinode.NewReputationEndpoint(
+			peer.Log.Named("multinode:reputation-endpoint"),
+			apiKeys,
+			peer.DB.Reputation(),
+		)
+		peer.Multinode.Status = multinode.NewStatusEndpoint(
+			peer.Log.Named("multinode:status-endpoint"),
+			apiKeys,
+			time.Now(),
+			versionInfo,
+		)
+		peer.Multinode.Payout = multinode.NewPayoutEndpoint(
+			peer.Log.Named("multinode:payout-endpoint"),
+			apiKeys,
+			peer.Payout.Service,
+			peer.Estimation.Service,
+		)
+
+		if err := multinodepb.DRPCRegisterNodeDiskSpace(peer.Server.PrivateDRPC(), peer.Multinode.Storage); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		if err := multinodepb.DRPCRegisterReputation(peer.Server.PrivateDRPC(), peer.Multinode.Reputation); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		if err := multinodepb.DRPCRegisterStatus(peer.Server.PrivateDRPC(), peer.Multinode.Status); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+		if err := multinodepb.DRPCRegisterPayouts(peer.Server.PrivateDRPC(), peer.Multinode.Payout); err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+	}
+
 	{ // setup piecetransfer service
 		peer.PieceTransfer.Service = piecetransfer.NewService(
 			peer.Log.Named("piecetransfer"),
@@ -760,6 +843,17 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, revocationDB exten
 	peer.Debug.Server.Panel.Add(
 		debug.Cycle("Collector", peer.Collector.Loop))
 
+	if config.Server.Config.Extensions.Revocation {
+		peer.Revocations = revocations.NewChore(peer.Log.Named("revocations"), peer.Dialer, peer.Storage2.Trust, revocationDB, config.Revocations)
+		peer.Services.Add(lifecycle.Item{
+			Name:  "revocations",
+			Run:   peer.Revocations.Run,
+			Close: peer.Revocations.Close,
+		})
+		peer.Debug.Server.Panel.Add(
+			debug.Cycle("Revocations", peer.Revocations.Loop))
+	}
+
 	peer.Bandwidth = bandwidth.NewService(peer.Log.Named("bandwidth"), peer.DB.Bandwidth(), config.Bandwidth)
 	peer.Services.Add(lifecycle.Item{
 		Name:  "bandwidth",
@@ -0,0 +1,471 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/pkcrypto"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+)
+
+// ErrMigrationVerification is returned when a copied piece does not match its source.
+var ErrMigrationVerification = errs.Class("piece migration verification")
+
+// DirMigrationStats contains the results of a DirMigrator pass.
+type DirMigrationStats struct {
+	// Copied is the number of pieces copied and verified during the pass.
+	Copied int64
+	// Existing is the number of pieces which were already in the destination.
+	Existing int64
+	// Trash is the number of trashed pieces copied during the pass.
+	Trash int64
+	// Removed is the number of pieces removed from the destination, because they
+	// no longer exist in the source.
+	Removed int64
+	// Failed is the number of pieces which could not be copied or verified.
+	Failed int64
+	// Corrupt is the number of pieces found to be corrupt in the source directory.
+	// They are skipped and not counted again in later passes.
+	Corrupt int64
+	// Bytes is the number of bytes copied during the pass.
+	Bytes int64
+}
+
+// Add adds the counts in other to stats.
+func (stats *DirMigrationStats) Add(other DirMigrationStats) {
+	stats.Copied += other.Copied
+	stats.Existing += other.Existing
+	stats.Trash += other.Trash
+	stats.Removed += other.Removed
+	stats.Failed += other.Failed
+	stats.Corrupt += other.Corrupt
+	stats.Bytes += other.Bytes
+}
+
+// DirMigrator copies pieces and trash from one storage directory to another.
+//
+// Pieces are only read from the source directory, so a storage node using the
+// source directory can keep serving requests while the migration runs. A copied
+// piece is verified against the hash in its piece header (or against the source
+// content for V0 pieces) and removed from the destination when it doesn't match.
+// Trashed pieces are verified against the content read from the source.
+// Pieces which don't match their own header in the source directory can never be
+// copied intact, so they are skipped and reported by Corrupt instead of failing
+// every pass. Running Pass repeatedly picks up pieces which were uploaded in the
+// meantime.
+//
+// architecture: Service
+type DirMigrator struct {
+	log *zap.Logger
+
+	from      *filestore.Dir
+	to        *filestore.Dir
+	fromBlobs storage.Blobs
+	toBlobs   storage.Blobs
+
+	corrupt map[blobKey]storage.BlobRef
+}
+
+// blobKey identifies a blob in a specific storage format version.
+type blobKey struct {
+	namespace string
+	key       string
+	formatVer storage.FormatVersion
+}
+
+// NewDirMigrator creates a new migrator from one storage directory to another.
+func NewDirMigrator(log *zap.Logger, from, to *filestore.Dir) *DirMigrator {
+	return &DirMigrator{
+		log:       log,
+		from:      from,
+		to:        to,
+		fromBlobs: filestore.New(log, from, filestore.DefaultConfig),
+		toBlobs:   filestore.New(log, to, filestore.DefaultConfig),
+		corrupt:   make(map[blobKey]storage.BlobRef),
+	}
+}
+
+// Corrupt returns the pieces which were found to be corrupt in the source directory
+// and were therefore not copied.
+func (migrator *DirMigrator) Corrupt() []storage.BlobRef {
+	refs := make([]storage.BlobRef, 0, len(migrator.corrupt))
+	for _, ref := range migrator.corrupt {
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// Pass copies every piece and every trashed piece from the source directory
+// which is not already present in the destination directory.
+func (migrator *DirMigrator) Pass(ctx context.Context) (stats DirMigrationStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	namespaces, err := migrator.from.ListNamespaces(ctx)
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+	for _, namespace := range namespaces {
+		err := migrator.from.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			return migrator.copyBlob(ctx, info, &stats)
+		})
+		if err != nil {
+			return stats, Error.Wrap(err)
+		}
+	}
+
+	trashNamespaces, err := migrator.from.ListTrashNamespaces(ctx)
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+	for _, namespace := range trashNamespaces {
+		if err := migrator.copyTrash(ctx, namespace, &stats); err != nil {
+			return stats, Error.Wrap(err)
+		}
+	}
+
+	return stats, nil
+}
+
+// RemoveDeleted removes pieces and trashed pieces from the destination directory
+// which no longer exist in the source directory, e.g. because they were deleted,
+// trashed, restored from the trash or the trash was emptied after being copied.
+func (migrator *DirMigrator) RemoveDeleted(ctx context.Context) (stats DirMigrationStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	namespaces, err := migrator.to.ListNamespaces(ctx)
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+	for _, namespace := range namespaces {
+		err := migrator.to.WalkNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+			_, err := migrator.from.StatWithStorageFormat(ctx, info.BlobRef(), info.StorageFormatVersion())
+			if err == nil {
+				return nil
+			}
+			if !os.IsNotExist(err) {
+				return err
+			}
+
+			stats.Removed++
+			return migrator.to.DeleteWithStorageFormat(ctx, info.BlobRef(), info.StorageFormatVersion())
+		})
+		if err != nil {
+			return stats, Error.Wrap(err)
+		}
+	}
+
+	trashNamespaces, err := migrator.to.ListTrashNamespaces(ctx)
+	if err != nil {
+		return stats, Error.Wrap(err)
+	}
+	for _, namespace := range trashNamespaces {
+		if err := migrator.removeDeletedTrash(ctx, namespace, &stats); err != nil {
+			return stats, Error.Wrap(err)
+		}
+	}
+	return stats, nil
+}
+
+// removeDeletedTrash removes the trashed pieces of namespace from the destination
+// trash, which are no longer in the source trash.
+func (migrator *DirMigrator) removeDeletedTrash(ctx context.Context, namespace []byte, stats *DirMigrationStats) (err error) {
+	trashed := make(map[blobKey]struct{})
+	err = migrator.from.WalkTrashNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		trashed[blobKey{string(namespace), string(info.BlobRef().Key), info.StorageFormatVersion()}] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return migrator.to.WalkTrashNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		if _, ok := trashed[blobKey{string(namespace), string(info.BlobRef().Key), info.StorageFormatVersion()}]; ok {
+			return nil
+		}
+
+		path, err := info.FullPath(ctx)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		stats.Removed++
+		return nil
+	})
+}
+
+// copyBlob copies a single blob to the destination directory and verifies it.
+func (migrator *DirMigrator) copyBlob(ctx context.Context, info storage.BlobInfo, stats *DirMigrationStats) (err error) {
+	ref, formatVer := info.BlobRef(), info.StorageFormatVersion()
+	corruptKey := blobKey{string(ref.Namespace), string(ref.Key), formatVer}
+	if _, ok := migrator.corrupt[corruptKey]; ok {
+		return nil
+	}
+
+	sourceInfo, err := info.Stat(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := migrator.to.StatWithStorageFormat(ctx, ref, formatVer)
+	if err == nil {
+		existingInfo, err := existing.Stat(ctx)
+		if err != nil {
+			return err
+		}
+		if existingInfo.Size() == sourceInfo.Size() {
+			stats.Existing++
+			return nil
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	size, err := migrator.copyFile(ctx, ref, formatVer, sourceInfo.Size(), func(file *os.File) error {
+		return migrator.to.Commit(ctx, file, ref, formatVer)
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			// the piece was deleted or trashed while we were copying it
+			return nil
+		}
+		return err
+	}
+
+	if err := migrator.verify(ctx, ref, formatVer); err != nil {
+		if migrator.sourceCorrupt(ctx, ref, formatVer) {
+			migrator.log.Warn("piece is corrupt in the source directory, skipping it",
+				zap.Binary("Namespace", ref.Namespace), zap.Binary("Key", ref.Key), zap.Error(err))
+			migrator.corrupt[corruptKey] = ref
+			stats.Corrupt++
+		} else {
+			migrator.log.Error("migrated piece failed verification",
+				zap.Binary("Namespace", ref.Namespace), zap.Binary("Key", ref.Key), zap.Error(err))
+			stats.Failed++
+		}
+		return migrator.to.DeleteWithStorageFormat(ctx, ref, formatVer)
+	}
+
+	stats.Copied++
+	stats.Bytes += size
+	return nil
+}
+
+// copyTrash copies the trashed blobs of namespace to the destination trash, keeping
+// the time they were trashed.
+func (migrator *DirMigrator) copyTrash(ctx context.Context, namespace []byte, stats *DirMigrationStats) (err error) {
+	// trash is usually small compared to the blobs, so it's fine to keep all the
+	// already copied keys in memory.
+	existing := make(map[string]int64)
+	err = migrator.to.WalkTrashNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		fileInfo, err := info.Stat(ctx)
+		if err != nil {
+			return err
+		}
+		existing[string(info.BlobRef().Key)] = fileInfo.Size()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return migrator.from.WalkTrashNamespace(ctx, namespace, func(info storage.BlobInfo) error {
+		ref, formatVer := info.BlobRef(), info.StorageFormatVersion()
+
+		fileInfo, err := info.Stat(ctx)
+		if err != nil {
+			return err
+		}
+		if size, ok := existing[string(ref.Key)]; ok && size == fileInfo.Size() {
+			stats.Existing++
+			return nil
+		}
+
+		path, err := info.FullPath(ctx)
+		if err != nil {
+			return err
+		}
+
+		size, err := migrator.copyTrashFile(ctx, path, fileInfo.Size(), func(file *os.File) error {
+			return migrator.to.CommitToTrash(ctx, file, ref, formatVer, fileInfo.ModTime())
+		})
+		if err != nil {
+			if os.IsNotExist(err) {
+				// the piece was restored or emptied while we were copying it
+				return nil
+			}
+			if ErrMigrationVerification.Has(err) {
+				migrator.log.Error("migrated trashed piece failed verification",
+					zap.Binary("Namespace", ref.Namespace), zap.Binary("Key", ref.Key), zap.Error(err))
+				stats.Failed++
+				return nil
+			}
+			return err
+		}
+
+		stats.Trash++
+		stats.Bytes += size
+		return nil
+	})
+}
+
+// copyTrashFile copies the trashed blob at path into a temporary file in the
+// destination directory and calls commit with it, once the size and the hash of
+// the copy match the source.
+func (migrator *DirMigrator) copyTrashFile(ctx context.Context, path string, size int64, commit func(*os.File) error) (_ int64, err error) {
+	source, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	// trashed blobs can't be opened as pieces, so the copy is compared with the
+	// content read from the source instead of the piece header.
+	sourceHash := pkcrypto.NewHash()
+	return migrator.copyReader(ctx, io.TeeReader(source, sourceHash), size, func(file *os.File) error {
+		if err := verifyTemporary(file, size, sourceHash.Sum(nil)); err != nil {
+			return errs.Combine(err, migrator.to.DeleteTemporary(ctx, file))
+		}
+		return commit(file)
+	})
+}
+
+// verifyTemporary checks that the size and the hash of the written temporary file
+// are the expected ones.
+func verifyTemporary(file *os.File, size int64, expected []byte) error {
+	written, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return ErrMigrationVerification.Wrap(err)
+	}
+	if written != size {
+		return ErrMigrationVerification.New("size mismatch: copied %d bytes of %d", written, size)
+	}
+
+	// read with ReadAt, so that the file position stays at the end of the copy.
+	hash := pkcrypto.NewHash()
+	if _, err := io.Copy(hash, io.NewSectionReader(file, 0, written)); err != nil {
+		return ErrMigrationVerification.Wrap(err)
+	}
+	if !bytes.Equal(hash.Sum(nil), expected) {
+		return ErrMigrationVerification.New("hash mismatch")
+	}
+	return nil
+}
+
+// copyFile copies the blob from the source directory into a temporary file in the
+// destination directory and calls commit with it.
+func (migrator *DirMigrator) copyFile(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion, size int64, commit func(*os.File) error) (_ int64, err error) {
+	source, err := migrator.from.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = errs.Combine(err, source.Close()) }()
+
+	return migrator.copyReader(ctx, source, size, commit)
+}
+
+func (migrator *DirMigrator) copyReader(ctx context.Context, source io.Reader, size int64, commit func(*os.File) error) (_ int64, err error) {
+	file, err := migrator.to.CreateTemporaryFile(ctx, size)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := io.Copy(file, source)
+	if err != nil {
+		return 0, errs.Combine(err, migrator.to.DeleteTemporary(ctx, file))
+	}
+
+	return n, commit(file)
+}
+
+// verify checks that the copied blob in the destination directory is intact.
+func (migrator *DirMigrator) verify(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	copied, err := migrator.contentHash(ctx, migrator.toBlobs, ref, formatVer)
+	if err != nil {
+		return ErrMigrationVerification.Wrap(err)
+	}
+
+	var expected []byte
+	if formatVer >= filestore.FormatV1 {
+		expected, err = migrator.headerHash(ctx, migrator.toBlobs, ref, formatVer)
+	} else {
+		// V0 pieces don't have a header, so compare against the source instead.
+		expected, err = migrator.contentHash(ctx, migrator.fromBlobs, ref, formatVer)
+	}
+	if err != nil {
+		return ErrMigrationVerification.Wrap(err)
+	}
+
+	if !bytes.Equal(copied, expected) {
+		return ErrMigrationVerification.New("hash mismatch")
+	}
+	return nil
+}
+
+// sourceCorrupt returns whether the piece in the source directory doesn't match the
+// hash in its own header. V0 pieces don't have a header, so they are never reported
+// as corrupt.
+func (migrator *DirMigrator) sourceCorrupt(ctx context.Context, ref storage.BlobRef, formatVer storage.FormatVersion) bool {
+	if formatVer < filestore.FormatV1 {
+		return false
+	}
+	content, err := migrator.contentHash(ctx, migrator.fromBlobs, ref, formatVer)
+	if err != nil {
+		return false
+	}
+	expected, err := migrator.headerHash(ctx, migrator.fromBlobs, ref, formatVer)
+	if err != nil {
+		return false
+	}
+	return !bytes.Equal(content, expected)
+}
+
+// contentHash calculates the hash of the piece content, excluding any piece header.
+func (migrator *DirMigrator) contentHash(ctx context.Context, blobs storage.Blobs, ref storage.BlobRef, formatVer storage.FormatVersion) (_ []byte, err error) {
+	blob, err := blobs.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := NewReader(blob)
+	if err != nil {
+		return nil, errs.Combine(err, blob.Close())
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	hash := pkcrypto.NewHash()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
+// headerHash returns the hash stored in the piece header.
+func (migrator *DirMigrator) headerHash(ctx context.Context, blobs storage.Blobs, ref storage.BlobRef, formatVer storage.FormatVersion) (_ []byte, err error) {
+	blob, err := blobs.OpenWithStorageFormat(ctx, ref, formatVer)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := NewReader(blob)
+	if err != nil {
+		return nil, errs.Combine(err, blob.Close())
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	header, err := reader.GetPieceHeader()
+	if err != nil {
+		return nil, err
+	}
+	return header.GetHash(), nil
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/pb"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storagenode/pieces"
+)
+
+func TestDirMigrator(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	fromDir, err := filestore.NewDir(log, ctx.Dir("from"))
+	require.NoError(t, err)
+	toDir, err := filestore.NewDir(log, ctx.Dir("to"))
+	require.NoError(t, err)
+
+	fromBlobs := filestore.New(log, fromDir, filestore.DefaultConfig)
+	defer ctx.Check(fromBlobs.Close)
+	toBlobs := filestore.New(log, toDir, filestore.DefaultConfig)
+	defer ctx.Check(toBlobs.Close)
+
+	from := pieces.NewStore(log, fromBlobs, nil, nil, nil, pieces.DefaultConfig)
+	to := pieces.NewStore(log, toBlobs, nil, nil, nil, pieces.DefaultConfig)
+
+	satellite := testrand.NodeID()
+
+	writePiece := func(pieceID storj.PieceID, data []byte) {
+		writer, err := from.Writer(ctx, satellite, pieceID)
+		require.NoError(t, err)
+		_, err = writer.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, writer.Commit(ctx, &pb.PieceHeader{Hash: writer.Hash()}))
+	}
+
+	readPiece := func(store *pieces.Store, pieceID storj.PieceID) []byte {
+		reader, err := store.Reader(ctx, satellite, pieceID)
+		require.NoError(t, err)
+		defer ctx.Check(reader.Close)
+		data, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		return data
+	}
+
+	kept, keptData := testrand.PieceID(), testrand.BytesInt(4096)
+	trashed, trashedData := testrand.PieceID(), testrand.BytesInt(1024)
+	writePiece(kept, keptData)
+	writePiece(trashed, trashedData)
+
+	trashedAt := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	fromDir.ReplaceTrashnow(func() time.Time { return trashedAt })
+	require.NoError(t, fromBlobs.Trash(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: trashed.Bytes()}))
+
+	migrator := pieces.NewDirMigrator(log, fromDir, toDir)
+
+	stats, err := migrator.Pass(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stats.Copied)
+	require.EqualValues(t, 1, stats.Trash)
+	require.Zero(t, stats.Failed)
+	require.Equal(t, keptData, readPiece(to, kept))
+
+	{ // the trashed piece keeps its trash time and can be restored
+		err = toDir.WalkTrashNamespace(ctx, satellite.Bytes(), func(info storage.BlobInfo) error {
+			fileInfo, err := info.Stat(ctx)
+			require.NoError(t, err)
+			require.True(t, fileInfo.ModTime().Equal(trashedAt))
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = toBlobs.RestoreTrash(ctx, satellite.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, trashedData, readPiece(to, trashed))
+		require.NoError(t, toBlobs.Trash(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: trashed.Bytes()}))
+	}
+
+	{ // a second pass only copies the pieces uploaded in the meantime
+		added, addedData := testrand.PieceID(), testrand.BytesInt(2048)
+		writePiece(added, addedData)
+
+		stats, err = migrator.Pass(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, stats.Copied)
+		require.EqualValues(t, 0, stats.Trash)
+		require.EqualValues(t, 2, stats.Existing)
+		require.Equal(t, addedData, readPiece(to, added))
+	}
+
+	{ // pieces deleted from the source are removed from the destination
+		require.NoError(t, from.Delete(ctx, satellite, kept))
+
+		stats, err = migrator.RemoveDeleted(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, stats.Removed)
+
+		_, err = to.Reader(ctx, satellite, kept)
+		require.True(t, os.IsNotExist(err))
+	}
+
+	{ // pieces restored from the source trash are removed from the destination trash
+		restored, err := fromBlobs.RestoreTrash(ctx, satellite.Bytes())
+		require.NoError(t, err)
+		require.Len(t, restored, 1)
+
+		stats, err = migrator.RemoveDeleted(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, stats.Removed)
+
+		err = toDir.WalkTrashNamespace(ctx, satellite.Bytes(), func(info storage.BlobInfo) error {
+			return errs.New("unexpected trashed piece %x", info.BlobRef().Key)
+		})
+		require.NoError(t, err)
+
+		stats, err = migrator.Pass(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, stats.Copied)
+		require.Equal(t, trashedData, readPiece(to, trashed))
+	}
+
+	{ // corrupted pieces are skipped and reported instead of failing every pass
+		corrupted := testrand.PieceID()
+		writePiece(corrupted, testrand.BytesInt(1024))
+
+		info, err := fromDir.Stat(ctx, storage.BlobRef{Namespace: satellite.Bytes(), Key: corrupted.Bytes()})
+		require.NoError(t, err)
+		path, err := info.FullPath(ctx)
+		require.NoError(t, err)
+
+		file, err := os.OpenFile(path, os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = file.WriteAt([]byte("corrupted"), pieces.V1PieceHeaderReservedArea+10)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		stats, err = migrator.Pass(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, stats.Corrupt)
+		require.Zero(t, stats.Failed)
+		require.Zero(t, stats.Copied)
+
+		_, err = to.Reader(ctx, satellite, corrupted)
+		require.True(t, os.IsNotExist(err))
+
+		stats, err = migrator.Pass(ctx)
+		require.NoError(t, err)
+		require.Zero(t, stats.Corrupt)
+		require.Zero(t, stats.Failed)
+		require.Zero(t, stats.Copied)
+
+		corrupt := migrator.Corrupt()
+		require.Len(t, corrupt, 1)
+		require.Equal(t, corrupted.Bytes(), corrupt[0].Key)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"storj.io/storj/storage"
+)
+
+// DiskHealth keeps track of the latency and the errors of the most recent disk
+// operations done by the Store.
+type DiskHealth struct {
+	mu     sync.Mutex
+	reads  diskOps
+	writes diskOps
+}
+
+// DiskOpStats contains statistics about the most recent disk operations of one kind.
+type DiskOpStats struct {
+	Count     int
+	Errors    int
+	ErrorRate float64
+
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+}
+
+// DiskHealthStats contains statistics about the most recent disk operations.
+type DiskHealthStats struct {
+	Reads  DiskOpStats
+	Writes DiskOpStats
+}
+
+// NewDiskHealth creates a DiskHealth which keeps window operations of each kind.
+func NewDiskHealth(window int) *DiskHealth {
+	if window <= 0 {
+		window = 1
+	}
+	return &DiskHealth{
+		reads:  newDiskOps(window),
+		writes: newDiskOps(window),
+	}
+}
+
+// ObserveRead records a read operation that took duration and failed with err.
+func (health *DiskHealth) ObserveRead(duration time.Duration, err error) {
+	mon.DurationVal("disk_read_latency").Observe(duration)
+	health.mu.Lock()
+	defer health.mu.Unlock()
+	health.reads.add(duration, isDiskError(err))
+}
+
+// ObserveWrite records a write operation that took duration and failed with err.
+func (health *DiskHealth) ObserveWrite(duration time.Duration, err error) {
+	mon.DurationVal("disk_write_latency").Observe(duration)
+	health.mu.Lock()
+	defer health.mu.Unlock()
+	health.writes.add(duration, isDiskError(err))
+}
+
+// Stats returns statistics about the most recent operations.
+func (health *DiskHealth) Stats() DiskHealthStats {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+	return DiskHealthStats{
+		Reads:  health.reads.stats(),
+		Writes: health.writes.stats(),
+	}
+}
+
+// Reset forgets all recorded operations.
+func (health *DiskHealth) Reset() {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+	health.reads.reset()
+	health.writes.reset()
+}
+
+// isDiskError returns whether err indicates a problem with the disk, rather than
+// a missing piece or a canceled request.
+func isDiskError(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, io.EOF),
+		os.IsNotExist(err),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return false
+	}
+	return true
+}
+
+// diskOp is a single recorded operation.
+type diskOp struct {
+	duration time.Duration
+	failed   bool
+}
+
+// diskOps is a ring buffer of the most recent operations.
+type diskOps struct {
+	ops  []diskOp
+	next int
+	full bool
+}
+
+func newDiskOps(window int) diskOps {
+	return diskOps{ops: make([]diskOp, window)}
+}
+
+func (ops *diskOps) add(duration time.Duration, failed bool) {
+	ops.ops[ops.next] = diskOp{duration: duration, failed: failed}
+	ops.next++
+	if ops.next == len(ops.ops) {
+		ops.next = 0
+		ops.full = true
+	}
+}
+
+func (ops *diskOps) reset() {
+	ops.next = 0
+	ops.full = false
+}
+
+func (ops *diskOps) stats() (stats DiskOpStats) {
+	recorded := ops.ops[:ops.next]
+	if ops.full {
+		recorded = ops.ops
+	}
+	if len(recorded) == 0 {
+		return stats
+	}
+
+	durations := make([]time.Duration, 0, len(recorded))
+	for _, op := range recorded {
+		if op.failed {
+			stats.Errors++
+		}
+		durations = append(durations, op.duration)
+	}
+	sort.Slice(durations, func(i, k int) bool { return durations[i] < durations[k] })
+
+	percentile := func(p float64) time.Duration {
+		return durations[int(p*float64(len(durations)-1))]
+	}
+
+	stats.Count = len(recorded)
+	stats.ErrorRate = float64(stats.Errors) / float64(stats.Count)
+	stats.P50 = percentile(0.50)
+	stats.P90 = percentile(0.90)
+	stats.P99 = percentile(0.99)
+	return stats
+}
+
+// healthBlobReader records the latency and errors of reads from a blob.
+type healthBlobReader struct {
+	storage.BlobReader
+	health *DiskHealth
+}
+
+// Read reads from the blob.
+func (blob *healthBlobReader) Read(p []byte) (n int, err error) {
+	start := time.Now()
+	n, err = blob.BlobReader.Read(p)
+	blob.health.ObserveRead(time.Since(start), err)
+	return n, err
+}
+
+// ReadAt reads from the blob at the given offset.
+func (blob *healthBlobReader) ReadAt(p []byte, off int64) (n int, err error) {
+	start := time.Now()
+	n, err = blob.BlobReader.ReadAt(p, off)
+	blob.health.ObserveRead(time.Since(start), err)
+	return n, err
+}
+
+// healthBlobWriter records the latency and errors of writes to a blob.
+type healthBlobWriter struct {
+	storage.BlobWriter
+	health *DiskHealth
+}
+
+// Write writes to the blob.
+func (blob *healthBlobWriter) Write(p []byte) (n int, err error) {
+	start := time.Now()
+	n, err = blob.BlobWriter.Write(p)
+	blob.health.ObserveWrite(time.Since(start), err)
+	return n, err
+}
+
+// Commit commits the blob, which includes syncing it to the disk.
+func (blob *healthBlobWriter) Commit(ctx context.Context) (err error) {
+	start := time.Now()
+	err = blob.BlobWriter.Commit(ctx)
+	blob.health.ObserveWrite(time.Since(start), err)
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package pieces_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/storagenode/pieces"
+)
+
+func TestDiskHealth(t *testing.T) {
+	health := pieces.NewDiskHealth(100)
+
+	stats := health.Stats()
+	require.Zero(t, stats.Reads.Count)
+	require.Zero(t, stats.Writes.Count)
+
+	for i := 1; i <= 100; i++ {
+		health.ObserveRead(time.Duration(i)*time.Millisecond, nil)
+	}
+	stats = health.Stats()
+	require.Equal(t, 100, stats.Reads.Count)
+	require.Zero(t, stats.Reads.Errors)
+	require.Equal(t, 50*time.Millisecond, stats.Reads.P50)
+	require.Equal(t, 90*time.Millisecond, stats.Reads.P90)
+	require.Equal(t, 99*time.Millisecond, stats.Reads.P99)
+
+	// only the most recent operations are kept
+	for i := 0; i < 100; i++ {
+		health.ObserveRead(time.Second, nil)
+	}
+	stats = health.Stats()
+	require.Equal(t, 100, stats.Reads.Count)
+	require.Equal(t, time.Second, stats.Reads.P50)
+
+	// missing pieces, canceled requests and the end of a piece are not disk errors
+	health.ObserveWrite(time.Millisecond, os.ErrNotExist)
+	health.ObserveWrite(time.Millisecond, context.Canceled)
+	health.ObserveWrite(time.Millisecond, io.EOF)
+	health.ObserveWrite(time.Millisecond, errors.New("input/output error"))
+	stats = health.Stats()
+	require.Equal(t, 4, stats.Writes.Count)
+	require.Equal(t, 1, stats.Writes.Errors)
+	require.Equal(t, 0.25, stats.Writes.ErrorRate)
+
+	health.Reset()
+	stats = health.Stats()
+	require.Zero(t, stats.Reads.Count)
+	require.Zero(t, stats.Writes.Count)
+}
@@ -155,11 +155,13 @@ type SatelliteUsage struct {
 // Config is configuration for Store.
 type Config struct {
 	WritePreallocSize memory.Size `help:"file preallocated for uploading" default:"4MiB"`
+	DiskHealthWindow  int         `help:"number of most recent disk reads and writes used to measure disk health" default:"1000"`
 }
 
 // DefaultConfig is the default value for the Config.
 var DefaultConfig = Config{
 	WritePreallocSize: 4 * memory.MiB,
+	DiskHealthWindow:  1000,
 }
 
 // Store implements storing pieces onto a blob storage implementation.
@@ -173,6 +175,8 @@ type Store struct {
 	v0PieceInfo    V0PieceInfoDB
 	expirationInfo PieceExpirationDB
 	spaceUsedDB    PieceSpaceUsedDB
+
+	health *DiskHealth
 }
 
 // StoreForTest is a wrapper around Store to be used only in test scenarios. It enables writing
@@ -192,9 +196,15 @@ func NewStore(log *zap.Logger, blobs storage.Blobs, v0PieceInfo V0PieceInfoDB,
 		v0PieceInfo:    v0PieceInfo,
 		expirationInfo: expirationInfo,
 		spaceUsedDB:    pieceSpaceUsedDB,
+		health:         NewDiskHealth(config.DiskHealthWindow),
 	}
 }
 
+// DiskHealth returns the statistics of the disk operations done by the store.
+func (store *Store) DiskHealth() *DiskHealth {
+	return store.health
+}
+
 // CreateVerificationFile creates a file to be used for storage directory verification.
 func (store *Store) CreateVerificationFile(id storj.NodeID) error {
 	return store.blobs.CreateVerificationFile(id)
@@ -209,13 +219,16 @@ func (store *Store) VerifyStorageDir(id storj.NodeID) error {
 // Writer returns a new piece writer.
 func (store *Store) Writer(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID) (_ *Writer, err error) {
 	defer mon.Task()(&ctx)(&err)
+	start := time.Now()
 	blobWriter, err := store.blobs.Create(ctx, storage.BlobRef{
 		Namespace: satellite.Bytes(),
 		Key:       pieceID.Bytes(),
 	}, store.config.WritePreallocSize.Int64())
+	store.health.ObserveWrite(time.Since(start), err)
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
+	blobWriter = &healthBlobWriter{BlobWriter: blobWriter, health: store.health}
 
 	writer, err := NewWriter(store.log.Named("blob-writer"), blobWriter, store.blobs, satellite)
 	return writer, Error.Wrap(err)
@@ -258,16 +271,19 @@ func (store StoreForTest) WriterForFormatVersion(ctx context.Context, satellite
 // Reader returns a new piece reader.
 func (store *Store) Reader(ctx context.Context, satellite storj.NodeID, pieceID storj.PieceID) (_ *Reader, err error) {
 	defer mon.Task()(&ctx)(&err)
+	start := time.Now()
 	blob, err := store.blobs.Open(ctx, storage.BlobRef{
 		Namespace: satellite.Bytes(),
 		Key:       pieceID.Bytes(),
 	})
+	store.health.ObserveRead(time.Since(start), err)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, err
 		}
 		return nil, Error.Wrap(err)
 	}
+	blob = &healthBlobReader{BlobReader: blob, health: store.health}
 
 	reader, err := NewReader(blob)
 	return reader, Error.Wrap(err)
@@ -280,13 +296,16 @@ func (store *Store) ReaderWithStorageFormat(ctx context.Context, satellite storj
 
 	defer mon.Task()(&ctx)(&err)
 	ref := storage.BlobRef{Namespace: satellite.Bytes(), Key: pieceID.Bytes()}
+	start := time.Now()
 	blob, err := store.blobs.OpenWithStorageFormat(ctx, ref, formatVersion)
+	store.health.ObserveRead(time.Since(start), err)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, err
 		}
 		return nil, Error.Wrap(err)
 	}
+	blob = &healthBlobReader{BlobReader: blob, health: store.health}
 
 	reader, err := NewReader(blob)
 	return reader, Error.Wrap(err)
@@ -711,6 +730,11 @@ func (store *Store) CheckWritability() error {
 	return store.blobs.CheckWritability()
 }
 
+// ProbeWrite tests that data reaches the disk of the storage directory by writing, syncing and deleting a file.
+func (store *Store) ProbeWrite() error {
+	return store.blobs.ProbeWrite()
+}
+
 type storedPieceAccess struct {
 	storage.BlobInfo
 	store   *Store
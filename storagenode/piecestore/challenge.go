@@ -0,0 +1,76 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package piecestore
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+
+	"storj.io/common/identity"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/storj"
+	"storj.io/storj/pkg/piecechallenge"
+	"storj.io/storj/pkg/piecechallenge/piecechallengepb"
+)
+
+// HashRange answers a hash challenge of the satellite issuing the call by
+// hashing the requested range of the piece with the nonce.
+func (endpoint *Endpoint) HashRange(ctx context.Context, req *piecechallengepb.HashRangeRequest) (_ *piecechallengepb.HashRangeResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Unauthenticated, err)
+	}
+
+	err = endpoint.trust.VerifySatelliteID(ctx, peer.ID)
+	if err != nil {
+		return nil, rpcstatus.Error(rpcstatus.PermissionDenied, "hash range called with untrusted ID")
+	}
+
+	pieceID, err := storj.PieceIDFromBytes(req.PieceId)
+	if err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.InvalidArgument, err)
+	}
+	if req.Offset < 0 || req.Length <= 0 {
+		return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "invalid range: offset %d, length %d", req.Offset, req.Length)
+	}
+	if len(req.Nonce) == 0 {
+		return nil, rpcstatus.Error(rpcstatus.InvalidArgument, "nonce missing")
+	}
+
+	pieceReader, err := endpoint.store.Reader(ctx, peer.ID, pieceID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			endpoint.monitor.VerifyDirReadableLoop.TriggerWait()
+			return nil, rpcstatus.Wrap(rpcstatus.NotFound, err)
+		}
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+	defer func() {
+		if err := pieceReader.Close(); err != nil {
+			endpoint.log.Error("failed to close piece reader", zap.Error(err))
+		}
+	}()
+
+	if req.Offset+req.Length > pieceReader.Size() {
+		return nil, rpcstatus.Errorf(rpcstatus.InvalidArgument, "requested range %d:%d exceeds piece size %d", req.Offset, req.Length, pieceReader.Size())
+	}
+
+	if _, err := pieceReader.Seek(req.Offset, io.SeekStart); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	hash := piecechallenge.NewHash(req.Nonce)
+	if _, err := io.CopyN(hash, pieceReader, req.Length); err != nil {
+		return nil, rpcstatus.Wrap(rpcstatus.Internal, err)
+	}
+
+	endpoint.log.Debug("answered hash challenge", zap.Stringer("Piece ID", pieceID), zap.Stringer("Satellite ID", peer.ID))
+
+	return &piecechallengepb.HashRangeResponse{Hash: hash.Sum(nil)}, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package piecestore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/memory"
+	"storj.io/common/rpc/rpcstatus"
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/storj/pkg/piecechallenge"
+	"storj.io/storj/pkg/piecechallenge/piecechallengepb"
+	"storj.io/storj/private/testplanet"
+	"storj.io/storj/satellite/metainfo/metabase"
+)
+
+func TestHashRange(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		err := planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", testrand.Bytes(8*memory.KiB))
+		require.NoError(t, err)
+
+		listResponse, _, err := satellite.Metainfo.Service.List(ctx, metabase.SegmentKey{}, "", true, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, listResponse, 1)
+
+		pointer, err := satellite.Metainfo.Service.Get(ctx, metabase.SegmentKey(listResponse[0].GetPath()))
+		require.NoError(t, err)
+
+		piece := pointer.GetRemote().GetRemotePieces()[0]
+		pieceID := pointer.GetRemote().RootPieceId.Derive(piece.NodeId, piece.PieceNum)
+		node := planet.FindNode(piece.NodeId)
+
+		reader, err := node.Storage2.Store.Reader(ctx, satellite.ID(), pieceID)
+		require.NoError(t, err)
+		data := make([]byte, 256)
+		_, err = reader.ReadAt(data, 512)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+
+		nonce := testrand.BytesInt(piecechallenge.NonceSize)
+		request := &piecechallengepb.HashRangeRequest{
+			PieceId: pieceID.Bytes(),
+			Offset:  512,
+			Length:  256,
+			Nonce:   nonce,
+		}
+
+		conn, err := satellite.Dialer.DialNodeURL(ctx, node.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		response, err := piecechallengepb.NewDRPCPieceChallengeClient(conn).HashRange(ctx, request)
+		require.NoError(t, err)
+		require.Equal(t, piecechallenge.Hash(nonce, data), response.Hash)
+
+		// uplinks aren't allowed to challenge nodes
+		uplinkConn, err := planet.Uplinks[0].Dialer.DialNodeURL(ctx, node.NodeURL())
+		require.NoError(t, err)
+		defer ctx.Check(uplinkConn.Close)
+
+		_, err = piecechallengepb.NewDRPCPieceChallengeClient(uplinkConn).HashRange(ctx, request)
+		require.True(t, rpcstatus.Code(err) == rpcstatus.PermissionDenied, err)
+	})
+}
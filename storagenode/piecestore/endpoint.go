@@ -43,16 +43,18 @@ var (
 
 // OldConfig contains everything necessary for a server.
 type OldConfig struct {
-	Path                   string         `help:"path to store data in" default:"$CONFDIR/storage"`
-	WhitelistedSatellites  storj.NodeURLs `help:"a comma-separated list of approved satellite node urls (unused)" devDefault:"" releaseDefault:""`
-	AllocatedDiskSpace     memory.Size    `user:"true" help:"total allocated disk space in bytes" default:"1TB"`
-	AllocatedBandwidth     memory.Size    `user:"true" help:"total allocated bandwidth in bytes (deprecated)" default:"0B"`
-	KBucketRefreshInterval time.Duration  `help:"how frequently Kademlia bucket should be refreshed with node stats" default:"1h0m0s"`
+	Path                   string                  `help:"path to store data in" default:"$CONFDIR/storage"`
+	WhitelistedSatellites  storj.NodeURLs          `help:"a comma-separated list of approved satellite node urls (unused)" devDefault:"" releaseDefault:""`
+	AllocatedDiskSpace     memory.Size             `user:"true" help:"total allocated disk space in bytes" default:"1TB"`
+	SatelliteQuotas        monitor.SatelliteQuotas `user:"true" help:"comma-separated list of per-satellite disk space limits as <satellite id>:<size> or <satellite id>:<percent of allocated disk space>%" default:""`
+	AllocatedBandwidth     memory.Size             `user:"true" help:"total allocated bandwidth in bytes (deprecated)" default:"0B"`
+	KBucketRefreshInterval time.Duration           `help:"how frequently Kademlia bucket should be refreshed with node stats" default:"1h0m0s"`
 }
 
 // Config defines parameters for piecestore endpoint.
 type Config struct {
 	DatabaseDir             string        `help:"directory to store databases. if empty, uses data path" default:""`
+	PieceBackend            string        `help:"how pieces are stored: filestore (a file per piece) or packedstore (packed into large log files). pieces stored with the other backend are not visible after changing it" default:"filestore"`
 	ExpirationGracePeriod   time.Duration `help:"how soon before expiration date should things be considered expired" default:"48h0m0s"`
 	MaxConcurrentRequests   int           `help:"how many concurrent requests are allowed, before uploads are rejected. 0 represents unlimited." default:"0"`
 	DeleteWorkers           int           `help:"how many piece delete workers" default:"1"`
@@ -228,7 +230,11 @@ func (endpoint *Endpoint) Upload(stream pb.DRPCPiecestore_UploadStream) (err err
 		return err
 	}
 
-	availableSpace, err := endpoint.monitor.AvailableSpace(ctx)
+	if endpoint.monitor.Degraded() {
+		return rpcstatus.Error(rpcstatus.Unavailable, "storage disk is degraded, not accepting uploads")
+	}
+
+	availableSpace, err := endpoint.monitor.AvailableSpaceForSatellite(ctx, limit.SatelliteId)
 	if err != nil {
 		return rpcstatus.Wrap(rpcstatus.Internal, err)
 	}
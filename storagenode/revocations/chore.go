@@ -0,0 +1,148 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package revocations implements fetching the certificate revocations known
+// to the trusted satellites.
+package revocations
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/common/peertls/extensions"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/sync2"
+	"storj.io/storj/pkg/revocation"
+	"storj.io/storj/pkg/revocation/revocationpb"
+	"storj.io/storj/storagenode/trust"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for the revocations chore.
+	Error = errs.Class("revocations chore error")
+)
+
+// Config defines parameters for the revocations chore.
+type Config struct {
+	Interval time.Duration `help:"how often to fetch the revocations known to the trusted satellites" releaseDefault:"1h" devDefault:"1m"`
+}
+
+// Chore periodically fetches the revocations known to the trusted satellites
+// and merges them into the local revocation database, so that revoked
+// identities are rejected during the TLS handshake.
+//
+// architecture: Chore
+type Chore struct {
+	log    *zap.Logger
+	dialer rpc.Dialer
+	trust  *trust.Pool
+	db     *revocation.DB
+
+	Loop *sync2.Cycle
+}
+
+// NewChore creates a new revocations chore.
+func NewChore(log *zap.Logger, dialer rpc.Dialer, trust *trust.Pool, db *revocation.DB, config Config) *Chore {
+	return &Chore{
+		log:    log,
+		dialer: dialer,
+		trust:  trust,
+		db:     db,
+		Loop:   sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the revocations chore.
+func (chore *Chore) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return chore.Loop.Run(ctx, func(ctx context.Context) error {
+		if err := chore.Sync(ctx); err != nil {
+			chore.log.Error("unable to sync revocations", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Sync fetches the revocations of every trusted satellite and merges them
+// into the local revocation database.
+func (chore *Chore) Sync(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var group errs.Group
+	for _, satelliteID := range chore.trust.GetSatellites(ctx) {
+		stored, err := chore.sync(ctx, satelliteID)
+		if err != nil {
+			group.Add(Error.New("%s: %w", satelliteID, err))
+		}
+		if stored > 0 {
+			chore.log.Info("revocations merged",
+				zap.Stringer("Satellite ID", satelliteID),
+				zap.Int("count", stored))
+		}
+	}
+	return group.Err()
+}
+
+// sync fetches the revocations of a satellite and merges them into the local
+// revocation database. It returns the number of newly stored revocations.
+func (chore *Chore) sync(ctx context.Context, satelliteID storj.NodeID) (stored int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	nodeurl, err := chore.trust.GetNodeURL(ctx, satelliteID)
+	if err != nil {
+		return 0, errs.New("unable to find satellite: %w", err)
+	}
+
+	conn, err := chore.dialer.DialNodeURL(ctx, nodeurl)
+	if err != nil {
+		return 0, errs.New("unable to connect to the satellite: %w", err)
+	}
+	defer func() { err = errs.Combine(err, conn.Close()) }()
+
+	response, err := revocationpb.NewDRPCRevocationsClient(conn).List(ctx, &revocationpb.ListRequest{})
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+
+	for _, rev := range response.Revocations {
+		ca, err := x509.ParseCertificate(rev.CaCertificate)
+		if err != nil {
+			chore.log.Warn("invalid revocation certificate",
+				zap.Stringer("Satellite ID", satelliteID),
+				zap.Error(err))
+			continue
+		}
+
+		// NB: the revocation is only stored, when it's signed by the CA.
+		ok, err := chore.db.Merge(ctx, ca, rev.Revocation)
+		if extensions.ErrRevocationDB.Has(err) {
+			return stored, err
+		}
+		if err != nil {
+			chore.log.Warn("invalid revocation",
+				zap.Stringer("Satellite ID", satelliteID),
+				zap.Error(err))
+			continue
+		}
+		if ok {
+			stored++
+		}
+	}
+
+	return stored, nil
+}
+
+// Close stops the revocations chore.
+func (chore *Chore) Close() error {
+	chore.Loop.Close()
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package revocations_test
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/identity/testidentity"
+	"storj.io/common/peertls"
+	"storj.io/common/peertls/extensions"
+	"storj.io/common/peertls/testpeertls"
+	"storj.io/common/peertls/tlsopts"
+	"storj.io/common/rpc"
+	"storj.io/common/storj"
+	"storj.io/common/testcontext"
+	"storj.io/storj/pkg/revocation"
+	"storj.io/storj/pkg/revocation/revocationpb"
+	"storj.io/storj/pkg/server"
+	"storj.io/storj/storagenode/revocations"
+	"storj.io/storj/storagenode/trust"
+)
+
+func TestChore(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	log := zaptest.NewLogger(t)
+
+	// satellite, which knows about a revoked leaf
+	satelliteDB, err := revocation.OpenDB(ctx, "bolt://"+ctx.File("satellite-revocations.db"))
+	require.NoError(t, err)
+	defer ctx.Check(satelliteDB.Close)
+
+	keys, chain, err := testpeertls.NewCertChain(2, storj.LatestIDVersion().Number)
+	require.NoError(t, err)
+	revokedLeafChain, leafRevocation, err := testpeertls.RevokeLeaf(keys[peertls.CAIndex], chain)
+	require.NoError(t, err)
+	require.NoError(t, satelliteDB.Put(ctx, revokedLeafChain, leafRevocation))
+
+	satelliteIdent := testidentity.MustPregeneratedIdentity(0, storj.LatestIDVersion())
+	satelliteTLS, err := tlsopts.NewOptions(satelliteIdent, tlsopts.Config{PeerIDVersions: "*"}, nil)
+	require.NoError(t, err)
+
+	srv, err := server.New(log, satelliteTLS, server.Config{
+		Address:        "127.0.0.1:0",
+		PrivateAddress: "127.0.0.1:0",
+	})
+	require.NoError(t, err)
+	defer ctx.Check(srv.Close)
+
+	require.NoError(t, revocationpb.DRPCRegisterRevocations(srv.DRPC(), revocation.NewEndpoint(log, satelliteDB)))
+	ctx.Go(func() error { return srv.Run(ctx) })
+
+	// storage node, which trusts the satellite
+	nodeDB, err := revocation.OpenDB(ctx, "bolt://"+ctx.File("node-revocations.db"))
+	require.NoError(t, err)
+	defer ctx.Check(nodeDB.Close)
+
+	nodeIdent := testidentity.MustPregeneratedIdentity(1, storj.LatestIDVersion())
+	nodeTLS, err := tlsopts.NewOptions(nodeIdent, tlsopts.Config{PeerIDVersions: "*"}, nodeDB)
+	require.NoError(t, err)
+	dialer := rpc.NewDefaultDialer(nodeTLS)
+
+	source, err := trust.NewStaticURLSource(satelliteIdent.ID.String() + "@" + srv.Addr().String())
+	require.NoError(t, err)
+	pool, err := trust.NewPool(log, trust.Dialer(dialer), trust.Config{
+		Sources:   []trust.Source{source},
+		CachePath: ctx.File("trust-cache.json"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, pool.Refresh(ctx))
+
+	rev, err := nodeDB.Get(ctx, chain)
+	require.NoError(t, err)
+	require.Nil(t, rev)
+
+	chore := revocations.NewChore(log, dialer, pool, nodeDB, revocations.Config{Interval: time.Hour})
+	require.NoError(t, chore.Sync(ctx))
+
+	rev, err = nodeDB.Get(ctx, chain)
+	require.NoError(t, err)
+	require.NotNil(t, rev)
+	revBytes, err := rev.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, leafRevocation.Value, revBytes)
+
+	// the revoked leaf is rejected, even though it doesn't carry the revocation
+	verify := revocation.VerifyNotRevoked(nodeDB)
+	require.Equal(t, extensions.ErrRevokedCert, verify(nil, [][]*x509.Certificate{chain}))
+
+	// syncing again doesn't store anything new
+	require.NoError(t, chore.Sync(ctx))
+}
@@ -25,6 +25,7 @@ import (
 	"storj.io/storj/private/tagsql"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/filestore"
+	"storj.io/storj/storage/packedstore"
 	"storj.io/storj/storagenode/apikeys"
 	"storj.io/storj/storagenode/bandwidth"
 	"storj.io/storj/storagenode/notifications"
@@ -83,8 +84,18 @@ type Config struct {
 	Driver    string // if unset, uses sqlite3
 	Pieces    string
 	Filestore filestore.Config
+
+	PieceBackend string // if unset, uses filestore
+	Packedstore  packedstore.Config
 }
 
+const (
+	// FilestoreBackend stores every piece in a separate file.
+	FilestoreBackend = "filestore"
+	// PackedstoreBackend packs pieces into large append-only log files.
+	PackedstoreBackend = "packedstore"
+)
+
 // DB contains access to different database tables.
 type DB struct {
 	log    *zap.Logger
@@ -114,13 +125,11 @@ type DB struct {
 
 // OpenNew creates a new master database for storage node.
 func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
-	piecesDir, err := filestore.NewDir(log, config.Pieces)
+	pieces, err := openPieces(log, config, true)
 	if err != nil {
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
-
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
 	bandwidthDB := &bandwidthDB{}
@@ -180,15 +189,37 @@ func OpenNew(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
 	return db, nil
 }
 
+// openPieces opens the blob storage for pieces with the configured backend. When create
+// is true, the storage directory is created if it doesn't exist.
+func openPieces(log *zap.Logger, config Config, create bool) (storage.Blobs, error) {
+	switch config.PieceBackend {
+	case "", FilestoreBackend:
+		openDir := filestore.OpenDir
+		if create {
+			openDir = filestore.NewDir
+		}
+		piecesDir, err := openDir(log, config.Pieces)
+		if err != nil {
+			return nil, err
+		}
+		return filestore.New(log, piecesDir, config.Filestore), nil
+	case PackedstoreBackend:
+		if create {
+			return packedstore.NewAt(log, config.Pieces, config.Packedstore)
+		}
+		return packedstore.OpenAt(log, config.Pieces, config.Packedstore)
+	default:
+		return nil, ErrDatabase.New("unknown piece backend %q", config.PieceBackend)
+	}
+}
+
 // OpenExisting opens an existing master database for storage node.
 func OpenExisting(ctx context.Context, log *zap.Logger, config Config) (*DB, error) {
-	piecesDir, err := filestore.OpenDir(log, config.Pieces)
+	pieces, err := openPieces(log, config, false)
 	if err != nil {
 		return nil, err
 	}
 
-	pieces := filestore.New(log, piecesDir, config.Filestore)
-
 	deprecatedInfoDB := &deprecatedInfoDB{}
 	v0PieceInfoDB := &v0PieceInfoDB{}
 	bandwidthDB := &bandwidthDB{}
@@ -456,7 +487,7 @@ func (db *DB) preflight(ctx context.Context, dbName string, dbContainer DBContai
 
 // Close closes any resources.
 func (db *DB) Close() error {
-	return db.closeDatabases()
+	return errs.Combine(db.closeDatabases(), db.pieces.Close())
 }
 
 // closeDatabases closes all the SQLite database connections and removes them from the associated maps.
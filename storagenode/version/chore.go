@@ -15,6 +15,7 @@ import (
 	"storj.io/private/version"
 	"storj.io/storj/private/version/checker"
 	"storj.io/storj/storagenode/notifications"
+	"storj.io/storj/storagenode/trust"
 )
 
 var (
@@ -29,19 +30,25 @@ type Chore struct {
 	Loop          *sync2.Cycle
 	nodeID        storj.NodeID
 	notifications *notifications.Service
+	trust         *trust.Pool
 
 	version Relevance
+	// satellites contains the minimum versions of satellites, which the node
+	// was notified about.
+	satellites map[storj.NodeID]version.SemVer
 	// nowFn used to mock time is tests.
 	nowFn func() time.Time
 }
 
 // NewChore creates a Version Check Client with default configuration for storagenode.
-func NewChore(log *zap.Logger, service *checker.Service, notifications *notifications.Service, nodeID storj.NodeID, checkInterval time.Duration) *Chore {
+func NewChore(log *zap.Logger, service *checker.Service, notifications *notifications.Service, trust *trust.Pool, nodeID storj.NodeID, checkInterval time.Duration) *Chore {
 	return &Chore{
 		log:           log,
 		service:       service,
 		nodeID:        nodeID,
 		notifications: notifications,
+		trust:         trust,
+		satellites:    map[storj.NodeID]version.SemVer{},
 		Loop:          sync2.NewCycle(checkInterval),
 		nowFn:         time.Now().UTC,
 	}
@@ -72,6 +79,8 @@ func (chore *Chore) Run(ctx context.Context) (err error) {
 			return err
 		}
 
+		chore.checkSatellites(ctx)
+
 		if !chore.version.IsOutdated {
 			return nil
 		}
@@ -137,6 +146,38 @@ func (chore *Chore) checkRelevance(ctx context.Context, suggested version.SemVer
 	return nil
 }
 
+// checkSatellites checks the version policies of the trusted satellites and
+// notifies once about every satellite, which requires a newer version.
+func (chore *Chore) checkSatellites(ctx context.Context) {
+	for _, satelliteID := range chore.trust.GetSatellites(ctx) {
+		minimum, allowed, err := chore.service.CheckSatellite(ctx, satelliteID)
+		if err != nil {
+			chore.log.Debug("Failed to check satellite version policy.", zap.Stringer("Satellite ID", satelliteID), zap.Error(err))
+			continue
+		}
+		if allowed {
+			delete(chore.satellites, satelliteID)
+			continue
+		}
+
+		chore.log.Warn("Version not allowed by satellite.",
+			zap.Stringer("Satellite ID", satelliteID),
+			zap.Stringer("Version", chore.service.Info.Version.Version),
+			zap.Stringer("Minimum Version", minimum.Version),
+		)
+
+		if notified, ok := chore.satellites[satelliteID]; ok && notified.Compare(minimum) == 0 {
+			continue
+		}
+		chore.satellites[satelliteID] = minimum
+
+		_, err = chore.notifications.Receive(ctx, NewSatelliteVersionNotification(satelliteID, minimum))
+		if err != nil {
+			chore.log.Error("Failed to receive notification.", zap.Error(err))
+		}
+	}
+}
+
 // Relevance contains information about software being outdated.
 type Relevance struct {
 	ExpectedVersion  version.SemVer
@@ -162,6 +203,17 @@ func (chore *Chore) TestCheckVersion() (relevance Relevance) {
 	return chore.version
 }
 
+// NewSatelliteVersionNotification returns the notification about a satellite,
+// which requires a newer version than the node is running.
+func NewSatelliteVersionNotification(satelliteID storj.NodeID, minimum version.SemVer) notifications.NewNotification {
+	return notifications.NewNotification{
+		SenderID: satelliteID,
+		Type:     notifications.TypeCustom,
+		Title:    "Please update your Node to Version " + minimum.String(),
+		Message:  "The satellite " + satelliteID.String() + " requires version " + minimum.String() + " or newer.",
+	}
+}
+
 // NewVersionNotification - returns version update required notification.
 func NewVersionNotification(timesSent notifications.TimesNotified, suggestedVersion version.SemVer, senderID storj.NodeID) (_ notifications.NewNotification) {
 	switch timesSent {
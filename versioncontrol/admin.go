@@ -0,0 +1,136 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package versioncontrol
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"storj.io/common/storj"
+)
+
+// HandleRollout serves the current stage of the rollout of a process.
+func (peer *Peer) HandleRollout(w http.ResponseWriter, r *http.Request) {
+	status, err := peer.Rollouts.Status(mux.Vars(r)["process"])
+	if err != nil {
+		peer.serveError(w, err)
+		return
+	}
+	peer.serveJSON(w, status)
+}
+
+// HandleNodeStage serves in which stage of the rollout of a process a node updates.
+func (peer *Peer) HandleNodeStage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	nodeID, err := storj.NodeIDFromString(vars["nodeID"])
+	if err != nil {
+		peer.serveJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stage, err := peer.Rollouts.NodeStage(vars["process"], nodeID)
+	if err != nil {
+		peer.serveError(w, err)
+		return
+	}
+	peer.serveJSON(w, stage)
+}
+
+// HandleAdminRollouts serves the current stages of the rollouts of all processes.
+func (peer *Peer) HandleAdminRollouts(w http.ResponseWriter, r *http.Request) {
+	peer.serveJSON(w, peer.Rollouts.All())
+}
+
+// HandleAdminRolloutAction pauses, resumes or aborts the rollout of a process.
+func (peer *Peer) HandleAdminRolloutAction(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	process := vars["process"]
+
+	var status RolloutStatus
+	var err error
+	switch vars["action"] {
+	case "pause":
+		status, err = peer.Rollouts.Pause(process)
+	case "resume":
+		status, err = peer.Rollouts.Resume(process)
+	case "abort":
+		status, err = peer.Rollouts.Abort(process)
+	default:
+		peer.serveJSONError(w, http.StatusNotFound, "unknown action")
+		return
+	}
+	if err != nil {
+		peer.serveError(w, err)
+		return
+	}
+
+	peer.Log.Info("Rollout changed.",
+		zap.String("Process", process),
+		zap.String("Action", vars["action"]),
+		zap.String("Version", status.Version),
+	)
+	peer.serveJSON(w, status)
+}
+
+// withAdminAuth only lets through requests with the configured admin token.
+func (peer *Peer) withAdminAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if peer.adminToken == "" {
+			peer.serveJSONError(w, http.StatusForbidden, "Authorization not enabled.")
+			return
+		}
+
+		equality := subtle.ConstantTimeCompare(
+			[]byte(r.Header.Get("Authorization")),
+			[]byte(peer.adminToken),
+		)
+		if equality != 1 {
+			peer.serveJSONError(w, http.StatusForbidden, "Forbidden")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// serveError serves err with the status code matching its class.
+func (peer *Peer) serveError(w http.ResponseWriter, err error) {
+	switch {
+	case ErrUnknownProcess.Has(err):
+		peer.serveJSONError(w, http.StatusNotFound, err.Error())
+	case ErrRolloutState.Has(err):
+		peer.serveJSONError(w, http.StatusConflict, err.Error())
+	default:
+		peer.Log.Error("Internal error.", zap.Error(err))
+		peer.serveJSONError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// serveJSONError serves the error message as JSON.
+func (peer *Peer) serveJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	var response struct {
+		Error string `json:"error"`
+	}
+	response.Error = message
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		peer.Log.Error("Error writing response to client.", zap.Error(err))
+	}
+}
+
+// serveJSON serves value as JSON.
+func (peer *Peer) serveJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		peer.Log.Error("Error writing response to client.", zap.Error(err))
+	}
+}
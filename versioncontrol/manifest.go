@@ -0,0 +1,135 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package versioncontrol
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/private/version/manifest"
+)
+
+// ManifestErr defines the release manifest config error class.
+var ManifestErr = errs.Class("release manifest config error")
+
+// HandleManifest serves the release manifest. It is signed on every request,
+// so that its timestamp shows clients that the manifest is current.
+func (peer *Peer) HandleManifest(w http.ResponseWriter, r *http.Request) {
+	// Only handle GET Requests
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if peer.manifest.signingKey == nil {
+		http.Error(w, "release manifest not configured", http.StatusNotFound)
+		return
+	}
+
+	response, err := signManifest(peer.manifest.signingKey, peer.manifest.processes, time.Now())
+	if err != nil {
+		peer.Log.Error("Error signing release manifest.", zap.Error(err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(response)
+	if err != nil {
+		peer.Log.Error("Error writing response to client.", zap.Error(err))
+	}
+}
+
+// releaseManifest contains the released binaries and the key signing them.
+type releaseManifest struct {
+	signingKey ed25519.PrivateKey
+	processes  map[string]manifest.Process
+}
+
+// loadManifest loads the released binaries and the signing key. It returns an
+// empty releaseManifest when no releases are configured.
+func loadManifest(config ManifestConfig, versions ProcessesConfig) (releaseManifest, error) {
+	if config.Releases == "" {
+		return releaseManifest{}, nil
+	}
+	if config.SigningKey == "" {
+		return releaseManifest{}, ManifestErr.New("releases are configured without a signing key")
+	}
+
+	privateKey, err := manifest.LoadPrivateKey(config.SigningKey)
+	if err != nil {
+		return releaseManifest{}, ManifestErr.Wrap(err)
+	}
+
+	data, err := ioutil.ReadFile(config.Releases)
+	if err != nil {
+		return releaseManifest{}, ManifestErr.Wrap(err)
+	}
+
+	var processes map[string]manifest.Process
+	if err := json.Unmarshal(data, &processes); err != nil {
+		return releaseManifest{}, ManifestErr.Wrap(err)
+	}
+
+	if err := validateReleases(processes, versions); err != nil {
+		return releaseManifest{}, err
+	}
+
+	// fail early, instead of on every request
+	if _, err := signManifest(privateKey, processes, time.Now()); err != nil {
+		return releaseManifest{}, err
+	}
+
+	return releaseManifest{
+		signingKey: privateKey,
+		processes:  processes,
+	}, nil
+}
+
+// signManifest returns the JSON encoded manifest of processes, signed at now.
+func signManifest(privateKey ed25519.PrivateKey, processes map[string]manifest.Process, now time.Time) ([]byte, error) {
+	signed, err := manifest.Sign(privateKey, manifest.Manifest{
+		Timestamp: now.UTC(),
+		Processes: processes,
+	})
+	if err != nil {
+		return nil, ManifestErr.Wrap(err)
+	}
+
+	response, err := json.Marshal(signed)
+	return response, ManifestErr.Wrap(err)
+}
+
+// validateReleases checks that every release describes the suggested version
+// of a known process with complete binaries.
+func validateReleases(releases map[string]manifest.Process, versions ProcessesConfig) error {
+	processes := processConfigs(versions)
+
+	var group errs.Group
+	for name, release := range releases {
+		process, ok := processes[name]
+		if !ok {
+			group.Add(ManifestErr.New("unknown process: %s", name))
+			continue
+		}
+		if version := process.Suggested.Version; release.Version != version {
+			group.Add(ManifestErr.New("release of %s is %s, but suggested version is %s", name, release.Version, version))
+		}
+		if len(release.Binaries) == 0 {
+			group.Add(ManifestErr.New("release of %s has no binaries", name))
+		}
+		for _, binary := range release.Binaries {
+			if err := binary.Validate(); err != nil {
+				group.Add(ManifestErr.New("release of %s: %v", name, err))
+			}
+		}
+	}
+	return group.Err()
+}
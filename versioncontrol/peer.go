@@ -11,12 +11,15 @@ import (
 	"net"
 	"net/http"
 	"reflect"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/common/errs2"
+	"storj.io/common/storj"
 	"storj.io/private/version"
 )
 
@@ -36,6 +39,24 @@ type Config struct {
 	Versions OldVersionConfig
 
 	Binary ProcessesConfig
+
+	Manifest ManifestConfig
+
+	RolloutState string `user:"true" help:"path of the file persisting the rollout state (stage schedule, pause and abort); kept in memory only when empty" default:""`
+	Admin        AdminConfig
+
+	SatellitePolicies string `user:"true" help:"path to the JSON file with the version policies of satellites: the minimum versions by process name per satellite ID" default:""`
+}
+
+// AdminConfig configures the admin endpoints.
+type AdminConfig struct {
+	AuthorizationToken string `internal:"true" help:"token required in the Authorization header of admin requests; admin endpoints are disabled when empty" default:""`
+}
+
+// ManifestConfig configures the signed release manifest.
+type ManifestConfig struct {
+	SigningKey string `user:"true" help:"path to the file with the hex-encoded ed25519 private key seed used to sign the release manifest" default:""`
+	Releases   string `user:"true" help:"path to the JSON file describing the released binaries (url, sha256 and size per os/arch) by process name" default:""`
 }
 
 // OldVersionConfig provides a list of allowed Versions per process.
@@ -76,6 +97,7 @@ type VersionConfig struct {
 type RolloutConfig struct {
 	Seed   string `user:"true" help:"random 32 byte, hex-encoded string"`
 	Cursor int    `user:"true" help:"percentage of nodes which should roll-out to the suggested version" default:"0"`
+	Stages string `user:"true" help:"comma separated rollout stages as percentage:duration, e.g. 5:24h,25:24h,100; the cursor is ignored when set" default:""`
 }
 
 // Peer is the representation of a VersionControl Server.
@@ -91,9 +113,13 @@ type Peer struct {
 		Listener net.Listener
 	}
 	Versions version.AllowedVersions
+	Rollouts *Rollouts
 
-	// response contains the byte version of current allowed versions
-	response []byte
+	adminToken string
+	// manifest contains the released binaries served as signed release manifest
+	manifest releaseManifest
+	// policies contains the version policies of satellites
+	policies map[storj.NodeID]SatellitePolicy
 }
 
 // HandleGet contains the request handler for the version control web server.
@@ -104,13 +130,34 @@ func (peer *Peer) HandleGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	response, err := json.Marshal(peer.currentVersions())
+	if err != nil {
+		peer.Log.Error("Error marshalling version info.", zap.Error(err))
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	_, err := w.Write(peer.response)
+	_, err = w.Write(response)
 	if err != nil {
 		peer.Log.Error("Error writing response to client.", zap.Error(err))
 	}
 }
 
+// currentVersions returns the allowed versions with the rollout cursors of the current stages.
+func (peer *Peer) currentVersions() version.AllowedVersions {
+	versions := peer.Versions
+	for name, process := range allowedProcesses(&versions) {
+		cursor, err := peer.Rollouts.Cursor(name)
+		if err != nil {
+			peer.Log.Error("Error retrieving rollout cursor.", zap.String("Process", name), zap.Error(err))
+			continue
+		}
+		process.Rollout.Cursor = cursor
+	}
+	return versions
+}
+
 // New creates a new VersionControl Server.
 func New(log *zap.Logger, config *Config) (peer *Peer, err error) {
 	if err := config.Binary.ValidateRollouts(log); err != nil {
@@ -118,7 +165,8 @@ func New(log *zap.Logger, config *Config) (peer *Peer, err error) {
 	}
 
 	peer = &Peer{
-		Log: log,
+		Log:        log,
+		adminToken: config.Admin.AuthorizationToken,
 	}
 
 	// Convert each Service's VersionConfig String to SemVer
@@ -178,18 +226,40 @@ func New(log *zap.Logger, config *Config) (peer *Peer, err error) {
 		return nil, RolloutErr.Wrap(err)
 	}
 
-	peer.response, err = json.Marshal(peer.Versions)
+	peer.Rollouts, err = NewRollouts(config.Binary, config.RolloutState, time.Now)
 	if err != nil {
-		peer.Log.Error("Error marshalling version info.", zap.Error(err))
-		return nil, RolloutErr.Wrap(err)
+		return nil, err
 	}
 
-	peer.Log.Debug("Setting version info.", zap.ByteString("Value", peer.response))
+	peer.Log.Debug("Setting version info.", zap.Any("Value", peer.Versions))
+
+	peer.manifest, err = loadManifest(config.Manifest, config.Binary)
+	if err != nil {
+		peer.Log.Error("Error loading release manifest.", zap.Error(err))
+		return nil, err
+	}
+
+	peer.policies, err = loadSatellitePolicies(config.SatellitePolicies, config.Binary)
+	if err != nil {
+		return nil, err
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/manifest", peer.HandleManifest)
+	router.HandleFunc("/rollouts/{process}", peer.HandleRollout).Methods(http.MethodGet)
+	router.HandleFunc("/rollouts/{process}/nodes/{nodeID}", peer.HandleNodeStage).Methods(http.MethodGet)
+	router.HandleFunc("/satellites/{satelliteID}", peer.HandleSatellite).Methods(http.MethodGet)
+
+	adminRouter := router.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(peer.withAdminAuth)
+	adminRouter.HandleFunc("/rollouts", peer.HandleAdminRollouts).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/rollouts/{process}/{action:pause|resume|abort}", peer.HandleAdminRolloutAction).Methods(http.MethodPost)
+
+	// the versions are served on every other path, as clients may request them from any path.
+	router.PathPrefix("/").HandlerFunc(peer.HandleGet)
 
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", peer.HandleGet)
 	peer.Server.Endpoint = http.Server{
-		Handler: mux,
+		Handler: router,
 	}
 
 	peer.Server.Listener, err = net.Listen("tcp", config.Address)
@@ -269,6 +339,10 @@ func (rollout RolloutConfig) Validate() error {
 	if _, err := hex.DecodeString(rollout.Seed); err != nil {
 		return RolloutErr.New("invalid seed: %s", rollout.Seed)
 	}
+
+	if _, err := ParseStages(rollout.Stages); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -4,14 +4,21 @@
 package versioncontrol_test
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
 	"math/rand"
+	"net/http"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 
+	"storj.io/common/testcontext"
+	"storj.io/private/version"
+	"storj.io/storj/private/version/manifest"
 	"storj.io/storj/versioncontrol"
 )
 
@@ -154,6 +161,47 @@ func TestPeer_Run_error(t *testing.T) {
 	}
 }
 
+func TestPeer_HandleGet(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	peer, err := versioncontrol.New(zaptest.NewLogger(t), &versioncontrol.Config{
+		Address: "127.0.0.1:0",
+		Versions: versioncontrol.OldVersionConfig{
+			Satellite:   "v0.0.1",
+			Storagenode: "v0.0.1",
+			Uplink:      "v0.0.1",
+			Gateway:     "v0.0.1",
+			Identity:    "v0.0.1",
+		},
+		Binary: validRandVersions(t),
+	})
+	require.NoError(t, err)
+	ctx.Go(func() error { return peer.Run(ctx) })
+	defer ctx.Check(peer.Close)
+
+	// the versions are served on any path, which isn't handled otherwise.
+	for _, path := range []string{"/", "/anything", "/nested/path", "/rollouts"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+peer.Addr()+path, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode, path)
+
+		var allowed version.AllowedVersions
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&allowed), path)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, peer.Versions.Processes.Storagenode.Suggested, allowed.Processes.Storagenode.Suggested, path)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+peer.Addr()+"/anything", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+}
+
 func TestVersions_ValidateRollouts(t *testing.T) {
 	versions := validRandVersions(t)
 	err := versions.ValidateRollouts(zaptest.NewLogger(t))
@@ -224,3 +272,86 @@ func randSeedString(t *testing.T) string {
 
 	return hex.EncodeToString(seed)
 }
+
+func TestPeer_Manifest_error(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	_, private, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	signingKey := ctx.File("signing.key")
+	require.NoError(t, manifest.SavePrivateKey(signingKey, private))
+
+	binary := manifest.Binary{
+		OS:     "linux",
+		Arch:   "amd64",
+		URL:    "https://example.test/storagenode_linux_amd64.zip",
+		SHA256: hex.EncodeToString(make([]byte, 32)),
+		Size:   1024,
+	}
+
+	versions := validRandVersions(t)
+	versions.Storagenode.Suggested.Version = "v1.2.3"
+
+	for _, scenario := range []struct {
+		name        string
+		releases    map[string]manifest.Process
+		signingKey  string
+		errContains string
+	}{
+		{
+			"missing signing key",
+			map[string]manifest.Process{"storagenode": {Version: "v1.2.3", Binaries: []manifest.Binary{binary}}},
+			"",
+			"without a signing key",
+		},
+		{
+			"not suggested version",
+			map[string]manifest.Process{"storagenode": {Version: "v1.2.4", Binaries: []manifest.Binary{binary}}},
+			signingKey,
+			"suggested version is v1.2.3",
+		},
+		{
+			"unknown process",
+			map[string]manifest.Process{"unknown": {Version: "v1.2.3", Binaries: []manifest.Binary{binary}}},
+			signingKey,
+			"unknown process",
+		},
+		{
+			"no binaries",
+			map[string]manifest.Process{"storagenode": {Version: "v1.2.3"}},
+			signingKey,
+			"no binaries",
+		},
+	} {
+		scenario := scenario
+		t.Run(scenario.name, func(t *testing.T) {
+			data, err := json.Marshal(scenario.releases)
+			require.NoError(t, err)
+			releases := ctx.File(scenario.name, "releases.json")
+			require.NoError(t, ioutil.WriteFile(releases, data, 0644))
+
+			config := versioncontrol.Config{
+				Address: "127.0.0.1:0",
+				Versions: versioncontrol.OldVersionConfig{
+					Satellite:   "v0.0.1",
+					Storagenode: "v0.0.1",
+					Uplink:      "v0.0.1",
+					Gateway:     "v0.0.1",
+					Identity:    "v0.0.1",
+				},
+				Binary: versions,
+				Manifest: versioncontrol.ManifestConfig{
+					SigningKey: scenario.signingKey,
+					Releases:   releases,
+				},
+			}
+
+			peer, err := versioncontrol.New(zaptest.NewLogger(t), &config)
+			require.Nil(t, peer)
+			require.Error(t, err)
+			require.True(t, versioncontrol.ManifestErr.Has(err))
+			require.Contains(t, err.Error(), scenario.errContains)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package versioncontrol
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/private/version"
+)
+
+// PolicyErr defines the satellite version policy config error class.
+var PolicyErr = errs.Class("satellite policy config error")
+
+// SatellitePolicy is the version policy of a satellite.
+type SatellitePolicy struct {
+	// Minimum contains the minimum versions the satellite accepts by process name.
+	// They are served instead of the minimum versions of all satellites, when higher.
+	Minimum map[string]string `json:"minimum"`
+}
+
+// HandleSatellite serves the allowed versions with the minimum versions
+// raised to the ones required by the satellite. Satellites without a policy
+// get the same versions as everyone else. Storage nodes check it for each of
+// their trusted satellites to notify about satellites, which they don't satisfy.
+func (peer *Peer) HandleSatellite(w http.ResponseWriter, r *http.Request) {
+	satelliteID, err := storj.NodeIDFromString(mux.Vars(r)["satelliteID"])
+	if err != nil {
+		peer.serveJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	versions := peer.currentVersions()
+	if policy, ok := peer.policies[satelliteID]; ok {
+		processes := allowedProcesses(&versions)
+		for name, minimum := range policy.Minimum {
+			process := processes[name]
+			if isNewer(minimum, process.Minimum.Version) {
+				process.Minimum = version.Version{Version: minimum}
+			}
+		}
+	}
+	peer.serveJSON(w, versions)
+}
+
+// loadSatellitePolicies loads the version policies of satellites from the JSON
+// file at path. It returns no policies when path is empty.
+func loadSatellitePolicies(path string, versions ProcessesConfig) (map[storj.NodeID]SatellitePolicy, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, PolicyErr.Wrap(err)
+	}
+
+	var config map[string]SatellitePolicy
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, PolicyErr.Wrap(err)
+	}
+
+	processes := processConfigs(versions)
+
+	var group errs.Group
+	policies := make(map[storj.NodeID]SatellitePolicy, len(config))
+	for id, policy := range config {
+		satelliteID, err := storj.NodeIDFromString(id)
+		if err != nil {
+			group.Add(PolicyErr.New("invalid satellite id %q: %v", id, err))
+			continue
+		}
+		for name, minimum := range policy.Minimum {
+			process, ok := processes[name]
+			if !ok {
+				group.Add(PolicyErr.New("%s: unknown process: %s", id, name))
+				continue
+			}
+			if _, err := version.NewSemVer(minimum); err != nil {
+				group.Add(PolicyErr.New("%s: invalid minimum version of %s: %v", id, name, err))
+				continue
+			}
+			// nodes couldn't satisfy the satellite without updating past the suggested version.
+			if isNewer(minimum, process.Suggested.Version) {
+				group.Add(PolicyErr.New("%s: minimum version of %s is %s, but suggested version is %s", id, name, minimum, process.Suggested.Version))
+			}
+		}
+		policies[satelliteID] = policy
+	}
+
+	return policies, group.Err()
+}
+
+// allowedProcesses returns the processes of versions by their name.
+func allowedProcesses(versions *version.AllowedVersions) map[string]*version.Process {
+	return map[string]*version.Process{
+		"satellite":           &versions.Processes.Satellite,
+		"storagenode":         &versions.Processes.Storagenode,
+		"storagenode-updater": &versions.Processes.StoragenodeUpdater,
+		"uplink":              &versions.Processes.Uplink,
+		"gateway":             &versions.Processes.Gateway,
+		"identity":            &versions.Processes.Identity,
+	}
+}
+
+// isNewer returns whether version a is newer than version b. Versions, which
+// can't be parsed, are never newer and everything is newer than them.
+func isNewer(a, b string) bool {
+	semA, err := version.NewSemVer(a)
+	if err != nil {
+		return false
+	}
+	semB, err := version.NewSemVer(b)
+	if err != nil {
+		return true
+	}
+	return semA.Compare(semB) > 0
+}
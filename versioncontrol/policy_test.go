@@ -0,0 +1,153 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package versioncontrol_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/private/version"
+	"storj.io/storj/versioncontrol"
+)
+
+func TestPeer_SatellitePolicies(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	strict, lenient, other := testrand.NodeID(), testrand.NodeID(), testrand.NodeID()
+
+	versions := validRandVersions(t)
+	versions.Storagenode.Minimum.Version = "v1.0.0"
+	versions.Storagenode.Suggested.Version = "v1.2.3"
+
+	policies := ctx.File("policies.json")
+	writePolicies(t, policies, map[string]versioncontrol.SatellitePolicy{
+		strict.String():  {Minimum: map[string]string{"storagenode": "v1.2.0"}},
+		lenient.String(): {Minimum: map[string]string{"storagenode": "v0.9.0"}},
+	})
+
+	peer, err := versioncontrol.New(zaptest.NewLogger(t), &versioncontrol.Config{
+		Address: "127.0.0.1:0",
+		Versions: versioncontrol.OldVersionConfig{
+			Satellite:   "v0.0.1",
+			Storagenode: "v0.0.1",
+			Uplink:      "v0.0.1",
+			Gateway:     "v0.0.1",
+			Identity:    "v0.0.1",
+		},
+		Binary:            versions,
+		SatellitePolicies: policies,
+	})
+	require.NoError(t, err)
+	ctx.Go(func() error { return peer.Run(ctx) })
+	defer ctx.Check(peer.Close)
+
+	get := func(path string) (int, version.AllowedVersions) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+peer.Addr()+path, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+
+		var allowed version.AllowedVersions
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&allowed))
+		}
+		return resp.StatusCode, allowed
+	}
+
+	// the satellite requires a higher minimum version
+	status, allowed := get("/satellites/" + strict.String())
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "v1.2.0", allowed.Processes.Storagenode.Minimum.Version)
+	require.Equal(t, "v1.2.3", allowed.Processes.Storagenode.Suggested.Version)
+
+	// a lower minimum version doesn't lower the minimum of all satellites
+	status, allowed = get("/satellites/" + lenient.String())
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "v1.0.0", allowed.Processes.Storagenode.Minimum.Version)
+
+	// satellites without a policy get the versions of everyone
+	status, allowed = get("/satellites/" + other.String())
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "v1.0.0", allowed.Processes.Storagenode.Minimum.Version)
+
+	status, _ = get("/satellites/invalid")
+	require.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestPeer_SatellitePolicies_error(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	satelliteID := testrand.NodeID().String()
+
+	versions := validRandVersions(t)
+	versions.Storagenode.Suggested.Version = "v1.2.3"
+
+	for _, scenario := range []struct {
+		name        string
+		policies    map[string]versioncontrol.SatellitePolicy
+		errContains string
+	}{
+		{
+			"invalid satellite id",
+			map[string]versioncontrol.SatellitePolicy{"invalid": {Minimum: map[string]string{"storagenode": "v1.2.0"}}},
+			"invalid satellite id",
+		},
+		{
+			"unknown process",
+			map[string]versioncontrol.SatellitePolicy{satelliteID: {Minimum: map[string]string{"unknown": "v1.2.0"}}},
+			"unknown process",
+		},
+		{
+			"invalid version",
+			map[string]versioncontrol.SatellitePolicy{satelliteID: {Minimum: map[string]string{"storagenode": "latest"}}},
+			"invalid minimum version",
+		},
+		{
+			"above suggested version",
+			map[string]versioncontrol.SatellitePolicy{satelliteID: {Minimum: map[string]string{"storagenode": "v1.3.0"}}},
+			"suggested version is v1.2.3",
+		},
+	} {
+		scenario := scenario
+		t.Run(scenario.name, func(t *testing.T) {
+			policies := ctx.File(scenario.name, "policies.json")
+			writePolicies(t, policies, scenario.policies)
+
+			peer, err := versioncontrol.New(zaptest.NewLogger(t), &versioncontrol.Config{
+				Address: "127.0.0.1:0",
+				Versions: versioncontrol.OldVersionConfig{
+					Satellite:   "v0.0.1",
+					Storagenode: "v0.0.1",
+					Uplink:      "v0.0.1",
+					Gateway:     "v0.0.1",
+					Identity:    "v0.0.1",
+				},
+				Binary:            versions,
+				SatellitePolicies: policies,
+			})
+			require.Nil(t, peer)
+			require.Error(t, err)
+			require.True(t, versioncontrol.PolicyErr.Has(err))
+			require.Contains(t, err.Error(), scenario.errContains)
+		})
+	}
+}
+
+func writePolicies(t *testing.T, path string, policies map[string]versioncontrol.SatellitePolicy) {
+	t.Helper()
+
+	data, err := json.Marshal(policies)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, data, 0644))
+}
@@ -0,0 +1,442 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package versioncontrol
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/storj"
+	"storj.io/private/version"
+)
+
+var (
+	// ErrUnknownProcess is used when a process has no rollout.
+	ErrUnknownProcess = errs.Class("unknown process")
+	// ErrRolloutState is used when the rollout state can't be changed.
+	ErrRolloutState = errs.Class("rollout state error")
+	// ErrRolloutPersistence is used when the rollout state can't be loaded or saved.
+	ErrRolloutPersistence = errs.Class("rollout persistence error")
+)
+
+// Stage is a step of a staged rollout: the percentage of nodes which should
+// update, for how long before the next stage begins.
+type Stage struct {
+	Percentage int           `json:"percentage"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// ParseStages parses comma separated stages formatted as percentage:duration,
+// e.g. "5:24h,25:24h,100". Only the last stage may omit its duration.
+func ParseStages(value string) ([]Stage, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	var stages []Stage
+	parts := strings.Split(value, ",")
+	for i, part := range parts {
+		percentageString, durationString := strings.TrimSpace(part), ""
+		if colon := strings.IndexByte(percentageString, ':'); colon >= 0 {
+			percentageString, durationString = percentageString[:colon], percentageString[colon+1:]
+		}
+
+		var stage Stage
+		var err error
+		stage.Percentage, err = strconv.Atoi(percentageString)
+		if err != nil {
+			return nil, RolloutErr.New("invalid stage percentage %q", percentageString)
+		}
+		if stage.Percentage < 0 || stage.Percentage > 100 {
+			return nil, RolloutErr.New("invalid stage percentage: %d", stage.Percentage)
+		}
+		if len(stages) > 0 && stage.Percentage <= stages[len(stages)-1].Percentage {
+			return nil, RolloutErr.New("stage percentages must increase: %d after %d", stage.Percentage, stages[len(stages)-1].Percentage)
+		}
+
+		if durationString != "" {
+			stage.Duration, err = time.ParseDuration(durationString)
+			if err != nil {
+				return nil, RolloutErr.New("invalid stage duration %q", durationString)
+			}
+		}
+		if stage.Duration <= 0 && i != len(parts)-1 {
+			return nil, RolloutErr.New("only the last stage may have no duration: %q", part)
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return stages, nil
+}
+
+// RolloutState is the persisted state of the rollout of a process version.
+type RolloutState struct {
+	Version   string     `json:"version"`
+	StartedAt time.Time  `json:"startedAt"`
+	PausedAt  *time.Time `json:"pausedAt,omitempty"`
+	// PausedFor is the total time the rollout was paused, before PausedAt.
+	PausedFor time.Duration `json:"pausedFor"`
+	Aborted   bool          `json:"aborted"`
+}
+
+// RolloutStatus describes the current stage of the rollout of a process version.
+type RolloutStatus struct {
+	Process     string     `json:"process"`
+	Version     string     `json:"version"`
+	Stages      []Stage    `json:"stages"`
+	Stage       int        `json:"stage"`
+	Percentage  int        `json:"percentage"`
+	StartedAt   time.Time  `json:"startedAt"`
+	NextStageAt *time.Time `json:"nextStageAt,omitempty"`
+	Paused      bool       `json:"paused"`
+	Aborted     bool       `json:"aborted"`
+}
+
+// NodeStage describes in which stage of a rollout a node updates.
+type NodeStage struct {
+	Process string       `json:"process"`
+	Version string       `json:"version"`
+	NodeID  storj.NodeID `json:"nodeId"`
+	// Stage is the index of the stage in which the node updates,
+	// or -1 when it's not part of any stage.
+	Stage int `json:"stage"`
+	// Updating is whether the node should update at the current stage.
+	Updating bool `json:"updating"`
+}
+
+// processRollout is the rollout of a single process.
+type processRollout struct {
+	seed    version.RolloutBytes
+	cursor  int
+	stages  []Stage
+	version string
+	state   RolloutState
+}
+
+// Rollouts keeps track of the staged rollouts of all processes and their
+// pause and abort state.
+//
+// architecture: Service
+type Rollouts struct {
+	mu        sync.Mutex
+	path      string
+	nowFn     func() time.Time
+	processes map[string]*processRollout
+}
+
+// NewRollouts creates the rollouts of all processes, restoring their state
+// from the file at path. The state isn't persisted when path is empty.
+func NewRollouts(config ProcessesConfig, path string, nowFn func() time.Time) (*Rollouts, error) {
+	rollouts := &Rollouts{
+		path:      path,
+		nowFn:     nowFn,
+		processes: map[string]*processRollout{},
+	}
+
+	for name, process := range processConfigs(config) {
+		stages, err := ParseStages(process.Rollout.Stages)
+		if err != nil {
+			return nil, RolloutErr.New("%s: %v", name, err)
+		}
+
+		rollout := &processRollout{
+			cursor:  process.Rollout.Cursor,
+			stages:  stages,
+			version: process.Suggested.Version,
+		}
+		if process.Rollout.Seed != "" {
+			seed, err := parseSeed(process.Rollout.Seed)
+			if err != nil {
+				return nil, RolloutErr.New("%s: %v", name, err)
+			}
+			rollout.seed = seed
+		}
+		rollouts.processes[name] = rollout
+	}
+
+	states, err := rollouts.load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := nowFn().UTC()
+	for name, rollout := range rollouts.processes {
+		state, ok := states[name]
+		if !ok || state.Version != rollout.version {
+			// a new version restarts the rollout
+			state = RolloutState{Version: rollout.version, StartedAt: now}
+		}
+		rollout.state = state
+	}
+
+	if err := rollouts.save(); err != nil {
+		return nil, err
+	}
+	return rollouts, nil
+}
+
+// Cursor returns the current rollout cursor of the process.
+func (rollouts *Rollouts) Cursor(process string) (version.RolloutBytes, error) {
+	rollouts.mu.Lock()
+	defer rollouts.mu.Unlock()
+
+	rollout, ok := rollouts.processes[process]
+	if !ok {
+		return version.RolloutBytes{}, ErrUnknownProcess.New("%s", process)
+	}
+
+	return version.PercentageToCursor(rollout.status(process, rollouts.nowFn()).Percentage), nil
+}
+
+// Status returns the current status of the rollout of the process.
+func (rollouts *Rollouts) Status(process string) (RolloutStatus, error) {
+	rollouts.mu.Lock()
+	defer rollouts.mu.Unlock()
+
+	rollout, ok := rollouts.processes[process]
+	if !ok {
+		return RolloutStatus{}, ErrUnknownProcess.New("%s", process)
+	}
+	return rollout.status(process, rollouts.nowFn()), nil
+}
+
+// All returns the current status of the rollouts of all processes.
+func (rollouts *Rollouts) All() []RolloutStatus {
+	rollouts.mu.Lock()
+	defer rollouts.mu.Unlock()
+
+	now := rollouts.nowFn()
+	statuses := make([]RolloutStatus, 0, len(rollouts.processes))
+	for name, rollout := range rollouts.processes {
+		statuses = append(statuses, rollout.status(name, now))
+	}
+	sort.Slice(statuses, func(i, k int) bool {
+		return statuses[i].Process < statuses[k].Process
+	})
+	return statuses
+}
+
+// NodeStage returns in which stage of the rollout of the process the node updates.
+func (rollouts *Rollouts) NodeStage(process string, nodeID storj.NodeID) (NodeStage, error) {
+	rollouts.mu.Lock()
+	defer rollouts.mu.Unlock()
+
+	rollout, ok := rollouts.processes[process]
+	if !ok {
+		return NodeStage{}, ErrUnknownProcess.New("%s", process)
+	}
+
+	stages := rollout.stages
+	if len(stages) == 0 {
+		stages = []Stage{{Percentage: rollout.cursor}}
+	}
+
+	nodeStage := NodeStage{
+		Process: process,
+		Version: rollout.version,
+		NodeID:  nodeID,
+		Stage:   -1,
+	}
+	for i, stage := range stages {
+		if version.ShouldUpdate(version.Rollout{
+			Seed:   rollout.seed,
+			Cursor: version.PercentageToCursor(stage.Percentage),
+		}, nodeID) {
+			nodeStage.Stage = i
+			break
+		}
+	}
+
+	current := rollout.status(process, rollouts.nowFn())
+	nodeStage.Updating = version.ShouldUpdate(version.Rollout{
+		Seed:   rollout.seed,
+		Cursor: version.PercentageToCursor(current.Percentage),
+	}, nodeID)
+
+	return nodeStage, nil
+}
+
+// Pause pauses the rollout of the process at its current stage.
+func (rollouts *Rollouts) Pause(process string) (RolloutStatus, error) {
+	return rollouts.update(process, func(rollout *processRollout, now time.Time) error {
+		if rollout.state.Aborted {
+			return ErrRolloutState.New("rollout of %s %s is aborted", process, rollout.version)
+		}
+		if rollout.state.PausedAt == nil {
+			rollout.state.PausedAt = &now
+		}
+		return nil
+	})
+}
+
+// Resume resumes the paused rollout of the process.
+func (rollouts *Rollouts) Resume(process string) (RolloutStatus, error) {
+	return rollouts.update(process, func(rollout *processRollout, now time.Time) error {
+		if rollout.state.Aborted {
+			return ErrRolloutState.New("rollout of %s %s is aborted", process, rollout.version)
+		}
+		if rollout.state.PausedAt != nil {
+			rollout.state.PausedFor += now.Sub(*rollout.state.PausedAt)
+			rollout.state.PausedAt = nil
+		}
+		return nil
+	})
+}
+
+// Abort aborts the rollout of the process, so that no more nodes update to
+// the suggested version. The rollout restarts only when the suggested version changes.
+func (rollouts *Rollouts) Abort(process string) (RolloutStatus, error) {
+	return rollouts.update(process, func(rollout *processRollout, now time.Time) error {
+		rollout.state.Aborted = true
+		return nil
+	})
+}
+
+// update changes the state of the rollout of the process and persists it.
+func (rollouts *Rollouts) update(process string, fn func(rollout *processRollout, now time.Time) error) (RolloutStatus, error) {
+	rollouts.mu.Lock()
+	defer rollouts.mu.Unlock()
+
+	rollout, ok := rollouts.processes[process]
+	if !ok {
+		return RolloutStatus{}, ErrUnknownProcess.New("%s", process)
+	}
+
+	now := rollouts.nowFn().UTC()
+
+	previous := rollout.state
+	if err := fn(rollout, now); err != nil {
+		return RolloutStatus{}, err
+	}
+	if err := rollouts.save(); err != nil {
+		rollout.state = previous
+		return RolloutStatus{}, err
+	}
+
+	return rollout.status(process, now), nil
+}
+
+// load loads the persisted rollout states.
+func (rollouts *Rollouts) load() (map[string]RolloutState, error) {
+	states := map[string]RolloutState{}
+	if rollouts.path == "" {
+		return states, nil
+	}
+
+	data, err := ioutil.ReadFile(rollouts.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return states, nil
+		}
+		return nil, ErrRolloutPersistence.Wrap(err)
+	}
+
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, ErrRolloutPersistence.Wrap(err)
+	}
+	return states, nil
+}
+
+// save persists the rollout states.
+func (rollouts *Rollouts) save() error {
+	if rollouts.path == "" {
+		return nil
+	}
+
+	states := map[string]RolloutState{}
+	for name, rollout := range rollouts.processes {
+		states[name] = rollout.state
+	}
+
+	data, err := json.MarshalIndent(states, "", "\t")
+	if err != nil {
+		return ErrRolloutPersistence.Wrap(err)
+	}
+
+	// write to a temporary file first, so that a crash doesn't corrupt the state.
+	tmpPath := rollouts.path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return ErrRolloutPersistence.Wrap(err)
+	}
+	return ErrRolloutPersistence.Wrap(os.Rename(tmpPath, rollouts.path))
+}
+
+// status calculates the status of the rollout at now.
+func (rollout *processRollout) status(process string, now time.Time) RolloutStatus {
+	status := RolloutStatus{
+		Process:    process,
+		Version:    rollout.version,
+		Stages:     rollout.stages,
+		Percentage: rollout.cursor,
+		StartedAt:  rollout.state.StartedAt,
+		Paused:     rollout.state.PausedAt != nil,
+		Aborted:    rollout.state.Aborted,
+	}
+
+	if len(rollout.stages) > 0 {
+		// the time spent paused doesn't count towards the stage durations.
+		elapsed := now.Sub(rollout.state.StartedAt) - rollout.state.PausedFor
+		if rollout.state.PausedAt != nil {
+			elapsed -= now.Sub(*rollout.state.PausedAt)
+		}
+
+		var end time.Duration
+		for i, stage := range rollout.stages {
+			status.Stage = i
+			status.Percentage = stage.Percentage
+			if stage.Duration <= 0 {
+				break
+			}
+
+			end += stage.Duration
+			if elapsed < end {
+				if !status.Paused && i < len(rollout.stages)-1 {
+					nextStageAt := now.Add(end - elapsed)
+					status.NextStageAt = &nextStageAt
+				}
+				break
+			}
+		}
+	}
+
+	if status.Aborted {
+		status.Percentage = 0
+		status.NextStageAt = nil
+	}
+
+	return status
+}
+
+// processConfigs returns the configuration of every process by its name.
+func processConfigs(config ProcessesConfig) map[string]ProcessConfig {
+	return map[string]ProcessConfig{
+		"satellite":           config.Satellite,
+		"storagenode":         config.Storagenode,
+		"storagenode-updater": config.StoragenodeUpdater,
+		"uplink":              config.Uplink,
+		"gateway":             config.Gateway,
+		"identity":            config.Identity,
+	}
+}
+
+// parseSeed parses a hex-encoded rollout seed.
+func parseSeed(value string) (seed version.RolloutBytes, err error) {
+	seedBytes, err := hex.DecodeString(value)
+	if err != nil {
+		return seed, err
+	}
+	copy(seed[:], seedBytes)
+	return seed, nil
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package versioncontrol_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/common/testcontext"
+	"storj.io/common/testrand"
+	"storj.io/private/version"
+	"storj.io/storj/versioncontrol"
+)
+
+func TestParseStages(t *testing.T) {
+	stages, err := versioncontrol.ParseStages("5:24h, 25:12h,100")
+	require.NoError(t, err)
+	require.Equal(t, []versioncontrol.Stage{
+		{Percentage: 5, Duration: 24 * time.Hour},
+		{Percentage: 25, Duration: 12 * time.Hour},
+		{Percentage: 100},
+	}, stages)
+
+	stages, err = versioncontrol.ParseStages("")
+	require.NoError(t, err)
+	require.Empty(t, stages)
+
+	for _, invalid := range []string{
+		"5,25:1h",
+		"25:1h,5",
+		"101",
+		"x:1h",
+		"5:x",
+		"5:1h,5",
+	} {
+		_, err := versioncontrol.ParseStages(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestRollouts(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	now := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	nowFn := func() time.Time { return now }
+
+	config := versioncontrol.ProcessesConfig{
+		Storagenode: versioncontrol.ProcessConfig{
+			Suggested: versioncontrol.VersionConfig{Version: "v1.2.3"},
+			Rollout: versioncontrol.RolloutConfig{
+				Seed:   randSeedString(t),
+				Stages: "5:24h,25:24h,100",
+			},
+		},
+		Uplink: versioncontrol.ProcessConfig{
+			Suggested: versioncontrol.VersionConfig{Version: "v1.2.3"},
+			Rollout: versioncontrol.RolloutConfig{
+				Seed:   randSeedString(t),
+				Cursor: 50,
+			},
+		},
+	}
+	statePath := ctx.File("rollouts.json")
+
+	rollouts, err := versioncontrol.NewRollouts(config, statePath, nowFn)
+	require.NoError(t, err)
+
+	requireStage := func(rollouts *versioncontrol.Rollouts, stage, percentage int) {
+		t.Helper()
+		status, err := rollouts.Status("storagenode")
+		require.NoError(t, err)
+		require.Equal(t, stage, status.Stage)
+		require.Equal(t, percentage, status.Percentage)
+
+		cursor, err := rollouts.Cursor("storagenode")
+		require.NoError(t, err)
+		require.Equal(t, version.PercentageToCursor(percentage), cursor)
+	}
+
+	requireStage(rollouts, 0, 5)
+	now = now.Add(25 * time.Hour)
+	requireStage(rollouts, 1, 25)
+
+	// the time spent paused doesn't count
+	status, err := rollouts.Pause("storagenode")
+	require.NoError(t, err)
+	require.True(t, status.Paused)
+	now = now.Add(48 * time.Hour)
+	requireStage(rollouts, 1, 25)
+
+	// the state survives restarts
+	rollouts, err = versioncontrol.NewRollouts(config, statePath, nowFn)
+	require.NoError(t, err)
+	requireStage(rollouts, 1, 25)
+
+	status, err = rollouts.Resume("storagenode")
+	require.NoError(t, err)
+	require.False(t, status.Paused)
+	require.NotNil(t, status.NextStageAt)
+	require.Equal(t, now.Add(23*time.Hour), *status.NextStageAt)
+
+	now = now.Add(23 * time.Hour)
+	requireStage(rollouts, 2, 100)
+
+	// rollouts without stages keep their cursor
+	uplink, err := rollouts.Status("uplink")
+	require.NoError(t, err)
+	require.Equal(t, 50, uplink.Percentage)
+
+	nodeID := testrand.NodeID()
+	nodeStage, err := rollouts.NodeStage("storagenode", nodeID)
+	require.NoError(t, err)
+	require.True(t, nodeStage.Updating)
+	require.True(t, nodeStage.Stage >= 0 && nodeStage.Stage <= 2)
+
+	// an aborted rollout doesn't update any more nodes
+	status, err = rollouts.Abort("storagenode")
+	require.NoError(t, err)
+	require.True(t, status.Aborted)
+	requireStage(rollouts, 2, 0)
+
+	nodeStage, err = rollouts.NodeStage("storagenode", nodeID)
+	require.NoError(t, err)
+	require.False(t, nodeStage.Updating)
+
+	_, err = rollouts.Resume("storagenode")
+	require.True(t, versioncontrol.ErrRolloutState.Has(err))
+
+	_, err = rollouts.Pause("unknown")
+	require.True(t, versioncontrol.ErrUnknownProcess.Has(err))
+
+	// a new version restarts the rollout
+	config.Storagenode.Suggested.Version = "v1.2.4"
+	rollouts, err = versioncontrol.NewRollouts(config, statePath, nowFn)
+	require.NoError(t, err)
+	requireStage(rollouts, 0, 5)
+}
+
+func TestPeer_Admin(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	versions := validRandVersions(t)
+	versions.Storagenode.Suggested.Version = "v1.2.3"
+	versions.Storagenode.Rollout.Stages = "5:24h,100"
+
+	peer, err := versioncontrol.New(zaptest.NewLogger(t), &versioncontrol.Config{
+		Address: "127.0.0.1:0",
+		Versions: versioncontrol.OldVersionConfig{
+			Satellite:   "v0.0.1",
+			Storagenode: "v0.0.1",
+			Uplink:      "v0.0.1",
+			Gateway:     "v0.0.1",
+			Identity:    "v0.0.1",
+		},
+		Binary:       versions,
+		RolloutState: ctx.File("rollouts.json"),
+		Admin:        versioncontrol.AdminConfig{AuthorizationToken: "secret"},
+	})
+	require.NoError(t, err)
+	ctx.Go(func() error { return peer.Run(ctx) })
+	defer ctx.Check(peer.Close)
+
+	baseURL := "http://" + peer.Addr()
+
+	request := func(method, path, token string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, method, baseURL+path, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := request(http.MethodPost, "/admin/rollouts/storagenode/abort", "")
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+
+	resp = request(http.MethodPost, "/admin/rollouts/storagenode/abort", "wrong")
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+
+	resp = request(http.MethodPost, "/admin/rollouts/unknown/abort", "secret")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+
+	resp = request(http.MethodPost, "/admin/rollouts/storagenode/abort", "secret")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var status versioncontrol.RolloutStatus
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	require.NoError(t, resp.Body.Close())
+	require.True(t, status.Aborted)
+
+	// the versions response reflects the aborted rollout
+	resp = request(http.MethodGet, "/", "")
+	var allowed version.AllowedVersions
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&allowed))
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, version.PercentageToCursor(0), allowed.Processes.Storagenode.Rollout.Cursor)
+
+	nodeID := testrand.NodeID()
+	resp = request(http.MethodGet, "/rollouts/storagenode/nodes/"+nodeID.String(), "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var nodeStage versioncontrol.NodeStage
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&nodeStage))
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, nodeID, nodeStage.NodeID)
+	require.False(t, nodeStage.Updating)
+
+	resp = request(http.MethodGet, "/rollouts/storagenode/nodes/invalid", "")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+}